@@ -2,17 +2,23 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"go-todo/configs"
 	"go-todo/internal/builder"
+	"go-todo/internal/entity"
+	"go-todo/internal/service"
 	"go-todo/pkg/cache"
 	"go-todo/pkg/database"
 	"go-todo/pkg/server"
+	"go-todo/pkg/token"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
 	"time"
+	_ "time/tzdata" // Menyertakan database zona waktu untuk image tanpa tzdata
 )
 
 func main() {
@@ -25,10 +31,37 @@ func main() {
 
 	rdb := cache.InitCache(cfg.RedisConfig)
 
-	publicRoutes := builder.BuildPublicRoutes(cfg, db, rdb)
-	privateRoutes := builder.BuildPrivateRoutes(cfg, db, rdb)
+	keys, err := token.LoadKeySet(cfg.JWT)
+	checkError(err)
+
+	mfaService, err := builder.BuildMFAService(cfg, db, rdb)
+	checkError(err)
+
+	mailSender, err := builder.BuildMailSender(cfg)
+	checkError(err)
+
+	// Perintah CLI: create-admin membuat admin pertama lalu keluar
+	if len(os.Args) > 1 && os.Args[1] == "create-admin" {
+		checkError(runCreateAdmin(builder.BuildUserService(cfg, db, rdb, keys, mfaService, mailSender), os.Args[2:]))
+		return
+	}
+	bootstrapAdmin(builder.BuildUserService(cfg, db, rdb, keys, mfaService, mailSender), cfg.Admin)
+
+	publicRoutes := builder.BuildPublicRoutes(cfg, db, rdb, keys, mfaService, mailSender)
+	privateRoutes := builder.BuildPrivateRoutes(cfg, db, rdb, keys, mfaService, mailSender)
+
+	// Dispatcher webhook berjalan di latar belakang hingga server dimatikan
+	dispatcherCtx, stopDispatcher := context.WithCancel(context.Background())
+	defer stopDispatcher()
+	pollInterval := time.Duration(cfg.Webhook.PollIntervalSeconds) * time.Second
+	go runWebhookDispatcher(dispatcherCtx, builder.BuildWebhookService(cfg, db), pollInterval)
 
-	srv := server.NewServer(cfg, publicRoutes, privateRoutes)
+	// Relay outbox meneruskan domain event yang sudah tersimpan ke subscriber
+	relayInterval := time.Duration(cfg.Outbox.PollIntervalSeconds) * time.Second
+	go runOutboxRelay(dispatcherCtx, builder.BuildOutboxRelay(cfg, db, rdb), relayInterval)
+
+	srv, err := server.NewServer(cfg, keys, token.NewRevocationStore(rdb), builder.BuildAuthorizationService(cfg, db, rdb), publicRoutes, privateRoutes)
+	checkError(err)
 	runServer(srv, cfg.PORT)
 	waitForShutdown(srv)
 }
@@ -40,6 +73,49 @@ func checkError(err error) {
 	}
 }
 
+// bootstrapAdmin membuat admin pertama dari konfigurasi jika belum ada admin sama sekali
+func bootstrapAdmin(userService service.UserService, cfg configs.AdminConfig) {
+	if cfg.Username == "" {
+		return
+	}
+	admin, err := userService.BootstrapAdmin(context.Background(), &entity.User{
+		Username: cfg.Username,
+		Password: cfg.Password,
+		FullName: cfg.FullName,
+	})
+	if errors.Is(err, service.ErrAdminSudahAda) {
+		return
+	}
+	checkError(err)
+	log.Printf("Admin pertama dibuat: %s", admin.Username)
+}
+
+// runCreateAdmin menjalankan perintah `create-admin -username <u> -password <p> [-full-name <n>]`.
+// Password juga dapat diberikan melalui variabel lingkungan ADMIN_PASSWORD agar tidak tercatat di riwayat shell.
+func runCreateAdmin(userService service.UserService, args []string) error {
+	flags := flag.NewFlagSet("create-admin", flag.ContinueOnError)
+	username := flags.String("username", "", "username admin")
+	password := flags.String("password", os.Getenv("ADMIN_PASSWORD"), "password admin, wajib jika username belum terdaftar")
+	fullName := flags.String("full-name", "Administrator", "nama lengkap admin")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *username == "" {
+		return errors.New("username harus diisi")
+	}
+
+	admin, err := userService.BootstrapAdmin(context.Background(), &entity.User{
+		Username: *username,
+		Password: *password,
+		FullName: *fullName,
+	})
+	if err != nil {
+		return err
+	}
+	log.Printf("Pengguna %s sekarang adalah admin", admin.Username)
+	return nil
+}
+
 // runServer menjalankan server pada port yang ditentukan
 func runServer(srv *server.Server, port string) {
 	go func() {
@@ -51,6 +127,59 @@ func runServer(srv *server.Server, port string) {
 	}()
 }
 
+// runWebhookDispatcher mengirim antrean webhook secara berkala hingga ctx dibatalkan
+func runWebhookDispatcher(ctx context.Context, webhookService service.WebhookService, interval time.Duration) {
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := webhookService.DispatchDue(ctx); err != nil {
+				log.Printf("Gagal mengirim webhook: %v", err)
+			}
+		}
+	}
+}
+
+// runOutboxRelay meneruskan event outbox secara berkala hingga ctx dibatalkan.
+// Selama masih ada event yang terkirim, batch berikutnya langsung diproses tanpa menunggu.
+func runOutboxRelay(ctx context.Context, relay service.OutboxRelay, interval time.Duration) {
+	if interval <= 0 {
+		interval = time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	purgeTicker := time.NewTicker(time.Hour)
+	defer purgeTicker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-purgeTicker.C:
+			if _, err := relay.PurgePublished(ctx); err != nil {
+				log.Printf("Gagal membersihkan outbox: %v", err)
+			}
+		case <-ticker.C:
+			for {
+				published, err := relay.RelayPending(ctx)
+				if err != nil {
+					log.Printf("Gagal meneruskan event outbox: %v", err)
+				}
+				if err != nil || published == 0 || ctx.Err() != nil {
+					break
+				}
+			}
+		}
+	}
+}
+
 // waitForShutdown menangani proses shutdown server ketika menerima sinyal interrupt
 func waitForShutdown(srv *server.Server) {
 	quit := make(chan os.Signal, 1)
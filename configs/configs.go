@@ -9,11 +9,22 @@ import (
 )
 
 type Config struct {
-	ENV            string         `env:"ENV" envDefault:"dev" mapstructure:"ENV"`
-	PORT           string         `env:"PORT" envDefault:"8080" mapstructure:"PORT"`
-	PostgresConfig PostgresConfig `envPrefix:"POSTGRES_" mapstructure:"POSTGRES"`
-	JWT            JWTConfig      `envPrefix:"JWT_" mapstructure:"JWT"`
-	RedisConfig    RedisConfig    `envPrefix:"REDIS_" mapstructure:"REDIS"`
+	ENV               string                  `env:"ENV" envDefault:"dev" mapstructure:"ENV"`
+	PORT              string                  `env:"PORT" envDefault:"8080" mapstructure:"PORT"`
+	PostgresConfig    PostgresConfig          `envPrefix:"POSTGRES_" mapstructure:"POSTGRES"`
+	JWT               JWTConfig               `envPrefix:"JWT_" mapstructure:"JWT"`
+	RedisConfig       RedisConfig             `envPrefix:"REDIS_" mapstructure:"REDIS"`
+	Webhook           WebhookConfig           `envPrefix:"WEBHOOK_" mapstructure:"WEBHOOK"`
+	Outbox            OutboxConfig            `envPrefix:"OUTBOX_" mapstructure:"OUTBOX"`
+	Undo              UndoConfig              `envPrefix:"UNDO_" mapstructure:"UNDO"`
+	Admin             AdminConfig             `envPrefix:"ADMIN_" mapstructure:"ADMIN"`
+	Login             LoginConfig             `envPrefix:"LOGIN_" mapstructure:"LOGIN"`
+	Password          PasswordConfig          `envPrefix:"PASSWORD_" mapstructure:"PASSWORD"`
+	PasswordReset     PasswordResetConfig     `envPrefix:"PASSWORD_RESET_" mapstructure:"PASSWORD_RESET"`
+	Mail              MailConfig              `envPrefix:"MAIL_" mapstructure:"MAIL"`
+	EmailVerification EmailVerificationConfig `envPrefix:"EMAIL_VERIFICATION_" mapstructure:"EMAIL_VERIFICATION"`
+	MFA               MFAConfig               `envPrefix:"MFA_" mapstructure:"MFA"`
+	Server            ServerConfig            `envPrefix:"SERVER_" mapstructure:"SERVER"`
 }
 
 type RedisConfig struct {
@@ -22,8 +33,142 @@ type RedisConfig struct {
 	Password string `env:"PASSWORD" envDefault:"" mapstructure:"PASSWORD"`
 }
 
+// JWTConfig mengatur penandatanganan access token dan masa berlaku token.
+// Jika Keys diisi, token ditandatangani dengan kunci asimetris dan SecretKey diabaikan.
 type JWTConfig struct {
-	SecretKey string `env:"SECRET_KEY" envDefault:"secret" mapstructure:"SECRET_KEY"`
+	SecretKey             string         `env:"SECRET_KEY" envDefault:"secret" mapstructure:"SECRET_KEY"`
+	AccessTokenTTLMinutes int            `env:"ACCESS_TOKEN_TTL_MINUTES" envDefault:"15" mapstructure:"ACCESS_TOKEN_TTL_MINUTES"`
+	RefreshTokenTTLHours  int            `env:"REFRESH_TOKEN_TTL_HOURS" envDefault:"720" mapstructure:"REFRESH_TOKEN_TTL_HOURS"`
+	Keys                  []JWTKeyConfig `mapstructure:"KEYS"`
+}
+
+// JWTKeyConfig mendefinisikan satu kunci asimetris (RS256, ES256, atau EdDSA) beserta jadwal
+// rotasinya. Waktu ditulis dalam format RFC3339. Kunci dengan ACTIVE_FROM terbaru yang sudah
+// lewat dipakai untuk menandatangani; kunci lain tetap diterima hingga RETIRE_AT.
+type JWTKeyConfig struct {
+	ID             string `mapstructure:"ID"`
+	Algorithm      string `mapstructure:"ALGORITHM"`
+	PrivateKeyFile string `mapstructure:"PRIVATE_KEY_FILE"`
+	PublicKeyFile  string `mapstructure:"PUBLIC_KEY_FILE"`
+	ActiveFrom     string `mapstructure:"ACTIVE_FROM"`
+	RetireAt       string `mapstructure:"RETIRE_AT"`
+}
+
+// WebhookConfig mengatur pengiriman webhook dan kebijakan retry-nya
+type WebhookConfig struct {
+	MaxAttempts         int `env:"MAX_ATTEMPTS" envDefault:"8" mapstructure:"MAX_ATTEMPTS"`
+	BackoffBaseSeconds  int `env:"BACKOFF_BASE_SECONDS" envDefault:"30" mapstructure:"BACKOFF_BASE_SECONDS"`
+	BackoffMaxSeconds   int `env:"BACKOFF_MAX_SECONDS" envDefault:"3600" mapstructure:"BACKOFF_MAX_SECONDS"`
+	TimeoutSeconds      int `env:"TIMEOUT_SECONDS" envDefault:"10" mapstructure:"TIMEOUT_SECONDS"`
+	PollIntervalSeconds int `env:"POLL_INTERVAL_SECONDS" envDefault:"5" mapstructure:"POLL_INTERVAL_SECONDS"`
+	BatchSize           int `env:"BATCH_SIZE" envDefault:"50" mapstructure:"BATCH_SIZE"`
+}
+
+// OutboxConfig mengatur relay yang meneruskan event dari tabel outbox
+type OutboxConfig struct {
+	PollIntervalSeconds int    `env:"POLL_INTERVAL_SECONDS" envDefault:"1" mapstructure:"POLL_INTERVAL_SECONDS"`
+	BatchSize           int    `env:"BATCH_SIZE" envDefault:"100" mapstructure:"BATCH_SIZE"`
+	BackoffBaseSeconds  int    `env:"BACKOFF_BASE_SECONDS" envDefault:"5" mapstructure:"BACKOFF_BASE_SECONDS"`
+	BackoffMaxSeconds   int    `env:"BACKOFF_MAX_SECONDS" envDefault:"300" mapstructure:"BACKOFF_MAX_SECONDS"`
+	RetentionHours      int    `env:"RETENTION_HOURS" envDefault:"168" mapstructure:"RETENTION_HOURS"`
+	StreamName          string `env:"STREAM_NAME" envDefault:"go-todo-api:events" mapstructure:"STREAM_NAME"`
+	StreamMaxLen        int64  `env:"STREAM_MAX_LEN" envDefault:"100000" mapstructure:"STREAM_MAX_LEN"`
+}
+
+// UndoConfig mengatur berapa lama aksi pada todo masih dapat dibatalkan
+type UndoConfig struct {
+	WindowSeconds int `env:"WINDOW_SECONDS" envDefault:"30" mapstructure:"WINDOW_SECONDS"`
+}
+
+// AdminConfig berisi admin pertama yang dibuat saat aplikasi dijalankan jika belum ada admin.
+// Kosongkan USERNAME untuk menonaktifkan bootstrap.
+type AdminConfig struct {
+	Username string `env:"USERNAME" envDefault:"" mapstructure:"USERNAME"`
+	Password string `env:"PASSWORD" envDefault:"" mapstructure:"PASSWORD"`
+	FullName string `env:"FULL_NAME" envDefault:"Administrator" mapstructure:"FULL_NAME"`
+}
+
+// LoginConfig mengatur perlindungan brute-force pada /login. Kegagalan dihitung dalam sliding
+// window per username dan per IP. Setelah DELAY_AFTER kegagalan, username harus menunggu jeda
+// yang berlipat dua di setiap kegagalan berikutnya, lalu dikunci selama LOCKOUT_SECONDS setelah
+// MAX_FAILURES_PER_USERNAME kegagalan. IP dikunci setelah MAX_FAILURES_PER_IP kegagalan.
+type LoginConfig struct {
+	WindowSeconds          int `env:"WINDOW_SECONDS" envDefault:"900" mapstructure:"WINDOW_SECONDS"`
+	DelayAfter             int `env:"DELAY_AFTER" envDefault:"3" mapstructure:"DELAY_AFTER"`
+	DelayBaseSeconds       int `env:"DELAY_BASE_SECONDS" envDefault:"1" mapstructure:"DELAY_BASE_SECONDS"`
+	DelayMaxSeconds        int `env:"DELAY_MAX_SECONDS" envDefault:"60" mapstructure:"DELAY_MAX_SECONDS"`
+	MaxFailuresPerUsername int `env:"MAX_FAILURES_PER_USERNAME" envDefault:"10" mapstructure:"MAX_FAILURES_PER_USERNAME"`
+	MaxFailuresPerIP       int `env:"MAX_FAILURES_PER_IP" envDefault:"50" mapstructure:"MAX_FAILURES_PER_IP"`
+	LockoutSeconds         int `env:"LOCKOUT_SECONDS" envDefault:"900" mapstructure:"LOCKOUT_SECONDS"`
+}
+
+// PasswordConfig mengatur kebijakan password baru. MAX_BYTES dibatasi paling banyak 72 karena
+// bcrypt mengabaikan byte setelahnya.
+type PasswordConfig struct {
+	MinLength        int  `env:"MIN_LENGTH" envDefault:"10" mapstructure:"MIN_LENGTH"`
+	MaxBytes         int  `env:"MAX_BYTES" envDefault:"72" mapstructure:"MAX_BYTES"`
+	RequireUppercase bool `env:"REQUIRE_UPPERCASE" envDefault:"true" mapstructure:"REQUIRE_UPPERCASE"`
+	RequireLowercase bool `env:"REQUIRE_LOWERCASE" envDefault:"true" mapstructure:"REQUIRE_LOWERCASE"`
+	RequireDigit     bool `env:"REQUIRE_DIGIT" envDefault:"true" mapstructure:"REQUIRE_DIGIT"`
+	RequireSymbol    bool `env:"REQUIRE_SYMBOL" envDefault:"false" mapstructure:"REQUIRE_SYMBOL"`
+	DisallowUsername bool `env:"DISALLOW_USERNAME" envDefault:"true" mapstructure:"DISALLOW_USERNAME"`
+	RejectCommon     bool `env:"REJECT_COMMON" envDefault:"true" mapstructure:"REJECT_COMMON"`
+}
+
+// PasswordResetConfig mengatur token reset password. URL adalah halaman frontend yang
+// menerima token melalui query string ?token=.
+type PasswordResetConfig struct {
+	TokenTTLMinutes    int    `env:"TOKEN_TTL_MINUTES" envDefault:"30" mapstructure:"TOKEN_TTL_MINUTES"`
+	MaxRequestsPerHour int    `env:"MAX_REQUESTS_PER_HOUR" envDefault:"3" mapstructure:"MAX_REQUESTS_PER_HOUR"`
+	URL                string `env:"URL" envDefault:"http://localhost:3000/reset-password" mapstructure:"URL"`
+}
+
+// EmailVerificationConfig mengatur verifikasi email pengguna. POLICY menentukan perlakuan
+// terhadap pengguna yang emailnya belum diverifikasi:
+//   - none: tidak dibatasi
+//   - restrict: izin dibatasi pada ALLOWED_PERMISSIONS hingga email diverifikasi
+//   - block: login ditolak hingga email diverifikasi
+//
+// URL adalah halaman frontend yang menerima token melalui query string ?token=.
+type EmailVerificationConfig struct {
+	Policy                string   `env:"POLICY" envDefault:"restrict" mapstructure:"POLICY"`
+	AllowedPermissions    []string `env:"ALLOWED_PERMISSIONS" envDefault:"todo:read" envSeparator:"," mapstructure:"ALLOWED_PERMISSIONS"`
+	TokenTTLHours         int      `env:"TOKEN_TTL_HOURS" envDefault:"24" mapstructure:"TOKEN_TTL_HOURS"`
+	ResendIntervalSeconds int      `env:"RESEND_INTERVAL_SECONDS" envDefault:"60" mapstructure:"RESEND_INTERVAL_SECONDS"`
+	MaxResendsPerHour     int      `env:"MAX_RESENDS_PER_HOUR" envDefault:"5" mapstructure:"MAX_RESENDS_PER_HOUR"`
+	URL                   string   `env:"URL" envDefault:"http://localhost:3000/verify-email" mapstructure:"URL"`
+}
+
+// MFAConfig mengatur autentikasi dua faktor berbasis TOTP. Pengguna dengan role pada
+// REQUIRED_ROLES tetap dapat login tanpa TOTP, tetapi izin dari role tersebut ditahan hingga
+// TOTP diaktifkan. ENCRYPTION_KEY wajib diisi untuk mengenkripsi secret TOTP di database dan
+// sebaiknya berbeda dari JWT SECRET_KEY.
+type MFAConfig struct {
+	Issuer               string   `env:"ISSUER" envDefault:"Go Todo" mapstructure:"ISSUER"`
+	RequiredRoles        []string `env:"REQUIRED_ROLES" envDefault:"admin" envSeparator:"," mapstructure:"REQUIRED_ROLES"`
+	ChallengeTTLSeconds  int      `env:"CHALLENGE_TTL_SECONDS" envDefault:"300" mapstructure:"CHALLENGE_TTL_SECONDS"`
+	MaxChallengeAttempts int      `env:"MAX_CHALLENGE_ATTEMPTS" envDefault:"5" mapstructure:"MAX_CHALLENGE_ATTEMPTS"`
+	RecoveryCodes        int      `env:"RECOVERY_CODES" envDefault:"10" mapstructure:"RECOVERY_CODES"`
+	EncryptionKey        string   `env:"ENCRYPTION_KEY" envDefault:"" mapstructure:"ENCRYPTION_KEY"`
+}
+
+// MailConfig mengatur pengiriman email. DRIVER smtp (default) mewajibkan SMTP_HOST. DRIVER log
+// hanya mencetak email beserta token di dalamnya ke log dan hanya untuk pengembangan.
+type MailConfig struct {
+	Driver         string `env:"DRIVER" envDefault:"smtp" mapstructure:"DRIVER"`
+	SMTPHost       string `env:"SMTP_HOST" envDefault:"" mapstructure:"SMTP_HOST"`
+	SMTPPort       string `env:"SMTP_PORT" envDefault:"587" mapstructure:"SMTP_PORT"`
+	Username       string `env:"USERNAME" envDefault:"" mapstructure:"USERNAME"`
+	Password       string `env:"PASSWORD" envDefault:"" mapstructure:"PASSWORD"`
+	From           string `env:"FROM" envDefault:"noreply@go-todo.local" mapstructure:"FROM"`
+	TimeoutSeconds int    `env:"TIMEOUT_SECONDS" envDefault:"10" mapstructure:"TIMEOUT_SECONDS"`
+}
+
+// ServerConfig mengatur server HTTP. TRUSTED_PROXIES berisi rentang CIDR reverse proxy yang
+// boleh menentukan IP klien melalui X-Forwarded-For. Jika kosong, IP diambil langsung dari
+// koneksi dan header tersebut diabaikan.
+type ServerConfig struct {
+	TrustedProxies []string `env:"TRUSTED_PROXIES" envSeparator:"," mapstructure:"TRUSTED_PROXIES"`
 }
 
 type PostgresConfig struct {
@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE IF EXISTS time_entries;
+ALTER TABLE todos DROP COLUMN IF EXISTS project;
+
+COMMIT;
@@ -0,0 +1,23 @@
+BEGIN;
+
+ALTER TABLE todos ADD COLUMN IF NOT EXISTS project VARCHAR(255);
+
+CREATE TABLE IF NOT EXISTS time_entries (
+    id BIGSERIAL PRIMARY KEY,
+    todo_id BIGINT NOT NULL,
+    user_id BIGINT NOT NULL,
+    started_at TIMESTAMP NOT NULL,
+    ended_at TIMESTAMP,
+    duration_seconds BIGINT NOT NULL DEFAULT 0,
+    note VARCHAR(255),
+    FOREIGN KEY (todo_id) REFERENCES todos(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_time_entries_todo_id ON time_entries (todo_id);
+CREATE INDEX IF NOT EXISTS idx_time_entries_user_started ON time_entries (user_id, started_at);
+
+-- Setiap pengguna hanya boleh memiliki satu timer yang sedang berjalan
+CREATE UNIQUE INDEX IF NOT EXISTS uq_time_entries_running_per_user ON time_entries (user_id) WHERE ended_at IS NULL;
+
+COMMIT;
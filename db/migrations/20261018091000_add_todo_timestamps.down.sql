@@ -0,0 +1,8 @@
+BEGIN;
+
+DROP INDEX IF EXISTS idx_todos_user_completed_at;
+DROP INDEX IF EXISTS idx_todos_user_created_at;
+ALTER TABLE todos DROP COLUMN IF EXISTS completed_at;
+ALTER TABLE todos DROP COLUMN IF EXISTS created_at;
+
+COMMIT;
@@ -0,0 +1,9 @@
+BEGIN;
+
+ALTER TABLE todos ADD COLUMN IF NOT EXISTS created_at TIMESTAMP NOT NULL DEFAULT NOW();
+ALTER TABLE todos ADD COLUMN IF NOT EXISTS completed_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_todos_user_created_at ON todos (user_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_todos_user_completed_at ON todos (user_id, completed_at);
+
+COMMIT;
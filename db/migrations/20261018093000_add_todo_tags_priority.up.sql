@@ -0,0 +1,8 @@
+BEGIN;
+
+ALTER TABLE todos ADD COLUMN IF NOT EXISTS tags JSONB NOT NULL DEFAULT '[]';
+ALTER TABLE todos ADD COLUMN IF NOT EXISTS priority VARCHAR(16);
+
+CREATE INDEX IF NOT EXISTS idx_todos_tags ON todos USING GIN (tags);
+
+COMMIT;
@@ -0,0 +1,14 @@
+BEGIN;
+
+ALTER TABLE login_events ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE 'Asia/Jakarta';
+ALTER TABLE time_entries ALTER COLUMN ended_at TYPE TIMESTAMP USING ended_at AT TIME ZONE 'Asia/Jakarta';
+ALTER TABLE time_entries ALTER COLUMN started_at TYPE TIMESTAMP USING started_at AT TIME ZONE 'Asia/Jakarta';
+ALTER TABLE todos ALTER COLUMN completed_at TYPE TIMESTAMP USING completed_at AT TIME ZONE 'Asia/Jakarta';
+ALTER TABLE todos ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE 'Asia/Jakarta';
+ALTER TABLE todos ALTER COLUMN due_date TYPE TIMESTAMP USING
+    CASE WHEN due_date = '0001-01-01 00:00:00+00' THEN '0001-01-01 00:00:00'::TIMESTAMP
+    ELSE due_date AT TIME ZONE 'Asia/Jakarta' END;
+
+DROP TABLE IF EXISTS user_preferences;
+
+COMMIT;
@@ -0,0 +1,23 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS user_preferences (
+    user_id BIGINT PRIMARY KEY,
+    timezone VARCHAR(64) NOT NULL DEFAULT 'Asia/Jakarta',
+    locale VARCHAR(16) NOT NULL DEFAULT 'id-ID',
+    week_start SMALLINT NOT NULL DEFAULT 1,
+    date_format VARCHAR(32) NOT NULL DEFAULT 'YYYY-MM-DD',
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- Nilai lama disimpan tanpa zona waktu dalam WIB, konversi menjadi TIMESTAMPTZ.
+-- Due date kosong (zero time Go) dipertahankan apa adanya dalam UTC.
+ALTER TABLE todos ALTER COLUMN due_date TYPE TIMESTAMPTZ USING
+    CASE WHEN due_date = '0001-01-01 00:00:00' THEN '0001-01-01 00:00:00+00'::TIMESTAMPTZ
+    ELSE due_date AT TIME ZONE 'Asia/Jakarta' END;
+ALTER TABLE todos ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE 'Asia/Jakarta';
+ALTER TABLE todos ALTER COLUMN completed_at TYPE TIMESTAMPTZ USING completed_at AT TIME ZONE 'Asia/Jakarta';
+ALTER TABLE time_entries ALTER COLUMN started_at TYPE TIMESTAMPTZ USING started_at AT TIME ZONE 'Asia/Jakarta';
+ALTER TABLE time_entries ALTER COLUMN ended_at TYPE TIMESTAMPTZ USING ended_at AT TIME ZONE 'Asia/Jakarta';
+ALTER TABLE login_events ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE 'Asia/Jakarta';
+
+COMMIT;
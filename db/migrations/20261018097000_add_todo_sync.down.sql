@@ -0,0 +1,20 @@
+BEGIN;
+
+DROP TRIGGER IF EXISTS trg_todos_track_delete ON todos;
+DROP TRIGGER IF EXISTS trg_todos_track_change ON todos;
+DROP FUNCTION IF EXISTS todos_track_delete();
+DROP FUNCTION IF EXISTS todos_track_change();
+
+DROP TABLE IF EXISTS todo_tombstones;
+
+DROP INDEX IF EXISTS idx_todos_user_change_seq;
+DROP INDEX IF EXISTS idx_todos_user_client_id;
+ALTER TABLE todos
+    DROP COLUMN IF EXISTS updated_at,
+    DROP COLUMN IF EXISTS change_seq,
+    DROP COLUMN IF EXISTS version,
+    DROP COLUMN IF EXISTS client_id;
+
+DROP SEQUENCE IF EXISTS todo_change_seq;
+
+COMMIT;
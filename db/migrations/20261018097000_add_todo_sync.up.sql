@@ -0,0 +1,60 @@
+BEGIN;
+
+-- change_seq adalah cursor sinkronisasi yang naik setiap kali todo dibuat, diubah, atau dihapus
+CREATE SEQUENCE IF NOT EXISTS todo_change_seq;
+
+ALTER TABLE todos
+    ADD COLUMN IF NOT EXISTS client_id VARCHAR(64),
+    ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1,
+    ADD COLUMN IF NOT EXISTS change_seq BIGINT NOT NULL DEFAULT nextval('todo_change_seq'),
+    ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_todos_user_client_id ON todos (user_id, client_id) WHERE client_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_todos_user_change_seq ON todos (user_id, change_seq);
+
+-- Tombstone mencatat todo yang dihapus agar klien offline dapat ikut menghapusnya
+CREATE TABLE IF NOT EXISTS todo_tombstones (
+    todo_id BIGINT PRIMARY KEY,
+    user_id INT NOT NULL,
+    client_id VARCHAR(64),
+    version BIGINT NOT NULL,
+    change_seq BIGINT NOT NULL,
+    deleted_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_todo_tombstones_user_change_seq ON todo_tombstones (user_id, change_seq);
+
+-- Lock per pengguna ditahan hingga commit, sehingga change_seq milik satu pengguna selalu
+-- terlihat sesuai urutan dan klien tidak melewatkan perubahan yang di-commit belakangan
+CREATE OR REPLACE FUNCTION todos_track_change() RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM pg_advisory_xact_lock(hashtext('todo_sync'), NEW.user_id);
+    IF TG_OP = 'UPDATE' THEN
+        NEW.version := OLD.version + 1;
+    ELSE
+        NEW.version := 1;
+    END IF;
+    NEW.change_seq := nextval('todo_change_seq');
+    NEW.updated_at := NOW();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION todos_track_delete() RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM pg_advisory_xact_lock(hashtext('todo_sync'), OLD.user_id);
+    INSERT INTO todo_tombstones (todo_id, user_id, client_id, version, change_seq)
+    VALUES (OLD.id, OLD.user_id, OLD.client_id, OLD.version + 1, nextval('todo_change_seq'));
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_todos_track_change ON todos;
+CREATE TRIGGER trg_todos_track_change BEFORE INSERT OR UPDATE ON todos
+    FOR EACH ROW EXECUTE FUNCTION todos_track_change();
+
+DROP TRIGGER IF EXISTS trg_todos_track_delete ON todos;
+CREATE TRIGGER trg_todos_track_delete AFTER DELETE ON todos
+    FOR EACH ROW EXECUTE FUNCTION todos_track_delete();
+
+COMMIT;
@@ -0,0 +1,16 @@
+BEGIN;
+
+-- Filter tersimpan (smart list) milik pengguna. Definisi berupa DSL JSON yang
+-- dikompilasi menjadi kondisi query todo, lihat pkg/filter.
+CREATE TABLE IF NOT EXISTS saved_filters (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    definition JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_saved_filters_user_id ON saved_filters (user_id);
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+
+DROP TABLE IF EXISTS todo_templates;
+
+DROP INDEX IF EXISTS idx_todos_parent_id;
+ALTER TABLE todos DROP COLUMN IF EXISTS parent_id;
+
+COMMIT;
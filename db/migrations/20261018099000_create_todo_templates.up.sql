@@ -0,0 +1,23 @@
+BEGIN;
+
+-- Subtask adalah todo biasa yang merujuk ke todo induknya
+ALTER TABLE todos
+    ADD COLUMN IF NOT EXISTS parent_id BIGINT REFERENCES todos(id) ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS idx_todos_parent_id ON todos (parent_id) WHERE parent_id IS NOT NULL;
+
+-- Template menyimpan kumpulan definisi todo (beserta subtask, offset jatuh tempo, dan tag)
+-- yang dapat dibuat ulang menjadi todo konkret dengan substitusi variabel
+CREATE TABLE IF NOT EXISTS todo_templates (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    items JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_todo_templates_user_id ON todo_templates (user_id);
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+
+DROP TABLE IF EXISTS notifications;
+
+DROP INDEX IF EXISTS idx_todos_assignee_id;
+ALTER TABLE todos DROP COLUMN IF EXISTS assignee_id;
+
+COMMIT;
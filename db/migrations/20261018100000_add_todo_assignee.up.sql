@@ -0,0 +1,26 @@
+BEGIN;
+
+-- Penanggung jawab todo, terpisah dari pemilik (user_id)
+ALTER TABLE todos
+    ADD COLUMN IF NOT EXISTS assignee_id BIGINT REFERENCES users(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_todos_assignee_id ON todos (assignee_id) WHERE assignee_id IS NOT NULL;
+
+-- Notifikasi dibuat dari event outbox; event_id membuat pembuatan notifikasi idempoten
+-- karena relay dapat meneruskan event yang sama lebih dari sekali
+CREATE TABLE IF NOT EXISTS notifications (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type VARCHAR(64) NOT NULL,
+    todo_id BIGINT,
+    actor_id BIGINT,
+    message TEXT NOT NULL,
+    event_id VARCHAR(64) NOT NULL,
+    read_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (event_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_user_id ON notifications (user_id, id DESC);
+
+COMMIT;
@@ -0,0 +1,26 @@
+BEGIN;
+
+-- Definisi field kustom milik pengguna. Nilainya disimpan di todos.custom_fields
+-- dengan key definisi sebagai key JSON.
+CREATE TABLE IF NOT EXISTS custom_field_definitions (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    key VARCHAR(64) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    type VARCHAR(16) NOT NULL,
+    required BOOLEAN NOT NULL DEFAULT FALSE,
+    options JSONB NOT NULL DEFAULT '[]',
+    min NUMERIC,
+    max NUMERIC,
+    max_length INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, key)
+);
+
+ALTER TABLE todos
+    ADD COLUMN IF NOT EXISTS custom_fields JSONB NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS idx_todos_custom_fields ON todos USING GIN (custom_fields);
+
+COMMIT;
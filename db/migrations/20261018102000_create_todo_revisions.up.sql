@@ -0,0 +1,15 @@
+BEGIN;
+
+-- Riwayat versi isi todo. Setiap revisi menyimpan snapshot lengkap sehingga
+-- revisi mana pun dapat dibandingkan atau dipulihkan tanpa menyusun ulang perubahan.
+CREATE TABLE IF NOT EXISTS todo_revisions (
+    id BIGSERIAL PRIMARY KEY,
+    todo_id BIGINT NOT NULL REFERENCES todos(id) ON DELETE CASCADE,
+    revision INT NOT NULL,
+    snapshot JSONB NOT NULL,
+    reverted_from INT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (todo_id, revision)
+);
+
+COMMIT;
@@ -0,0 +1,20 @@
+BEGIN;
+
+-- Refresh token disimpan sebagai hash SHA-256 sehingga isi tabel tidak dapat dipakai untuk login.
+-- Setiap login memulai family baru; rotasi menghasilkan token baru dalam family yang sama,
+-- dan pemakaian ulang token yang sudah dirotasi mencabut seluruh family.
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    family_id VARCHAR(64) NOT NULL,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_family_id ON refresh_tokens (family_id);
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_user_id ON refresh_tokens (user_id);
+
+COMMIT;
@@ -0,0 +1,19 @@
+BEGIN;
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS role VARCHAR(255) NOT NULL DEFAULT 'user';
+
+-- Pengguna dengan beberapa role dikembalikan ke satu role, dengan admin diutamakan
+UPDATE users SET role = picked.name
+FROM (
+    SELECT DISTINCT ON (user_roles.user_id) user_roles.user_id, roles.name
+    FROM user_roles JOIN roles ON roles.id = user_roles.role_id
+    ORDER BY user_roles.user_id, (roles.name = 'admin') DESC, roles.id
+) AS picked
+WHERE users.id = picked.user_id;
+
+ALTER TABLE users ALTER COLUMN role DROP DEFAULT;
+
+DROP TABLE IF EXISTS user_roles;
+DROP TABLE IF EXISTS roles;
+
+COMMIT;
@@ -0,0 +1,41 @@
+BEGIN;
+
+-- Role menjadi kumpulan izin yang disimpan di database dan dapat diubah oleh admin.
+-- Izin "*" pada role admin berarti seluruh izin, termasuk izin yang ditambahkan kemudian.
+CREATE TABLE IF NOT EXISTS roles (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(50) NOT NULL UNIQUE,
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    permissions JSONB NOT NULL DEFAULT '[]',
+    built_in BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Seorang pengguna dapat memiliki lebih dari satu role
+CREATE TABLE IF NOT EXISTS user_roles (
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role_id BIGINT NOT NULL REFERENCES roles(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, role_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_roles_role_id ON user_roles (role_id);
+
+INSERT INTO roles (name, description, permissions, built_in) VALUES
+    ('admin', 'Administrator dengan seluruh izin', '["*"]', TRUE),
+    ('user', 'Pengguna biasa yang mengelola todo miliknya sendiri', '["todo:read", "todo:write"]', TRUE)
+ON CONFLICT (name) DO NOTHING;
+
+-- Role lama di luar role bawaan dipertahankan tanpa izin agar dapat diatur ulang oleh admin
+INSERT INTO roles (name, permissions)
+SELECT DISTINCT role, '[]'::jsonb FROM users WHERE role NOT IN ('admin', 'user')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO user_roles (user_id, role_id)
+SELECT users.id, roles.id FROM users JOIN roles ON roles.name = users.role
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE users DROP COLUMN IF EXISTS role;
+
+COMMIT;
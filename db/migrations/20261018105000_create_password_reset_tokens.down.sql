@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE IF EXISTS password_reset_tokens;
+ALTER TABLE users DROP COLUMN IF EXISTS email;
+
+COMMIT;
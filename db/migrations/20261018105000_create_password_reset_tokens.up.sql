@@ -0,0 +1,18 @@
+BEGIN;
+
+-- Alamat email tujuan instruksi reset password; boleh kosong untuk pengguna lama
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email VARCHAR(255);
+
+-- Token reset disimpan sebagai hash SHA-256 dan hanya dapat dipakai sekali sebelum kedaluwarsa
+CREATE TABLE IF NOT EXISTS password_reset_tokens (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_password_reset_tokens_user_id_created_at ON password_reset_tokens (user_id, created_at);
+
+COMMIT;
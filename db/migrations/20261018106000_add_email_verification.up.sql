@@ -0,0 +1,25 @@
+BEGIN;
+
+-- Email disimpan dalam huruf kecil sehingga keunikan tidak bergantung pada kapitalisasi.
+-- Email kosong dari data lama diubah menjadi NULL agar tidak dianggap duplikat.
+UPDATE users SET email = NULL WHERE TRIM(email) = '';
+UPDATE users SET email = LOWER(TRIM(email)) WHERE email IS NOT NULL;
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email_verified_at TIMESTAMPTZ;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email ON users (email);
+
+-- Token verifikasi mencatat alamat yang diverifikasi sehingga token untuk email lama
+-- tidak berlaku lagi setelah pengguna mengganti email
+CREATE TABLE IF NOT EXISTS email_verification_tokens (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_email_verification_tokens_user_id_created_at ON email_verification_tokens (user_id, created_at);
+
+COMMIT;
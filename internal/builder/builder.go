@@ -1,41 +1,245 @@
 package builder
 
 import (
+	"errors"
+	"fmt"
 	"go-todo/configs"
 	"go-todo/internal/http/router"
 	"go-todo/internal/http/handler"
 	"go-todo/internal/repository"
 	"go-todo/internal/service"
 	"go-todo/pkg/cache"
+	"go-todo/pkg/eventstream"
+	"go-todo/pkg/mail"
+	"go-todo/pkg/password"
+	"go-todo/pkg/realtime"
 	"go-todo/pkg/route"
+	"go-todo/pkg/secretbox"
+	"go-todo/pkg/throttle"
 	"go-todo/pkg/token"
+	"go-todo/pkg/webhook"
+	"time"
 
 	"github.com/redis/go-redis/v9"
 	"gorm.io/gorm"
 )
 
-func BuildPublicRoutes(cfg *configs.Config, db *gorm.DB, rdb *redis.Client) []route.Route {
+func BuildPublicRoutes(cfg *configs.Config, db *gorm.DB, rdb *redis.Client, keys *token.KeySet, mfaService service.MFAService, mailSender mail.Sender) []route.Route {
 	cacheable := cache.NewCacheable(rdb)
 	userRepository := repository.NewUserRepository(db)
-	tokenUseCase := token.NewTokenUseCase(cfg.JWT.SecretKey)
-	
-	userService := service.NewUserService(userRepository, tokenUseCase, cacheable)
+
+	loginEventRepository := repository.NewLoginEventRepository(db)
+	transactor := repository.NewTransactor(db)
+	eventRecorder := service.NewEventRecorder(repository.NewOutboxRepository(db))
+
+	roleRepository := repository.NewRoleRepository(db)
+	authorizationService := BuildAuthorizationService(cfg, db, rdb)
+	loginGuard := service.NewLoginGuard(throttle.NewAttemptStore(rdb), cfg.Login)
+
+	tokenService := BuildTokenService(cfg, db, rdb, keys)
+	authHandler := handler.NewAuthHandler(tokenService)
+
+	emailVerificationService := BuildEmailVerificationService(cfg, db, rdb, mailSender)
+	emailVerificationHandler := handler.NewEmailVerificationHandler(emailVerificationService)
+
+	userService := service.NewUserService(userRepository, roleRepository, tokenService, authorizationService, cacheable, loginEventRepository, loginGuard, password.NewPolicy(cfg.Password), emailVerificationService, mfaService, transactor, eventRecorder)
 	userHandler := handler.NewUserHandler(userService)
 
-	return router.PublicRoutes(userHandler)
+	passwordResetService := service.NewPasswordResetService(userRepository, repository.NewPasswordResetRepository(db), tokenService, loginGuard, password.NewPolicy(cfg.Password), mailSender, transactor, cfg.PasswordReset)
+	passwordResetHandler := handler.NewPasswordResetHandler(passwordResetService)
+
+	return router.PublicRoutes(userHandler, authHandler, passwordResetHandler, emailVerificationHandler)
 }
 
-func BuildPrivateRoutes(cfg *configs.Config, db *gorm.DB, rdb *redis.Client) []route.Route {
+func BuildPrivateRoutes(cfg *configs.Config, db *gorm.DB, rdb *redis.Client, keys *token.KeySet, mfaService service.MFAService, mailSender mail.Sender) []route.Route {
 	cacheable := cache.NewCacheable(rdb)
 	userRepository := repository.NewUserRepository(db)
-	tokenUseCase := token.NewTokenUseCase(cfg.JWT.SecretKey)
-	
-	userService := service.NewUserService(userRepository, tokenUseCase, cacheable)
+
+	loginEventRepository := repository.NewLoginEventRepository(db)
+	transactor := repository.NewTransactor(db)
+	eventRecorder := service.NewEventRecorder(repository.NewOutboxRepository(db))
+
+	webhookService := BuildWebhookService(cfg, db)
+	webhookHandler := handler.NewWebhookHandler(webhookService)
+
+	realtimeService := service.NewRealtimeService(realtime.NewBroker(rdb))
+	realtimeHandler := handler.NewRealtimeHandler(realtimeService)
+
+	roleRepository := repository.NewRoleRepository(db)
+	authorizationService := BuildAuthorizationService(cfg, db, rdb)
+	loginGuard := service.NewLoginGuard(throttle.NewAttemptStore(rdb), cfg.Login)
+
+	tokenService := BuildTokenService(cfg, db, rdb, keys)
+	authHandler := handler.NewAuthHandler(tokenService)
+
+	emailVerificationService := BuildEmailVerificationService(cfg, db, rdb, mailSender)
+	emailVerificationHandler := handler.NewEmailVerificationHandler(emailVerificationService)
+
+	mfaHandler := handler.NewMFAHandler(mfaService)
+
+	userService := service.NewUserService(userRepository, roleRepository, tokenService, authorizationService, cacheable, loginEventRepository, loginGuard, password.NewPolicy(cfg.Password), emailVerificationService, mfaService, transactor, eventRecorder)
 	userHandler := handler.NewUserHandler(userService)
 
+	roleService := service.NewRoleService(roleRepository, authorizationService, transactor)
+	roleHandler := handler.NewRoleHandler(roleService)
+
+	userPreferenceRepository := repository.NewUserPreferenceRepository(db)
+	userPreferenceService := service.NewUserPreferenceService(userPreferenceRepository, cacheable)
+	userPreferenceHandler := handler.NewUserPreferenceHandler(userPreferenceService)
+
 	todoRepository := repository.NewTodoRepository(db)
-	todoService := service.NewTodoService(todoRepository, cacheable)
-	todoHandler := handler.NewTodoHandler(todoService)
+	customFieldService := service.NewCustomFieldService(repository.NewCustomFieldRepository(db), todoRepository, transactor)
+	customFieldHandler := handler.NewCustomFieldHandler(customFieldService)
+	revisionRepository := repository.NewRevisionRepository(db)
+	todoService := service.NewTodoService(todoRepository, cacheable, transactor, eventRecorder, customFieldService, service.NewRevisionRecorder(revisionRepository))
+	assignmentService := service.NewAssignmentService(todoRepository, userRepository, todoService, cacheable, transactor, eventRecorder, authorizationService)
+	assignmentHandler := handler.NewAssignmentHandler(assignmentService)
+	syncRepository := repository.NewSyncRepository(db)
+	undoService := service.NewUndoService(todoRepository, syncRepository, todoService, cacheable, transactor, cfg.Undo)
+	undoHandler := handler.NewUndoHandler(undoService)
+	todoHandler := handler.NewTodoHandler(todoService, userPreferenceService, assignmentService, undoService)
+	revisionService := service.NewRevisionService(revisionRepository, todoRepository, todoService)
+	revisionHandler := handler.NewRevisionHandler(revisionService, assignmentService)
+
+	notificationService := service.NewNotificationService(repository.NewNotificationRepository(db), realtime.NewBroker(rdb))
+	notificationHandler := handler.NewNotificationHandler(notificationService)
+
+	syncService := service.NewSyncService(syncRepository, todoRepository, todoService, transactor)
+	syncHandler := handler.NewSyncHandler(syncService)
+
+	savedFilterRepository := repository.NewSavedFilterRepository(db)
+	filterService := service.NewFilterService(savedFilterRepository, todoRepository, userPreferenceService)
+	filterHandler := handler.NewFilterHandler(filterService)
+
+	templateRepository := repository.NewTemplateRepository(db)
+	templateService := service.NewTemplateService(templateRepository, todoRepository, todoService, userPreferenceService, transactor)
+	templateHandler := handler.NewTemplateHandler(templateService)
+
+	timeEntryRepository := repository.NewTimeEntryRepository(db)
+	timeEntryService := service.NewTimeEntryService(timeEntryRepository, todoRepository, cacheable)
+	timeEntryHandler := handler.NewTimeEntryHandler(timeEntryService, userPreferenceService)
+
+	statsRepository := repository.NewStatsRepository(db)
+	statsService := service.NewStatsService(statsRepository, userPreferenceService, cacheable)
+	statsHandler := handler.NewStatsHandler(statsService, userPreferenceService)
+
+	analyticsRepository := repository.NewAnalyticsRepository(db)
+	analyticsService := service.NewAnalyticsService(analyticsRepository)
+	analyticsHandler := handler.NewAnalyticsHandler(analyticsService, userPreferenceService)
+
+	return router.PrivateRoutes(userHandler, todoHandler, timeEntryHandler, statsHandler, analyticsHandler, userPreferenceHandler, webhookHandler, realtimeHandler, syncHandler, filterHandler, templateHandler, assignmentHandler, notificationHandler, customFieldHandler, revisionHandler, undoHandler, authHandler, roleHandler, emailVerificationHandler, mfaHandler)
+}
+
+// BuildOutboxRelay menyusun relay yang meneruskan event outbox ke Redis Stream,
+// antrean webhook, klien realtime, dan notifikasi pengguna
+func BuildOutboxRelay(cfg *configs.Config, db *gorm.DB, rdb *redis.Client) service.OutboxRelay {
+	stream := eventstream.NewWriter(rdb, cfg.Outbox.StreamName, cfg.Outbox.StreamMaxLen)
+	return service.NewOutboxRelay(
+		repository.NewTransactor(db),
+		repository.NewOutboxRepository(db),
+		cfg.Outbox,
+		service.NewStreamSubscriber(stream),
+		BuildWebhookService(cfg, db),
+		service.NewRealtimeService(realtime.NewBroker(rdb)),
+		service.NewNotificationService(repository.NewNotificationRepository(db), realtime.NewBroker(rdb)),
+	)
+}
+
+// BuildUserService menyusun UserService untuk bootstrap admin saat startup dan dari perintah CLI
+func BuildUserService(cfg *configs.Config, db *gorm.DB, rdb *redis.Client, keys *token.KeySet, mfaService service.MFAService, mailSender mail.Sender) service.UserService {
+	return service.NewUserService(
+		repository.NewUserRepository(db),
+		repository.NewRoleRepository(db),
+		BuildTokenService(cfg, db, rdb, keys),
+		BuildAuthorizationService(cfg, db, rdb),
+		cache.NewCacheable(rdb),
+		repository.NewLoginEventRepository(db),
+		service.NewLoginGuard(throttle.NewAttemptStore(rdb), cfg.Login),
+		password.NewPolicy(cfg.Password),
+		BuildEmailVerificationService(cfg, db, rdb, mailSender),
+		mfaService,
+		repository.NewTransactor(db),
+		service.NewEventRecorder(repository.NewOutboxRepository(db)),
+	)
+}
+
+// BuildTokenService menyusun TokenService yang menerbitkan, merotasi, dan mencabut token login
+func BuildTokenService(cfg *configs.Config, db *gorm.DB, rdb *redis.Client, keys *token.KeySet) service.TokenService {
+	return service.NewTokenService(
+		token.NewKeySetTokenUseCase(keys),
+		repository.NewRefreshTokenRepository(db),
+		repository.NewUserRepository(db),
+		repository.NewTransactor(db),
+		token.NewRevocationStore(rdb),
+		BuildAuthorizationService(cfg, db, rdb),
+		cfg.JWT,
+	)
+}
+
+// BuildAuthorizationService menyusun AuthorizationService yang menyediakan role dan izin pengguna
+// untuk middleware maupun service
+func BuildAuthorizationService(cfg *configs.Config, db *gorm.DB, rdb *redis.Client) service.AuthorizationService {
+	return service.NewAuthorizationService(repository.NewRoleRepository(db), repository.NewUserRepository(db), repository.NewMFARepository(db), cache.NewCacheable(rdb), cfg.EmailVerification, cfg.MFA)
+}
+
+// BuildEmailVerificationService menyusun EmailVerificationService yang mengirim dan memeriksa
+// token verifikasi email
+func BuildEmailVerificationService(cfg *configs.Config, db *gorm.DB, rdb *redis.Client, mailSender mail.Sender) service.EmailVerificationService {
+	return service.NewEmailVerificationService(
+		repository.NewUserRepository(db),
+		repository.NewEmailVerificationRepository(db),
+		BuildAuthorizationService(cfg, db, rdb),
+		mailSender,
+		repository.NewTransactor(db),
+		cfg.EmailVerification,
+	)
+}
+
+// BuildMFAService menyusun MFAService untuk pendaftaran TOTP dan langkah kedua login. Secret
+// TOTP dienkripsi dengan MFA ENCRYPTION_KEY yang wajib diisi.
+func BuildMFAService(cfg *configs.Config, db *gorm.DB, rdb *redis.Client) (service.MFAService, error) {
+	box, err := secretbox.New(cfg.MFA.EncryptionKey)
+	if err != nil {
+		return nil, fmt.Errorf("MFA ENCRYPTION_KEY tidak valid: %w", err)
+	}
+	return service.NewMFAService(
+		repository.NewMFARepository(db),
+		repository.NewUserRepository(db),
+		BuildAuthorizationService(cfg, db, rdb),
+		cache.NewCacheable(rdb),
+		throttle.NewAttemptStore(rdb),
+		box,
+		repository.NewTransactor(db),
+		cfg.MFA,
+	), nil
+}
+
+// BuildWebhookService menyusun WebhookService yang digunakan untuk antrean event dan dispatcher
+func BuildWebhookService(cfg *configs.Config, db *gorm.DB) service.WebhookService {
+	webhookRepository := repository.NewWebhookRepository(db)
+	sender := webhook.NewSender(time.Duration(cfg.Webhook.TimeoutSeconds) * time.Second)
+	return service.NewWebhookService(webhookRepository, sender, cfg.Webhook)
+}
 
-	return router.PrivateRoutes(userHandler, todoHandler)
+// BuildMailSender menyusun pengirim email sesuai MAIL DRIVER. Pengirim log hanya dipakai jika
+// dipilih secara eksplisit agar token di dalam email tidak bocor ke log karena SMTP_HOST lupa diisi.
+func BuildMailSender(cfg *configs.Config) (mail.Sender, error) {
+	switch cfg.Mail.Driver {
+	case "log":
+		return mail.NewLogSender(), nil
+	case "smtp", "":
+	default:
+		return nil, fmt.Errorf("MAIL DRIVER %q tidak dikenal, gunakan smtp atau log", cfg.Mail.Driver)
+	}
+	if cfg.Mail.SMTPHost == "" {
+		return nil, errors.New("MAIL SMTP_HOST wajib diisi untuk driver smtp")
+	}
+	return mail.NewSMTPSender(mail.SMTPConfig{
+		Host:     cfg.Mail.SMTPHost,
+		Port:     cfg.Mail.SMTPPort,
+		Username: cfg.Mail.Username,
+		Password: cfg.Mail.Password,
+		From:     cfg.Mail.From,
+		Timeout:  time.Duration(cfg.Mail.TimeoutSeconds) * time.Second,
+	}), nil
 }
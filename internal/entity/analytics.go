@@ -0,0 +1,31 @@
+package entity
+
+import "time"
+
+// DailyCount berisi jumlah suatu metrik pada satu hari
+type DailyCount struct {
+	Day   time.Time `json:"day"`
+	Count int64     `json:"count"`
+}
+
+// DailyTodoActivity berisi jumlah todo yang dibuat dan diselesaikan pada satu hari
+type DailyTodoActivity struct {
+	Day       time.Time `json:"day"`
+	Created   int64     `json:"created"`
+	Completed int64     `json:"completed"`
+}
+
+// DailyLoginActivity berisi jumlah login berhasil dan gagal pada satu hari
+type DailyLoginActivity struct {
+	Day       time.Time `json:"day"`
+	Successes int64     `json:"successes"`
+	Failures  int64     `json:"failures"`
+}
+
+// TopUser berisi pengguna dengan jumlah todo terbanyak dalam rentang waktu
+type TopUser struct {
+	UserID    int64  `json:"user_id"`
+	Username  string `json:"username"`
+	Created   int64  `json:"created"`
+	Completed int64  `json:"completed"`
+}
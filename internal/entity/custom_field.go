@@ -0,0 +1,99 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Tipe field kustom yang didukung
+const (
+	CustomFieldText     = "text"
+	CustomFieldNumber   = "number"
+	CustomFieldDate     = "date"
+	CustomFieldSelect   = "select"
+	CustomFieldCheckbox = "checkbox"
+)
+
+// IsValidCustomFieldType memeriksa apakah tipe field kustom dikenal
+func IsValidCustomFieldType(fieldType string) bool {
+	switch fieldType {
+	case CustomFieldText, CustomFieldNumber, CustomFieldDate, CustomFieldSelect, CustomFieldCheckbox:
+		return true
+	}
+	return false
+}
+
+// CustomFieldDefinition mendefinisikan satu field kustom milik pengguna beserta aturan validasinya.
+// Options hanya berlaku untuk select, Min dan Max untuk number, MaxLength untuk text.
+type CustomFieldDefinition struct {
+	ID        int64      `json:"id" gorm:"primaryKey"`
+	UserID    int64      `json:"user_id"`
+	Key       string     `json:"key"`
+	Name      string     `json:"name"`
+	Type      string     `json:"type"`
+	Required  bool       `json:"required"`
+	Options   StringList `json:"options" gorm:"type:jsonb"`
+	Min       *float64   `json:"min"`
+	Max       *float64   `json:"max"`
+	MaxLength int        `json:"max_length"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// CustomFieldValues adalah nilai field kustom sebuah todo yang disimpan sebagai kolom JSONB.
+// Nilai number berupa angka, date berupa YYYY-MM-DD, checkbox berupa boolean,
+// sedangkan text dan select berupa string.
+type CustomFieldValues map[string]interface{}
+
+// Value mengubah CustomFieldValues menjadi JSON untuk disimpan ke database
+func (v CustomFieldValues) Value() (driver.Value, error) {
+	if v == nil {
+		return "{}", nil
+	}
+	data, err := json.Marshal(map[string]interface{}(v))
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan membaca kolom JSONB dari database ke dalam CustomFieldValues
+func (v *CustomFieldValues) Scan(value interface{}) error {
+	var data []byte
+	switch val := value.(type) {
+	case nil:
+		*v = CustomFieldValues{}
+		return nil
+	case []byte:
+		data = val
+	case string:
+		data = []byte(val)
+	default:
+		return fmt.Errorf("tipe %T tidak dapat dikonversi ke CustomFieldValues", value)
+	}
+	return json.Unmarshal(data, (*map[string]interface{})(v))
+}
+
+// CustomFieldFilter adalah kondisi pencarian todo berdasarkan field kustom sebelum divalidasi
+type CustomFieldFilter struct {
+	Key   string
+	Op    string
+	Value string
+}
+
+// CustomFieldCondition adalah kondisi pencarian yang sudah divalidasi dan memiliki tipe
+type CustomFieldCondition struct {
+	Key   string
+	Type  string
+	Op    string
+	Value interface{}
+}
+
+// CustomFieldSort mengurutkan todo berdasarkan nilai field kustom
+type CustomFieldSort struct {
+	Key  string
+	Type string
+	Desc bool
+}
@@ -0,0 +1,15 @@
+package entity
+
+import "time"
+
+// EmailVerificationToken adalah token verifikasi email yang tersimpan dalam bentuk hash.
+// Email berisi alamat yang diverifikasi oleh token ini.
+type EmailVerificationToken struct {
+	ID        int64      `json:"id" gorm:"primaryKey"`
+	UserID    int64      `json:"user_id"`
+	Email     string     `json:"email"`
+	TokenHash string     `json:"-"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
@@ -0,0 +1,12 @@
+package entity
+
+import "time"
+
+// LoginEvent mencatat setiap percobaan login, berhasil maupun gagal
+type LoginEvent struct {
+	ID        int64     `json:"id" gorm:"primaryKey"`
+	UserID    *int64    `json:"user_id"`
+	Username  string    `json:"username"`
+	Success   bool      `json:"success"`
+	CreatedAt time.Time `json:"created_at"`
+}
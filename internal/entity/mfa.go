@@ -0,0 +1,61 @@
+package entity
+
+import "time"
+
+// UserTOTP adalah pendaftaran TOTP milik pengguna. Secret tersimpan terenkripsi dan baru
+// berlaku untuk login setelah ConfirmedAt terisi.
+type UserTOTP struct {
+	UserID       int64      `json:"user_id" gorm:"primaryKey;autoIncrement:false"`
+	Secret       string     `json:"-"`
+	ConfirmedAt  *time.Time `json:"confirmed_at"`
+	LastUsedStep int64      `json:"-"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+func (UserTOTP) TableName() string {
+	return "user_totp"
+}
+
+// RecoveryCode adalah kode cadangan sekali pakai yang tersimpan dalam bentuk hash
+type RecoveryCode struct {
+	ID        int64      `json:"id" gorm:"primaryKey"`
+	UserID    int64      `json:"user_id"`
+	CodeHash  string     `json:"-"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+func (RecoveryCode) TableName() string {
+	return "user_recovery_codes"
+}
+
+// TOTPEnrollment berisi secret dan URI otpauth:// yang ditampilkan klien sebagai kode QR.
+// Secret hanya dikembalikan sekali saat pendaftaran.
+type TOTPEnrollment struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+// MFAStatus menjelaskan status autentikasi dua faktor pengguna
+type MFAStatus struct {
+	Enabled                bool       `json:"enabled"`
+	ConfirmedAt            *time.Time `json:"confirmed_at,omitempty"`
+	RecoveryCodesRemaining int64      `json:"recovery_codes_remaining"`
+	// Required berarti role pengguna mewajibkan autentikasi dua faktor
+	Required bool `json:"required"`
+}
+
+// MFAChallenge adalah token sementara yang ditukar dengan access token setelah pengguna
+// memasukkan kode TOTP atau recovery code
+type MFAChallenge struct {
+	Token     string    `json:"mfa_token"`
+	ExpiresAt time.Time `json:"mfa_expires_at"`
+}
+
+// LoginResult adalah hasil langkah pertama login: token jika pengguna tidak memakai
+// autentikasi dua faktor, atau challenge jika kode kedua masih diperlukan
+type LoginResult struct {
+	Tokens    *TokenPair
+	Challenge *MFAChallenge
+}
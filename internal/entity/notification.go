@@ -0,0 +1,24 @@
+package entity
+
+import "time"
+
+// Notification adalah pemberitahuan untuk pengguna, misalnya ketika ditugaskan ke sebuah todo
+type Notification struct {
+	ID        int64      `json:"id" gorm:"primaryKey"`
+	UserID    int64      `json:"user_id"`
+	Type      string     `json:"type"`
+	TodoID    *int64     `json:"todo_id"`
+	ActorID   *int64     `json:"actor_id"`
+	Message   string     `json:"message"`
+	EventID   string     `json:"-"`
+	ReadAt    *time.Time `json:"read_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// TodoAssignment adalah payload event todo.assigned dan todo.unassigned: todo setelah
+// perubahan beserta pengguna yang melakukan perubahan dan penanggung jawab sebelumnya
+type TodoAssignment struct {
+	Todo
+	ActorID            int64  `json:"actor_id"`
+	PreviousAssigneeID *int64 `json:"previous_assignee_id"`
+}
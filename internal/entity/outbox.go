@@ -0,0 +1,53 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Jenis aggregate yang menghasilkan domain event
+const (
+	AggregateTodo = "todo"
+	AggregateUser = "user"
+)
+
+// DomainEvent adalah perubahan pada sebuah aggregate yang dicatat ke outbox
+// dalam transaksi yang sama dengan perubahan datanya
+type DomainEvent struct {
+	Type          string
+	AggregateType string
+	AggregateID   int64
+	Data          interface{}
+}
+
+// OutboxEvent adalah domain event yang tersimpan di tabel outbox dan menunggu
+// diteruskan oleh relay. Event dianggap terkirim setelah PublishedAt terisi.
+type OutboxEvent struct {
+	ID            int64           `json:"id" gorm:"primaryKey"`
+	EventID       string          `json:"event_id"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateID   int64           `json:"aggregate_id"`
+	EventType     string          `json:"event_type"`
+	Payload       json.RawMessage `json:"payload" gorm:"type:jsonb"`
+	Attempts      int             `json:"attempts"`
+	NextAttemptAt *time.Time      `json:"next_attempt_at"`
+	LastError     string          `json:"last_error"`
+	CreatedAt     time.Time       `json:"created_at"`
+	PublishedAt   *time.Time      `json:"published_at"`
+}
+
+func (OutboxEvent) TableName() string {
+	return "outbox"
+}
+
+// Event menyusun amplop event yang diteruskan ke subscriber
+func (e *OutboxEvent) Event() Event {
+	return Event{
+		ID:            e.EventID,
+		Type:          e.EventType,
+		AggregateType: e.AggregateType,
+		AggregateID:   e.AggregateID,
+		OccurredAt:    e.CreatedAt.UTC(),
+		Data:          e.Payload,
+	}
+}
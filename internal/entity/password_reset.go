@@ -0,0 +1,14 @@
+package entity
+
+import "time"
+
+// PasswordResetToken adalah token reset password yang tersimpan dalam bentuk hash.
+// Token yang sudah dipakai atau digantikan memiliki UsedAt.
+type PasswordResetToken struct {
+	ID        int64      `json:"id" gorm:"primaryKey"`
+	UserID    int64      `json:"user_id"`
+	TokenHash string     `json:"-"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
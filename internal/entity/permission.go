@@ -0,0 +1,71 @@
+package entity
+
+// Izin bernama dengan format <resource>:<aksi>[:any]. Akhiran :any berarti izin berlaku
+// untuk data milik pengguna lain; tanpa akhiran, izin hanya berlaku untuk data sendiri.
+const (
+	PermissionAll           = "*"
+	PermissionTodoRead      = "todo:read"
+	PermissionTodoWrite     = "todo:write"
+	PermissionTodoUpdateAny = "todo:update:any"
+	PermissionTodoDeleteAny = "todo:delete:any"
+	PermissionReportReadAny = "report:read:any"
+	PermissionUserRead      = "user:read"
+	PermissionUserWrite     = "user:write"
+	PermissionRoleManage    = "role:manage"
+	PermissionAnalyticsRead = "analytics:read"
+	PermissionWebhookManage = "webhook:manage"
+)
+
+// Permissions berisi seluruh izin yang dapat dimasukkan ke dalam role
+var Permissions = []string{
+	PermissionTodoRead,
+	PermissionTodoWrite,
+	PermissionTodoUpdateAny,
+	PermissionTodoDeleteAny,
+	PermissionReportReadAny,
+	PermissionUserRead,
+	PermissionUserWrite,
+	PermissionRoleManage,
+	PermissionAnalyticsRead,
+	PermissionWebhookManage,
+}
+
+// IsValidPermission memeriksa apakah izin dikenal aplikasi
+func IsValidPermission(permission string) bool {
+	for _, known := range Permissions {
+		if permission == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Authorization adalah gabungan role dan izin milik seorang pengguna. EmailUnverified
+// menandai izin yang sedang dibatasi karena email pengguna belum diverifikasi, sedangkan
+// MFARequired menandai izin role yang ditahan hingga pengguna mengaktifkan TOTP.
+type Authorization struct {
+	Roles           []string `json:"roles"`
+	Permissions     []string `json:"permissions"`
+	EmailUnverified bool     `json:"email_unverified,omitempty"`
+	MFARequired     bool     `json:"mfa_required,omitempty"`
+}
+
+// Has memeriksa apakah izin dimiliki, termasuk melalui izin wildcard milik admin
+func (a Authorization) Has(permission string) bool {
+	for _, granted := range a.Permissions {
+		if granted == permission || granted == PermissionAll {
+			return true
+		}
+	}
+	return false
+}
+
+// HasRole memeriksa apakah pengguna memiliki role tertentu
+func (a Authorization) HasRole(role string) bool {
+	for _, granted := range a.Roles {
+		if granted == role {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,26 @@
+package entity
+
+import "time"
+
+// RefreshToken adalah refresh token yang tersimpan dalam bentuk hash. Token yang sudah
+// dirotasi memiliki UsedAt, sedangkan token yang dicabut memiliki RevokedAt.
+type RefreshToken struct {
+	ID        int64      `json:"id" gorm:"primaryKey"`
+	UserID    int64      `json:"user_id"`
+	FamilyID  string     `json:"family_id"`
+	TokenHash string     `json:"-"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at"`
+	RevokedAt *time.Time `json:"revoked_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// TokenPair adalah hasil login atau refresh: access token berumur pendek dan
+// refresh token untuk memperolehnya kembali tanpa mengirim password
+type TokenPair struct {
+	AccessToken           string    `json:"access_token"`
+	TokenType             string    `json:"token_type"`
+	ExpiresAt             time.Time `json:"expires_at"`
+	RefreshToken          string    `json:"refresh_token"`
+	RefreshTokenExpiresAt time.Time `json:"refresh_token_expires_at"`
+}
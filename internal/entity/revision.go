@@ -0,0 +1,57 @@
+package entity
+
+import "time"
+
+// TodoSnapshot adalah isi todo yang disimpan pada setiap revisi. Status selesai tidak
+// termasuk karena bukan bagian dari isi dan tidak ikut dipulihkan saat revert.
+type TodoSnapshot struct {
+	Title        string            `json:"title"`
+	Content      string            `json:"content"`
+	DueDate      time.Time         `json:"due_date"`
+	Project      string            `json:"project"`
+	Tags         StringList        `json:"tags"`
+	Priority     string            `json:"priority"`
+	CustomFields CustomFieldValues `json:"custom_fields"`
+}
+
+// TodoRevision adalah satu versi isi todo. RevertedFrom terisi jika revisi ini dibuat
+// dengan memulihkan revisi sebelumnya.
+type TodoRevision struct {
+	ID           int64        `json:"id" gorm:"primaryKey"`
+	TodoID       int64        `json:"todo_id"`
+	Revision     int          `json:"revision"`
+	Snapshot     TodoSnapshot `json:"snapshot" gorm:"type:jsonb;serializer:json"`
+	RevertedFrom *int         `json:"reverted_from"`
+	CreatedAt    time.Time    `json:"created_at"`
+}
+
+// RevisionChange adalah perbedaan satu field antara dua revisi
+type RevisionChange struct {
+	Field string      `json:"field"`
+	From  interface{} `json:"from"`
+	To    interface{} `json:"to"`
+}
+
+// Snapshot mengambil isi todo untuk disimpan sebagai revisi
+func (t *Todo) Snapshot() TodoSnapshot {
+	return TodoSnapshot{
+		Title:        t.Title,
+		Content:      t.Content,
+		DueDate:      t.DueDate,
+		Project:      t.Project,
+		Tags:         t.Tags,
+		Priority:     t.Priority,
+		CustomFields: t.CustomFields,
+	}
+}
+
+// ApplySnapshot mengganti isi todo dengan isi dari sebuah revisi
+func (t *Todo) ApplySnapshot(snapshot TodoSnapshot) {
+	t.Title = snapshot.Title
+	t.Content = snapshot.Content
+	t.DueDate = snapshot.DueDate
+	t.Project = snapshot.Project
+	t.Tags = snapshot.Tags
+	t.Priority = snapshot.Priority
+	t.CustomFields = snapshot.CustomFields
+}
@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"regexp"
+	"time"
+)
+
+// Role bawaan. Pengguna yang mendaftar sendiri selalu mendapat DefaultRole; role lain
+// hanya dapat diberikan oleh pengguna dengan izin role:manage.
+const (
+	RoleAdmin = "admin"
+	RoleUser  = "user"
+
+	DefaultRole = RoleUser
+)
+
+// Role adalah kumpulan izin bernama yang disimpan di database dan dapat diubah oleh admin.
+// Role bawaan tidak dapat dihapus, dan izin role admin tidak dapat diubah.
+type Role struct {
+	ID          int64      `json:"id" gorm:"primaryKey"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Permissions StringList `json:"permissions" gorm:"type:jsonb"`
+	BuiltIn     bool       `json:"built_in"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+var roleNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]{1,49}$`)
+
+// IsValidRoleName memeriksa format nama role: huruf kecil, angka, - atau _, 2-50 karakter
+func IsValidRoleName(name string) bool {
+	return roleNamePattern.MatchString(name)
+}
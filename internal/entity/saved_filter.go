@@ -0,0 +1,37 @@
+package entity
+
+import (
+	"go-todo/pkg/filter"
+	"strings"
+	"time"
+)
+
+// Key filter bawaan yang tersedia untuk setiap pengguna
+const (
+	FilterToday    = "today"
+	FilterUpcoming = "upcoming"
+	FilterOverdue  = "overdue"
+	FilterSomeday  = "someday"
+)
+
+// SavedFilter adalah smart list milik pengguna. Filter bawaan tidak disimpan di
+// database, memiliki ID 0, dan dikenali melalui Key.
+type SavedFilter struct {
+	ID         int64       `json:"id" gorm:"primaryKey"`
+	UserID     int64       `json:"user_id"`
+	Key        string      `json:"key,omitempty" gorm:"-"`
+	BuiltIn    bool        `json:"built_in" gorm:"-"`
+	Name       string      `json:"name"`
+	Definition filter.Expr `json:"definition" gorm:"type:jsonb;serializer:json"`
+	CreatedAt  time.Time   `json:"created_at"`
+	UpdatedAt  time.Time   `json:"updated_at"`
+}
+
+// IsBuiltInFilter memeriksa apakah key merujuk ke filter bawaan
+func IsBuiltInFilter(key string) bool {
+	switch strings.ToLower(key) {
+	case FilterToday, FilterUpcoming, FilterOverdue, FilterSomeday:
+		return true
+	}
+	return false
+}
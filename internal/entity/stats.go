@@ -0,0 +1,31 @@
+package entity
+
+import "time"
+
+// TodoStatusCounts berisi jumlah todo berdasarkan statusnya
+type TodoStatusCounts struct {
+	Open      int64 `json:"open"`
+	Completed int64 `json:"completed"`
+	Overdue   int64 `json:"overdue"`
+}
+
+// CompletionBucket berisi jumlah todo yang dibuat dan diselesaikan dalam satu periode
+type CompletionBucket struct {
+	BucketStart    time.Time `json:"bucket_start"`
+	Created        int64     `json:"created"`
+	Completed      int64     `json:"completed"`
+	CompletionRate float64   `json:"completion_rate" gorm:"-"`
+}
+
+// UserStats adalah ringkasan produktivitas seorang pengguna
+type UserStats struct {
+	UserID                 int64              `json:"user_id"`
+	Bucket                 string             `json:"bucket"`
+	From                   time.Time          `json:"from"`
+	To                     time.Time          `json:"to"`
+	Counts                 TodoStatusCounts   `json:"counts"`
+	CompletionRate         []CompletionBucket `json:"completion_rate"`
+	AverageLeadTimeSeconds float64            `json:"average_lead_time_seconds"`
+	CurrentStreakDays      int                `json:"current_streak_days"`
+	LongestStreakDays      int                `json:"longest_streak_days"`
+}
@@ -0,0 +1,39 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// StringList adalah daftar string yang disimpan sebagai kolom JSONB
+type StringList []string
+
+// Value mengubah StringList menjadi JSON untuk disimpan ke database
+func (l StringList) Value() (driver.Value, error) {
+	if l == nil {
+		return "[]", nil
+	}
+	data, err := json.Marshal([]string(l))
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan membaca kolom JSONB dari database ke dalam StringList
+func (l *StringList) Scan(value interface{}) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		*l = StringList{}
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("tipe %T tidak dapat dikonversi ke StringList", value)
+	}
+	return json.Unmarshal(data, (*[]string)(l))
+}
@@ -0,0 +1,80 @@
+package entity
+
+import "time"
+
+// Jenis perubahan pada feed sinkronisasi
+const (
+	SyncChangeUpsert = "upsert"
+	SyncChangeDelete = "delete"
+)
+
+// Operasi mutasi yang dikirim klien offline
+const (
+	SyncOpCreate = "create"
+	SyncOpUpdate = "update"
+	SyncOpDelete = "delete"
+)
+
+// Status hasil mutasi. Kebijakan konflik:
+//   - create bersifat idempoten terhadap client_id; mengirim ulang create yang sama
+//     mengembalikan todo yang sudah ada dengan status applied.
+//   - update dan delete hanya diterapkan jika base_version sama dengan versi di server.
+//     Jika berbeda, server yang menang: mutasi ditolak dengan status conflict dan
+//     todo versi server dikembalikan agar klien dapat menyesuaikan lalu mengirim ulang.
+//   - Penghapusan selalu menang atas perubahan: update pada todo yang sudah dihapus
+//     menghasilkan status deleted, sedangkan delete yang diulang tetap applied.
+const (
+	SyncStatusApplied  = "applied"
+	SyncStatusConflict = "conflict"
+	SyncStatusDeleted  = "deleted"
+	SyncStatusNotFound = "not_found"
+	SyncStatusInvalid  = "invalid"
+)
+
+// TodoTombstone mencatat todo yang sudah dihapus untuk keperluan sinkronisasi
+type TodoTombstone struct {
+	TodoID    int64     `json:"todo_id" gorm:"primaryKey"`
+	UserID    int64     `json:"user_id"`
+	ClientID  *string   `json:"client_id"`
+	Version   int64     `json:"version"`
+	ChangeSeq int64     `json:"-"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// SyncChange adalah satu perubahan todo sejak cursor tertentu, diurutkan berdasarkan Seq
+type SyncChange struct {
+	Seq       int64      `json:"seq"`
+	Type      string     `json:"type"`
+	ID        int64      `json:"id"`
+	ClientID  *string    `json:"client_id"`
+	Todo      *Todo      `json:"todo,omitempty"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+// SyncChanges adalah hasil GET /sync. Cursor dikirim kembali sebagai since pada
+// permintaan berikutnya; HasMore berarti masih ada perubahan yang belum diambil.
+type SyncChanges struct {
+	Cursor  int64        `json:"cursor"`
+	HasMore bool         `json:"has_more"`
+	Changes []SyncChange `json:"changes"`
+}
+
+// SyncMutation adalah satu perubahan yang dibuat klien saat offline. Todo yang dituju
+// dapat dirujuk dengan ID server atau dengan client_id jika ID server belum diketahui.
+type SyncMutation struct {
+	Op          string `json:"op"`
+	ID          int64  `json:"id"`
+	ClientID    string `json:"client_id"`
+	BaseVersion int64  `json:"base_version"`
+	Todo        Todo   `json:"todo"`
+}
+
+// SyncResult adalah hasil penerapan satu mutasi beserta state todo yang berlaku di server
+type SyncResult struct {
+	Index    int    `json:"index"`
+	Op       string `json:"op"`
+	ClientID string `json:"client_id,omitempty"`
+	Status   string `json:"status"`
+	Todo     *Todo  `json:"todo,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
@@ -0,0 +1,35 @@
+package entity
+
+import "time"
+
+// TodoTemplate adalah kumpulan definisi todo bernama yang dapat dibuat ulang menjadi todo konkret.
+// Teks pada item dapat berisi variabel {{nama}} yang diisi saat template digunakan.
+type TodoTemplate struct {
+	ID          int64          `json:"id" gorm:"primaryKey"`
+	UserID      int64          `json:"user_id"`
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Items       []TemplateItem `json:"items" gorm:"type:jsonb;serializer:json"`
+	Variables   []string       `json:"variables" gorm:"-"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+}
+
+// TemplateItem adalah definisi satu todo di dalam template. DueOffset berupa offset relatif
+// terhadap tanggal mulai, misalnya "+3d", "-1d", atau "+2w"; kosong berarti tanpa jatuh tempo.
+type TemplateItem struct {
+	Title     string         `json:"title"`
+	Content   string         `json:"content,omitempty"`
+	Project   string         `json:"project,omitempty"`
+	Tags      []string       `json:"tags,omitempty"`
+	Priority  string         `json:"priority,omitempty"`
+	DueOffset string         `json:"due_offset,omitempty"`
+	Subtasks  []TemplateItem `json:"subtasks,omitempty"`
+}
+
+// TemplateInstantiation adalah parameter untuk membuat todo dari template. StartDate
+// (YYYY-MM-DD) menjadi acuan DueOffset; kosong berarti hari ini pada zona waktu pengguna.
+type TemplateInstantiation struct {
+	Variables map[string]string `json:"variables"`
+	StartDate string            `json:"start_date"`
+}
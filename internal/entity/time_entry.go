@@ -0,0 +1,30 @@
+package entity
+
+import "time"
+
+type TimeEntry struct {
+	ID              int64      `json:"id" gorm:"primaryKey"`
+	TodoID          int64      `json:"todo_id"`
+	UserID          int64      `json:"user_id"`
+	StartedAt       time.Time  `json:"started_at"`
+	EndedAt         *time.Time `json:"ended_at"`
+	DurationSeconds int64      `json:"duration_seconds"`
+	Note            string     `json:"note"`
+}
+
+// TimeReportFilter berisi parameter filter untuk laporan waktu
+type TimeReportFilter struct {
+	UserID  int64
+	Project string
+	From    time.Time
+	To      time.Time
+}
+
+// TimeReportRow adalah satu baris laporan waktu per pengguna dan per project
+type TimeReportRow struct {
+	UserID       int64  `json:"user_id"`
+	Username     string `json:"username"`
+	Project      string `json:"project"`
+	EntryCount   int64  `json:"entry_count"`
+	TotalSeconds int64  `json:"total_seconds"`
+}
@@ -2,11 +2,52 @@ package entity
 
 import "time"
 
+// Nilai prioritas todo yang valid
+const (
+	PriorityLow    = "low"
+	PriorityMedium = "medium"
+	PriorityHigh   = "high"
+	PriorityUrgent = "urgent"
+)
+
 type Todo struct {
-	ID        int64     `json:"id" gorm:"primaryKey"`
-	Title     string    `json:"title"`
-	Content   string    `json:"content"`
-	DueDate   time.Time `json:"due_date"`
-	Completed bool      `json:"completed"`
-	UserID    int64     `json:"user_id"`
+	ID             int64             `json:"id" gorm:"primaryKey"`
+	Title          string            `json:"title"`
+	Content        string            `json:"content"`
+	DueDate        time.Time         `json:"due_date"`
+	Completed      bool              `json:"completed"`
+	UserID         int64             `json:"user_id"`
+	ParentID       *int64            `json:"parent_id"`
+	AssigneeID     *int64            `json:"assignee_id" gorm:"<-:update"`
+	Project        string            `json:"project"`
+	Tags           StringList        `json:"tags" gorm:"type:jsonb"`
+	CustomFields   CustomFieldValues `json:"custom_fields" gorm:"type:jsonb"`
+	Priority       string            `json:"priority"`
+	CreatedAt      time.Time         `json:"created_at"`
+	CompletedAt    *time.Time        `json:"completed_at"`
+	TrackedSeconds int64             `json:"tracked_seconds" gorm:"->;-:migration"`
+	ClientID       *string           `json:"client_id"`
+	Version        int64             `json:"version" gorm:"->"`
+	UpdatedAt      time.Time         `json:"updated_at" gorm:"->"`
+	ChangeSeq      int64             `json:"-" gorm:"->"`
+}
+
+// CanEdit memeriksa apakah pengguna boleh mengubah seluruh isi todo. Hanya pemilik yang boleh.
+func (t *Todo) CanEdit(userID int64) bool {
+	return t.UserID == userID
+}
+
+// CanUpdateStatus memeriksa apakah pengguna boleh mengubah status selesai todo.
+// Penanggung jawab boleh mengubah status, tetapi tidak boleh mengubah isi atau menghapus todo.
+func (t *Todo) CanUpdateStatus(userID int64) bool {
+	return t.UserID == userID || (t.AssigneeID != nil && *t.AssigneeID == userID)
+}
+
+// IsValidPriority memeriksa apakah nilai prioritas dikenal. Prioritas kosong diperbolehkan.
+func IsValidPriority(priority string) bool {
+	switch priority {
+	case "", PriorityLow, PriorityMedium, PriorityHigh, PriorityUrgent:
+		return true
+	}
+	return false
 }
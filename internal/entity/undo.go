@@ -0,0 +1,26 @@
+package entity
+
+import "time"
+
+// Jenis aksi todo yang dapat dibatalkan
+const (
+	UndoActionUpdate = "update"
+	UndoActionDelete = "delete"
+)
+
+// UndoToken dikembalikan bersama respons aksi yang dapat dibatalkan melalui POST /undo/:token
+type UndoToken struct {
+	Token     string    `json:"undo_token"`
+	ExpiresAt time.Time `json:"undo_expires_at"`
+}
+
+// UndoAction adalah keadaan yang disimpan di cache untuk membatalkan sebuah aksi.
+// Todos berisi todo sebelum aksi; untuk penghapusan, subtask ikut disimpan dengan
+// induk selalu mendahului anaknya. Version adalah versi todo tepat setelah perubahan,
+// digunakan untuk mendeteksi perubahan lain sebelum undo.
+type UndoAction struct {
+	Type    string `json:"type"`
+	ActorID int64  `json:"actor_id"`
+	Version int64  `json:"version"`
+	Todos   []Todo `json:"todos"`
+}
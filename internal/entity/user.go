@@ -1,9 +1,22 @@
 package entity
 
+import "time"
+
 type User struct {
 	ID       int64  `json:"id" gorm:"primaryKey"`
 	Username string `json:"username"`
 	Password string `json:"-"`
-	Role     string `json:"role"`
 	FullName string `json:"full_name"`
-}
\ No newline at end of file
+	// Email disimpan dalam huruf kecil; email kosong disimpan sebagai NULL
+	Email string `json:"email,omitempty" gorm:"default:null"`
+	// EmailVerifiedAt kosong berarti email belum diverifikasi
+	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty" gorm:"default:null"`
+	// Roles diisi dari tabel user_roles, tidak disimpan pada tabel users
+	Roles []string `json:"roles,omitempty" gorm:"-"`
+}
+
+// EmailUnverified memeriksa apakah pengguna memiliki email yang belum diverifikasi.
+// Pengguna tanpa email, misalnya admin dari bootstrap, tidak dianggap belum terverifikasi.
+func (u *User) EmailUnverified() bool {
+	return u.Email != "" && u.EmailVerifiedAt == nil
+}
@@ -0,0 +1,31 @@
+package entity
+
+// Nilai default preferensi untuk pengguna yang belum menyimpan preferensi
+const (
+	DefaultTimezone   = "Asia/Jakarta"
+	DefaultLocale     = "id-ID"
+	DefaultWeekStart  = 1 // Senin
+	DefaultDateFormat = "YYYY-MM-DD"
+)
+
+// DateFormats adalah format tanggal yang dapat dipilih pengguna
+var DateFormats = []string{"YYYY-MM-DD", "DD/MM/YYYY", "MM/DD/YYYY", "DD-MM-YYYY", "DD.MM.YYYY"}
+
+type UserPreference struct {
+	UserID     int64  `json:"user_id" gorm:"primaryKey;autoIncrement:false"`
+	Timezone   string `json:"timezone"`
+	Locale     string `json:"locale"`
+	WeekStart  int    `json:"week_start"`
+	DateFormat string `json:"date_format"`
+}
+
+// DefaultUserPreference mengembalikan preferensi default untuk pengguna
+func DefaultUserPreference(userID int64) *UserPreference {
+	return &UserPreference{
+		UserID:     userID,
+		Timezone:   DefaultTimezone,
+		Locale:     DefaultLocale,
+		WeekStart:  DefaultWeekStart,
+		DateFormat: DefaultDateFormat,
+	}
+}
@@ -0,0 +1,90 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Jenis event yang dapat dilanggan oleh webhook
+const (
+	EventTodoCreated    = "todo.created"
+	EventTodoUpdated    = "todo.updated"
+	EventTodoCompleted  = "todo.completed"
+	EventTodoDeleted    = "todo.deleted"
+	EventTodoAssigned   = "todo.assigned"
+	EventTodoUnassigned = "todo.unassigned"
+	EventUserCreated    = "user.created"
+	EventUserUpdated    = "user.updated"
+	EventUserDeleted    = "user.deleted"
+)
+
+// EventTypes adalah seluruh jenis event yang dikirim aplikasi
+var EventTypes = []string{
+	EventTodoCreated, EventTodoUpdated, EventTodoCompleted, EventTodoDeleted,
+	EventTodoAssigned, EventTodoUnassigned,
+	EventUserCreated, EventUserUpdated, EventUserDeleted,
+}
+
+// Status pengiriman webhook. Pengiriman pending akan dicoba ulang hingga berhasil
+// atau jumlah percobaan habis dan berpindah ke status dead.
+const (
+	DeliveryStatusPending   = "pending"
+	DeliveryStatusSucceeded = "succeeded"
+	DeliveryStatusDead      = "dead"
+)
+
+// IsValidEventType memeriksa apakah jenis event dikenal
+func IsValidEventType(eventType string) bool {
+	for _, known := range EventTypes {
+		if eventType == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Event adalah amplop yang dikirim sebagai body webhook
+type Event struct {
+	ID            string      `json:"id"`
+	Type          string      `json:"type"`
+	AggregateType string      `json:"aggregate_type"`
+	AggregateID   int64       `json:"aggregate_id"`
+	OccurredAt    time.Time   `json:"occurred_at"`
+	Data          interface{} `json:"data"`
+}
+
+type Webhook struct {
+	ID         int64      `json:"id" gorm:"primaryKey"`
+	URL        string     `json:"url"`
+	EventTypes StringList `json:"event_types" gorm:"type:jsonb"`
+	Secret     string     `json:"-"`
+	Active     bool       `json:"active"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// SubscribesTo memeriksa apakah webhook melanggan jenis event tertentu
+func (w *Webhook) SubscribesTo(eventType string) bool {
+	for _, subscribed := range w.EventTypes {
+		if subscribed == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+type WebhookDelivery struct {
+	ID             int64           `json:"id" gorm:"primaryKey"`
+	WebhookID      int64           `json:"webhook_id"`
+	EventID        string          `json:"event_id"`
+	EventType      string          `json:"event_type"`
+	Payload        json.RawMessage `json:"payload" gorm:"type:jsonb"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  *time.Time      `json:"next_attempt_at"`
+	ResponseStatus int             `json:"response_status"`
+	LastError      string          `json:"last_error"`
+	ReplayOf       *int64          `json:"replay_of"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+	DeliveredAt    *time.Time      `json:"delivered_at"`
+}
@@ -0,0 +1,157 @@
+package handler
+
+import (
+	"errors"
+	"go-todo/internal/service"
+	"go-todo/pkg/response"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type AnalyticsHandler struct {
+	analyticsService      service.AnalyticsService
+	userPreferenceService service.UserPreferenceService
+}
+
+// NewAnalyticsHandler membuat instance baru dari AnalyticsHandler
+func NewAnalyticsHandler(analyticsService service.AnalyticsService, userPreferenceService service.UserPreferenceService) *AnalyticsHandler {
+	return &AnalyticsHandler{
+		analyticsService:      analyticsService,
+		userPreferenceService: userPreferenceService,
+	}
+}
+
+// GetActiveUsers menangani permintaan jumlah pengguna aktif per hari
+func (h *AnalyticsHandler) GetActiveUsers(c echo.Context) error {
+	// Periode harian dihitung pada zona waktu admin yang meminta
+	loc := h.userPreferenceService.Location(c.Request().Context(), currentUser(c).UserID)
+	from, to, err := parseDateRange(c, loc)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, err.Error()))
+	}
+
+	counts, err := h.analyticsService.ActiveUsers(c.Request().Context(), from, to, loc)
+	if err != nil {
+		status := analyticsErrorStatus(err)
+		return c.JSON(status, response.ErrorResponse(status, err.Error()))
+	}
+
+	if c.QueryParam("format") == "csv" {
+		records := make([][]string, 0, len(counts))
+		for _, count := range counts {
+			records = append(records, []string{count.Day.Format(dateLayout), strconv.FormatInt(count.Count, 10)})
+		}
+		return writeCSV(c, "active-users.csv", []string{"day", "active_users"}, records)
+	}
+
+	return c.JSON(http.StatusOK, response.SuccessResponse("Berhasil mengambil pengguna aktif", counts))
+}
+
+// GetTodoActivity menangani permintaan jumlah todo dibuat dan diselesaikan per hari
+func (h *AnalyticsHandler) GetTodoActivity(c echo.Context) error {
+	// Periode harian dihitung pada zona waktu admin yang meminta
+	loc := h.userPreferenceService.Location(c.Request().Context(), currentUser(c).UserID)
+	from, to, err := parseDateRange(c, loc)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, err.Error()))
+	}
+
+	activity, err := h.analyticsService.TodoActivity(c.Request().Context(), from, to, loc)
+	if err != nil {
+		status := analyticsErrorStatus(err)
+		return c.JSON(status, response.ErrorResponse(status, err.Error()))
+	}
+
+	if c.QueryParam("format") == "csv" {
+		records := make([][]string, 0, len(activity))
+		for _, day := range activity {
+			records = append(records, []string{
+				day.Day.Format(dateLayout),
+				strconv.FormatInt(day.Created, 10),
+				strconv.FormatInt(day.Completed, 10),
+			})
+		}
+		return writeCSV(c, "todo-activity.csv", []string{"day", "created", "completed"}, records)
+	}
+
+	return c.JSON(http.StatusOK, response.SuccessResponse("Berhasil mengambil aktivitas todo", activity))
+}
+
+// GetTopUsers menangani permintaan pengguna dengan jumlah todo terbanyak
+func (h *AnalyticsHandler) GetTopUsers(c echo.Context) error {
+	// Periode harian dihitung pada zona waktu admin yang meminta
+	loc := h.userPreferenceService.Location(c.Request().Context(), currentUser(c).UserID)
+	from, to, err := parseDateRange(c, loc)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, err.Error()))
+	}
+
+	limit := 0
+	if value := c.QueryParam("limit"); value != "" {
+		limit, err = strconv.Atoi(value)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, service.ErrLimitTidakValid.Error()))
+		}
+	}
+
+	users, err := h.analyticsService.TopUsers(c.Request().Context(), from, to, loc, limit)
+	if err != nil {
+		status := analyticsErrorStatus(err)
+		return c.JSON(status, response.ErrorResponse(status, err.Error()))
+	}
+
+	if c.QueryParam("format") == "csv" {
+		records := make([][]string, 0, len(users))
+		for _, user := range users {
+			records = append(records, []string{
+				strconv.FormatInt(user.UserID, 10),
+				user.Username,
+				strconv.FormatInt(user.Created, 10),
+				strconv.FormatInt(user.Completed, 10),
+			})
+		}
+		return writeCSV(c, "top-users.csv", []string{"user_id", "username", "created", "completed"}, records)
+	}
+
+	return c.JSON(http.StatusOK, response.SuccessResponse("Berhasil mengambil pengguna teratas", users))
+}
+
+// GetLoginActivity menangani permintaan jumlah login berhasil dan gagal per hari
+func (h *AnalyticsHandler) GetLoginActivity(c echo.Context) error {
+	// Periode harian dihitung pada zona waktu admin yang meminta
+	loc := h.userPreferenceService.Location(c.Request().Context(), currentUser(c).UserID)
+	from, to, err := parseDateRange(c, loc)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, err.Error()))
+	}
+
+	activity, err := h.analyticsService.LoginActivity(c.Request().Context(), from, to, loc)
+	if err != nil {
+		status := analyticsErrorStatus(err)
+		return c.JSON(status, response.ErrorResponse(status, err.Error()))
+	}
+
+	if c.QueryParam("format") == "csv" {
+		records := make([][]string, 0, len(activity))
+		for _, day := range activity {
+			records = append(records, []string{
+				day.Day.Format(dateLayout),
+				strconv.FormatInt(day.Successes, 10),
+				strconv.FormatInt(day.Failures, 10),
+			})
+		}
+		return writeCSV(c, "login-activity.csv", []string{"day", "successes", "failures"}, records)
+	}
+
+	return c.JSON(http.StatusOK, response.SuccessResponse("Berhasil mengambil aktivitas login", activity))
+}
+
+// analyticsErrorStatus memetakan error dari service ke status HTTP
+func analyticsErrorStatus(err error) int {
+	if errors.Is(err, service.ErrRentangTanggalTidakValid) || errors.Is(err, service.ErrLimitTidakValid) {
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
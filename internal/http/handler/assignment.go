@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"errors"
+	"go-todo/internal/service"
+	"go-todo/pkg/response"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type AssignmentHandler struct {
+	assignmentService service.AssignmentService
+}
+
+// NewAssignmentHandler membuat instance baru dari AssignmentHandler
+func NewAssignmentHandler(assignmentService service.AssignmentService) *AssignmentHandler {
+	return &AssignmentHandler{assignmentService: assignmentService}
+}
+
+// AssignTodo menangani permintaan untuk menugaskan todo kepada pengguna lain
+func (h *AssignmentHandler) AssignTodo(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, "ID todo tidak valid"))
+	}
+
+	var req struct {
+		AssigneeID int64 `json:"assignee_id"`
+	}
+	if err := c.Bind(&req); err != nil || req.AssigneeID <= 0 {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, "assignee_id tidak valid"))
+	}
+
+	todo, err := h.assignmentService.Assign(c.Request().Context(), currentUser(c).UserID, id, req.AssigneeID)
+	if err != nil {
+		status := assignmentErrorStatus(err)
+		return c.JSON(status, response.ErrorResponse(status, err.Error()))
+	}
+	return c.JSON(http.StatusOK, response.SuccessResponse("Todo berhasil ditugaskan", todo))
+}
+
+// UnassignTodo menangani permintaan untuk menghapus penanggung jawab todo
+func (h *AssignmentHandler) UnassignTodo(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, "ID todo tidak valid"))
+	}
+
+	todo, err := h.assignmentService.Unassign(c.Request().Context(), currentUser(c).UserID, id)
+	if err != nil {
+		status := assignmentErrorStatus(err)
+		return c.JSON(status, response.ErrorResponse(status, err.Error()))
+	}
+	return c.JSON(http.StatusOK, response.SuccessResponse("Penanggung jawab todo berhasil dihapus", todo))
+}
+
+// GetAssignedTodos menangani permintaan daftar todo yang ditugaskan kepada pengguna yang sedang login
+func (h *AssignmentHandler) GetAssignedTodos(c echo.Context) error {
+	todos, err := h.assignmentService.FindAssigned(c.Request().Context(), currentUser(c).UserID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, response.ErrorResponse(http.StatusInternalServerError, "Gagal mengambil todo yang ditugaskan"))
+	}
+	return c.JSON(http.StatusOK, response.SuccessResponse("Berhasil mengambil todo yang ditugaskan", todos))
+}
+
+// UpdateTodoStatus menangani perubahan status selesai oleh pemilik atau penanggung jawab todo
+func (h *AssignmentHandler) UpdateTodoStatus(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, "ID todo tidak valid"))
+	}
+
+	var req struct {
+		Completed *bool `json:"completed"`
+	}
+	if err := c.Bind(&req); err != nil || req.Completed == nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, "Field completed harus diisi"))
+	}
+
+	todo, err := h.assignmentService.UpdateStatus(c.Request().Context(), currentUser(c).UserID, id, *req.Completed)
+	if err != nil {
+		status := assignmentErrorStatus(err)
+		return c.JSON(status, response.ErrorResponse(status, err.Error()))
+	}
+	return c.JSON(http.StatusOK, response.SuccessResponse("Status todo berhasil diperbarui", todo))
+}
+
+// assignmentErrorStatus memetakan error service penugasan ke status HTTP
+func assignmentErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrTodoTidakDitemukan), errors.Is(err, service.ErrPenggunaTidakDitemukan):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrAksesDitolak):
+		return http.StatusForbidden
+	}
+	return http.StatusInternalServerError
+}
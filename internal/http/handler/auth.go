@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"errors"
+	"go-todo/internal/service"
+	"go-todo/pkg/response"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type AuthHandler struct {
+	tokenService service.TokenService
+}
+
+// NewAuthHandler membuat instance baru dari AuthHandler
+func NewAuthHandler(tokenService service.TokenService) *AuthHandler {
+	return &AuthHandler{tokenService: tokenService}
+}
+
+// RefreshToken menangani permintaan untuk menukar refresh token dengan pasangan token baru.
+// Refresh token lama tidak dapat dipakai lagi setelah permintaan ini berhasil.
+func (h *AuthHandler) RefreshToken(c echo.Context) error {
+	var req struct {
+		RefreshToken string `json:"refresh_token"`
+	}
+	if err := c.Bind(&req); err != nil || req.RefreshToken == "" {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, "refresh_token harus diisi"))
+	}
+
+	pair, err := h.tokenService.Refresh(c.Request().Context(), req.RefreshToken)
+	if err != nil {
+		status := authErrorStatus(err)
+		return c.JSON(status, response.ErrorResponse(status, err.Error()))
+	}
+	return c.JSON(http.StatusOK, response.SuccessResponse("Token berhasil diperbarui", pair))
+}
+
+// Logout mencabut access token yang dipakai pada permintaan ini. Refresh token pada body
+// bersifat opsional; jika dikirim, seluruh rotasinya ikut dicabut.
+func (h *AuthHandler) Logout(c echo.Context) error {
+	var req struct {
+		RefreshToken string `json:"refresh_token"`
+	}
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, "Permintaan tidak valid"))
+	}
+
+	if err := h.tokenService.Logout(c.Request().Context(), currentUser(c), req.RefreshToken); err != nil {
+		status := authErrorStatus(err)
+		return c.JSON(status, response.ErrorResponse(status, err.Error()))
+	}
+	return c.JSON(http.StatusOK, response.SuccessResponse("Berhasil logout", nil))
+}
+
+// LogoutAll mencabut seluruh access token dan refresh token milik pengguna di semua perangkat
+func (h *AuthHandler) LogoutAll(c echo.Context) error {
+	if err := h.tokenService.RevokeAll(c.Request().Context(), currentUser(c).UserID); err != nil {
+		status := authErrorStatus(err)
+		return c.JSON(status, response.ErrorResponse(status, err.Error()))
+	}
+	return c.JSON(http.StatusOK, response.SuccessResponse("Berhasil logout dari semua perangkat", nil))
+}
+
+// authErrorStatus memetakan error service token ke status HTTP
+func authErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrRefreshTokenTidakValid), errors.Is(err, service.ErrRefreshTokenDipakaiUlang):
+		return http.StatusUnauthorized
+	}
+	return http.StatusInternalServerError
+}
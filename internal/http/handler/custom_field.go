@@ -0,0 +1,183 @@
+package handler
+
+import (
+	"errors"
+	"go-todo/internal/entity"
+	"go-todo/internal/service"
+	"go-todo/pkg/response"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// customFieldQueryPattern membaca parameter pencarian cf[key]=nilai atau cf[key][op]=nilai
+var customFieldQueryPattern = regexp.MustCompile(`^cf\[([a-z0-9_]+)\](?:\[([a-z]+)\])?$`)
+
+type CustomFieldHandler struct {
+	customFieldService service.CustomFieldService
+}
+
+// NewCustomFieldHandler membuat instance baru dari CustomFieldHandler
+func NewCustomFieldHandler(customFieldService service.CustomFieldService) *CustomFieldHandler {
+	return &CustomFieldHandler{customFieldService: customFieldService}
+}
+
+// GetCustomFields menangani permintaan daftar definisi field kustom milik pengguna
+func (h *CustomFieldHandler) GetCustomFields(c echo.Context) error {
+	definitions, err := h.customFieldService.FindAll(c.Request().Context(), currentUser(c).UserID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, response.ErrorResponse(http.StatusInternalServerError, "Gagal mengambil field kustom"))
+	}
+	return c.JSON(http.StatusOK, response.SuccessResponse("Berhasil mengambil field kustom", definitions))
+}
+
+// CreateCustomField menangani permintaan untuk membuat definisi field kustom baru
+func (h *CustomFieldHandler) CreateCustomField(c echo.Context) error {
+	var definition entity.CustomFieldDefinition
+	if err := c.Bind(&definition); err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, "Permintaan tidak valid"))
+	}
+	definition.ID = 0
+	definition.UserID = currentUser(c).UserID
+
+	created, err := h.customFieldService.Create(c.Request().Context(), &definition)
+	if err != nil {
+		status := customFieldErrorStatus(err)
+		return c.JSON(status, response.ErrorResponse(status, err.Error()))
+	}
+	return c.JSON(http.StatusCreated, response.SuccessResponse("Field kustom berhasil dibuat", created))
+}
+
+// UpdateCustomField menangani permintaan untuk memperbarui definisi field kustom
+func (h *CustomFieldHandler) UpdateCustomField(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, "ID field kustom tidak valid"))
+	}
+
+	var definition entity.CustomFieldDefinition
+	if err := c.Bind(&definition); err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, "Permintaan tidak valid"))
+	}
+	definition.ID = id
+	definition.UserID = currentUser(c).UserID
+
+	updated, err := h.customFieldService.Update(c.Request().Context(), &definition)
+	if err != nil {
+		status := customFieldErrorStatus(err)
+		return c.JSON(status, response.ErrorResponse(status, err.Error()))
+	}
+	return c.JSON(http.StatusOK, response.SuccessResponse("Field kustom berhasil diperbarui", updated))
+}
+
+// DeleteCustomField menangani permintaan untuk menghapus field kustom beserta nilainya
+func (h *CustomFieldHandler) DeleteCustomField(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, "ID field kustom tidak valid"))
+	}
+
+	if err := h.customFieldService.Delete(c.Request().Context(), currentUser(c).UserID, id); err != nil {
+		status := customFieldErrorStatus(err)
+		return c.JSON(status, response.ErrorResponse(status, err.Error()))
+	}
+	return c.JSON(http.StatusOK, response.SuccessResponse("Field kustom berhasil dihapus", nil))
+}
+
+// SearchTodos menangani pencarian todo berdasarkan field kustom, misalnya
+// ?cf[estimasi][gte]=3&cf[status]=review&sort=-estimasi. Parameter format=csv
+// mengekspor hasilnya dengan satu kolom untuk setiap field kustom.
+func (h *CustomFieldHandler) SearchTodos(c echo.Context) error {
+	var filters []entity.CustomFieldFilter
+	for name, values := range c.QueryParams() {
+		match := customFieldQueryPattern.FindStringSubmatch(name)
+		if match == nil {
+			continue
+		}
+		for _, value := range values {
+			filters = append(filters, entity.CustomFieldFilter{Key: match[1], Op: match[2], Value: value})
+		}
+	}
+	// Urutan kondisi dibuat tetap agar query yang dihasilkan konsisten
+	sort.Slice(filters, func(i, j int) bool {
+		if filters[i].Key != filters[j].Key {
+			return filters[i].Key < filters[j].Key
+		}
+		return filters[i].Op < filters[j].Op
+	})
+
+	ctx := c.Request().Context()
+	userID := currentUser(c).UserID
+	todos, err := h.customFieldService.SearchTodos(ctx, userID, filters, c.QueryParam("sort"))
+	if err != nil {
+		status := customFieldErrorStatus(err)
+		return c.JSON(status, response.ErrorResponse(status, err.Error()))
+	}
+
+	if c.QueryParam("format") == "csv" {
+		definitions, err := h.customFieldService.FindAll(ctx, userID)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, response.ErrorResponse(http.StatusInternalServerError, "Gagal mengambil field kustom"))
+		}
+		header := []string{"id", "title", "completed", "due_date", "project", "tags", "priority"}
+		for _, definition := range definitions {
+			header = append(header, definition.Key)
+		}
+		records := make([][]string, 0, len(todos))
+		for _, todo := range todos {
+			dueDate := ""
+			if !todo.DueDate.IsZero() {
+				dueDate = todo.DueDate.Format(time.RFC3339)
+			}
+			record := []string{
+				strconv.FormatInt(todo.ID, 10),
+				todo.Title,
+				strconv.FormatBool(todo.Completed),
+				dueDate,
+				todo.Project,
+				strings.Join(todo.Tags, ";"),
+				todo.Priority,
+			}
+			for _, definition := range definitions {
+				record = append(record, customFieldCSVValue(todo.CustomFields[definition.Key]))
+			}
+			records = append(records, record)
+		}
+		return writeCSV(c, "todos.csv", header, records)
+	}
+
+	return c.JSON(http.StatusOK, response.SuccessResponse("Berhasil mencari todo", todos))
+}
+
+// customFieldCSVValue mengubah nilai field kustom menjadi teks untuk ekspor CSV
+func customFieldCSVValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	return ""
+}
+
+// customFieldErrorStatus memetakan error service field kustom ke status HTTP
+func customFieldErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrFieldKustomTidakDitemukan):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrKeyFieldKustomDipakai):
+		return http.StatusConflict
+	case errors.Is(err, service.ErrDefinisiFieldKustomTidakValid), errors.Is(err, service.ErrPencarianFieldKustomTidakValid):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"errors"
+	"go-todo/internal/service"
+	"go-todo/pkg/response"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+type EmailVerificationHandler struct {
+	emailVerificationService service.EmailVerificationService
+}
+
+// NewEmailVerificationHandler membuat instance baru dari EmailVerificationHandler
+func NewEmailVerificationHandler(emailVerificationService service.EmailVerificationService) *EmailVerificationHandler {
+	return &EmailVerificationHandler{emailVerificationService: emailVerificationService}
+}
+
+// VerifyEmail menangani verifikasi email dengan token dari email. Route ini publik agar link
+// dapat dibuka tanpa login, misalnya dari perangkat lain.
+func (h *EmailVerificationHandler) VerifyEmail(c echo.Context) error {
+	var req struct {
+		Token string `json:"token"`
+	}
+	if err := c.Bind(&req); err != nil || req.Token == "" {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, "token harus diisi"))
+	}
+
+	if err := h.emailVerificationService.Verify(c.Request().Context(), req.Token); err != nil {
+		if errors.Is(err, service.ErrTokenVerifikasiTidakValid) {
+			return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, err.Error()))
+		}
+		return c.JSON(http.StatusInternalServerError,
+			response.ErrorResponse(http.StatusInternalServerError, service.ErrServerInternal.Error()))
+	}
+	return c.JSON(http.StatusOK, response.SuccessResponse("Email berhasil diverifikasi", nil))
+}
+
+// ResendVerification menangani permintaan kirim ulang email verifikasi untuk pengguna yang login
+func (h *EmailVerificationHandler) ResendVerification(c echo.Context) error {
+	err := h.emailVerificationService.Resend(c.Request().Context(), currentUser(c).UserID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, service.ErrVerifikasiTerlaluSering):
+			status = http.StatusTooManyRequests
+		case errors.Is(err, service.ErrEmailSudahDiverifikasi):
+			status = http.StatusConflict
+		case errors.Is(err, service.ErrEmailTidakAda):
+			status = http.StatusBadRequest
+		case errors.Is(err, service.ErrPenggunaTidakDitemukan):
+			status = http.StatusNotFound
+		default:
+			err = service.ErrServerInternal
+		}
+		return c.JSON(status, response.ErrorResponse(status, err.Error()))
+	}
+	return c.JSON(http.StatusAccepted, response.SuccessResponse("Email verifikasi telah dikirim ulang", nil))
+}
+
+// RequestVerification menangani permintaan kirim ulang email verifikasi tanpa login, untuk
+// pengguna yang tidak dapat login karena emailnya belum diverifikasi. Responsnya selalu sama,
+// baik akun terdaftar maupun tidak, agar endpoint ini tidak dapat dipakai menebak akun.
+func (h *EmailVerificationHandler) RequestVerification(c echo.Context) error {
+	var req struct {
+		Login string `json:"login"`
+	}
+	if err := c.Bind(&req); err != nil || strings.TrimSpace(req.Login) == "" {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, "login harus diisi dengan username atau email"))
+	}
+
+	if err := h.emailVerificationService.ResendByLogin(c.Request().Context(), strings.TrimSpace(req.Login)); err != nil {
+		return c.JSON(http.StatusInternalServerError,
+			response.ErrorResponse(http.StatusInternalServerError, service.ErrServerInternal.Error()))
+	}
+	return c.JSON(http.StatusAccepted,
+		response.SuccessResponse("Jika akun terdaftar dan emailnya belum diverifikasi, email verifikasi telah dikirim", nil))
+}
@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"errors"
+	"go-todo/internal/entity"
+	"go-todo/internal/service"
+	"go-todo/pkg/filter"
+	"go-todo/pkg/response"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type FilterHandler struct {
+	filterService service.FilterService
+}
+
+// NewFilterHandler membuat instance baru dari FilterHandler
+func NewFilterHandler(filterService service.FilterService) *FilterHandler {
+	return &FilterHandler{filterService: filterService}
+}
+
+// filterRequest adalah body untuk membuat dan memperbarui filter tersimpan
+type filterRequest struct {
+	Name       string      `json:"name"`
+	Definition filter.Expr `json:"definition"`
+}
+
+// GetFilters menangani permintaan daftar filter bawaan dan filter milik pengguna
+func (h *FilterHandler) GetFilters(c echo.Context) error {
+	filters, err := h.filterService.FindAll(c.Request().Context(), currentUser(c).UserID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, response.ErrorResponse(http.StatusInternalServerError, "Gagal mengambil filter"))
+	}
+	return c.JSON(http.StatusOK, response.SuccessResponse("Berhasil mengambil filter", filters))
+}
+
+// GetFilter menangani permintaan detail filter berdasarkan ID atau key filter bawaan
+func (h *FilterHandler) GetFilter(c echo.Context) error {
+	found, err := h.filterService.Find(c.Request().Context(), currentUser(c).UserID, c.Param("id"))
+	if err != nil {
+		status := filterErrorStatus(err)
+		return c.JSON(status, response.ErrorResponse(status, err.Error()))
+	}
+	return c.JSON(http.StatusOK, response.SuccessResponse("Berhasil mengambil filter", found))
+}
+
+// GetFilterTodos menangani permintaan todo yang cocok dengan sebuah filter
+func (h *FilterHandler) GetFilterTodos(c echo.Context) error {
+	todos, err := h.filterService.Todos(c.Request().Context(), currentUser(c).UserID, c.Param("id"))
+	if err != nil {
+		status := filterErrorStatus(err)
+		return c.JSON(status, response.ErrorResponse(status, err.Error()))
+	}
+	return c.JSON(http.StatusOK, response.SuccessResponse("Berhasil mengambil todo", todos))
+}
+
+// CreateFilter menangani permintaan untuk menyimpan filter baru
+func (h *FilterHandler) CreateFilter(c echo.Context) error {
+	var req filterRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, "Permintaan tidak valid"))
+	}
+
+	created, err := h.filterService.Create(c.Request().Context(), &entity.SavedFilter{
+		UserID:     currentUser(c).UserID,
+		Name:       req.Name,
+		Definition: req.Definition,
+	})
+	if err != nil {
+		status := filterErrorStatus(err)
+		return c.JSON(status, response.ErrorResponse(status, err.Error()))
+	}
+	return c.JSON(http.StatusCreated, response.SuccessResponse("Filter berhasil dibuat", created))
+}
+
+// UpdateFilter menangani permintaan untuk memperbarui filter milik pengguna
+func (h *FilterHandler) UpdateFilter(c echo.Context) error {
+	id, err := h.savedFilterID(c)
+	if err != nil {
+		status := filterErrorStatus(err)
+		return c.JSON(status, response.ErrorResponse(status, err.Error()))
+	}
+
+	var req filterRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, "Permintaan tidak valid"))
+	}
+
+	updated, err := h.filterService.Update(c.Request().Context(), &entity.SavedFilter{
+		ID:         id,
+		UserID:     currentUser(c).UserID,
+		Name:       req.Name,
+		Definition: req.Definition,
+	})
+	if err != nil {
+		status := filterErrorStatus(err)
+		return c.JSON(status, response.ErrorResponse(status, err.Error()))
+	}
+	return c.JSON(http.StatusOK, response.SuccessResponse("Filter berhasil diperbarui", updated))
+}
+
+// DeleteFilter menangani permintaan untuk menghapus filter milik pengguna
+func (h *FilterHandler) DeleteFilter(c echo.Context) error {
+	id, err := h.savedFilterID(c)
+	if err != nil {
+		status := filterErrorStatus(err)
+		return c.JSON(status, response.ErrorResponse(status, err.Error()))
+	}
+
+	if err := h.filterService.Delete(c.Request().Context(), currentUser(c).UserID, id); err != nil {
+		status := filterErrorStatus(err)
+		return c.JSON(status, response.ErrorResponse(status, err.Error()))
+	}
+	return c.JSON(http.StatusOK, response.SuccessResponse("Filter berhasil dihapus", nil))
+}
+
+// savedFilterID membaca ID filter tersimpan; filter bawaan tidak dapat diubah
+func (h *FilterHandler) savedFilterID(c echo.Context) (int64, error) {
+	if entity.IsBuiltInFilter(c.Param("id")) {
+		return 0, service.ErrFilterBawaan
+	}
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return 0, service.ErrFilterTidakDitemukan
+	}
+	return id, nil
+}
+
+// filterErrorStatus memetakan error service filter ke status HTTP
+func filterErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrFilterTidakDitemukan):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrFilterBawaan):
+		return http.StatusForbidden
+	case errors.Is(err, service.ErrNamaFilterTidakValid), errors.Is(err, filter.ErrTidakValid):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"go-todo/pkg/server"
+	"go-todo/pkg/token"
+	"net/http"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+)
+
+const dateLayout = "2006-01-02"
+
+var errRentangTanggalTidakValid = errors.New("format tanggal harus YYYY-MM-DD")
+
+// currentUser mengambil klaim JWT milik pengguna yang sedang login
+func currentUser(c echo.Context) *token.JwtCustomClaims {
+	user, ok := c.Get("user").(*jwt.Token)
+	if !ok {
+		return &token.JwtCustomClaims{}
+	}
+	claims, ok := user.Claims.(*token.JwtCustomClaims)
+	if !ok {
+		return &token.JwtCustomClaims{}
+	}
+	return claims
+}
+
+// hasPermission memeriksa izin pengguna yang sudah dimuat oleh PermissionMiddleware
+func hasPermission(c echo.Context, permission string) bool {
+	granted, _ := c.Get(server.PermissionsContextKey).([]string)
+	return server.HasPermission(granted, permission)
+}
+
+// parseDateRange membaca query parameter from dan to (YYYY-MM-DD) pada zona waktu loc.
+// Tanggal to bersifat inklusif sehingga dikembalikan sebagai awal hari berikutnya.
+func parseDateRange(c echo.Context, loc *time.Location) (time.Time, time.Time, error) {
+	var from, to time.Time
+	var err error
+
+	if value := c.QueryParam("from"); value != "" {
+		from, err = time.ParseInLocation(dateLayout, value, loc)
+		if err != nil {
+			return time.Time{}, time.Time{}, errRentangTanggalTidakValid
+		}
+	}
+	if value := c.QueryParam("to"); value != "" {
+		to, err = time.ParseInLocation(dateLayout, value, loc)
+		if err != nil {
+			return time.Time{}, time.Time{}, errRentangTanggalTidakValid
+		}
+		to = to.AddDate(0, 0, 1)
+	}
+	return from, to, nil
+}
+
+// writeCSV menulis baris-baris data sebagai file CSV yang dapat diunduh
+func writeCSV(c echo.Context, filename string, header []string, rows [][]string) error {
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/csv; charset=utf-8")
+	res.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", filename))
+	res.WriteHeader(http.StatusOK)
+
+	writer := csv.NewWriter(res)
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	if err := writer.WriteAll(rows); err != nil {
+		return err
+	}
+	return writer.Error()
+}
@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"errors"
+	"go-todo/internal/service"
+	"go-todo/pkg/response"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type MFAHandler struct {
+	mfaService service.MFAService
+}
+
+// NewMFAHandler membuat instance baru dari MFAHandler
+func NewMFAHandler(mfaService service.MFAService) *MFAHandler {
+	return &MFAHandler{mfaService: mfaService}
+}
+
+// mfaCodeRequest adalah body permintaan yang membutuhkan kode TOTP atau recovery code
+type mfaCodeRequest struct {
+	Code string `json:"code" validate:"required"`
+}
+
+// GetStatus menangani permintaan status autentikasi dua faktor pengguna yang login
+func (h *MFAHandler) GetStatus(c echo.Context) error {
+	status, err := h.mfaService.Status(c.Request().Context(), currentUser(c).UserID)
+	if err != nil {
+		return mfaError(c, err)
+	}
+	return c.JSON(http.StatusOK, response.SuccessResponse("Status autentikasi dua faktor berhasil diambil", status))
+}
+
+// EnrollTOTP menangani pendaftaran TOTP. Klien menampilkan provisioning_uri sebagai kode QR.
+func (h *MFAHandler) EnrollTOTP(c echo.Context) error {
+	enrollment, err := h.mfaService.Enroll(c.Request().Context(), currentUser(c).UserID)
+	if err != nil {
+		return mfaError(c, err)
+	}
+	return c.JSON(http.StatusOK,
+		response.SuccessResponse("Pindai kode QR lalu konfirmasi dengan kode dari aplikasi authenticator", enrollment))
+}
+
+// ConfirmTOTP menangani konfirmasi pendaftaran TOTP dan mengembalikan recovery code
+func (h *MFAHandler) ConfirmTOTP(c echo.Context) error {
+	var req mfaCodeRequest
+	if err := c.Bind(&req); err != nil || req.Code == "" {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, "code harus diisi"))
+	}
+
+	codes, err := h.mfaService.Confirm(c.Request().Context(), currentUser(c).UserID, req.Code)
+	if err != nil {
+		return mfaError(c, err)
+	}
+	return c.JSON(http.StatusOK,
+		response.SuccessResponse("Autentikasi dua faktor berhasil diaktifkan, simpan recovery code di tempat aman",
+			map[string][]string{"recovery_codes": codes}))
+}
+
+// DisableTOTP menangani penonaktifan TOTP dengan kode TOTP atau recovery code
+func (h *MFAHandler) DisableTOTP(c echo.Context) error {
+	var req mfaCodeRequest
+	if err := c.Bind(&req); err != nil || req.Code == "" {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, "code harus diisi"))
+	}
+
+	if err := h.mfaService.Disable(c.Request().Context(), currentUser(c).UserID, req.Code); err != nil {
+		return mfaError(c, err)
+	}
+	return c.JSON(http.StatusOK, response.SuccessResponse("Autentikasi dua faktor berhasil dinonaktifkan", nil))
+}
+
+// RegenerateRecoveryCodes menangani pembuatan ulang recovery code
+func (h *MFAHandler) RegenerateRecoveryCodes(c echo.Context) error {
+	var req mfaCodeRequest
+	if err := c.Bind(&req); err != nil || req.Code == "" {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, "code harus diisi"))
+	}
+
+	codes, err := h.mfaService.RegenerateRecoveryCodes(c.Request().Context(), currentUser(c).UserID, req.Code)
+	if err != nil {
+		return mfaError(c, err)
+	}
+	return c.JSON(http.StatusOK,
+		response.SuccessResponse("Recovery code berhasil dibuat ulang, kode lama tidak berlaku lagi",
+			map[string][]string{"recovery_codes": codes}))
+}
+
+// ResetUserMFA menangani permintaan admin untuk menghapus TOTP pengguna yang kehilangan perangkatnya
+func (h *MFAHandler) ResetUserMFA(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest,
+			response.ErrorResponse(http.StatusBadRequest, "ID pengguna tidak valid"))
+	}
+
+	if err := h.mfaService.Reset(c.Request().Context(), currentUser(c).UserID, id); err != nil {
+		return mfaError(c, err)
+	}
+	return c.JSON(http.StatusOK, response.SuccessResponse("Autentikasi dua faktor pengguna berhasil direset", nil))
+}
+
+// mfaError memetakan error autentikasi dua faktor ke status HTTP
+func mfaError(c echo.Context, err error) error {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, service.ErrKodeMFATidakValid), errors.Is(err, service.ErrMFATidakAktif),
+		errors.Is(err, service.ErrMFABelumDidaftarkan):
+		status = http.StatusBadRequest
+	case errors.Is(err, service.ErrMFASudahAktif):
+		status = http.StatusConflict
+	case errors.Is(err, service.ErrMFAWajib), errors.Is(err, service.ErrAksesDitolak):
+		status = http.StatusForbidden
+	case errors.Is(err, service.ErrPenggunaTidakDitemukan):
+		status = http.StatusNotFound
+	default:
+		err = service.ErrServerInternal
+	}
+	return c.JSON(status, response.ErrorResponse(status, err.Error()))
+}
@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"errors"
+	"go-todo/internal/service"
+	"go-todo/pkg/response"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type NotificationHandler struct {
+	notificationService service.NotificationService
+}
+
+// NewNotificationHandler membuat instance baru dari NotificationHandler
+func NewNotificationHandler(notificationService service.NotificationService) *NotificationHandler {
+	return &NotificationHandler{notificationService: notificationService}
+}
+
+// GetNotifications menangani permintaan daftar notifikasi, gunakan ?unread=true untuk yang belum dibaca
+func (h *NotificationHandler) GetNotifications(c echo.Context) error {
+	unreadOnly := c.QueryParam("unread") == "true"
+	notifications, err := h.notificationService.FindAll(c.Request().Context(), currentUser(c).UserID, unreadOnly)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, response.ErrorResponse(http.StatusInternalServerError, "Gagal mengambil notifikasi"))
+	}
+	return c.JSON(http.StatusOK, response.SuccessResponse("Berhasil mengambil notifikasi", notifications))
+}
+
+// MarkNotificationRead menangani permintaan untuk menandai notifikasi sebagai sudah dibaca
+func (h *NotificationHandler) MarkNotificationRead(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, "ID notifikasi tidak valid"))
+	}
+
+	if err := h.notificationService.MarkRead(c.Request().Context(), currentUser(c).UserID, id); err != nil {
+		if errors.Is(err, service.ErrNotifikasiTidakDitemukan) {
+			return c.JSON(http.StatusNotFound, response.ErrorResponse(http.StatusNotFound, err.Error()))
+		}
+		return c.JSON(http.StatusInternalServerError, response.ErrorResponse(http.StatusInternalServerError, "Gagal menandai notifikasi"))
+	}
+	return c.JSON(http.StatusOK, response.SuccessResponse("Notifikasi ditandai sudah dibaca", nil))
+}
+
+// MarkAllNotificationsRead menangani permintaan untuk menandai semua notifikasi sebagai sudah dibaca
+func (h *NotificationHandler) MarkAllNotificationsRead(c echo.Context) error {
+	updated, err := h.notificationService.MarkAllRead(c.Request().Context(), currentUser(c).UserID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, response.ErrorResponse(http.StatusInternalServerError, "Gagal menandai notifikasi"))
+	}
+	return c.JSON(http.StatusOK, response.SuccessResponse("Semua notifikasi ditandai sudah dibaca", map[string]int64{"updated": updated}))
+}
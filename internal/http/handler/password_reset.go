@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"errors"
+	"go-todo/internal/service"
+	"go-todo/pkg/password"
+	"go-todo/pkg/response"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type PasswordResetHandler struct {
+	passwordResetService service.PasswordResetService
+}
+
+// NewPasswordResetHandler membuat instance baru dari PasswordResetHandler
+func NewPasswordResetHandler(passwordResetService service.PasswordResetService) *PasswordResetHandler {
+	return &PasswordResetHandler{passwordResetService: passwordResetService}
+}
+
+// ForgotPassword menangani permintaan link reset password. Responsnya selalu sama, baik
+// username terdaftar maupun tidak, agar endpoint ini tidak dapat dipakai menebak akun.
+func (h *PasswordResetHandler) ForgotPassword(c echo.Context) error {
+	var req struct {
+		Username string `json:"username"`
+	}
+	if err := c.Bind(&req); err != nil || req.Username == "" {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, "username harus diisi"))
+	}
+
+	if err := h.passwordResetService.Forgot(c.Request().Context(), req.Username); err != nil {
+		return c.JSON(http.StatusInternalServerError,
+			response.ErrorResponse(http.StatusInternalServerError, service.ErrServerInternal.Error()))
+	}
+	return c.JSON(http.StatusAccepted,
+		response.SuccessResponse("Jika akun terdaftar dan memiliki email, link reset password telah dikirim", nil))
+}
+
+// ResetPassword menangani penggantian password dengan token dari email
+func (h *PasswordResetHandler) ResetPassword(c echo.Context) error {
+	var req struct {
+		Token    string `json:"token"`
+		Password string `json:"password"`
+	}
+	if err := c.Bind(&req); err != nil || req.Token == "" || req.Password == "" {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, "token dan password harus diisi"))
+	}
+
+	err := h.passwordResetService.Reset(c.Request().Context(), req.Token, req.Password)
+	var policyErr *password.ValidationError
+	if errors.As(err, &policyErr) {
+		return c.JSON(http.StatusBadRequest,
+			response.ValidationErrorResponse(password.ErrTidakMemenuhiKebijakan.Error(), policyErr))
+	}
+	if err != nil {
+		if errors.Is(err, service.ErrTokenResetTidakValid) {
+			return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, err.Error()))
+		}
+		return c.JSON(http.StatusInternalServerError,
+			response.ErrorResponse(http.StatusInternalServerError, service.ErrServerInternal.Error()))
+	}
+	return c.JSON(http.StatusOK,
+		response.SuccessResponse("Password berhasil direset, silakan login kembali", nil))
+}
@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go-todo/internal/service"
+	"go-todo/pkg/response"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"golang.org/x/net/websocket"
+)
+
+// heartbeatInterval menjaga koneksi tetap hidup melewati proxy yang menutup koneksi idle
+const heartbeatInterval = 25 * time.Second
+
+type RealtimeHandler struct {
+	realtimeService service.RealtimeService
+}
+
+// NewRealtimeHandler membuat instance baru dari RealtimeHandler
+func NewRealtimeHandler(realtimeService service.RealtimeService) *RealtimeHandler {
+	return &RealtimeHandler{realtimeService: realtimeService}
+}
+
+// StreamEvents menangani koneksi Server-Sent Events untuk perubahan todo milik pengguna.
+// Klien dapat melanjutkan dari event terakhir melalui header Last-Event-ID atau query last_event_id.
+func (h *RealtimeHandler) StreamEvents(c echo.Context) error {
+	ctx := c.Request().Context()
+	messages, err := h.realtimeService.Subscribe(ctx, currentUser(c).UserID, lastEventID(c))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, response.ErrorResponse(http.StatusInternalServerError, "Gagal berlangganan event"))
+	}
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set(echo.HeaderCacheControl, "no-cache")
+	res.Header().Set(echo.HeaderConnection, "keep-alive")
+	res.Header().Set("X-Accel-Buffering", "no")
+	res.WriteHeader(http.StatusOK)
+	// Jeda reconnect yang disarankan untuk EventSource di browser
+	fmt.Fprint(res, "retry: 3000\n\n")
+	res.Flush()
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(res, ": ping\n\n"); err != nil {
+				return nil
+			}
+			res.Flush()
+		case message, ok := <-messages:
+			if !ok {
+				return nil
+			}
+			if _, err := fmt.Fprintf(res, "id: %s\nevent: %s\ndata: %s\n\n", message.ID, message.Type, message.Data); err != nil {
+				return nil
+			}
+			res.Flush()
+		}
+	}
+}
+
+// StreamWebSocket menangani koneksi WebSocket untuk perubahan todo milik pengguna.
+// Setiap pesan berupa JSON {"id","type","data"}; resume menggunakan query last_event_id.
+func (h *RealtimeHandler) StreamWebSocket(c echo.Context) error {
+	userID := currentUser(c).UserID
+	resumeFrom := lastEventID(c)
+
+	// Origin tidak diperiksa karena koneksi sudah diautentikasi dengan JWT, bukan cookie
+	server := websocket.Server{Handler: func(ws *websocket.Conn) {
+		defer ws.Close()
+
+		ctx, cancel := context.WithCancel(c.Request().Context())
+		defer cancel()
+
+		messages, err := h.realtimeService.Subscribe(ctx, userID, resumeFrom)
+		if err != nil {
+			_ = websocket.JSON.Send(ws, map[string]string{"type": "error", "message": "Gagal berlangganan event"})
+			return
+		}
+
+		// Pesan dari klien tidak diproses; pembacaan hanya untuk mendeteksi koneksi ditutup
+		go func() {
+			_, _ = io.Copy(io.Discard, ws)
+			cancel()
+		}()
+
+		heartbeat := time.NewTicker(heartbeatInterval)
+		defer heartbeat.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-heartbeat.C:
+				if err := websocket.Message.Send(ws, `{"type":"ping"}`); err != nil {
+					return
+				}
+			case message, ok := <-messages:
+				if !ok {
+					return
+				}
+				payload, err := json.Marshal(message)
+				if err != nil {
+					continue
+				}
+				if err := websocket.Message.Send(ws, string(payload)); err != nil {
+					return
+				}
+			}
+		}
+	}}
+	server.ServeHTTP(c.Response(), c.Request())
+	return nil
+}
+
+// lastEventID membaca ID event terakhir yang diterima klien
+func lastEventID(c echo.Context) string {
+	if id := c.Request().Header.Get("Last-Event-ID"); id != "" {
+		return id
+	}
+	return c.QueryParam("last_event_id")
+}
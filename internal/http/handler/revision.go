@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"errors"
+	"go-todo/internal/service"
+	"go-todo/pkg/response"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type RevisionHandler struct {
+	revisionService   service.RevisionService
+	assignmentService service.AssignmentService
+}
+
+// NewRevisionHandler membuat instance baru dari RevisionHandler
+func NewRevisionHandler(revisionService service.RevisionService, assignmentService service.AssignmentService) *RevisionHandler {
+	return &RevisionHandler{revisionService: revisionService, assignmentService: assignmentService}
+}
+
+// GetRevisions menangani permintaan riwayat revisi sebuah todo
+func (h *RevisionHandler) GetRevisions(c echo.Context) error {
+	id, err := h.authorizedTodoID(c)
+	if err != nil {
+		status := revisionErrorStatus(err)
+		return c.JSON(status, response.ErrorResponse(status, err.Error()))
+	}
+
+	revisions, err := h.revisionService.FindAll(c.Request().Context(), id)
+	if err != nil {
+		status := revisionErrorStatus(err)
+		return c.JSON(status, response.ErrorResponse(status, err.Error()))
+	}
+	return c.JSON(http.StatusOK, response.SuccessResponse("Berhasil mengambil revisi todo", revisions))
+}
+
+// GetRevisionDiff menangani permintaan perbedaan per field antara dua revisi,
+// misalnya /todos/1/revisions/diff?from=2&to=5
+func (h *RevisionHandler) GetRevisionDiff(c echo.Context) error {
+	id, err := h.authorizedTodoID(c)
+	if err != nil {
+		status := revisionErrorStatus(err)
+		return c.JSON(status, response.ErrorResponse(status, err.Error()))
+	}
+
+	from, errFrom := strconv.Atoi(c.QueryParam("from"))
+	to, errTo := strconv.Atoi(c.QueryParam("to"))
+	if errFrom != nil || errTo != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, "Parameter from dan to harus berupa nomor revisi"))
+	}
+
+	changes, err := h.revisionService.Diff(c.Request().Context(), id, from, to)
+	if err != nil {
+		status := revisionErrorStatus(err)
+		return c.JSON(status, response.ErrorResponse(status, err.Error()))
+	}
+	return c.JSON(http.StatusOK, response.SuccessResponse("Berhasil membandingkan revisi todo", changes))
+}
+
+// RevertRevision menangani permintaan untuk memulihkan isi todo ke sebuah revisi.
+// Hasilnya dicatat sebagai revisi baru.
+func (h *RevisionHandler) RevertRevision(c echo.Context) error {
+	id, err := h.authorizedTodoID(c)
+	if err != nil {
+		status := revisionErrorStatus(err)
+		return c.JSON(status, response.ErrorResponse(status, err.Error()))
+	}
+
+	revision, err := strconv.Atoi(c.Param("rev"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, "Nomor revisi tidak valid"))
+	}
+
+	todo, err := h.revisionService.Revert(c.Request().Context(), id, revision)
+	if err != nil {
+		status := revisionErrorStatus(err)
+		return c.JSON(status, response.ErrorResponse(status, err.Error()))
+	}
+	return c.JSON(http.StatusOK, response.SuccessResponse("Todo berhasil dipulihkan", todo))
+}
+
+// authorizedTodoID membaca ID todo dan memastikan hanya pemilik atau pengguna dengan izin
+// todo:update:any yang dapat melihat atau memulihkan revisi
+func (h *RevisionHandler) authorizedTodoID(c echo.Context) (int64, error) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return 0, service.ErrTodoTidakDitemukan
+	}
+	if err := h.assignmentService.AuthorizeEdit(c.Request().Context(), currentUser(c).UserID, id); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// revisionErrorStatus memetakan error service revisi ke status HTTP
+func revisionErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrTodoTidakDitemukan), errors.Is(err, service.ErrRevisiTidakDitemukan):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrAksesDitolak):
+		return http.StatusForbidden
+	case errors.Is(err, service.ErrNilaiFieldKustomTidakValid):
+		return http.StatusUnprocessableEntity
+	}
+	return http.StatusInternalServerError
+}
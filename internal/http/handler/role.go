@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"go-todo/internal/entity"
+	"go-todo/internal/service"
+	"go-todo/pkg/response"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type RoleHandler struct {
+	roleService service.RoleService
+}
+
+// NewRoleHandler membuat instance baru dari RoleHandler
+func NewRoleHandler(roleService service.RoleService) *RoleHandler {
+	return &RoleHandler{roleService: roleService}
+}
+
+// roleRequest adalah body untuk membuat dan memperbarui role
+type roleRequest struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+}
+
+// GetRoles menangani permintaan daftar role beserta izinnya
+func (h *RoleHandler) GetRoles(c echo.Context) error {
+	roles, err := h.roleService.FindAll(c.Request().Context(), currentUser(c).UserID)
+	if err != nil {
+		status := roleErrorStatus(err)
+		return c.JSON(status, response.ErrorResponse(status, err.Error()))
+	}
+	return c.JSON(http.StatusOK, response.SuccessResponse("Berhasil mengambil role", roles))
+}
+
+// GetPermissions menangani permintaan daftar izin yang dapat dimasukkan ke dalam role
+func (h *RoleHandler) GetPermissions(c echo.Context) error {
+	return c.JSON(http.StatusOK, response.SuccessResponse("Berhasil mengambil izin", entity.Permissions))
+}
+
+// CreateRole menangani permintaan untuk membuat role baru
+func (h *RoleHandler) CreateRole(c echo.Context) error {
+	var req roleRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, "Permintaan tidak valid"))
+	}
+
+	created, err := h.roleService.Create(c.Request().Context(), currentUser(c).UserID, &entity.Role{
+		Name:        req.Name,
+		Description: req.Description,
+		Permissions: req.Permissions,
+	})
+	if err != nil {
+		status := roleErrorStatus(err)
+		return c.JSON(status, response.ErrorResponse(status, err.Error()))
+	}
+	return c.JSON(http.StatusCreated, response.SuccessResponse("Role berhasil dibuat", created))
+}
+
+// UpdateRole menangani permintaan untuk mengganti deskripsi dan izin role
+func (h *RoleHandler) UpdateRole(c echo.Context) error {
+	var req roleRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, "Permintaan tidak valid"))
+	}
+
+	updated, err := h.roleService.Update(c.Request().Context(), currentUser(c).UserID, c.Param("name"), &entity.Role{
+		Description: req.Description,
+		Permissions: req.Permissions,
+	})
+	if err != nil {
+		status := roleErrorStatus(err)
+		return c.JSON(status, response.ErrorResponse(status, err.Error()))
+	}
+	return c.JSON(http.StatusOK, response.SuccessResponse("Role berhasil diperbarui", updated))
+}
+
+// DeleteRole menangani permintaan untuk menghapus role buatan admin
+func (h *RoleHandler) DeleteRole(c echo.Context) error {
+	if err := h.roleService.Delete(c.Request().Context(), currentUser(c).UserID, c.Param("name")); err != nil {
+		status := roleErrorStatus(err)
+		return c.JSON(status, response.ErrorResponse(status, err.Error()))
+	}
+	return c.JSON(http.StatusOK, response.SuccessResponse("Role berhasil dihapus", nil))
+}
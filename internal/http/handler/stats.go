@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"errors"
+	"go-todo/internal/service"
+	"go-todo/pkg/response"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type StatsHandler struct {
+	statsService          service.StatsService
+	userPreferenceService service.UserPreferenceService
+}
+
+// NewStatsHandler membuat instance baru dari StatsHandler
+func NewStatsHandler(statsService service.StatsService, userPreferenceService service.UserPreferenceService) *StatsHandler {
+	return &StatsHandler{
+		statsService:          statsService,
+		userPreferenceService: userPreferenceService,
+	}
+}
+
+// GetStats menangani permintaan statistik produktivitas pengguna yang sedang login
+func (h *StatsHandler) GetStats(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := currentUser(c).UserID
+
+	from, to, err := parseDateRange(c, h.userPreferenceService.Location(ctx, userID))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, err.Error()))
+	}
+
+	stats, err := h.statsService.GetUserStats(ctx, userID, c.QueryParam("bucket"), from, to)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, service.ErrBucketTidakValid) {
+			status = http.StatusBadRequest
+		}
+		return c.JSON(status, response.ErrorResponse(status, err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, response.SuccessResponse("Berhasil mengambil statistik", stats))
+}
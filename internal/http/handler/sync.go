@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"errors"
+	"go-todo/internal/entity"
+	"go-todo/internal/service"
+	"go-todo/pkg/response"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type SyncHandler struct {
+	syncService service.SyncService
+}
+
+// NewSyncHandler membuat instance baru dari SyncHandler
+func NewSyncHandler(syncService service.SyncService) *SyncHandler {
+	return &SyncHandler{syncService: syncService}
+}
+
+// PullChanges menangani permintaan perubahan todo sejak cursor ?since= (0 untuk sinkronisasi awal)
+func (h *SyncHandler) PullChanges(c echo.Context) error {
+	var since int64
+	if value := c.QueryParam("since"); value != "" {
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || parsed < 0 {
+			return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, service.ErrCursorTidakValid.Error()))
+		}
+		since = parsed
+	}
+
+	limit := 0
+	if value := c.QueryParam("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 {
+			return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, "limit harus berupa bilangan positif"))
+		}
+		limit = parsed
+	}
+
+	changes, err := h.syncService.Pull(c.Request().Context(), currentUser(c).UserID, since, limit)
+	if err != nil {
+		status := syncErrorStatus(err)
+		return c.JSON(status, response.ErrorResponse(status, err.Error()))
+	}
+	return c.JSON(http.StatusOK, response.SuccessResponse("Berhasil mengambil perubahan", changes))
+}
+
+// PushMutations menangani batch mutasi dari klien offline dan mengembalikan state todo yang berlaku
+func (h *SyncHandler) PushMutations(c echo.Context) error {
+	var req struct {
+		Mutations []entity.SyncMutation `json:"mutations"`
+	}
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, "Permintaan tidak valid"))
+	}
+
+	results, err := h.syncService.Push(c.Request().Context(), currentUser(c).UserID, req.Mutations)
+	if err != nil {
+		status := syncErrorStatus(err)
+		return c.JSON(status, response.ErrorResponse(status, err.Error()))
+	}
+	return c.JSON(http.StatusOK, response.SuccessResponse("Mutasi berhasil diproses", results))
+}
+
+// syncErrorStatus memetakan error dari SyncService ke status HTTP
+func syncErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrCursorTidakValid):
+		return http.StatusBadRequest
+	case errors.Is(err, service.ErrMutasiTerlaluBanyak):
+		return http.StatusRequestEntityTooLarge
+	default:
+		return http.StatusInternalServerError
+	}
+}
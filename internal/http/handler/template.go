@@ -0,0 +1,185 @@
+package handler
+
+import (
+	"errors"
+	"go-todo/internal/entity"
+	"go-todo/internal/service"
+	"go-todo/pkg/response"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type TemplateHandler struct {
+	templateService service.TemplateService
+}
+
+// NewTemplateHandler membuat instance baru dari TemplateHandler
+func NewTemplateHandler(templateService service.TemplateService) *TemplateHandler {
+	return &TemplateHandler{templateService: templateService}
+}
+
+// templateRequest adalah body untuk membuat dan memperbarui template
+type templateRequest struct {
+	Name        string                `json:"name"`
+	Description string                `json:"description"`
+	Items       []entity.TemplateItem `json:"items"`
+}
+
+// GetTemplates menangani permintaan daftar template milik pengguna
+func (h *TemplateHandler) GetTemplates(c echo.Context) error {
+	templates, err := h.templateService.FindAll(c.Request().Context(), currentUser(c).UserID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, response.ErrorResponse(http.StatusInternalServerError, "Gagal mengambil template"))
+	}
+	return c.JSON(http.StatusOK, response.SuccessResponse("Berhasil mengambil template", templates))
+}
+
+// GetTemplate menangani permintaan detail template
+func (h *TemplateHandler) GetTemplate(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, "ID template tidak valid"))
+	}
+
+	template, err := h.templateService.FindByID(c.Request().Context(), currentUser(c).UserID, id)
+	if err != nil {
+		status := templateErrorStatus(err)
+		return c.JSON(status, response.ErrorResponse(status, err.Error()))
+	}
+	return c.JSON(http.StatusOK, response.SuccessResponse("Berhasil mengambil template", template))
+}
+
+// CreateTemplate menangani permintaan untuk membuat template dari daftar item
+func (h *TemplateHandler) CreateTemplate(c echo.Context) error {
+	var req templateRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, "Permintaan tidak valid"))
+	}
+
+	created, err := h.templateService.Create(c.Request().Context(), &entity.TodoTemplate{
+		UserID:      currentUser(c).UserID,
+		Name:        req.Name,
+		Description: req.Description,
+		Items:       req.Items,
+	})
+	if err != nil {
+		status := templateErrorStatus(err)
+		return c.JSON(status, response.ErrorResponse(status, err.Error()))
+	}
+	return c.JSON(http.StatusCreated, response.SuccessResponse("Template berhasil dibuat", created))
+}
+
+// CreateTemplateFromTodo menangani permintaan untuk menyimpan todo beserta subtask-nya sebagai template
+func (h *TemplateHandler) CreateTemplateFromTodo(c echo.Context) error {
+	var req struct {
+		TodoID int64  `json:"todo_id"`
+		Name   string `json:"name"`
+	}
+	if err := c.Bind(&req); err != nil || req.TodoID <= 0 {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, "ID todo tidak valid"))
+	}
+
+	created, err := h.templateService.CreateFromTodo(c.Request().Context(), currentUser(c).UserID, req.TodoID, req.Name)
+	if err != nil {
+		status := templateErrorStatus(err)
+		return c.JSON(status, response.ErrorResponse(status, err.Error()))
+	}
+	return c.JSON(http.StatusCreated, response.SuccessResponse("Template berhasil dibuat", created))
+}
+
+// CreateTemplateFromProject menangani permintaan untuk menyimpan seluruh todo dalam proyek sebagai template
+func (h *TemplateHandler) CreateTemplateFromProject(c echo.Context) error {
+	var req struct {
+		Project string `json:"project"`
+		Name    string `json:"name"`
+	}
+	if err := c.Bind(&req); err != nil || req.Project == "" {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, "Nama proyek harus diisi"))
+	}
+
+	created, err := h.templateService.CreateFromProject(c.Request().Context(), currentUser(c).UserID, req.Project, req.Name)
+	if err != nil {
+		status := templateErrorStatus(err)
+		return c.JSON(status, response.ErrorResponse(status, err.Error()))
+	}
+	return c.JSON(http.StatusCreated, response.SuccessResponse("Template berhasil dibuat", created))
+}
+
+// UpdateTemplate menangani permintaan untuk memperbarui template
+func (h *TemplateHandler) UpdateTemplate(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, "ID template tidak valid"))
+	}
+
+	var req templateRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, "Permintaan tidak valid"))
+	}
+
+	updated, err := h.templateService.Update(c.Request().Context(), &entity.TodoTemplate{
+		ID:          id,
+		UserID:      currentUser(c).UserID,
+		Name:        req.Name,
+		Description: req.Description,
+		Items:       req.Items,
+	})
+	if err != nil {
+		status := templateErrorStatus(err)
+		return c.JSON(status, response.ErrorResponse(status, err.Error()))
+	}
+	return c.JSON(http.StatusOK, response.SuccessResponse("Template berhasil diperbarui", updated))
+}
+
+// DeleteTemplate menangani permintaan untuk menghapus template
+func (h *TemplateHandler) DeleteTemplate(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, "ID template tidak valid"))
+	}
+
+	if err := h.templateService.Delete(c.Request().Context(), currentUser(c).UserID, id); err != nil {
+		status := templateErrorStatus(err)
+		return c.JSON(status, response.ErrorResponse(status, err.Error()))
+	}
+	return c.JSON(http.StatusOK, response.SuccessResponse("Template berhasil dihapus", nil))
+}
+
+// InstantiateTemplate menangani permintaan untuk membuat todo dari template
+func (h *TemplateHandler) InstantiateTemplate(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, "ID template tidak valid"))
+	}
+
+	var req entity.TemplateInstantiation
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, "Permintaan tidak valid"))
+	}
+
+	todos, err := h.templateService.Instantiate(c.Request().Context(), currentUser(c).UserID, id, req)
+	if err != nil {
+		status := templateErrorStatus(err)
+		return c.JSON(status, response.ErrorResponse(status, err.Error()))
+	}
+	return c.JSON(http.StatusCreated, response.SuccessResponse("Todo berhasil dibuat dari template", todos))
+}
+
+// templateErrorStatus memetakan error service template ke status HTTP
+func templateErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrTemplateTidakDitemukan), errors.Is(err, service.ErrTodoTidakDitemukan):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrNamaTemplateTidakValid),
+		errors.Is(err, service.ErrItemTemplateTidakValid),
+		errors.Is(err, service.ErrVariabelTidakLengkap),
+		errors.Is(err, service.ErrTanggalMulaiTidakValid),
+		errors.Is(err, service.ErrProyekTidakMemilikiTodo),
+		errors.Is(err, service.ErrPrioritasTidakValid),
+		errors.Is(err, service.ErrNilaiFieldKustomTidakValid):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
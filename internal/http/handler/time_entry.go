@@ -0,0 +1,227 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"go-todo/internal/entity"
+	"go-todo/internal/service"
+	"go-todo/pkg/response"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+type TimeEntryHandler struct {
+	timeEntryService      service.TimeEntryService
+	userPreferenceService service.UserPreferenceService
+}
+
+// NewTimeEntryHandler membuat instance baru dari TimeEntryHandler
+func NewTimeEntryHandler(timeEntryService service.TimeEntryService, userPreferenceService service.UserPreferenceService) *TimeEntryHandler {
+	return &TimeEntryHandler{
+		timeEntryService:      timeEntryService,
+		userPreferenceService: userPreferenceService,
+	}
+}
+
+// StartTimer menangani permintaan untuk memulai timer pada todo
+func (h *TimeEntryHandler) StartTimer(c echo.Context) error {
+	todoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, "ID todo tidak valid"))
+	}
+
+	var req struct {
+		Note string `json:"note"`
+	}
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, "Permintaan tidak valid"))
+	}
+
+	entry, err := h.timeEntryService.StartTimer(c.Request().Context(), currentUser(c).UserID, todoID, req.Note)
+	if err != nil {
+		status := timeEntryErrorStatus(err)
+		return c.JSON(status, response.ErrorResponse(status, err.Error()))
+	}
+	return c.JSON(http.StatusCreated, response.SuccessResponse("Timer berhasil dimulai", entry))
+}
+
+// StopTimer menangani permintaan untuk menghentikan timer pada todo
+func (h *TimeEntryHandler) StopTimer(c echo.Context) error {
+	todoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, "ID todo tidak valid"))
+	}
+
+	entry, err := h.timeEntryService.StopTimer(c.Request().Context(), currentUser(c).UserID, todoID)
+	if err != nil {
+		status := timeEntryErrorStatus(err)
+		return c.JSON(status, response.ErrorResponse(status, err.Error()))
+	}
+	return c.JSON(http.StatusOK, response.SuccessResponse("Timer berhasil dihentikan", entry))
+}
+
+// GetTimeEntries menangani permintaan untuk mengambil catatan waktu sebuah todo
+func (h *TimeEntryHandler) GetTimeEntries(c echo.Context) error {
+	todoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, "ID todo tidak valid"))
+	}
+
+	entries, err := h.timeEntryService.FindByTodoID(c.Request().Context(), currentUser(c).UserID, todoID)
+	if err != nil {
+		status := timeEntryErrorStatus(err)
+		return c.JSON(status, response.ErrorResponse(status, err.Error()))
+	}
+	return c.JSON(http.StatusOK, response.SuccessResponse("Berhasil mengambil catatan waktu", entries))
+}
+
+// CreateTimeEntry menangani permintaan untuk menambahkan catatan waktu secara manual
+func (h *TimeEntryHandler) CreateTimeEntry(c echo.Context) error {
+	todoID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, "ID todo tidak valid"))
+	}
+
+	var req struct {
+		StartedAt time.Time  `json:"started_at"`
+		EndedAt   *time.Time `json:"ended_at"`
+		Note      string     `json:"note"`
+	}
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, "Permintaan tidak valid"))
+	}
+
+	entry := &entity.TimeEntry{
+		TodoID:    todoID,
+		UserID:    currentUser(c).UserID,
+		StartedAt: req.StartedAt,
+		EndedAt:   req.EndedAt,
+		Note:      req.Note,
+	}
+
+	createdEntry, err := h.timeEntryService.Create(c.Request().Context(), entry)
+	if err != nil {
+		status := timeEntryErrorStatus(err)
+		return c.JSON(status, response.ErrorResponse(status, err.Error()))
+	}
+	return c.JSON(http.StatusCreated, response.SuccessResponse("Catatan waktu berhasil dibuat", createdEntry))
+}
+
+// UpdateTimeEntry menangani permintaan untuk memperbarui catatan waktu
+func (h *TimeEntryHandler) UpdateTimeEntry(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, "ID catatan waktu tidak valid"))
+	}
+
+	var req struct {
+		StartedAt time.Time  `json:"started_at"`
+		EndedAt   *time.Time `json:"ended_at"`
+		Note      string     `json:"note"`
+	}
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, "Permintaan tidak valid"))
+	}
+
+	entry := &entity.TimeEntry{
+		ID:        id,
+		StartedAt: req.StartedAt,
+		EndedAt:   req.EndedAt,
+		Note:      req.Note,
+	}
+
+	updatedEntry, err := h.timeEntryService.Update(c.Request().Context(), currentUser(c).UserID, entry)
+	if err != nil {
+		status := timeEntryErrorStatus(err)
+		return c.JSON(status, response.ErrorResponse(status, err.Error()))
+	}
+	return c.JSON(http.StatusOK, response.SuccessResponse("Catatan waktu berhasil diperbarui", updatedEntry))
+}
+
+// DeleteTimeEntry menangani permintaan untuk menghapus catatan waktu
+func (h *TimeEntryHandler) DeleteTimeEntry(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, "ID catatan waktu tidak valid"))
+	}
+
+	if err := h.timeEntryService.Delete(c.Request().Context(), currentUser(c).UserID, id); err != nil {
+		status := timeEntryErrorStatus(err)
+		return c.JSON(status, response.ErrorResponse(status, err.Error()))
+	}
+	return c.JSON(http.StatusOK, response.SuccessResponse("Catatan waktu berhasil dihapus", nil))
+}
+
+// GetTimeReport menangani permintaan laporan waktu per pengguna dan per project.
+// Gunakan query format=csv untuk mengunduh laporan sebagai CSV.
+func (h *TimeEntryHandler) GetTimeReport(c echo.Context) error {
+	claims := currentUser(c)
+	loc := h.userPreferenceService.Location(c.Request().Context(), claims.UserID)
+	from, to, err := parseDateRange(c, loc)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, err.Error()))
+	}
+
+	filter := entity.TimeReportFilter{
+		Project: c.QueryParam("project"),
+		From:    from,
+		To:      to,
+	}
+
+	// Tanpa izin report:read:any, pengguna hanya dapat melihat laporan miliknya sendiri
+	if hasPermission(c, entity.PermissionReportReadAny) {
+		if value := c.QueryParam("user_id"); value != "" {
+			filter.UserID, err = strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, "ID pengguna tidak valid"))
+			}
+		}
+	} else {
+		filter.UserID = claims.UserID
+	}
+
+	rows, err := h.timeEntryService.Report(c.Request().Context(), filter)
+	if err != nil {
+		status := timeEntryErrorStatus(err)
+		return c.JSON(status, response.ErrorResponse(status, err.Error()))
+	}
+
+	if c.QueryParam("format") == "csv" {
+		records := make([][]string, 0, len(rows))
+		for _, row := range rows {
+			records = append(records, []string{
+				strconv.FormatInt(row.UserID, 10),
+				row.Username,
+				row.Project,
+				strconv.FormatInt(row.EntryCount, 10),
+				strconv.FormatInt(row.TotalSeconds, 10),
+				fmt.Sprintf("%.2f", float64(row.TotalSeconds)/3600),
+			})
+		}
+		header := []string{"user_id", "username", "project", "entry_count", "total_seconds", "total_hours"}
+		return writeCSV(c, "time-report.csv", header, records)
+	}
+
+	return c.JSON(http.StatusOK, response.SuccessResponse("Berhasil mengambil laporan waktu", rows))
+}
+
+// timeEntryErrorStatus memetakan error dari service ke status HTTP
+func timeEntryErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrTodoTidakDitemukan),
+		errors.Is(err, service.ErrCatatanWaktuTidakDitemukan):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrTimerSudahBerjalan),
+		errors.Is(err, service.ErrTimerTidakBerjalan):
+		return http.StatusConflict
+	case errors.Is(err, service.ErrCatatanWaktuTidakValid):
+		return http.StatusBadRequest
+	case errors.Is(err, service.ErrAksesDitolak):
+		return http.StatusForbidden
+	default:
+		return http.StatusInternalServerError
+	}
+}
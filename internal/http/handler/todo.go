@@ -2,23 +2,35 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"go-todo/internal/entity"
 	"go-todo/internal/service"
+	"go-todo/pkg/quickadd"
 	"go-todo/pkg/response"
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/labstack/echo/v4"
 )
 
 type TodoHandler struct {
-	todoService service.TodoService
+	todoService           service.TodoService
+	userPreferenceService service.UserPreferenceService
+	assignmentService     service.AssignmentService
+	undoService           service.UndoService
 }
 
 // NewTodoHandler menginisialisasi handler baru untuk todo
-func NewTodoHandler(todoService service.TodoService) *TodoHandler {
-	return &TodoHandler{todoService}
+func NewTodoHandler(
+	todoService service.TodoService,
+	userPreferenceService service.UserPreferenceService,
+	assignmentService service.AssignmentService,
+	undoService service.UndoService,
+) *TodoHandler {
+	return &TodoHandler{todoService, userPreferenceService, assignmentService, undoService}
 }
 
 // GetAllTodos menghandle request untuk mengambil semua todo
@@ -39,14 +51,72 @@ func (h *TodoHandler) CreateTodo(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, "Permintaan tidak valid"))
 	}
 
+	// Pemilik selalu pengguna yang login; field yang dikelola server tidak diambil dari body
+	todo.UserID = currentUser(c).UserID
+	todo.ID = 0
+	todo.AssigneeID = nil
+	todo.CompletedAt = nil
+	todo.Version = 0
+
 	ctx := context.Background()
 	createdTodo, err := h.todoService.Create(ctx, todo)
 	if err != nil {
+		if errors.Is(err, service.ErrPrioritasTidakValid) || errors.Is(err, service.ErrIndukTidakValid) ||
+			errors.Is(err, service.ErrNilaiFieldKustomTidakValid) {
+			return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, err.Error()))
+		}
 		return c.JSON(http.StatusInternalServerError, response.ErrorResponse(http.StatusInternalServerError, "Gagal membuat todo"))
 	}
 	return c.JSON(http.StatusOK, response.SuccessResponse("Todo berhasil dibuat", createdTodo))
 }
 
+// QuickAddTodo menangani permintaan membuat todo dari teks bebas,
+// misalnya "Bayar sewa besok jam 9 pagi #rumah !tinggi"
+func (h *TodoHandler) QuickAddTodo(c echo.Context) error {
+	var req struct {
+		Text     string `json:"text"`
+		Timezone string `json:"timezone"`
+	}
+	if err := c.Bind(&req); err != nil || strings.TrimSpace(req.Text) == "" {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, "Teks todo harus diisi"))
+	}
+
+	// Tanggal relatif dihitung pada zona waktu pengguna, dapat ditimpa melalui field timezone
+	loc := h.userPreferenceService.Location(c.Request().Context(), currentUser(c).UserID)
+	if req.Timezone != "" {
+		var err error
+		loc, err = time.LoadLocation(req.Timezone)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, "Zona waktu tidak valid"))
+		}
+	}
+
+	parsed := quickadd.Parse(req.Text, time.Now().In(loc))
+	if parsed.Title == "" {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, "Judul todo tidak boleh kosong"))
+	}
+
+	todo := entity.Todo{
+		Title:    parsed.Title,
+		Tags:     parsed.Tags,
+		Priority: parsed.Priority,
+		UserID:   currentUser(c).UserID,
+	}
+	if parsed.DueDate != nil {
+		todo.DueDate = *parsed.DueDate
+	}
+
+	createdTodo, err := h.todoService.Create(c.Request().Context(), todo)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, response.ErrorResponse(http.StatusInternalServerError, "Gagal membuat todo"))
+	}
+
+	return c.JSON(http.StatusOK, response.SuccessResponse("Todo berhasil dibuat", map[string]interface{}{
+		"parsed": parsed,
+		"todo":   createdTodo,
+	}))
+}
+
 // UpdateTodo menangani permintaan untuk memperbarui data todo berdasarkan ID
 func (h *TodoHandler) UpdateTodo(c echo.Context) error {
 	// Mengonversi ID dari parameter URL menjadi int64
@@ -61,21 +131,34 @@ func (h *TodoHandler) UpdateTodo(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, "Permintaan tidak valid"))
 	}
 
-	// Menyiapkan konteks dan memanggil metode Update di service
+	// Hanya pemilik atau pengguna dengan izin todo:update:any yang boleh mengubah isi todo.
+	// Penanggung jawab mengubah status melalui PATCH /todos/:id/status.
+	if err := h.assignmentService.AuthorizeEdit(c.Request().Context(), currentUser(c).UserID, id); err != nil {
+		status := assignmentErrorStatus(err)
+		return c.JSON(status, response.ErrorResponse(status, err.Error()))
+	}
+
+	// Menyiapkan konteks dan memanggil metode Update melalui UndoService agar perubahan dapat dibatalkan
 	ctx := context.Background()
-	updatedTodo, err := h.todoService.Update(ctx, id, todo)
+	updatedTodo, undoToken, err := h.undoService.UpdateTodo(ctx, currentUser(c).UserID, id, todo)
 	if err != nil {
 		// Memeriksa apakah error disebabkan karena Todo tidak ditemukan
 		if err.Error() == "todo tidak ditemukan" {
 			return c.JSON(http.StatusNotFound, response.ErrorResponse(http.StatusNotFound, "Todo tidak ditemukan"))
 		}
+		if errors.Is(err, service.ErrPrioritasTidakValid) || errors.Is(err, service.ErrNilaiFieldKustomTidakValid) {
+			return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, err.Error()))
+		}
 
 		// Mengembalikan error internal server untuk masalah lainnya
 		return c.JSON(http.StatusInternalServerError, response.ErrorResponse(http.StatusInternalServerError, "Gagal memperbarui todo"))
 	}
 
-	// Mengembalikan respons sukses dengan Todo yang diperbarui
-	return c.JSON(http.StatusOK, response.SuccessResponse("Todo berhasil diperbarui", updatedTodo))
+	// Mengembalikan respons sukses dengan Todo yang diperbarui beserta token undo
+	return c.JSON(http.StatusOK, response.SuccessResponse("Todo berhasil diperbarui", struct {
+		entity.Todo
+		*entity.UndoToken
+	}{updatedTodo, undoToken}))
 }
 
 // DeleteTodo menangani permintaan untuk menghapus todo berdasarkan ID
@@ -87,8 +170,9 @@ func (h *TodoHandler) DeleteTodo(c echo.Context) error {
 	}
 
 	ctx := context.Background()
-	if err := h.todoService.Delete(ctx, id); err != nil {
+	undoToken, err := h.undoService.DeleteTodo(ctx, currentUser(c).UserID, id)
+	if err != nil {
 		return c.JSON(http.StatusInternalServerError, response.ErrorResponse(http.StatusInternalServerError, "Gagal menghapus todo"))
 	}
-	return c.JSON(http.StatusOK, response.SuccessResponse("Todo berhasil dihapus", nil))
+	return c.JSON(http.StatusOK, response.SuccessResponse("Todo berhasil dihapus", undoToken))
 }
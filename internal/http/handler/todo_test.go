@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"context"
+	"go-todo/internal/entity"
+	"go-todo/pkg/token"
+	mock_service "go-todo/test/mock/service"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestTodoHandler_CreateTodo_IgnoresClientOwner menguji bahwa pemilik todo selalu pengguna yang
+// login dan field yang dikelola server dari body diabaikan
+func TestTodoHandler_CreateTodo_IgnoresClientOwner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	todoService := mock_service.NewMockTodoService(ctrl)
+	h := NewTodoHandler(todoService, nil, nil, nil)
+
+	body := `{"id": 99, "title": "Bayar sewa", "user_id": 2, "assignee_id": 3, "completed_at": "2026-10-18T00:00:00Z", "version": 5}`
+	req := httptest.NewRequest(http.MethodPost, "/todos", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.Set("user", jwt.NewWithClaims(jwt.SigningMethodHS256, &token.JwtCustomClaims{UserID: 7}))
+
+	todoService.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, todo entity.Todo) (entity.Todo, error) {
+			assert.Equal(t, int64(7), todo.UserID)
+			assert.Equal(t, int64(0), todo.ID)
+			assert.Nil(t, todo.AssigneeID)
+			assert.Nil(t, todo.CompletedAt)
+			assert.Equal(t, int64(0), todo.Version)
+			assert.Equal(t, "Bayar sewa", todo.Title)
+			todo.ID = 1
+			return todo, nil
+		})
+
+	assert.NoError(t, h.CreateTodo(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
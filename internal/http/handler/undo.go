@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"errors"
+	"go-todo/internal/service"
+	"go-todo/pkg/response"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type UndoHandler struct {
+	undoService service.UndoService
+}
+
+// NewUndoHandler membuat instance baru dari UndoHandler
+func NewUndoHandler(undoService service.UndoService) *UndoHandler {
+	return &UndoHandler{undoService: undoService}
+}
+
+// Undo menangani permintaan untuk membatalkan perubahan atau penghapusan todo
+// menggunakan token yang dikembalikan oleh aksi tersebut
+func (h *UndoHandler) Undo(c echo.Context) error {
+	todo, err := h.undoService.Undo(c.Request().Context(), currentUser(c).UserID, c.Param("token"))
+	if err != nil {
+		status := undoErrorStatus(err)
+		return c.JSON(status, response.ErrorResponse(status, err.Error()))
+	}
+	return c.JSON(http.StatusOK, response.SuccessResponse("Aksi berhasil dibatalkan", todo))
+}
+
+// undoErrorStatus memetakan error service undo ke status HTTP
+func undoErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrTokenUndoTidakValid):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrUndoKonflik):
+		return http.StatusConflict
+	case errors.Is(err, service.ErrNilaiFieldKustomTidakValid):
+		return http.StatusUnprocessableEntity
+	}
+	return http.StatusInternalServerError
+}
@@ -4,7 +4,9 @@ import (
 	"errors"
 	"go-todo/internal/entity"
 	"go-todo/internal/service"
+	"go-todo/pkg/password"
 	"go-todo/pkg/response"
+	"math"
 	"net/http"
 	"strconv"
 
@@ -32,13 +34,14 @@ func (h *UserHandler) GetAllUsers(c echo.Context) error {
 		response.SuccessResponse("Data pengguna berhasil diambil", users))
 }
 
-// CreateUser menangani permintaan untuk membuat pengguna baru
+// CreateUser menangani permintaan pendaftaran pengguna baru. Role tidak dapat dipilih
+// sendiri; pengguna baru selalu mendapat role default.
 func (h *UserHandler) CreateUser(c echo.Context) error {
 	var req struct {
 		Username string `json:"username" validate:"required"`
 		Password string `json:"password" validate:"required"`
 		FullName string `json:"full_name" validate:"required"`
-		Role     string `json:"role" validate:"required"`
+		Email    string `json:"email" validate:"required"`
 	}
 
 	// Validasi permintaan
@@ -46,19 +49,30 @@ func (h *UserHandler) CreateUser(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest,
 			response.ErrorResponse(http.StatusBadRequest, "Format permintaan tidak valid"))
 	}
+	if req.Email == "" {
+		return c.JSON(http.StatusBadRequest,
+			response.ErrorResponse(http.StatusBadRequest, "Email harus diisi"))
+	}
 
 	user := &entity.User{
 		Username: req.Username,
 		Password: req.Password, 
 		FullName: req.FullName,
-		Role:     req.Role,
+		Email:    req.Email,
 	}
 
 	createdUser, err := h.userService.CreateUser(c.Request().Context(), user)
+	var policyErr *password.ValidationError
+	if errors.As(err, &policyErr) {
+		return c.JSON(http.StatusBadRequest,
+			response.ValidationErrorResponse(password.ErrTidakMemenuhiKebijakan.Error(), policyErr))
+	}
 	if err != nil {
 		status := http.StatusInternalServerError
-		if errors.Is(err, service.ErrUsernameSudahAda) {
+		if errors.Is(err, service.ErrUsernameSudahAda) || errors.Is(err, service.ErrEmailSudahAda) {
 			status = http.StatusConflict
+		} else if errors.Is(err, service.ErrRoleTidakValid) || errors.Is(err, service.ErrEmailTidakValid) {
+			status = http.StatusBadRequest
 		}
 		return c.JSON(status, response.ErrorResponse(status, err.Error()))
 	}
@@ -84,20 +98,77 @@ func (h *UserHandler) LoginUser(c echo.Context) error {
 			response.ErrorResponse(http.StatusBadRequest, "Username dan password harus diisi"))
 	}
 
-	token, err := h.userService.Login(c.Request().Context(), req.Username, req.Password)
+	result, err := h.userService.Login(c.Request().Context(), req.Username, req.Password, c.RealIP())
 	if err != nil {
-		status := http.StatusUnauthorized
-		if err == service.ErrServerInternal {
-			status = http.StatusInternalServerError
-		}
-		return c.JSON(status, response.ErrorResponse(status, err.Error()))
+		return loginError(c, err)
+	}
+
+	// Pengguna dengan TOTP harus mengirim kode ke /login/mfa bersama mfa_token
+	if result.Challenge != nil {
+		return c.JSON(http.StatusOK,
+			response.SuccessResponse("Masukkan kode autentikasi dua faktor", struct {
+				MFARequired bool `json:"mfa_required"`
+				*entity.MFAChallenge
+			}{true, result.Challenge}))
+	}
+	return loginSuccess(c, result.Tokens)
+}
+
+// LoginMFA menangani langkah kedua login dengan kode TOTP atau recovery code
+func (h *UserHandler) LoginMFA(c echo.Context) error {
+	var req struct {
+		MFAToken string `json:"mfa_token" validate:"required"`
+		Code     string `json:"code" validate:"required"`
+	}
+
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest,
+			response.ErrorResponse(http.StatusBadRequest, "Format permintaan tidak valid"))
+	}
+
+	if req.MFAToken == "" || req.Code == "" {
+		return c.JSON(http.StatusBadRequest,
+			response.ErrorResponse(http.StatusBadRequest, "mfa_token dan code harus diisi"))
 	}
 
+	pair, err := h.userService.LoginMFA(c.Request().Context(), req.MFAToken, req.Code, c.RealIP())
+	if err != nil {
+		return loginError(c, err)
+	}
+	return loginSuccess(c, pair)
+}
+
+// loginSuccess mengirim token hasil login. Field token dipertahankan untuk klien lama dan
+// berisi access token yang sama.
+func loginSuccess(c echo.Context, pair *entity.TokenPair) error {
 	return c.JSON(http.StatusOK,
-		response.SuccessResponse("Login berhasil", map[string]string{"token": token}))
+		response.SuccessResponse("Login berhasil", struct {
+			Token string `json:"token"`
+			*entity.TokenPair
+		}{pair.AccessToken, pair}))
+}
+
+// loginError memetakan error kedua langkah login ke status HTTP
+func loginError(c echo.Context, err error) error {
+	var locked *service.LoginLockedError
+	if errors.As(err, &locked) {
+		c.Response().Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(locked.RetryAfter.Seconds()))))
+		return c.JSON(http.StatusTooManyRequests,
+			response.ErrorResponse(http.StatusTooManyRequests, err.Error()))
+	}
+	status := http.StatusUnauthorized
+	if errors.Is(err, service.ErrEmailBelumDiverifikasi) {
+		status = http.StatusForbidden
+	} else if !errors.Is(err, service.ErrKredensialTidakValid) && !errors.Is(err, service.ErrKodeMFATidakValid) &&
+		!errors.Is(err, service.ErrChallengeMFATidakValid) {
+		status = http.StatusInternalServerError
+		err = service.ErrServerInternal
+	}
+	return c.JSON(status, response.ErrorResponse(status, err.Error()))
 }
 
-// UpdateUser menangani permintaan untuk memperbarui data pengguna
+// UpdateUser menangani permintaan untuk memperbarui data pengguna.
+// Role diubah melalui endpoint role agar setiap perubahan hak akses tervalidasi.
 func (h *UserHandler) UpdateUser(c echo.Context) error {
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
@@ -109,7 +180,7 @@ func (h *UserHandler) UpdateUser(c echo.Context) error {
 		Username string `json:"username"`
 		Password string `json:"password"`
 		FullName string `json:"full_name"`
-		Role     string `json:"role"`
+		Email    string `json:"email"`
 	}
 
 	if err := c.Bind(&req); err != nil {
@@ -122,14 +193,23 @@ func (h *UserHandler) UpdateUser(c echo.Context) error {
 		Username: req.Username,
 		Password: req.Password, // Password akan di-hash di service layer
 		FullName: req.FullName,
-		Role:     req.Role,
+		Email:    req.Email,
 	}
 
 	updatedUser, err := h.userService.UpdateUser(c.Request().Context(), user)
+	var policyErr *password.ValidationError
+	if errors.As(err, &policyErr) {
+		return c.JSON(http.StatusBadRequest,
+			response.ValidationErrorResponse(password.ErrTidakMemenuhiKebijakan.Error(), policyErr))
+	}
 	if err != nil {
 		status := http.StatusInternalServerError
 		if errors.Is(err, service.ErrPenggunaTidakDitemukan) {
 			status = http.StatusNotFound
+		} else if errors.Is(err, service.ErrEmailSudahAda) {
+			status = http.StatusConflict
+		} else if errors.Is(err, service.ErrEmailTidakValid) {
+			status = http.StatusBadRequest
 		}
 		return c.JSON(status, response.ErrorResponse(status, err.Error()))
 	}
@@ -157,3 +237,80 @@ func (h *UserHandler) DeleteUser(c echo.Context) error {
 	return c.JSON(http.StatusOK,
 		response.SuccessResponse("Pengguna berhasil dihapus", nil))
 }
+
+// GrantRole menangani permintaan admin untuk memberikan role kepada pengguna
+func (h *UserHandler) GrantRole(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest,
+			response.ErrorResponse(http.StatusBadRequest, "ID pengguna tidak valid"))
+	}
+
+	var req struct {
+		Role string `json:"role"`
+	}
+	if err := c.Bind(&req); err != nil || req.Role == "" {
+		return c.JSON(http.StatusBadRequest,
+			response.ErrorResponse(http.StatusBadRequest, "role harus diisi"))
+	}
+
+	user, err := h.userService.GrantRole(c.Request().Context(), currentUser(c).UserID, id, req.Role)
+	if err != nil {
+		status := roleErrorStatus(err)
+		return c.JSON(status, response.ErrorResponse(status, err.Error()))
+	}
+
+	return c.JSON(http.StatusOK,
+		response.SuccessResponse("Role berhasil diberikan", user))
+}
+
+// RevokeRole menangani permintaan admin untuk mencabut role dari pengguna
+func (h *UserHandler) RevokeRole(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest,
+			response.ErrorResponse(http.StatusBadRequest, "ID pengguna tidak valid"))
+	}
+
+	user, err := h.userService.RevokeRole(c.Request().Context(), currentUser(c).UserID, id, c.Param("role"))
+	if err != nil {
+		status := roleErrorStatus(err)
+		return c.JSON(status, response.ErrorResponse(status, err.Error()))
+	}
+
+	return c.JSON(http.StatusOK,
+		response.SuccessResponse("Role berhasil dicabut", user))
+}
+
+// UnlockUser menangani permintaan admin untuk membuka kunci login pengguna
+func (h *UserHandler) UnlockUser(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest,
+			response.ErrorResponse(http.StatusBadRequest, "ID pengguna tidak valid"))
+	}
+
+	if err := h.userService.UnlockLogin(c.Request().Context(), currentUser(c).UserID, id); err != nil {
+		status := roleErrorStatus(err)
+		return c.JSON(status, response.ErrorResponse(status, err.Error()))
+	}
+
+	return c.JSON(http.StatusOK,
+		response.SuccessResponse("Kunci login pengguna berhasil dibuka", nil))
+}
+
+// roleErrorStatus memetakan error role dan perubahan role pengguna ke status HTTP
+func roleErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrPenggunaTidakDitemukan), errors.Is(err, service.ErrRoleTidakDitemukan):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrRoleTidakValid), errors.Is(err, service.ErrRoleDefaultTidakDicabut),
+		errors.Is(err, service.ErrIzinTidakValid):
+		return http.StatusBadRequest
+	case errors.Is(err, service.ErrAdminTerakhir), errors.Is(err, service.ErrRoleSudahAda):
+		return http.StatusConflict
+	case errors.Is(err, service.ErrAksesDitolak), errors.Is(err, service.ErrRoleBawaan):
+		return http.StatusForbidden
+	}
+	return http.StatusInternalServerError
+}
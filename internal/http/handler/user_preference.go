@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"errors"
+	"go-todo/internal/service"
+	"go-todo/pkg/response"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type UserPreferenceHandler struct {
+	userPreferenceService service.UserPreferenceService
+}
+
+// NewUserPreferenceHandler membuat instance baru dari UserPreferenceHandler
+func NewUserPreferenceHandler(userPreferenceService service.UserPreferenceService) *UserPreferenceHandler {
+	return &UserPreferenceHandler{userPreferenceService: userPreferenceService}
+}
+
+// GetPreferences menangani permintaan preferensi pengguna yang sedang login
+func (h *UserPreferenceHandler) GetPreferences(c echo.Context) error {
+	preference, err := h.userPreferenceService.Get(c.Request().Context(), currentUser(c).UserID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, response.ErrorResponse(http.StatusInternalServerError, "Gagal mengambil preferensi"))
+	}
+	return c.JSON(http.StatusOK, response.SuccessResponse("Berhasil mengambil preferensi", preference))
+}
+
+// UpdatePreferences menangani permintaan untuk memperbarui preferensi pengguna.
+// Field yang tidak dikirim tetap menggunakan nilai sebelumnya.
+func (h *UserPreferenceHandler) UpdatePreferences(c echo.Context) error {
+	var req struct {
+		Timezone   string `json:"timezone"`
+		Locale     string `json:"locale"`
+		WeekStart  *int   `json:"week_start"`
+		DateFormat string `json:"date_format"`
+	}
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, "Permintaan tidak valid"))
+	}
+
+	ctx := c.Request().Context()
+	preference, err := h.userPreferenceService.Get(ctx, currentUser(c).UserID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, response.ErrorResponse(http.StatusInternalServerError, "Gagal mengambil preferensi"))
+	}
+
+	if req.Timezone != "" {
+		preference.Timezone = req.Timezone
+	}
+	if req.Locale != "" {
+		preference.Locale = req.Locale
+	}
+	if req.WeekStart != nil {
+		preference.WeekStart = *req.WeekStart
+	}
+	if req.DateFormat != "" {
+		preference.DateFormat = req.DateFormat
+	}
+
+	updated, err := h.userPreferenceService.Update(ctx, preference)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, service.ErrZonaWaktuTidakValid) ||
+			errors.Is(err, service.ErrLocaleTidakValid) ||
+			errors.Is(err, service.ErrAwalMingguTidakValid) ||
+			errors.Is(err, service.ErrFormatTanggalTidakValid) {
+			status = http.StatusBadRequest
+		}
+		return c.JSON(status, response.ErrorResponse(status, err.Error()))
+	}
+	return c.JSON(http.StatusOK, response.SuccessResponse("Preferensi berhasil diperbarui", updated))
+}
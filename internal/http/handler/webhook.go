@@ -0,0 +1,166 @@
+package handler
+
+import (
+	"errors"
+	"go-todo/internal/entity"
+	"go-todo/internal/service"
+	"go-todo/pkg/response"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type WebhookHandler struct {
+	webhookService service.WebhookService
+}
+
+// NewWebhookHandler membuat instance baru dari WebhookHandler
+func NewWebhookHandler(webhookService service.WebhookService) *WebhookHandler {
+	return &WebhookHandler{webhookService: webhookService}
+}
+
+// webhookRequest adalah body untuk membuat dan memperbarui webhook
+type webhookRequest struct {
+	URL        string   `json:"url"`
+	EventTypes []string `json:"event_types"`
+	Secret     string   `json:"secret"`
+	Active     *bool    `json:"active"`
+}
+
+// GetWebhooks menangani permintaan daftar webhook
+func (h *WebhookHandler) GetWebhooks(c echo.Context) error {
+	webhooks, err := h.webhookService.FindAll(c.Request().Context())
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, response.ErrorResponse(http.StatusInternalServerError, "Gagal mengambil webhook"))
+	}
+	return c.JSON(http.StatusOK, response.SuccessResponse("Berhasil mengambil webhook", webhooks))
+}
+
+// GetWebhook menangani permintaan detail webhook
+func (h *WebhookHandler) GetWebhook(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, "ID webhook tidak valid"))
+	}
+
+	hook, err := h.webhookService.FindByID(c.Request().Context(), id)
+	if err != nil {
+		status := webhookErrorStatus(err)
+		return c.JSON(status, response.ErrorResponse(status, err.Error()))
+	}
+	return c.JSON(http.StatusOK, response.SuccessResponse("Berhasil mengambil webhook", hook))
+}
+
+// CreateWebhook menangani permintaan pendaftaran webhook baru.
+// Secret hanya ditampilkan sekali pada respons ini untuk verifikasi tanda tangan oleh penerima.
+func (h *WebhookHandler) CreateWebhook(c echo.Context) error {
+	var req webhookRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, "Permintaan tidak valid"))
+	}
+
+	hook := &entity.Webhook{URL: req.URL, EventTypes: req.EventTypes, Secret: req.Secret, Active: true}
+	if req.Active != nil {
+		hook.Active = *req.Active
+	}
+
+	created, err := h.webhookService.Create(c.Request().Context(), hook)
+	if err != nil {
+		status := webhookErrorStatus(err)
+		return c.JSON(status, response.ErrorResponse(status, err.Error()))
+	}
+	return c.JSON(http.StatusCreated, response.SuccessResponse("Webhook berhasil dibuat", map[string]interface{}{
+		"webhook": created,
+		"secret":  created.Secret,
+	}))
+}
+
+// UpdateWebhook menangani permintaan untuk memperbarui webhook
+func (h *WebhookHandler) UpdateWebhook(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, "ID webhook tidak valid"))
+	}
+
+	var req webhookRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, "Permintaan tidak valid"))
+	}
+
+	ctx := c.Request().Context()
+	existing, err := h.webhookService.FindByID(ctx, id)
+	if err != nil {
+		status := webhookErrorStatus(err)
+		return c.JSON(status, response.ErrorResponse(status, err.Error()))
+	}
+
+	hook := &entity.Webhook{ID: id, URL: req.URL, EventTypes: req.EventTypes, Secret: req.Secret, Active: existing.Active}
+	if req.Active != nil {
+		hook.Active = *req.Active
+	}
+
+	updated, err := h.webhookService.Update(ctx, hook)
+	if err != nil {
+		status := webhookErrorStatus(err)
+		return c.JSON(status, response.ErrorResponse(status, err.Error()))
+	}
+	return c.JSON(http.StatusOK, response.SuccessResponse("Webhook berhasil diperbarui", updated))
+}
+
+// DeleteWebhook menangani permintaan untuk menghapus webhook
+func (h *WebhookHandler) DeleteWebhook(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, "ID webhook tidak valid"))
+	}
+
+	if err := h.webhookService.Delete(c.Request().Context(), id); err != nil {
+		status := webhookErrorStatus(err)
+		return c.JSON(status, response.ErrorResponse(status, err.Error()))
+	}
+	return c.JSON(http.StatusOK, response.SuccessResponse("Webhook berhasil dihapus", nil))
+}
+
+// GetDeliveries menangani permintaan log pengiriman sebuah webhook
+func (h *WebhookHandler) GetDeliveries(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, "ID webhook tidak valid"))
+	}
+
+	deliveries, err := h.webhookService.Deliveries(c.Request().Context(), id)
+	if err != nil {
+		status := webhookErrorStatus(err)
+		return c.JSON(status, response.ErrorResponse(status, err.Error()))
+	}
+	return c.JSON(http.StatusOK, response.SuccessResponse("Berhasil mengambil log pengiriman", deliveries))
+}
+
+// ReplayDelivery menangani permintaan untuk mengirim ulang sebuah pengiriman webhook
+func (h *WebhookHandler) ReplayDelivery(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, "ID pengiriman tidak valid"))
+	}
+
+	delivery, err := h.webhookService.Replay(c.Request().Context(), id)
+	if err != nil {
+		status := webhookErrorStatus(err)
+		return c.JSON(status, response.ErrorResponse(status, err.Error()))
+	}
+	return c.JSON(http.StatusAccepted, response.SuccessResponse("Pengiriman dijadwalkan ulang", delivery))
+}
+
+// webhookErrorStatus memetakan error service webhook ke status HTTP
+func webhookErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrWebhookTidakDitemukan), errors.Is(err, service.ErrPengirimanTidakDitemukan):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrURLWebhookTidakValid),
+		errors.Is(err, service.ErrJenisEventTidakValid),
+		errors.Is(err, service.ErrJenisEventKosong):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
@@ -1,73 +1,573 @@
 package router
 
 import (
+	"go-todo/internal/entity"
 	"go-todo/internal/http/handler"
 	"go-todo/pkg/route"
 	"net/http"
 )
 
-// PublicRoutes mengatur route publik untuk login dan pembuatan pengguna
-func PublicRoutes(userHandler *handler.UserHandler) []route.Route {
+// PublicRoutes mengatur route publik untuk login beserta langkah kedua autentikasi dua faktor,
+// pembuatan pengguna, reset password, dan verifikasi email
+func PublicRoutes(
+	userHandler *handler.UserHandler,
+	authHandler *handler.AuthHandler,
+	passwordResetHandler *handler.PasswordResetHandler,
+	emailVerificationHandler *handler.EmailVerificationHandler,
+) []route.Route {
 	return []route.Route{
 		{
 			Method:  http.MethodPost,
 			Path:    "/login",
 			Handler: userHandler.LoginUser, // Route login untuk pengguna
 		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/login/mfa",
+			Handler: userHandler.LoginMFA, // Route langkah kedua login dengan kode TOTP atau recovery code
+		},
 		{
 			Method:  http.MethodPost,
 			Path:    "/register",
 			Handler: userHandler.CreateUser, // Route untuk mendaftarkan pengguna baru
 		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/token/refresh",
+			Handler: authHandler.RefreshToken, // Route untuk menukar refresh token dengan token baru
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/password/forgot",
+			Handler: passwordResetHandler.ForgotPassword, // Route untuk meminta link reset password
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/password/reset",
+			Handler: passwordResetHandler.ResetPassword, // Route untuk mengganti password dengan token reset
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/verify-email",
+			Handler: emailVerificationHandler.VerifyEmail, // Route untuk memverifikasi email dengan token
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/verify-email/request",
+			Handler: emailVerificationHandler.RequestVerification, // Route untuk meminta ulang email verifikasi tanpa login
+		},
 	}
 }
 
 // PrivateRoutes mengatur route privat untuk operasi pengguna dan todo
-func PrivateRoutes(userHandler *handler.UserHandler, todoHandler *handler.TodoHandler) []route.Route {
+func PrivateRoutes(
+	userHandler *handler.UserHandler,
+	todoHandler *handler.TodoHandler,
+	timeEntryHandler *handler.TimeEntryHandler,
+	statsHandler *handler.StatsHandler,
+	analyticsHandler *handler.AnalyticsHandler,
+	userPreferenceHandler *handler.UserPreferenceHandler,
+	webhookHandler *handler.WebhookHandler,
+	realtimeHandler *handler.RealtimeHandler,
+	syncHandler *handler.SyncHandler,
+	filterHandler *handler.FilterHandler,
+	templateHandler *handler.TemplateHandler,
+	assignmentHandler *handler.AssignmentHandler,
+	notificationHandler *handler.NotificationHandler,
+	customFieldHandler *handler.CustomFieldHandler,
+	revisionHandler *handler.RevisionHandler,
+	undoHandler *handler.UndoHandler,
+	authHandler *handler.AuthHandler,
+	roleHandler *handler.RoleHandler,
+	emailVerificationHandler *handler.EmailVerificationHandler,
+	mfaHandler *handler.MFAHandler,
+) []route.Route {
 	return []route.Route{
-		// User Routes
+		// Auth Routes
+		{
+			Method:  http.MethodPost,
+			Path:    "/logout",
+			Handler: authHandler.Logout, // Route untuk mencabut token sesi saat ini
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/logout/all",
+			Handler: authHandler.LogoutAll, // Route untuk mencabut seluruh sesi pengguna di semua perangkat
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/verify-email/resend",
+			Handler: emailVerificationHandler.ResendVerification, // Route untuk mengirim ulang email verifikasi
+		},
+		// MFA Routes tanpa izin agar admin dapat mendaftarkan TOTP sebelum izinnya berlaku
 		{
 			Method:  http.MethodGet,
-			Path:    "/users",
-			Handler: userHandler.GetAllUsers, // Route untuk mengambil semua pengguna
-			Roles:   []string{"admin"},       // Hanya dapat diakses oleh admin
+			Path:    "/mfa",
+			Handler: mfaHandler.GetStatus, // Route untuk melihat status autentikasi dua faktor
 		},
 		{
-			Method:  http.MethodPut,
-			Path:    "/users/:id",
-			Handler: userHandler.UpdateUser, // Route untuk memperbarui data pengguna berdasarkan ID
-			Roles:   []string{"admin"},      // Hanya dapat diakses oleh admin
+			Method:  http.MethodPost,
+			Path:    "/mfa/totp/enroll",
+			Handler: mfaHandler.EnrollTOTP, // Route untuk membuat secret TOTP dan URI kode QR
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/mfa/totp/confirm",
+			Handler: mfaHandler.ConfirmTOTP, // Route untuk mengaktifkan TOTP dengan kode pertama
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/mfa/totp/disable",
+			Handler: mfaHandler.DisableTOTP, // Route untuk menonaktifkan TOTP
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/mfa/recovery-codes",
+			Handler: mfaHandler.RegenerateRecoveryCodes, // Route untuk membuat ulang recovery code
+		},
+		// User Routes
+		{
+			Method:     http.MethodGet,
+			Path:       "/users",
+			Handler:    userHandler.GetAllUsers, // Route untuk mengambil semua pengguna
+			Permission: entity.PermissionUserRead,
+		},
+		{
+			Method:     http.MethodPut,
+			Path:       "/users/:id",
+			Handler:    userHandler.UpdateUser, // Route untuk memperbarui data pengguna berdasarkan ID
+			Permission: entity.PermissionUserWrite,
 		},
 		{
-			Method:  http.MethodDelete,
-			Path:    "/users/:id",
-			Handler: userHandler.DeleteUser, // Route untuk menghapus pengguna berdasarkan ID
-			Roles:   []string{"admin"},      // Hanya dapat diakses oleh admin
+			Method:     http.MethodDelete,
+			Path:       "/users/:id",
+			Handler:    userHandler.DeleteUser, // Route untuk menghapus pengguna berdasarkan ID
+			Permission: entity.PermissionUserWrite,
+		},
+		{
+			Method:     http.MethodPost,
+			Path:       "/users/:id/roles",
+			Handler:    userHandler.GrantRole, // Route untuk memberikan role kepada pengguna
+			Permission: entity.PermissionRoleManage,
+		},
+		{
+			Method:     http.MethodDelete,
+			Path:       "/users/:id/roles/:role",
+			Handler:    userHandler.RevokeRole, // Route untuk mencabut role dari pengguna
+			Permission: entity.PermissionRoleManage,
+		},
+		{
+			Method:     http.MethodPost,
+			Path:       "/users/:id/unlock",
+			Handler:    userHandler.UnlockUser, // Route untuk membuka kunci login pengguna setelah lockout
+			Permission: entity.PermissionUserWrite,
+		},
+		{
+			Method:     http.MethodDelete,
+			Path:       "/users/:id/mfa",
+			Handler:    mfaHandler.ResetUserMFA, // Route untuk mereset TOTP pengguna yang kehilangan perangkatnya
+			Permission: entity.PermissionUserWrite,
+		},
+		// Role Routes
+		{
+			Method:     http.MethodGet,
+			Path:       "/roles",
+			Handler:    roleHandler.GetRoles, // Route untuk mengambil seluruh role beserta izinnya
+			Permission: entity.PermissionRoleManage,
+		},
+		{
+			Method:     http.MethodPost,
+			Path:       "/roles",
+			Handler:    roleHandler.CreateRole, // Route untuk membuat role baru
+			Permission: entity.PermissionRoleManage,
+		},
+		{
+			Method:     http.MethodPut,
+			Path:       "/roles/:name",
+			Handler:    roleHandler.UpdateRole, // Route untuk mengganti deskripsi dan izin role
+			Permission: entity.PermissionRoleManage,
+		},
+		{
+			Method:     http.MethodDelete,
+			Path:       "/roles/:name",
+			Handler:    roleHandler.DeleteRole, // Route untuk menghapus role buatan admin
+			Permission: entity.PermissionRoleManage,
+		},
+		{
+			Method:     http.MethodGet,
+			Path:       "/permissions",
+			Handler:    roleHandler.GetPermissions, // Route untuk mengambil daftar izin yang tersedia
+			Permission: entity.PermissionRoleManage,
 		},
 		// Todo Routes
+		{
+			Method:     http.MethodGet,
+			Path:       "/todos",
+			Handler:    todoHandler.GetAllTodos, // Route untuk mengambil semua todo
+			Permission: entity.PermissionTodoRead,
+		},
+		{
+			Method:     http.MethodPost,
+			Path:       "/todos",
+			Handler:    todoHandler.CreateTodo, // Route untuk membuat todo baru
+			Permission: entity.PermissionTodoWrite,
+		},
+		{
+			Method:     http.MethodPost,
+			Path:       "/todos/quick",
+			Handler:    todoHandler.QuickAddTodo, // Route untuk membuat todo dari teks bebas
+			Permission: entity.PermissionTodoWrite,
+		},
+		{
+			Method:     http.MethodPut,
+			Path:       "/todos/:id",
+			Handler:    todoHandler.UpdateTodo, // Route untuk memperbarui todo berdasarkan ID
+			Permission: entity.PermissionTodoWrite,
+		},
+		{
+			Method:     http.MethodDelete,
+			Path:       "/todos/:id",
+			Handler:    todoHandler.DeleteTodo, // Route untuk menghapus todo berdasarkan ID
+			Permission: entity.PermissionTodoDeleteAny,
+		},
+		{
+			Method:     http.MethodGet,
+			Path:       "/todos/search",
+			Handler:    customFieldHandler.SearchTodos, // Route untuk mencari dan mengekspor todo berdasarkan field kustom
+			Permission: entity.PermissionTodoRead,
+		},
+		// Undo Routes
+		{
+			Method:     http.MethodPost,
+			Path:       "/undo/:token",
+			Handler:    undoHandler.Undo, // Route untuk membatalkan perubahan atau penghapusan todo
+			Permission: entity.PermissionTodoWrite,
+		},
+		// Revision Routes
+		{
+			Method:     http.MethodGet,
+			Path:       "/todos/:id/revisions",
+			Handler:    revisionHandler.GetRevisions, // Route untuk mengambil riwayat revisi todo
+			Permission: entity.PermissionTodoRead,
+		},
+		{
+			Method:     http.MethodGet,
+			Path:       "/todos/:id/revisions/diff",
+			Handler:    revisionHandler.GetRevisionDiff, // Route untuk membandingkan dua revisi todo
+			Permission: entity.PermissionTodoRead,
+		},
+		{
+			Method:     http.MethodPost,
+			Path:       "/todos/:id/revisions/:rev/revert",
+			Handler:    revisionHandler.RevertRevision, // Route untuk memulihkan todo ke revisi lama sebagai revisi baru
+			Permission: entity.PermissionTodoWrite,
+		},
+		// Custom Field Routes
+		{
+			Method:     http.MethodGet,
+			Path:       "/custom-fields",
+			Handler:    customFieldHandler.GetCustomFields, // Route untuk mengambil definisi field kustom
+			Permission: entity.PermissionTodoRead,
+		},
+		{
+			Method:     http.MethodPost,
+			Path:       "/custom-fields",
+			Handler:    customFieldHandler.CreateCustomField, // Route untuk membuat field kustom baru
+			Permission: entity.PermissionTodoWrite,
+		},
+		{
+			Method:     http.MethodPut,
+			Path:       "/custom-fields/:id",
+			Handler:    customFieldHandler.UpdateCustomField, // Route untuk memperbarui nama dan aturan validasi field kustom
+			Permission: entity.PermissionTodoWrite,
+		},
+		{
+			Method:     http.MethodDelete,
+			Path:       "/custom-fields/:id",
+			Handler:    customFieldHandler.DeleteCustomField, // Route untuk menghapus field kustom beserta nilainya
+			Permission: entity.PermissionTodoWrite,
+		},
+		// Assignment Routes
+		{
+			Method:     http.MethodGet,
+			Path:       "/todos/assigned",
+			Handler:    assignmentHandler.GetAssignedTodos, // Route untuk mengambil todo yang ditugaskan kepada saya
+			Permission: entity.PermissionTodoRead,
+		},
+		{
+			Method:     http.MethodPut,
+			Path:       "/todos/:id/assignee",
+			Handler:    assignmentHandler.AssignTodo, // Route untuk menugaskan todo kepada pengguna lain
+			Permission: entity.PermissionTodoWrite,
+		},
+		{
+			Method:     http.MethodDelete,
+			Path:       "/todos/:id/assignee",
+			Handler:    assignmentHandler.UnassignTodo, // Route untuk menghapus penanggung jawab todo
+			Permission: entity.PermissionTodoWrite,
+		},
+		{
+			Method:     http.MethodPatch,
+			Path:       "/todos/:id/status",
+			Handler:    assignmentHandler.UpdateTodoStatus, // Route untuk mengubah status selesai oleh pemilik atau penanggung jawab
+			Permission: entity.PermissionTodoWrite,
+		},
+		// Time Tracking Routes
+		{
+			Method:     http.MethodPost,
+			Path:       "/todos/:id/timer/start",
+			Handler:    timeEntryHandler.StartTimer, // Route untuk memulai timer pada todo
+			Permission: entity.PermissionTodoWrite,
+		},
+		{
+			Method:     http.MethodPost,
+			Path:       "/todos/:id/timer/stop",
+			Handler:    timeEntryHandler.StopTimer, // Route untuk menghentikan timer pada todo
+			Permission: entity.PermissionTodoWrite,
+		},
+		{
+			Method:     http.MethodGet,
+			Path:       "/todos/:id/time-entries",
+			Handler:    timeEntryHandler.GetTimeEntries, // Route untuk mengambil catatan waktu sebuah todo
+			Permission: entity.PermissionTodoRead,
+		},
+		{
+			Method:     http.MethodPost,
+			Path:       "/todos/:id/time-entries",
+			Handler:    timeEntryHandler.CreateTimeEntry, // Route untuk menambahkan catatan waktu manual
+			Permission: entity.PermissionTodoWrite,
+		},
+		{
+			Method:     http.MethodPut,
+			Path:       "/time-entries/:id",
+			Handler:    timeEntryHandler.UpdateTimeEntry, // Route untuk memperbarui catatan waktu
+			Permission: entity.PermissionTodoWrite,
+		},
+		{
+			Method:     http.MethodDelete,
+			Path:       "/time-entries/:id",
+			Handler:    timeEntryHandler.DeleteTimeEntry, // Route untuk menghapus catatan waktu
+			Permission: entity.PermissionTodoWrite,
+		},
+		{
+			Method:     http.MethodGet,
+			Path:       "/reports/time",
+			Handler:    timeEntryHandler.GetTimeReport, // Route untuk laporan waktu (JSON atau CSV)
+			Permission: entity.PermissionTodoRead,
+		},
+		// Notification Routes
 		{
 			Method:  http.MethodGet,
-			Path:    "/todos",
-			Handler: todoHandler.GetAllTodos, // Route untuk mengambil semua todo
-			Roles:   []string{"admin", "user"},
+			Path:    "/notifications",
+			Handler: notificationHandler.GetNotifications, // Route untuk mengambil notifikasi pengguna
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/notifications/read-all",
+			Handler: notificationHandler.MarkAllNotificationsRead, // Route untuk menandai semua notifikasi sudah dibaca
 		},
 		{
 			Method:  http.MethodPost,
-			Path:    "/todos",
-			Handler: todoHandler.CreateTodo, // Route untuk membuat todo baru
-			Roles:   []string{"admin", "user"},
+			Path:    "/notifications/:id/read",
+			Handler: notificationHandler.MarkNotificationRead, // Route untuk menandai notifikasi sudah dibaca
+		},
+		// Realtime Routes
+		{
+			Method:     http.MethodGet,
+			Path:       "/events",
+			Handler:    realtimeHandler.StreamEvents, // Route Server-Sent Events untuk perubahan todo
+			QueryToken: true,
+		},
+		{
+			Method:     http.MethodGet,
+			Path:       "/ws",
+			Handler:    realtimeHandler.StreamWebSocket, // Route WebSocket untuk perubahan todo
+			QueryToken: true,
+		},
+		// Filter Routes
+		{
+			Method:     http.MethodGet,
+			Path:       "/filters",
+			Handler:    filterHandler.GetFilters, // Route untuk mengambil filter bawaan dan filter tersimpan
+			Permission: entity.PermissionTodoRead,
+		},
+		{
+			Method:     http.MethodPost,
+			Path:       "/filters",
+			Handler:    filterHandler.CreateFilter, // Route untuk menyimpan filter baru
+			Permission: entity.PermissionTodoWrite,
+		},
+		{
+			Method:     http.MethodGet,
+			Path:       "/filters/:id",
+			Handler:    filterHandler.GetFilter, // Route untuk mengambil filter berdasarkan ID atau key bawaan
+			Permission: entity.PermissionTodoRead,
+		},
+		{
+			Method:     http.MethodPut,
+			Path:       "/filters/:id",
+			Handler:    filterHandler.UpdateFilter, // Route untuk memperbarui filter tersimpan
+			Permission: entity.PermissionTodoWrite,
+		},
+		{
+			Method:     http.MethodDelete,
+			Path:       "/filters/:id",
+			Handler:    filterHandler.DeleteFilter, // Route untuk menghapus filter tersimpan
+			Permission: entity.PermissionTodoWrite,
+		},
+		{
+			Method:     http.MethodGet,
+			Path:       "/filters/:id/todos",
+			Handler:    filterHandler.GetFilterTodos, // Route untuk mengambil todo yang cocok dengan filter
+			Permission: entity.PermissionTodoRead,
+		},
+		// Template Routes
+		{
+			Method:     http.MethodGet,
+			Path:       "/templates",
+			Handler:    templateHandler.GetTemplates, // Route untuk mengambil template milik pengguna
+			Permission: entity.PermissionTodoRead,
+		},
+		{
+			Method:     http.MethodPost,
+			Path:       "/templates",
+			Handler:    templateHandler.CreateTemplate, // Route untuk membuat template dari daftar item
+			Permission: entity.PermissionTodoWrite,
+		},
+		{
+			Method:     http.MethodPost,
+			Path:       "/templates/from-todo",
+			Handler:    templateHandler.CreateTemplateFromTodo, // Route untuk menyimpan todo beserta subtask sebagai template
+			Permission: entity.PermissionTodoWrite,
+		},
+		{
+			Method:     http.MethodPost,
+			Path:       "/templates/from-project",
+			Handler:    templateHandler.CreateTemplateFromProject, // Route untuk menyimpan todo dalam proyek sebagai template
+			Permission: entity.PermissionTodoWrite,
+		},
+		{
+			Method:     http.MethodGet,
+			Path:       "/templates/:id",
+			Handler:    templateHandler.GetTemplate, // Route untuk mengambil template berdasarkan ID
+			Permission: entity.PermissionTodoRead,
+		},
+		{
+			Method:     http.MethodPut,
+			Path:       "/templates/:id",
+			Handler:    templateHandler.UpdateTemplate, // Route untuk memperbarui template
+			Permission: entity.PermissionTodoWrite,
+		},
+		{
+			Method:     http.MethodDelete,
+			Path:       "/templates/:id",
+			Handler:    templateHandler.DeleteTemplate, // Route untuk menghapus template
+			Permission: entity.PermissionTodoWrite,
+		},
+		{
+			Method:     http.MethodPost,
+			Path:       "/templates/:id/instantiate",
+			Handler:    templateHandler.InstantiateTemplate, // Route untuk membuat todo dari template
+			Permission: entity.PermissionTodoWrite,
+		},
+		// Sync Routes
+		{
+			Method:     http.MethodGet,
+			Path:       "/sync",
+			Handler:    syncHandler.PullChanges, // Route untuk mengambil perubahan todo sejak cursor
+			Permission: entity.PermissionTodoRead,
+		},
+		{
+			Method:     http.MethodPost,
+			Path:       "/sync",
+			Handler:    syncHandler.PushMutations, // Route untuk mengirim mutasi dari klien offline
+			Permission: entity.PermissionTodoWrite,
+		},
+		// Preference Routes
+		{
+			Method:  http.MethodGet,
+			Path:    "/preferences",
+			Handler: userPreferenceHandler.GetPreferences, // Route untuk mengambil preferensi pengguna
 		},
 		{
 			Method:  http.MethodPut,
-			Path:    "/todos/:id",
-			Handler: todoHandler.UpdateTodo, // Route untuk memperbarui todo berdasarkan ID
-			Roles:   []string{"admin", "user"},
+			Path:    "/preferences",
+			Handler: userPreferenceHandler.UpdatePreferences, // Route untuk memperbarui zona waktu, locale, dan format tanggal
+		},
+		// Stats Routes
+		{
+			Method:     http.MethodGet,
+			Path:       "/stats",
+			Handler:    statsHandler.GetStats, // Route untuk statistik produktivitas pengguna
+			Permission: entity.PermissionTodoRead,
+		},
+		// Admin Analytics Routes
+		{
+			Method:     http.MethodGet,
+			Path:       "/admin/analytics/active-users",
+			Handler:    analyticsHandler.GetActiveUsers, // Route untuk jumlah pengguna aktif per hari
+			Permission: entity.PermissionAnalyticsRead,
+		},
+		{
+			Method:     http.MethodGet,
+			Path:       "/admin/analytics/todos",
+			Handler:    analyticsHandler.GetTodoActivity, // Route untuk todo dibuat/diselesaikan per hari
+			Permission: entity.PermissionAnalyticsRead,
+		},
+		{
+			Method:     http.MethodGet,
+			Path:       "/admin/analytics/top-users",
+			Handler:    analyticsHandler.GetTopUsers, // Route untuk pengguna dengan todo terbanyak
+			Permission: entity.PermissionAnalyticsRead,
+		},
+		{
+			Method:     http.MethodGet,
+			Path:       "/admin/analytics/logins",
+			Handler:    analyticsHandler.GetLoginActivity, // Route untuk aktivitas login per hari
+			Permission: entity.PermissionAnalyticsRead,
+		},
+		// Webhook Routes
+		{
+			Method:     http.MethodGet,
+			Path:       "/webhooks",
+			Handler:    webhookHandler.GetWebhooks, // Route untuk mengambil semua webhook
+			Permission: entity.PermissionWebhookManage,
+		},
+		{
+			Method:     http.MethodPost,
+			Path:       "/webhooks",
+			Handler:    webhookHandler.CreateWebhook, // Route untuk mendaftarkan webhook baru
+			Permission: entity.PermissionWebhookManage,
+		},
+		{
+			Method:     http.MethodGet,
+			Path:       "/webhooks/:id",
+			Handler:    webhookHandler.GetWebhook, // Route untuk mengambil webhook berdasarkan ID
+			Permission: entity.PermissionWebhookManage,
+		},
+		{
+			Method:     http.MethodPut,
+			Path:       "/webhooks/:id",
+			Handler:    webhookHandler.UpdateWebhook, // Route untuk memperbarui webhook
+			Permission: entity.PermissionWebhookManage,
+		},
+		{
+			Method:     http.MethodDelete,
+			Path:       "/webhooks/:id",
+			Handler:    webhookHandler.DeleteWebhook, // Route untuk menghapus webhook
+			Permission: entity.PermissionWebhookManage,
+		},
+		{
+			Method:     http.MethodGet,
+			Path:       "/webhooks/:id/deliveries",
+			Handler:    webhookHandler.GetDeliveries, // Route untuk log pengiriman webhook
+			Permission: entity.PermissionWebhookManage,
 		},
 		{
-			Method:  http.MethodDelete,
-			Path:    "/todos/:id",
-			Handler: todoHandler.DeleteTodo, // Route untuk menghapus todo berdasarkan ID
-			Roles:   []string{"admin"},      // Hanya dapat diakses oleh admin
+			Method:     http.MethodPost,
+			Path:       "/webhook-deliveries/:id/replay",
+			Handler:    webhookHandler.ReplayDelivery, // Route untuk mengirim ulang pengiriman webhook
+			Permission: entity.PermissionWebhookManage,
 		},
 	}
 }
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"go-todo/internal/entity"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// AnalyticsRepository mendefinisikan query agregat lintas pengguna untuk admin.
+type AnalyticsRepository interface {
+	ActiveUsers(ctx context.Context, from, to time.Time, timezone string) ([]entity.DailyCount, error)
+	TodoActivity(ctx context.Context, from, to time.Time, timezone string) ([]entity.DailyTodoActivity, error)
+	TopUsers(ctx context.Context, from, to time.Time, limit int) ([]entity.TopUser, error)
+	LoginActivity(ctx context.Context, from, to time.Time, timezone string) ([]entity.DailyLoginActivity, error)
+}
+
+type analyticsRepository struct {
+	db *gorm.DB
+}
+
+// dayInZone membulatkan kolom timestamptz ke awal hari pada zona waktu @tz.
+func dayInZone(column string) string {
+	return fmt.Sprintf("(date_trunc('day', %s AT TIME ZONE @tz) AT TIME ZONE @tz)", column)
+}
+
+// NewAnalyticsRepository inisialisasi AnalyticsRepository baru.
+func NewAnalyticsRepository(db *gorm.DB) AnalyticsRepository {
+	return &analyticsRepository{db}
+}
+
+// ActiveUsers menghitung jumlah pengguna unik yang berhasil login per hari pada zona waktu timezone.
+func (r *analyticsRepository) ActiveUsers(ctx context.Context, from, to time.Time, timezone string) ([]entity.DailyCount, error) {
+	counts := make([]entity.DailyCount, 0)
+	err := dbFromContext(ctx, r.db).Raw(`
+		SELECT `+dayInZone("created_at")+` AS day, COUNT(DISTINCT user_id) AS count
+		FROM login_events
+		WHERE success AND created_at >= @from AND created_at < @to
+		GROUP BY day
+		ORDER BY day`, rangeArgs(from, to, timezone)).
+		Scan(&counts).Error
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return counts, nil
+}
+
+// TodoActivity menghitung todo yang dibuat dan diselesaikan per hari di seluruh sistem.
+func (r *analyticsRepository) TodoActivity(ctx context.Context, from, to time.Time, timezone string) ([]entity.DailyTodoActivity, error) {
+	activity := make([]entity.DailyTodoActivity, 0)
+	err := dbFromContext(ctx, r.db).Raw(`
+		SELECT day, SUM(created) AS created, SUM(completed) AS completed FROM (
+			SELECT `+dayInZone("created_at")+` AS day, 1 AS created, 0 AS completed
+			FROM todos WHERE created_at >= @from AND created_at < @to
+			UNION ALL
+			SELECT `+dayInZone("completed_at")+` AS day, 0 AS created, 1 AS completed
+			FROM todos WHERE completed_at >= @from AND completed_at < @to
+		) AS activity
+		GROUP BY day
+		ORDER BY day`, rangeArgs(from, to, timezone)).
+		Scan(&activity).Error
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return activity, nil
+}
+
+// TopUsers mengambil pengguna dengan jumlah todo yang dibuat terbanyak.
+func (r *analyticsRepository) TopUsers(ctx context.Context, from, to time.Time, limit int) ([]entity.TopUser, error) {
+	users := make([]entity.TopUser, 0)
+	err := dbFromContext(ctx, r.db).Raw(`
+		SELECT u.id AS user_id, u.username,
+			COUNT(t.id) AS created,
+			COUNT(t.id) FILTER (WHERE t.completed) AS completed
+		FROM users u
+		JOIN todos t ON t.user_id = u.id
+		WHERE t.created_at >= ? AND t.created_at < ?
+		GROUP BY u.id, u.username
+		ORDER BY created DESC, u.username
+		LIMIT ?`, from, to, limit).
+		Scan(&users).Error
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return users, nil
+}
+
+// LoginActivity menghitung login berhasil dan gagal per hari pada zona waktu timezone.
+func (r *analyticsRepository) LoginActivity(ctx context.Context, from, to time.Time, timezone string) ([]entity.DailyLoginActivity, error) {
+	activity := make([]entity.DailyLoginActivity, 0)
+	err := dbFromContext(ctx, r.db).Raw(`
+		SELECT `+dayInZone("created_at")+` AS day,
+			COUNT(*) FILTER (WHERE success) AS successes,
+			COUNT(*) FILTER (WHERE NOT success) AS failures
+		FROM login_events
+		WHERE created_at >= @from AND created_at < @to
+		GROUP BY day
+		ORDER BY day`, rangeArgs(from, to, timezone)).
+		Scan(&activity).Error
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return activity, nil
+}
+
+func rangeArgs(from, to time.Time, timezone string) map[string]interface{} {
+	return map[string]interface{}{"from": from, "to": to, "tz": timezone}
+}
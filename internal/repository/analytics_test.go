@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestAnalyticsRepository_LoginActivity menguji agregasi login berhasil dan gagal per hari
+func TestAnalyticsRepository_LoginActivity(t *testing.T) {
+	db, mock := setupMockDB(t)
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	repo := NewAnalyticsRepository(db)
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 10, 3, 0, 0, 0, 0, time.UTC)
+
+	rows := sqlmock.NewRows([]string{"day", "successes", "failures"}).
+		AddRow(from, 10, 2).
+		AddRow(from.AddDate(0, 0, 1), 7, 0)
+	mock.ExpectQuery("AT TIME ZONE \\?\\) AT TIME ZONE \\?\\) AS day.*FROM login_events\\s+WHERE created_at >= \\? AND created_at < \\?").
+		WithArgs("Asia/Tokyo", "Asia/Tokyo", from, to).
+		WillReturnRows(rows)
+
+	activity, err := repo.LoginActivity(context.Background(), from, to, "Asia/Tokyo")
+	assert.NoError(t, err)
+	assert.Len(t, activity, 2)
+	assert.Equal(t, int64(2), activity[0].Failures)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestAnalyticsRepository_TopUsers menguji pengambilan pengguna dengan todo terbanyak
+func TestAnalyticsRepository_TopUsers(t *testing.T) {
+	db, mock := setupMockDB(t)
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	repo := NewAnalyticsRepository(db)
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+
+	rows := sqlmock.NewRows([]string{"user_id", "username", "created", "completed"}).
+		AddRow(1, "budi", 20, 15)
+	mock.ExpectQuery("ORDER BY created DESC, u.username\\s+LIMIT \\?").
+		WithArgs(from, to, 5).
+		WillReturnRows(rows)
+
+	users, err := repo.TopUsers(context.Background(), from, to, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, "budi", users[0].Username)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-todo/internal/entity"
+
+	"gorm.io/gorm"
+)
+
+// CustomFieldRepository mendefinisikan operasi database untuk definisi field kustom milik pengguna.
+type CustomFieldRepository interface {
+	FindByUserID(ctx context.Context, userID int64) ([]entity.CustomFieldDefinition, error)
+	FindByID(ctx context.Context, userID, id int64) (*entity.CustomFieldDefinition, error)
+	Create(ctx context.Context, definition *entity.CustomFieldDefinition) (*entity.CustomFieldDefinition, error)
+	Update(ctx context.Context, definition *entity.CustomFieldDefinition) (*entity.CustomFieldDefinition, error)
+	Delete(ctx context.Context, userID, id int64) error
+}
+
+var ErrFieldKustomTidakDitemukan = errors.New("field kustom tidak ditemukan")
+
+type customFieldRepository struct {
+	db *gorm.DB
+}
+
+// NewCustomFieldRepository inisialisasi CustomFieldRepository baru.
+func NewCustomFieldRepository(db *gorm.DB) CustomFieldRepository {
+	return &customFieldRepository{db}
+}
+
+// FindByUserID mengambil semua definisi field kustom milik pengguna.
+func (r *customFieldRepository) FindByUserID(ctx context.Context, userID int64) ([]entity.CustomFieldDefinition, error) {
+	definitions := make([]entity.CustomFieldDefinition, 0)
+	if err := dbFromContext(ctx, r.db).Where("user_id = ?", userID).Order("id").Find(&definitions).Error; err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return definitions, nil
+}
+
+// FindByID mencari definisi field kustom milik pengguna berdasarkan ID.
+func (r *customFieldRepository) FindByID(ctx context.Context, userID, id int64) (*entity.CustomFieldDefinition, error) {
+	definition := new(entity.CustomFieldDefinition)
+	if err := dbFromContext(ctx, r.db).Where("id = ? AND user_id = ?", id, userID).First(definition).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrFieldKustomTidakDitemukan
+		}
+		return nil, fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return definition, nil
+}
+
+// Create menambahkan definisi field kustom baru.
+func (r *customFieldRepository) Create(ctx context.Context, definition *entity.CustomFieldDefinition) (*entity.CustomFieldDefinition, error) {
+	if err := dbFromContext(ctx, r.db).Create(definition).Error; err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return definition, nil
+}
+
+// Update memperbarui nama dan aturan validasi field kustom. Key dan tipe tidak dapat diubah.
+func (r *customFieldRepository) Update(ctx context.Context, definition *entity.CustomFieldDefinition) (*entity.CustomFieldDefinition, error) {
+	result := dbFromContext(ctx, r.db).Model(definition).
+		Where("user_id = ?", definition.UserID).
+		Select("Name", "Required", "Options", "Min", "Max", "MaxLength", "UpdatedAt").
+		Updates(definition)
+	if result.Error != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDatabaseError, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrFieldKustomTidakDitemukan
+	}
+	return definition, nil
+}
+
+// Delete menghapus definisi field kustom beserta nilainya pada seluruh todo milik pengguna.
+// Sebaiknya dipanggil di dalam transaksi agar kedua perubahan konsisten.
+func (r *customFieldRepository) Delete(ctx context.Context, userID, id int64) error {
+	definition, err := r.FindByID(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+	db := dbFromContext(ctx, r.db)
+	if err := db.Delete(&entity.CustomFieldDefinition{}, definition.ID).Error; err != nil {
+		return fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	if err := db.Exec("UPDATE todos SET custom_fields = custom_fields - ? WHERE user_id = ?", definition.Key, userID).Error; err != nil {
+		return fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"go-todo/internal/entity"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCustomFieldRepository_FindByUserID menguji pembacaan pilihan select dari kolom JSONB
+func TestCustomFieldRepository_FindByUserID(t *testing.T) {
+	db, mock := setupMockDB(t)
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	repo := NewCustomFieldRepository(db)
+
+	rows := sqlmock.NewRows([]string{"id", "user_id", "key", "name", "type", "options"}).
+		AddRow(1, 5, "status", "Status", "select", []byte(`["draft","review"]`))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `custom_field_definitions` WHERE user_id = ? ORDER BY id")).
+		WithArgs(5).
+		WillReturnRows(rows)
+
+	definitions, err := repo.FindByUserID(context.Background(), 5)
+	assert.NoError(t, err)
+	assert.Len(t, definitions, 1)
+	assert.Equal(t, entity.StringList{"draft", "review"}, definitions[0].Options)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestCustomFieldRepository_Delete menguji penghapusan definisi beserta nilainya pada todo pengguna
+func TestCustomFieldRepository_Delete(t *testing.T) {
+	db, mock := setupMockDB(t)
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	repo := NewCustomFieldRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `custom_field_definitions` WHERE id = ? AND user_id = ?")).
+		WithArgs(1, 5, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "key"}).AddRow(1, 5, "status"))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `custom_field_definitions` WHERE `custom_field_definitions`.`id` = ?")).
+		WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE todos SET custom_fields = custom_fields - ? WHERE user_id = ?")).
+		WithArgs("status", 5).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	err := repo.Delete(context.Background(), 5, 1)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestCustomFieldRepository_Delete_NotFound menguji definisi milik pengguna lain yang dianggap tidak ada
+func TestCustomFieldRepository_Delete_NotFound(t *testing.T) {
+	db, mock := setupMockDB(t)
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	repo := NewCustomFieldRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `custom_field_definitions` WHERE id = ? AND user_id = ?")).
+		WithArgs(1, 6, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	err := repo.Delete(context.Background(), 6, 1)
+	assert.ErrorIs(t, err, ErrFieldKustomTidakDitemukan)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestTodoRepository_FindByCustomFields menguji kondisi dan urutan berdasarkan nilai field kustom
+func TestTodoRepository_FindByCustomFields(t *testing.T) {
+	db, mock := setupMockDB(t)
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	repo := NewTodoRepository(db)
+
+	rows := sqlmock.NewRows([]string{"id", "user_id", "title", "custom_fields"}).
+		AddRow(1, 5, "Laporan", []byte(`{"estimasi":5,"status":"review"}`))
+	mock.ExpectQuery(regexp.QuoteMeta("WHERE user_id = ? AND (custom_fields->>?)::numeric >= ? AND custom_fields->>? IS NOT NULL "+
+		"ORDER BY (custom_fields->>?)::date DESC NULLS LAST, id")).
+		WithArgs(5, "estimasi", 3.0, "status", "mulai").
+		WillReturnRows(rows)
+
+	todos, err := repo.FindByCustomFields(context.Background(), 5, []entity.CustomFieldCondition{
+		{Key: "estimasi", Type: entity.CustomFieldNumber, Op: "gte", Value: 3.0},
+		{Key: "status", Type: entity.CustomFieldSelect, Op: "exists"},
+	}, &entity.CustomFieldSort{Key: "mulai", Type: entity.CustomFieldDate, Desc: true})
+	assert.NoError(t, err)
+	assert.Len(t, todos, 1)
+	assert.Equal(t, "review", todos[0].CustomFields["status"])
+	assert.Equal(t, 5.0, todos[0].CustomFields["estimasi"])
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestTodoRepository_FindByCustomFields_ContainsEscapesWildcards menguji % dan _ dari pengguna
+// dicari secara harfiah, bukan sebagai wildcard
+func TestTodoRepository_FindByCustomFields_ContainsEscapesWildcards(t *testing.T) {
+	db, mock := setupMockDB(t)
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	repo := NewTodoRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`WHERE user_id = ? AND (custom_fields->>?) ILIKE ? ESCAPE '\' ORDER BY due_date, id`)).
+		WithArgs(5, "catatan", `%50\%\_diskon\\%`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	_, err := repo.FindByCustomFields(context.Background(), 5, []entity.CustomFieldCondition{
+		{Key: "catatan", Type: entity.CustomFieldText, Op: "contains", Value: `50%_diskon\`},
+	}, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestTodoRepository_FindByCustomFields_InvalidOperator menguji operator yang tidak dikenal
+func TestTodoRepository_FindByCustomFields_InvalidOperator(t *testing.T) {
+	db, _ := setupMockDB(t)
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	repo := NewTodoRepository(db)
+
+	_, err := repo.FindByCustomFields(context.Background(), 5, []entity.CustomFieldCondition{
+		{Key: "estimasi", Type: entity.CustomFieldNumber, Op: "; DROP", Value: 1.0},
+	}, nil)
+	assert.ErrorIs(t, err, ErrKondisiFieldKustomTidakValid)
+}
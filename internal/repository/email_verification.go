@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-todo/internal/entity"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// EmailVerificationRepository mendefinisikan operasi database untuk token verifikasi email.
+type EmailVerificationRepository interface {
+	Create(ctx context.Context, verificationToken *entity.EmailVerificationToken) error
+	FindByHashForUpdate(ctx context.Context, tokenHash string) (*entity.EmailVerificationToken, error)
+	CountSince(ctx context.Context, userID int64, since time.Time) (int64, error)
+	InvalidateByUserID(ctx context.Context, userID int64, usedAt time.Time) (int64, error)
+}
+
+var ErrTokenVerifikasiTidakDitemukan = errors.New("token verifikasi email tidak ditemukan")
+
+type emailVerificationRepository struct {
+	db *gorm.DB
+}
+
+// NewEmailVerificationRepository inisialisasi EmailVerificationRepository baru.
+func NewEmailVerificationRepository(db *gorm.DB) EmailVerificationRepository {
+	return &emailVerificationRepository{db}
+}
+
+// Create menyimpan token verifikasi baru.
+func (r *emailVerificationRepository) Create(ctx context.Context, verificationToken *entity.EmailVerificationToken) error {
+	if err := dbFromContext(ctx, r.db).Create(verificationToken).Error; err != nil {
+		return fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return nil
+}
+
+// FindByHashForUpdate mencari token verifikasi berdasarkan hash dan menguncinya hingga transaksi
+// selesai, sehingga satu token tidak dapat dipakai dua kali bersamaan.
+func (r *emailVerificationRepository) FindByHashForUpdate(ctx context.Context, tokenHash string) (*entity.EmailVerificationToken, error) {
+	verificationToken := new(entity.EmailVerificationToken)
+	err := dbFromContext(ctx, r.db).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("token_hash = ?", tokenHash).
+		Take(verificationToken).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTokenVerifikasiTidakDitemukan
+		}
+		return nil, fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return verificationToken, nil
+}
+
+// CountSince menghitung token verifikasi yang diminta pengguna sejak waktu tertentu.
+func (r *emailVerificationRepository) CountSince(ctx context.Context, userID int64, since time.Time) (int64, error) {
+	var count int64
+	err := dbFromContext(ctx, r.db).Model(&entity.EmailVerificationToken{}).
+		Where("user_id = ? AND created_at >= ?", userID, since).
+		Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return count, nil
+}
+
+// InvalidateByUserID menandai seluruh token verifikasi pengguna yang belum dipakai sebagai terpakai.
+func (r *emailVerificationRepository) InvalidateByUserID(ctx context.Context, userID int64, usedAt time.Time) (int64, error) {
+	result := dbFromContext(ctx, r.db).Model(&entity.EmailVerificationToken{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Update("used_at", usedAt)
+	if result.Error != nil {
+		return 0, fmt.Errorf("%w: %v", ErrDatabaseError, result.Error)
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestEmailVerificationRepository_FindByHashForUpdate menguji penguncian baris token verifikasi saat dipakai
+func TestEmailVerificationRepository_FindByHashForUpdate(t *testing.T) {
+	db, mock := setupMockDB(t)
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	repo := NewEmailVerificationRepository(db)
+
+	rows := sqlmock.NewRows([]string{"id", "user_id", "token_hash"}).AddRow(3, 7, "abc")
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `email_verification_tokens` WHERE token_hash = ? LIMIT ? FOR UPDATE")).
+		WithArgs("abc", 1).
+		WillReturnRows(rows)
+
+	found, err := repo.FindByHashForUpdate(context.Background(), "abc")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), found.UserID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestEmailVerificationRepository_FindByHashForUpdate_NotFound menguji token yang tidak dikenal
+func TestEmailVerificationRepository_FindByHashForUpdate_NotFound(t *testing.T) {
+	db, mock := setupMockDB(t)
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	repo := NewEmailVerificationRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `email_verification_tokens` WHERE token_hash = ? LIMIT ? FOR UPDATE")).
+		WithArgs("abc", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	_, err := repo.FindByHashForUpdate(context.Background(), "abc")
+	assert.ErrorIs(t, err, ErrTokenVerifikasiTidakDitemukan)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestEmailVerificationRepository_CountSince menguji jumlah permintaan verifikasi dalam periode tertentu
+func TestEmailVerificationRepository_CountSince(t *testing.T) {
+	db, mock := setupMockDB(t)
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	repo := NewEmailVerificationRepository(db)
+	since := time.Now().Add(-time.Hour)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `email_verification_tokens` WHERE user_id = ? AND created_at >= ?")).
+		WithArgs(7, since).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+	count, err := repo.CountSince(context.Background(), 7, since)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestEmailVerificationRepository_InvalidateByUserID menguji pembatalan seluruh token verifikasi yang belum dipakai
+func TestEmailVerificationRepository_InvalidateByUserID(t *testing.T) {
+	db, mock := setupMockDB(t)
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	repo := NewEmailVerificationRepository(db)
+	now := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `email_verification_tokens` SET `used_at`=? WHERE user_id = ? AND used_at IS NULL")).
+		WithArgs(now, 7).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	invalidated, err := repo.InvalidateByUserID(context.Background(), 7, now)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), invalidated)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
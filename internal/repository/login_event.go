@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"go-todo/internal/entity"
+
+	"gorm.io/gorm"
+)
+
+// LoginEventRepository mendefinisikan operasi database untuk riwayat login.
+type LoginEventRepository interface {
+	Create(ctx context.Context, event *entity.LoginEvent) error
+}
+
+type loginEventRepository struct {
+	db *gorm.DB
+}
+
+// NewLoginEventRepository inisialisasi LoginEventRepository baru.
+func NewLoginEventRepository(db *gorm.DB) LoginEventRepository {
+	return &loginEventRepository{db}
+}
+
+// Create mencatat percobaan login ke database.
+func (r *loginEventRepository) Create(ctx context.Context, event *entity.LoginEvent) error {
+	if err := dbFromContext(ctx, r.db).Create(event).Error; err != nil {
+		return fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-todo/internal/entity"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MFARepository mendefinisikan operasi database untuk TOTP dan recovery code pengguna.
+type MFARepository interface {
+	FindByUserID(ctx context.Context, userID int64) (*entity.UserTOTP, error)
+	// Save menyimpan pendaftaran TOTP baru atau menimpa pendaftaran yang belum dikonfirmasi
+	Save(ctx context.Context, totp *entity.UserTOTP) error
+	Confirm(ctx context.Context, userID int64, confirmedAt time.Time, step int64) error
+	// UseStep mencatat periode kode yang diterima; false berarti periode tersebut sudah dipakai
+	UseStep(ctx context.Context, userID, step int64) (bool, error)
+	Delete(ctx context.Context, userID int64) error
+	ReplaceRecoveryCodes(ctx context.Context, userID int64, codeHashes []string) error
+	// UseRecoveryCode menandai recovery code terpakai; false berarti kode tidak dikenal atau sudah dipakai
+	UseRecoveryCode(ctx context.Context, userID int64, codeHash string, usedAt time.Time) (bool, error)
+	CountUnusedRecoveryCodes(ctx context.Context, userID int64) (int64, error)
+}
+
+var ErrMFATidakDitemukan = errors.New("pendaftaran TOTP tidak ditemukan")
+
+type mfaRepository struct {
+	db *gorm.DB
+}
+
+// NewMFARepository inisialisasi MFARepository baru.
+func NewMFARepository(db *gorm.DB) MFARepository {
+	return &mfaRepository{db}
+}
+
+// FindByUserID mencari pendaftaran TOTP milik pengguna.
+func (r *mfaRepository) FindByUserID(ctx context.Context, userID int64) (*entity.UserTOTP, error) {
+	totp := new(entity.UserTOTP)
+	if err := dbFromContext(ctx, r.db).Where("user_id = ?", userID).Take(totp).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrMFATidakDitemukan
+		}
+		return nil, fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return totp, nil
+}
+
+// Save menyimpan pendaftaran TOTP; secret dan status konfirmasi lama ditimpa.
+func (r *mfaRepository) Save(ctx context.Context, totp *entity.UserTOTP) error {
+	err := dbFromContext(ctx, r.db).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"secret", "confirmed_at", "last_used_step", "updated_at"}),
+	}).Create(totp).Error
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return nil
+}
+
+// Confirm mengaktifkan TOTP yang belum dikonfirmasi dan mencatat periode kode konfirmasinya.
+func (r *mfaRepository) Confirm(ctx context.Context, userID int64, confirmedAt time.Time, step int64) error {
+	result := dbFromContext(ctx, r.db).Model(&entity.UserTOTP{}).
+		Where("user_id = ? AND confirmed_at IS NULL", userID).
+		Updates(map[string]interface{}{"confirmed_at": confirmedAt, "last_used_step": step})
+	if result.Error != nil {
+		return fmt.Errorf("%w: %v", ErrDatabaseError, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrMFATidakDitemukan
+	}
+	return nil
+}
+
+// UseStep hanya memperbarui periode yang lebih baru sehingga dua permintaan bersamaan dengan
+// kode yang sama tidak dapat sama-sama berhasil.
+func (r *mfaRepository) UseStep(ctx context.Context, userID, step int64) (bool, error) {
+	result := dbFromContext(ctx, r.db).Model(&entity.UserTOTP{}).
+		Where("user_id = ? AND last_used_step < ?", userID, step).
+		Update("last_used_step", step)
+	if result.Error != nil {
+		return false, fmt.Errorf("%w: %v", ErrDatabaseError, result.Error)
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// Delete menghapus pendaftaran TOTP beserta seluruh recovery code pengguna.
+func (r *mfaRepository) Delete(ctx context.Context, userID int64) error {
+	db := dbFromContext(ctx, r.db)
+	if err := db.Where("user_id = ?", userID).Delete(&entity.RecoveryCode{}).Error; err != nil {
+		return fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	if err := db.Where("user_id = ?", userID).Delete(&entity.UserTOTP{}).Error; err != nil {
+		return fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return nil
+}
+
+// ReplaceRecoveryCodes mengganti seluruh recovery code pengguna dengan yang baru.
+func (r *mfaRepository) ReplaceRecoveryCodes(ctx context.Context, userID int64, codeHashes []string) error {
+	db := dbFromContext(ctx, r.db)
+	if err := db.Where("user_id = ?", userID).Delete(&entity.RecoveryCode{}).Error; err != nil {
+		return fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	if len(codeHashes) == 0 {
+		return nil
+	}
+	codes := make([]entity.RecoveryCode, len(codeHashes))
+	for i, codeHash := range codeHashes {
+		codes[i] = entity.RecoveryCode{UserID: userID, CodeHash: codeHash}
+	}
+	if err := db.Create(&codes).Error; err != nil {
+		return fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return nil
+}
+
+// UseRecoveryCode menandai recovery code yang belum dipakai sebagai terpakai.
+func (r *mfaRepository) UseRecoveryCode(ctx context.Context, userID int64, codeHash string, usedAt time.Time) (bool, error) {
+	result := dbFromContext(ctx, r.db).Model(&entity.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", usedAt)
+	if result.Error != nil {
+		return false, fmt.Errorf("%w: %v", ErrDatabaseError, result.Error)
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// CountUnusedRecoveryCodes menghitung recovery code pengguna yang masih dapat dipakai.
+func (r *mfaRepository) CountUnusedRecoveryCodes(ctx context.Context, userID int64) (int64, error) {
+	var count int64
+	err := dbFromContext(ctx, r.db).Model(&entity.RecoveryCode{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return count, nil
+}
@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestMFARepository_FindByUserID menguji pencarian pendaftaran TOTP pengguna
+func TestMFARepository_FindByUserID(t *testing.T) {
+	db, mock := setupMockDB(t)
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	repo := NewMFARepository(db)
+
+	rows := sqlmock.NewRows([]string{"user_id", "secret", "last_used_step"}).AddRow(7, "terenkripsi", 100)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `user_totp` WHERE user_id = ? LIMIT ?")).
+		WithArgs(7, 1).
+		WillReturnRows(rows)
+
+	totp, err := repo.FindByUserID(context.Background(), 7)
+	assert.NoError(t, err)
+	assert.Equal(t, "terenkripsi", totp.Secret)
+	assert.Equal(t, int64(100), totp.LastUsedStep)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestMFARepository_FindByUserID_NotFound menguji pengguna yang belum mendaftarkan TOTP
+func TestMFARepository_FindByUserID_NotFound(t *testing.T) {
+	db, mock := setupMockDB(t)
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	repo := NewMFARepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `user_totp` WHERE user_id = ? LIMIT ?")).
+		WithArgs(7, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}))
+
+	_, err := repo.FindByUserID(context.Background(), 7)
+	assert.ErrorIs(t, err, ErrMFATidakDitemukan)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestMFARepository_Confirm_AlreadyConfirmed menguji konfirmasi ulang TOTP yang sudah aktif
+func TestMFARepository_Confirm_AlreadyConfirmed(t *testing.T) {
+	db, mock := setupMockDB(t)
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	repo := NewMFARepository(db)
+	now := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `user_totp` SET `confirmed_at`=?,`last_used_step`=?,`updated_at`=? WHERE user_id = ? AND confirmed_at IS NULL")).
+		WithArgs(now, 100, sqlmock.AnyArg(), 7).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	err := repo.Confirm(context.Background(), 7, now, 100)
+	assert.ErrorIs(t, err, ErrMFATidakDitemukan)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestMFARepository_UseStep_Replay menguji penolakan periode kode yang sudah dipakai
+func TestMFARepository_UseStep_Replay(t *testing.T) {
+	db, mock := setupMockDB(t)
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	repo := NewMFARepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `user_totp` SET `last_used_step`=?,`updated_at`=? WHERE user_id = ? AND last_used_step < ?")).
+		WithArgs(100, sqlmock.AnyArg(), 7, 100).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	used, err := repo.UseStep(context.Background(), 7, 100)
+	assert.NoError(t, err)
+	assert.False(t, used)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestMFARepository_ReplaceRecoveryCodes menguji penggantian seluruh recovery code pengguna
+func TestMFARepository_ReplaceRecoveryCodes(t *testing.T) {
+	db, mock := setupMockDB(t)
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	repo := NewMFARepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `user_recovery_codes` WHERE user_id = ?")).
+		WithArgs(7).
+		WillReturnResult(sqlmock.NewResult(0, 10))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `user_recovery_codes` (`user_id`,`code_hash`,`used_at`,`created_at`) VALUES (?,?,?,?),(?,?,?,?)")).
+		WithArgs(7, "hash-1", nil, sqlmock.AnyArg(), 7, "hash-2", nil, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 2))
+	mock.ExpectCommit()
+
+	err := repo.ReplaceRecoveryCodes(context.Background(), 7, []string{"hash-1", "hash-2"})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestMFARepository_UseRecoveryCode menguji pemakaian recovery code sekali pakai
+func TestMFARepository_UseRecoveryCode(t *testing.T) {
+	db, mock := setupMockDB(t)
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	repo := NewMFARepository(db)
+	now := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `user_recovery_codes` SET `used_at`=? WHERE user_id = ? AND code_hash = ? AND used_at IS NULL")).
+		WithArgs(now, 7, "hash-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	used, err := repo.UseRecoveryCode(context.Background(), 7, "hash-1", now)
+	assert.NoError(t, err)
+	assert.True(t, used)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestMFARepository_CountUnusedRecoveryCodes menguji jumlah recovery code yang tersisa
+func TestMFARepository_CountUnusedRecoveryCodes(t *testing.T) {
+	db, mock := setupMockDB(t)
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	repo := NewMFARepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `user_recovery_codes` WHERE user_id = ? AND used_at IS NULL")).
+		WithArgs(7).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(8))
+
+	count, err := repo.CountUnusedRecoveryCodes(context.Background(), 7)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(8), count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
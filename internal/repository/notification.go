@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-todo/internal/entity"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// NotificationRepository mendefinisikan operasi database untuk notifikasi pengguna.
+type NotificationRepository interface {
+	Create(ctx context.Context, notification *entity.Notification) (bool, error)
+	FindByUserID(ctx context.Context, userID int64, unreadOnly bool, limit int) ([]entity.Notification, error)
+	MarkRead(ctx context.Context, userID, id int64, readAt time.Time) error
+	MarkAllRead(ctx context.Context, userID int64, readAt time.Time) (int64, error)
+}
+
+var ErrNotifikasiTidakDitemukan = errors.New("notifikasi tidak ditemukan")
+
+type notificationRepository struct {
+	db *gorm.DB
+}
+
+// NewNotificationRepository inisialisasi NotificationRepository baru.
+func NewNotificationRepository(db *gorm.DB) NotificationRepository {
+	return &notificationRepository{db}
+}
+
+// Create menyimpan notifikasi baru. Notifikasi dari event yang sama untuk pengguna yang sama
+// hanya disimpan sekali; nilai false berarti notifikasi tersebut sudah ada.
+func (r *notificationRepository) Create(ctx context.Context, notification *entity.Notification) (bool, error) {
+	result := dbFromContext(ctx, r.db).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "event_id"}, {Name: "user_id"}}, DoNothing: true}).
+		Create(notification)
+	if result.Error != nil {
+		return false, fmt.Errorf("%w: %v", ErrDatabaseError, result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// FindByUserID mengambil notifikasi terbaru milik pengguna.
+func (r *notificationRepository) FindByUserID(ctx context.Context, userID int64, unreadOnly bool, limit int) ([]entity.Notification, error) {
+	notifications := make([]entity.Notification, 0)
+	query := dbFromContext(ctx, r.db).Where("user_id = ?", userID)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+	if err := query.Order("id DESC").Limit(limit).Find(&notifications).Error; err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return notifications, nil
+}
+
+// MarkRead menandai satu notifikasi milik pengguna sebagai sudah dibaca.
+func (r *notificationRepository) MarkRead(ctx context.Context, userID, id int64, readAt time.Time) error {
+	result := dbFromContext(ctx, r.db).Model(&entity.Notification{}).
+		Where("id = ? AND user_id = ?", id, userID).
+		Update("read_at", gorm.Expr("COALESCE(read_at, ?)", readAt))
+	if result.Error != nil {
+		return fmt.Errorf("%w: %v", ErrDatabaseError, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotifikasiTidakDitemukan
+	}
+	return nil
+}
+
+// MarkAllRead menandai semua notifikasi pengguna yang belum dibaca sebagai sudah dibaca.
+func (r *notificationRepository) MarkAllRead(ctx context.Context, userID int64, readAt time.Time) (int64, error) {
+	result := dbFromContext(ctx, r.db).Model(&entity.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Update("read_at", readAt)
+	if result.Error != nil {
+		return 0, fmt.Errorf("%w: %v", ErrDatabaseError, result.Error)
+	}
+	return result.RowsAffected, nil
+}
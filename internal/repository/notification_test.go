@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"go-todo/internal/entity"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNotificationRepository_MarkRead_NotFound menguji notifikasi milik pengguna lain yang tidak dapat ditandai
+func TestNotificationRepository_MarkRead_NotFound(t *testing.T) {
+	db, mock := setupMockDB(t)
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	repo := NewNotificationRepository(db)
+	readAt := time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `notifications` SET `read_at`=COALESCE(read_at, ?) WHERE id = ? AND user_id = ?")).
+		WithArgs(readAt, 3, 6).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	err := repo.MarkRead(context.Background(), 6, 3, readAt)
+	assert.ErrorIs(t, err, ErrNotifikasiTidakDitemukan)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestTodoRepository_UpdateAssignee menguji penghapusan penanggung jawab todo
+func TestTodoRepository_UpdateAssignee(t *testing.T) {
+	db, mock := setupMockDB(t)
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	repo := NewTodoRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `todos` SET `assignee_id`=? WHERE `id` = ?")).
+		WithArgs(nil, 7).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := repo.UpdateAssignee(context.Background(), 7, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestNotificationRepository_FindByUserID menguji pengambilan notifikasi yang belum dibaca
+func TestNotificationRepository_FindByUserID(t *testing.T) {
+	db, mock := setupMockDB(t)
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	repo := NewNotificationRepository(db)
+
+	rows := sqlmock.NewRows([]string{"id", "user_id", "type", "message"}).
+		AddRow(2, 6, entity.EventTodoAssigned, "Anda ditugaskan")
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `notifications` WHERE user_id = ? AND read_at IS NULL ORDER BY id DESC LIMIT ?")).
+		WithArgs(6, 100).
+		WillReturnRows(rows)
+
+	notifications, err := repo.FindByUserID(context.Background(), 6, true, 100)
+	assert.NoError(t, err)
+	assert.Len(t, notifications, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"go-todo/internal/entity"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// OutboxRepository mendefinisikan operasi database untuk tabel outbox.
+type OutboxRepository interface {
+	Create(ctx context.Context, event *entity.OutboxEvent) error
+	TryLock(ctx context.Context) (bool, error)
+	FindPending(ctx context.Context, now time.Time, limit int) ([]entity.OutboxEvent, error)
+	MarkPublished(ctx context.Context, id int64, publishedAt time.Time) error
+	MarkFailed(ctx context.Context, event *entity.OutboxEvent) error
+	DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// outboxRelayLockID adalah kunci advisory lock PostgreSQL yang memastikan
+// hanya satu relay yang meneruskan event pada satu waktu
+const outboxRelayLockID = 8_240_033
+
+type outboxRepository struct {
+	db *gorm.DB
+}
+
+// NewOutboxRepository inisialisasi OutboxRepository baru.
+func NewOutboxRepository(db *gorm.DB) OutboxRepository {
+	return &outboxRepository{db}
+}
+
+// Create menyimpan event ke outbox. Dipanggil di dalam transaksi perubahan entitas.
+func (r *outboxRepository) Create(ctx context.Context, event *entity.OutboxEvent) error {
+	if err := dbFromContext(ctx, r.db).Create(event).Error; err != nil {
+		return fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return nil
+}
+
+// TryLock mengambil advisory lock relay hingga transaksi selesai. Mengembalikan false
+// jika lock sedang dipegang relay lain. Harus dipanggil di dalam transaksi.
+func (r *outboxRepository) TryLock(ctx context.Context) (bool, error) {
+	var locked bool
+	if err := dbFromContext(ctx, r.db).Raw("SELECT pg_try_advisory_xact_lock(?)", outboxRelayLockID).Scan(&locked).Error; err != nil {
+		return false, fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return locked, nil
+}
+
+// FindPending mengambil event terlama yang belum terkirim dari setiap aggregate dan sudah
+// jatuh waktu. Event berikutnya dari aggregate yang sama baru diambil setelah event
+// sebelumnya terkirim, sehingga urutan per aggregate selalu terjaga.
+func (r *outboxRepository) FindPending(ctx context.Context, now time.Time, limit int) ([]entity.OutboxEvent, error) {
+	events := make([]entity.OutboxEvent, 0)
+	err := dbFromContext(ctx, r.db).Raw(`
+		SELECT * FROM outbox o
+		WHERE o.published_at IS NULL
+			AND (o.next_attempt_at IS NULL OR o.next_attempt_at <= ?)
+			AND NOT EXISTS (
+				SELECT 1 FROM outbox p
+				WHERE p.published_at IS NULL AND p.aggregate_type = o.aggregate_type
+					AND p.aggregate_id = o.aggregate_id AND p.id < o.id
+			)
+		ORDER BY o.id
+		LIMIT ?`, now, limit).
+		Scan(&events).Error
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return events, nil
+}
+
+// MarkPublished menandai event sebagai terkirim.
+func (r *outboxRepository) MarkPublished(ctx context.Context, id int64, publishedAt time.Time) error {
+	err := dbFromContext(ctx, r.db).Model(&entity.OutboxEvent{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{"published_at": publishedAt, "last_error": ""}).Error
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return nil
+}
+
+// MarkFailed menyimpan jumlah percobaan, jadwal percobaan berikutnya, dan pesan kesalahan.
+func (r *outboxRepository) MarkFailed(ctx context.Context, event *entity.OutboxEvent) error {
+	err := dbFromContext(ctx, r.db).Model(event).
+		Select("Attempts", "NextAttemptAt", "LastError").
+		Updates(event).Error
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return nil
+}
+
+// DeletePublishedBefore menghapus event terkirim yang lebih lama dari before.
+func (r *outboxRepository) DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := dbFromContext(ctx, r.db).Where("published_at < ?", before).Delete(&entity.OutboxEvent{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("%w: %v", ErrDatabaseError, result.Error)
+	}
+	return result.RowsAffected, nil
+}
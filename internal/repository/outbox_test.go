@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"go-todo/internal/entity"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestTransactor_WithinTransaction_Commit menguji bahwa perubahan todo dan event outbox
+// ditulis dalam satu transaksi
+func TestTransactor_WithinTransaction_Commit(t *testing.T) {
+	db, mock := setupMockDB(t)
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	transactor := NewTransactor(db)
+	todoRepo := NewTodoRepository(db)
+	outboxRepo := NewOutboxRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `todos`")).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `outbox`")).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err := transactor.WithinTransaction(context.Background(), func(ctx context.Context) error {
+		todo, err := todoRepo.Create(ctx, entity.Todo{Title: "Todo", UserID: 5})
+		if err != nil {
+			return err
+		}
+		return outboxRepo.Create(ctx, &entity.OutboxEvent{
+			EventID:       "evt_1",
+			AggregateType: entity.AggregateTodo,
+			AggregateID:   todo.ID,
+			EventType:     entity.EventTodoCreated,
+			Payload:       json.RawMessage(`{}`),
+		})
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestTransactor_WithinTransaction_Rollback menguji bahwa perubahan todo dibatalkan
+// jika event outbox gagal disimpan
+func TestTransactor_WithinTransaction_Rollback(t *testing.T) {
+	db, mock := setupMockDB(t)
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	transactor := NewTransactor(db)
+	todoRepo := NewTodoRepository(db)
+	outboxRepo := NewOutboxRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `todos`")).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `outbox`")).
+		WillReturnError(errors.New("database error"))
+	mock.ExpectRollback()
+
+	err := transactor.WithinTransaction(context.Background(), func(ctx context.Context) error {
+		if _, err := todoRepo.Create(ctx, entity.Todo{Title: "Todo", UserID: 5}); err != nil {
+			return err
+		}
+		return outboxRepo.Create(ctx, &entity.OutboxEvent{EventID: "evt_1", Payload: json.RawMessage(`{}`)})
+	})
+	assert.ErrorIs(t, err, ErrDatabaseError)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestOutboxRepository_FindPending menguji pengambilan event terlama per aggregate
+func TestOutboxRepository_FindPending(t *testing.T) {
+	db, mock := setupMockDB(t)
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	repo := NewOutboxRepository(db)
+	now := time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC)
+
+	rows := sqlmock.NewRows([]string{"id", "event_id", "aggregate_type", "aggregate_id", "event_type", "payload"}).
+		AddRow(3, "evt_3", "todo", 7, "todo.updated", []byte(`{"id":7}`))
+	mock.ExpectQuery("SELECT \\* FROM outbox o WHERE o.published_at IS NULL .*NOT EXISTS .*p.id < o.id.*ORDER BY o.id LIMIT \\?").
+		WithArgs(now, 100).
+		WillReturnRows(rows)
+
+	events, err := repo.FindPending(context.Background(), now, 100)
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+	assert.Equal(t, "evt_3", events[0].EventID)
+	assert.JSONEq(t, `{"id":7}`, string(events[0].Payload))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestOutboxRepository_TryLock menguji pengambilan advisory lock relay
+func TestOutboxRepository_TryLock(t *testing.T) {
+	db, mock := setupMockDB(t)
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	repo := NewOutboxRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT pg_try_advisory_xact_lock(?)")).
+		WithArgs(outboxRelayLockID).
+		WillReturnRows(sqlmock.NewRows([]string{"pg_try_advisory_xact_lock"}).AddRow(false))
+
+	locked, err := repo.TryLock(context.Background())
+	assert.NoError(t, err)
+	assert.False(t, locked)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestOutboxRepository_MarkPublished menguji penandaan event yang terkirim
+func TestOutboxRepository_MarkPublished(t *testing.T) {
+	db, mock := setupMockDB(t)
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	repo := NewOutboxRepository(db)
+	publishedAt := time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `outbox` SET `last_error`=?,`published_at`=? WHERE id = ?")).
+		WithArgs("", publishedAt, 3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	assert.NoError(t, repo.MarkPublished(context.Background(), 3, publishedAt))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
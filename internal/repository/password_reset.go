@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-todo/internal/entity"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PasswordResetRepository mendefinisikan operasi database untuk token reset password.
+type PasswordResetRepository interface {
+	Create(ctx context.Context, resetToken *entity.PasswordResetToken) error
+	FindByHashForUpdate(ctx context.Context, tokenHash string) (*entity.PasswordResetToken, error)
+	CountSince(ctx context.Context, userID int64, since time.Time) (int64, error)
+	InvalidateByUserID(ctx context.Context, userID int64, usedAt time.Time) (int64, error)
+}
+
+var ErrTokenResetTidakDitemukan = errors.New("token reset password tidak ditemukan")
+
+type passwordResetRepository struct {
+	db *gorm.DB
+}
+
+// NewPasswordResetRepository inisialisasi PasswordResetRepository baru.
+func NewPasswordResetRepository(db *gorm.DB) PasswordResetRepository {
+	return &passwordResetRepository{db}
+}
+
+// Create menyimpan token reset baru.
+func (r *passwordResetRepository) Create(ctx context.Context, resetToken *entity.PasswordResetToken) error {
+	if err := dbFromContext(ctx, r.db).Create(resetToken).Error; err != nil {
+		return fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return nil
+}
+
+// FindByHashForUpdate mencari token reset berdasarkan hash dan menguncinya hingga transaksi
+// selesai, sehingga satu token tidak dapat dipakai dua kali bersamaan.
+func (r *passwordResetRepository) FindByHashForUpdate(ctx context.Context, tokenHash string) (*entity.PasswordResetToken, error) {
+	resetToken := new(entity.PasswordResetToken)
+	err := dbFromContext(ctx, r.db).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("token_hash = ?", tokenHash).
+		Take(resetToken).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTokenResetTidakDitemukan
+		}
+		return nil, fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return resetToken, nil
+}
+
+// CountSince menghitung token reset yang diminta pengguna sejak waktu tertentu.
+func (r *passwordResetRepository) CountSince(ctx context.Context, userID int64, since time.Time) (int64, error) {
+	var count int64
+	err := dbFromContext(ctx, r.db).Model(&entity.PasswordResetToken{}).
+		Where("user_id = ? AND created_at >= ?", userID, since).
+		Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return count, nil
+}
+
+// InvalidateByUserID menandai seluruh token reset pengguna yang belum dipakai sebagai terpakai.
+func (r *passwordResetRepository) InvalidateByUserID(ctx context.Context, userID int64, usedAt time.Time) (int64, error) {
+	result := dbFromContext(ctx, r.db).Model(&entity.PasswordResetToken{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Update("used_at", usedAt)
+	if result.Error != nil {
+		return 0, fmt.Errorf("%w: %v", ErrDatabaseError, result.Error)
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-todo/internal/entity"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RefreshTokenRepository mendefinisikan operasi database untuk refresh token.
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, refreshToken *entity.RefreshToken) error
+	FindByHashForUpdate(ctx context.Context, tokenHash string) (*entity.RefreshToken, error)
+	MarkUsed(ctx context.Context, id int64, usedAt time.Time) error
+	RevokeFamily(ctx context.Context, familyID string, revokedAt time.Time) (int64, error)
+	RevokeByUserID(ctx context.Context, userID int64, revokedAt time.Time) (int64, error)
+}
+
+var ErrRefreshTokenTidakDitemukan = errors.New("refresh token tidak ditemukan")
+
+type refreshTokenRepository struct {
+	db *gorm.DB
+}
+
+// NewRefreshTokenRepository inisialisasi RefreshTokenRepository baru.
+func NewRefreshTokenRepository(db *gorm.DB) RefreshTokenRepository {
+	return &refreshTokenRepository{db}
+}
+
+// Create menyimpan refresh token baru.
+func (r *refreshTokenRepository) Create(ctx context.Context, refreshToken *entity.RefreshToken) error {
+	if err := dbFromContext(ctx, r.db).Create(refreshToken).Error; err != nil {
+		return fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return nil
+}
+
+// FindByHashForUpdate mencari refresh token berdasarkan hash dan menguncinya hingga
+// transaksi selesai, sehingga satu token tidak dapat dirotasi dua kali bersamaan.
+func (r *refreshTokenRepository) FindByHashForUpdate(ctx context.Context, tokenHash string) (*entity.RefreshToken, error) {
+	refreshToken := new(entity.RefreshToken)
+	err := dbFromContext(ctx, r.db).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("token_hash = ?", tokenHash).
+		Take(refreshToken).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRefreshTokenTidakDitemukan
+		}
+		return nil, fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return refreshToken, nil
+}
+
+// MarkUsed menandai refresh token sudah dirotasi.
+func (r *refreshTokenRepository) MarkUsed(ctx context.Context, id int64, usedAt time.Time) error {
+	if err := dbFromContext(ctx, r.db).Model(&entity.RefreshToken{ID: id}).Update("used_at", usedAt).Error; err != nil {
+		return fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return nil
+}
+
+// RevokeFamily mencabut seluruh refresh token dalam satu family yang belum dicabut.
+func (r *refreshTokenRepository) RevokeFamily(ctx context.Context, familyID string, revokedAt time.Time) (int64, error) {
+	result := dbFromContext(ctx, r.db).Model(&entity.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", revokedAt)
+	if result.Error != nil {
+		return 0, fmt.Errorf("%w: %v", ErrDatabaseError, result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// RevokeByUserID mencabut seluruh refresh token milik pengguna yang belum dicabut.
+func (r *refreshTokenRepository) RevokeByUserID(ctx context.Context, userID int64, revokedAt time.Time) (int64, error) {
+	result := dbFromContext(ctx, r.db).Model(&entity.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", revokedAt)
+	if result.Error != nil {
+		return 0, fmt.Errorf("%w: %v", ErrDatabaseError, result.Error)
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRefreshTokenRepository_FindByHashForUpdate menguji penguncian baris refresh token saat rotasi
+func TestRefreshTokenRepository_FindByHashForUpdate(t *testing.T) {
+	db, mock := setupMockDB(t)
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	repo := NewRefreshTokenRepository(db)
+
+	rows := sqlmock.NewRows([]string{"id", "user_id", "family_id", "token_hash"}).AddRow(7, 1, "fam", "abc")
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `refresh_tokens` WHERE token_hash = ? LIMIT ? FOR UPDATE")).
+		WithArgs("abc", 1).
+		WillReturnRows(rows)
+
+	found, err := repo.FindByHashForUpdate(context.Background(), "abc")
+	assert.NoError(t, err)
+	assert.Equal(t, "fam", found.FamilyID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestRefreshTokenRepository_RevokeFamily menguji pencabutan seluruh token aktif dalam satu family
+func TestRefreshTokenRepository_RevokeFamily(t *testing.T) {
+	db, mock := setupMockDB(t)
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	repo := NewRefreshTokenRepository(db)
+	now := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `refresh_tokens` SET `revoked_at`=? WHERE family_id = ? AND revoked_at IS NULL")).
+		WithArgs(now, "fam").
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectCommit()
+
+	revoked, err := repo.RevokeFamily(context.Background(), "fam", now)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), revoked)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-todo/internal/entity"
+
+	"gorm.io/gorm"
+)
+
+// RevisionRepository mendefinisikan operasi database untuk riwayat revisi todo.
+type RevisionRepository interface {
+	FindByTodoID(ctx context.Context, todoID int64) ([]entity.TodoRevision, error)
+	FindByRevision(ctx context.Context, todoID int64, revision int) (*entity.TodoRevision, error)
+	FindLatest(ctx context.Context, todoID int64) (*entity.TodoRevision, error)
+	Create(ctx context.Context, revision *entity.TodoRevision) error
+}
+
+var ErrRevisiTidakDitemukan = errors.New("revisi tidak ditemukan")
+
+type revisionRepository struct {
+	db *gorm.DB
+}
+
+// NewRevisionRepository inisialisasi RevisionRepository baru.
+func NewRevisionRepository(db *gorm.DB) RevisionRepository {
+	return &revisionRepository{db}
+}
+
+// FindByTodoID mengambil seluruh revisi todo, dimulai dari yang terbaru.
+func (r *revisionRepository) FindByTodoID(ctx context.Context, todoID int64) ([]entity.TodoRevision, error) {
+	revisions := make([]entity.TodoRevision, 0)
+	if err := dbFromContext(ctx, r.db).Where("todo_id = ?", todoID).Order("revision DESC").Find(&revisions).Error; err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return revisions, nil
+}
+
+// FindByRevision mencari revisi todo berdasarkan nomor revisinya.
+func (r *revisionRepository) FindByRevision(ctx context.Context, todoID int64, revision int) (*entity.TodoRevision, error) {
+	found := new(entity.TodoRevision)
+	if err := dbFromContext(ctx, r.db).Where("todo_id = ? AND revision = ?", todoID, revision).First(found).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRevisiTidakDitemukan
+		}
+		return nil, fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return found, nil
+}
+
+// FindLatest mengambil revisi terakhir todo, atau ErrRevisiTidakDitemukan jika belum ada.
+func (r *revisionRepository) FindLatest(ctx context.Context, todoID int64) (*entity.TodoRevision, error) {
+	found := new(entity.TodoRevision)
+	if err := dbFromContext(ctx, r.db).Where("todo_id = ?", todoID).Order("revision DESC").Take(found).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRevisiTidakDitemukan
+		}
+		return nil, fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return found, nil
+}
+
+// Create menyimpan revisi baru. Nomor revisi unik per todo.
+func (r *revisionRepository) Create(ctx context.Context, revision *entity.TodoRevision) error {
+	if err := dbFromContext(ctx, r.db).Create(revision).Error; err != nil {
+		return fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRevisionRepository_FindLatest menguji pembacaan snapshot revisi terakhir dari kolom JSONB
+func TestRevisionRepository_FindLatest(t *testing.T) {
+	db, mock := setupMockDB(t)
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	repo := NewRevisionRepository(db)
+
+	rows := sqlmock.NewRows([]string{"id", "todo_id", "revision", "snapshot"}).
+		AddRow(7, 1, 3, []byte(`{"title":"Laporan","content":"Isi","tags":["kerja"]}`))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `todo_revisions` WHERE todo_id = ? ORDER BY revision DESC LIMIT ?")).
+		WithArgs(1, 1).
+		WillReturnRows(rows)
+
+	latest, err := repo.FindLatest(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, latest.Revision)
+	assert.Equal(t, "Laporan", latest.Snapshot.Title)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestRevisionRepository_FindLatest_NoRevision menguji todo yang belum memiliki revisi
+func TestRevisionRepository_FindLatest_NoRevision(t *testing.T) {
+	db, mock := setupMockDB(t)
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	repo := NewRevisionRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `todo_revisions` WHERE todo_id = ?")).
+		WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	_, err := repo.FindLatest(context.Background(), 1)
+	assert.ErrorIs(t, err, ErrRevisiTidakDitemukan)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-todo/internal/entity"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RoleRepository mendefinisikan operasi database untuk role dan keanggotaan role pengguna.
+type RoleRepository interface {
+	FindAll(ctx context.Context) ([]entity.Role, error)
+	FindByName(ctx context.Context, name string) (*entity.Role, error)
+	FindByUserID(ctx context.Context, userID int64) ([]entity.Role, error)
+	FindRoleNamesByUserIDs(ctx context.Context, userIDs []int64) (map[int64][]string, error)
+	Create(ctx context.Context, role *entity.Role) error
+	Update(ctx context.Context, role *entity.Role) error
+	Delete(ctx context.Context, id int64) error
+	AssignToUser(ctx context.Context, userID, roleID int64) error
+	RemoveFromUser(ctx context.Context, userID, roleID int64) (int64, error)
+	CountUsers(ctx context.Context, roleID int64) (int64, error)
+}
+
+var ErrRoleTidakDitemukan = errors.New("role tidak ditemukan")
+
+type roleRepository struct {
+	db *gorm.DB
+}
+
+// NewRoleRepository inisialisasi RoleRepository baru.
+func NewRoleRepository(db *gorm.DB) RoleRepository {
+	return &roleRepository{db}
+}
+
+// FindAll mengambil seluruh role diurutkan berdasarkan nama.
+func (r *roleRepository) FindAll(ctx context.Context) ([]entity.Role, error) {
+	roles := make([]entity.Role, 0)
+	if err := dbFromContext(ctx, r.db).Order("name").Find(&roles).Error; err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return roles, nil
+}
+
+// FindByName mencari role berdasarkan nama.
+func (r *roleRepository) FindByName(ctx context.Context, name string) (*entity.Role, error) {
+	role := new(entity.Role)
+	if err := dbFromContext(ctx, r.db).Where("name = ?", name).Take(role).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRoleTidakDitemukan
+		}
+		return nil, fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return role, nil
+}
+
+// FindByUserID mengambil seluruh role milik pengguna.
+func (r *roleRepository) FindByUserID(ctx context.Context, userID int64) ([]entity.Role, error) {
+	roles := make([]entity.Role, 0)
+	err := dbFromContext(ctx, r.db).
+		Joins("JOIN user_roles ON user_roles.role_id = roles.id").
+		Where("user_roles.user_id = ?", userID).
+		Order("roles.name").
+		Find(&roles).Error
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return roles, nil
+}
+
+// FindRoleNamesByUserIDs mengambil nama role untuk banyak pengguna sekaligus.
+func (r *roleRepository) FindRoleNamesByUserIDs(ctx context.Context, userIDs []int64) (map[int64][]string, error) {
+	result := make(map[int64][]string, len(userIDs))
+	if len(userIDs) == 0 {
+		return result, nil
+	}
+
+	var rows []struct {
+		UserID int64
+		Name   string
+	}
+	err := dbFromContext(ctx, r.db).Table("user_roles").
+		Select("user_roles.user_id, roles.name").
+		Joins("JOIN roles ON roles.id = user_roles.role_id").
+		Where("user_roles.user_id IN ?", userIDs).
+		Order("roles.name").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	for _, row := range rows {
+		result[row.UserID] = append(result[row.UserID], row.Name)
+	}
+	return result, nil
+}
+
+// Create menyimpan role baru.
+func (r *roleRepository) Create(ctx context.Context, role *entity.Role) error {
+	if err := dbFromContext(ctx, r.db).Create(role).Error; err != nil {
+		return fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return nil
+}
+
+// Update memperbarui deskripsi dan izin role.
+func (r *roleRepository) Update(ctx context.Context, role *entity.Role) error {
+	result := dbFromContext(ctx, r.db).Model(&entity.Role{ID: role.ID}).
+		Select("description", "permissions", "updated_at").
+		Updates(role)
+	if result.Error != nil {
+		return fmt.Errorf("%w: %v", ErrDatabaseError, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrRoleTidakDitemukan
+	}
+	return nil
+}
+
+// Delete menghapus role; keanggotaan pengguna ikut terhapus melalui ON DELETE CASCADE.
+func (r *roleRepository) Delete(ctx context.Context, id int64) error {
+	result := dbFromContext(ctx, r.db).Delete(&entity.Role{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("%w: %v", ErrDatabaseError, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrRoleTidakDitemukan
+	}
+	return nil
+}
+
+// AssignToUser memberikan role kepada pengguna; pemberian ulang diabaikan.
+func (r *roleRepository) AssignToUser(ctx context.Context, userID, roleID int64) error {
+	err := dbFromContext(ctx, r.db).Table("user_roles").
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(map[string]interface{}{"user_id": userID, "role_id": roleID}).Error
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return nil
+}
+
+// RemoveFromUser mencabut role dari pengguna dan mengembalikan jumlah baris yang terhapus.
+func (r *roleRepository) RemoveFromUser(ctx context.Context, userID, roleID int64) (int64, error) {
+	result := dbFromContext(ctx, r.db).Exec("DELETE FROM user_roles WHERE user_id = ? AND role_id = ?", userID, roleID)
+	if result.Error != nil {
+		return 0, fmt.Errorf("%w: %v", ErrDatabaseError, result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// CountUsers menghitung jumlah pengguna yang memiliki role tertentu.
+func (r *roleRepository) CountUsers(ctx context.Context, roleID int64) (int64, error) {
+	var count int64
+	if err := dbFromContext(ctx, r.db).Table("user_roles").Where("role_id = ?", roleID).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return count, nil
+}
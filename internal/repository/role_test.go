@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRoleRepository_FindByUserID menguji pengambilan role pengguna beserta izinnya
+func TestRoleRepository_FindByUserID(t *testing.T) {
+	db, mock := setupMockDB(t)
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	repo := NewRoleRepository(db)
+
+	rows := sqlmock.NewRows([]string{"id", "name", "permissions", "built_in"}).
+		AddRow(3, "editor", []byte(`["todo:read","todo:update:any"]`), false).
+		AddRow(2, "user", []byte(`["todo:read","todo:write"]`), true)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT `roles`.`id`,`roles`.`name`,`roles`.`description`,`roles`.`permissions`,`roles`.`built_in`,`roles`.`created_at`,`roles`.`updated_at` FROM `roles` JOIN user_roles ON user_roles.role_id = roles.id WHERE user_roles.user_id = ? ORDER BY roles.name")).
+		WithArgs(5).
+		WillReturnRows(rows)
+
+	roles, err := repo.FindByUserID(context.Background(), 5)
+	assert.NoError(t, err)
+	assert.Len(t, roles, 2)
+	assert.Equal(t, "editor", roles[0].Name)
+	assert.Equal(t, []string{"todo:read", "todo:update:any"}, []string(roles[0].Permissions))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestRoleRepository_FindRoleNamesByUserIDs menguji pengelompokan nama role per pengguna
+func TestRoleRepository_FindRoleNamesByUserIDs(t *testing.T) {
+	db, mock := setupMockDB(t)
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	repo := NewRoleRepository(db)
+
+	rows := sqlmock.NewRows([]string{"user_id", "name"}).
+		AddRow(1, "admin").
+		AddRow(1, "user").
+		AddRow(2, "user")
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT user_roles.user_id, roles.name FROM `user_roles` JOIN roles ON roles.id = user_roles.role_id WHERE user_roles.user_id IN (?,?) ORDER BY roles.name")).
+		WithArgs(1, 2).
+		WillReturnRows(rows)
+
+	roles, err := repo.FindRoleNamesByUserIDs(context.Background(), []int64{1, 2})
+	assert.NoError(t, err)
+	assert.Equal(t, map[int64][]string{1: {"admin", "user"}, 2: {"user"}}, roles)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// Tanpa pengguna tidak ada query yang dijalankan
+	roles, err = repo.FindRoleNamesByUserIDs(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Empty(t, roles)
+}
+
+// TestRoleRepository_RemoveFromUser menguji pencabutan role dari pengguna
+func TestRoleRepository_RemoveFromUser(t *testing.T) {
+	db, mock := setupMockDB(t)
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	repo := NewRoleRepository(db)
+
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM user_roles WHERE user_id = ? AND role_id = ?")).
+		WithArgs(3, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	removed, err := repo.RemoveFromUser(context.Background(), 3, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), removed)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestRoleRepository_CountUsers menguji penghitungan pemilik role
+func TestRoleRepository_CountUsers(t *testing.T) {
+	db, mock := setupMockDB(t)
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	repo := NewRoleRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `user_roles` WHERE role_id = ?")).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+	count, err := repo.CountUsers(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
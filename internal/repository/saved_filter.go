@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-todo/internal/entity"
+
+	"gorm.io/gorm"
+)
+
+// SavedFilterRepository mendefinisikan operasi database untuk filter tersimpan milik pengguna.
+// Setiap operasi dibatasi pada user_id sehingga filter milik pengguna lain dianggap tidak ada.
+type SavedFilterRepository interface {
+	FindByUserID(ctx context.Context, userID int64) ([]entity.SavedFilter, error)
+	FindByID(ctx context.Context, userID, id int64) (*entity.SavedFilter, error)
+	Create(ctx context.Context, savedFilter *entity.SavedFilter) (*entity.SavedFilter, error)
+	Update(ctx context.Context, savedFilter *entity.SavedFilter) (*entity.SavedFilter, error)
+	Delete(ctx context.Context, userID, id int64) error
+}
+
+var ErrFilterTidakDitemukan = errors.New("filter tidak ditemukan")
+
+type savedFilterRepository struct {
+	db *gorm.DB
+}
+
+// NewSavedFilterRepository inisialisasi SavedFilterRepository baru.
+func NewSavedFilterRepository(db *gorm.DB) SavedFilterRepository {
+	return &savedFilterRepository{db}
+}
+
+// FindByUserID mengambil semua filter tersimpan milik pengguna.
+func (r *savedFilterRepository) FindByUserID(ctx context.Context, userID int64) ([]entity.SavedFilter, error) {
+	filters := make([]entity.SavedFilter, 0)
+	if err := dbFromContext(ctx, r.db).Where("user_id = ?", userID).Order("name, id").Find(&filters).Error; err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return filters, nil
+}
+
+// FindByID mencari filter tersimpan milik pengguna berdasarkan ID.
+func (r *savedFilterRepository) FindByID(ctx context.Context, userID, id int64) (*entity.SavedFilter, error) {
+	savedFilter := new(entity.SavedFilter)
+	if err := dbFromContext(ctx, r.db).Where("id = ? AND user_id = ?", id, userID).First(savedFilter).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrFilterTidakDitemukan
+		}
+		return nil, fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return savedFilter, nil
+}
+
+// Create menambahkan filter tersimpan baru.
+func (r *savedFilterRepository) Create(ctx context.Context, savedFilter *entity.SavedFilter) (*entity.SavedFilter, error) {
+	if err := dbFromContext(ctx, r.db).Create(savedFilter).Error; err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return savedFilter, nil
+}
+
+// Update memperbarui nama dan definisi filter tersimpan.
+func (r *savedFilterRepository) Update(ctx context.Context, savedFilter *entity.SavedFilter) (*entity.SavedFilter, error) {
+	result := dbFromContext(ctx, r.db).Model(savedFilter).
+		Where("user_id = ?", savedFilter.UserID).
+		Select("Name", "Definition", "UpdatedAt").
+		Updates(savedFilter)
+	if result.Error != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDatabaseError, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrFilterTidakDitemukan
+	}
+	return savedFilter, nil
+}
+
+// Delete menghapus filter tersimpan milik pengguna.
+func (r *savedFilterRepository) Delete(ctx context.Context, userID, id int64) error {
+	result := dbFromContext(ctx, r.db).Where("user_id = ?", userID).Delete(&entity.SavedFilter{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("%w: %v", ErrDatabaseError, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrFilterTidakDitemukan
+	}
+	return nil
+}
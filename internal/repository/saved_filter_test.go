@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSavedFilterRepository_FindByID menguji pembacaan definisi filter dari kolom JSONB
+func TestSavedFilterRepository_FindByID(t *testing.T) {
+	db, mock := setupMockDB(t)
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	repo := NewSavedFilterRepository(db)
+
+	rows := sqlmock.NewRows([]string{"id", "user_id", "name", "definition"}).
+		AddRow(3, 5, "Rumah", []byte(`{"field":"tags","op":"contains","value":"home"}`))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `saved_filters` WHERE id = ? AND user_id = ? ORDER BY `saved_filters`.`id` LIMIT ?")).
+		WithArgs(3, 5, 1).
+		WillReturnRows(rows)
+
+	found, err := repo.FindByID(context.Background(), 5, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, "tags", found.Definition.Field)
+	assert.Equal(t, "home", found.Definition.Value)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestSavedFilterRepository_FindByID_NotFound menguji filter milik pengguna lain yang dianggap tidak ada
+func TestSavedFilterRepository_FindByID_NotFound(t *testing.T) {
+	db, mock := setupMockDB(t)
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	repo := NewSavedFilterRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `saved_filters` WHERE id = ? AND user_id = ?")).
+		WithArgs(3, 6, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	_, err := repo.FindByID(context.Background(), 6, 3)
+	assert.ErrorIs(t, err, ErrFilterTidakDitemukan)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestTodoRepository_FindByCondition menguji kondisi filter yang selalu dibatasi pada pemilik todo
+func TestTodoRepository_FindByCondition(t *testing.T) {
+	db, mock := setupMockDB(t)
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	repo := NewTodoRepository(db)
+
+	rows := sqlmock.NewRows([]string{"id", "user_id", "title"}).AddRow(1, 5, "Belanja")
+	mock.ExpectQuery(regexp.QuoteMeta("WHERE user_id = ? AND ((completed = ?) OR (title ILIKE ?)) ORDER BY due_date, id")).
+		WithArgs(5, false, "%belanja%").
+		WillReturnRows(rows)
+
+	todos, err := repo.FindByCondition(context.Background(), 5, "(completed = ?) OR (title ILIKE ?)", false, "%belanja%")
+	assert.NoError(t, err)
+	assert.Len(t, todos, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
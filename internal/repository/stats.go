@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"go-todo/internal/entity"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// StatsRepository mendefinisikan query agregat untuk statistik produktivitas.
+type StatsRepository interface {
+	StatusCounts(ctx context.Context, userID int64, now time.Time) (entity.TodoStatusCounts, error)
+	CompletionBuckets(ctx context.Context, userID int64, bucket string, from, to time.Time, timezone string, weekStart int) ([]entity.CompletionBucket, error)
+	AverageLeadTime(ctx context.Context, userID int64) (float64, error)
+	CompletionDays(ctx context.Context, userID int64, timezone string) ([]time.Time, error)
+}
+
+// dueDateIsSet menyaring todo yang memiliki due date. Todo tanpa due date tersimpan sebagai zero time.
+const dueDateIsSet = "EXTRACT(YEAR FROM due_date) > 1"
+
+type statsRepository struct {
+	db *gorm.DB
+}
+
+// NewStatsRepository inisialisasi StatsRepository baru.
+func NewStatsRepository(db *gorm.DB) StatsRepository {
+	return &statsRepository{db}
+}
+
+// StatusCounts menghitung jumlah todo yang terbuka, selesai, dan melewati due date.
+func (r *statsRepository) StatusCounts(ctx context.Context, userID int64, now time.Time) (entity.TodoStatusCounts, error) {
+	var counts entity.TodoStatusCounts
+	err := dbFromContext(ctx, r.db).
+		Table("todos").
+		Select("COUNT(*) FILTER (WHERE NOT completed) AS open, "+
+			"COUNT(*) FILTER (WHERE completed) AS completed, "+
+			"COUNT(*) FILTER (WHERE NOT completed AND "+dueDateIsSet+" AND due_date < ?) AS overdue", now).
+		Where("user_id = ?", userID).
+		Scan(&counts).Error
+	if err != nil {
+		return entity.TodoStatusCounts{}, fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return counts, nil
+}
+
+// CompletionBuckets menghitung todo yang dibuat dan diselesaikan per periode (day atau week).
+// Batas periode dihitung pada zona waktu pengguna, dan minggu dimulai pada weekStart (0 = Minggu).
+func (r *statsRepository) CompletionBuckets(ctx context.Context, userID int64, bucket string, from, to time.Time, timezone string, weekStart int) ([]entity.CompletionBucket, error) {
+	// date_trunc('week') selalu dimulai hari Senin, sehingga waktu digeser sesuai awal minggu pengguna
+	offset := 0
+	if bucket == "week" {
+		offset = weekStart - 1
+	}
+
+	buckets := make([]entity.CompletionBucket, 0)
+	err := dbFromContext(ctx, r.db).Raw(`
+		SELECT bucket_start, SUM(created) AS created, SUM(completed) AS completed FROM (
+			SELECT (date_trunc(@bucket, (created_at AT TIME ZONE @tz) - make_interval(days => @offset))
+				+ make_interval(days => @offset)) AT TIME ZONE @tz AS bucket_start, 1 AS created, 0 AS completed
+			FROM todos WHERE user_id = @user_id AND created_at >= @from AND created_at < @to
+			UNION ALL
+			SELECT (date_trunc(@bucket, (completed_at AT TIME ZONE @tz) - make_interval(days => @offset))
+				+ make_interval(days => @offset)) AT TIME ZONE @tz AS bucket_start, 0 AS created, 1 AS completed
+			FROM todos WHERE user_id = @user_id AND completed_at >= @from AND completed_at < @to
+		) AS activity
+		GROUP BY bucket_start
+		ORDER BY bucket_start`,
+		map[string]interface{}{
+			"bucket":  bucket,
+			"tz":      timezone,
+			"offset":  offset,
+			"user_id": userID,
+			"from":    from,
+			"to":      to,
+		},
+	).Scan(&buckets).Error
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return buckets, nil
+}
+
+// AverageLeadTime menghitung rata-rata detik dari todo dibuat hingga diselesaikan.
+func (r *statsRepository) AverageLeadTime(ctx context.Context, userID int64) (float64, error) {
+	var seconds float64
+	err := dbFromContext(ctx, r.db).
+		Table("todos").
+		Select("COALESCE(AVG(EXTRACT(EPOCH FROM (completed_at - created_at))), 0)").
+		Where("user_id = ? AND completed_at IS NOT NULL", userID).
+		Scan(&seconds).Error
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return seconds, nil
+}
+
+// CompletionDays mengambil tanggal-tanggal unik (pada zona waktu pengguna) saat pengguna
+// menyelesaikan todo, terbaru lebih dulu.
+func (r *statsRepository) CompletionDays(ctx context.Context, userID int64, timezone string) ([]time.Time, error) {
+	days := make([]time.Time, 0)
+	err := dbFromContext(ctx, r.db).Raw(`
+		SELECT DISTINCT DATE(completed_at AT TIME ZONE ?) AS day
+		FROM todos WHERE user_id = ? AND completed_at IS NOT NULL
+		ORDER BY day DESC`, timezone, userID).
+		Scan(&days).Error
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return days, nil
+}
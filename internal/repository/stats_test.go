@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestStatsRepository_StatusCounts menguji perhitungan jumlah todo per status
+func TestStatsRepository_StatusCounts(t *testing.T) {
+	db, mock := setupMockDB(t)
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	repo := NewStatsRepository(db)
+	now := time.Now()
+
+	rows := sqlmock.NewRows([]string{"open", "completed", "overdue"}).AddRow(4, 6, 2)
+	mock.ExpectQuery(regexp.QuoteMeta("COUNT(*) FILTER (WHERE NOT completed AND "+dueDateIsSet+" AND due_date < ?) AS overdue FROM `todos` WHERE user_id = ?")).
+		WithArgs(now, 5).
+		WillReturnRows(rows)
+
+	counts, err := repo.StatusCounts(context.Background(), 5, now)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), counts.Open)
+	assert.Equal(t, int64(6), counts.Completed)
+	assert.Equal(t, int64(2), counts.Overdue)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestStatsRepository_AverageLeadTime_Error menguji error database saat menghitung lead time
+func TestStatsRepository_AverageLeadTime_Error(t *testing.T) {
+	db, mock := setupMockDB(t)
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	repo := NewStatsRepository(db)
+
+	mock.ExpectQuery("SELECT COALESCE\\(AVG").WillReturnError(errors.New("database error"))
+
+	_, err := repo.AverageLeadTime(context.Background(), 5)
+	assert.ErrorIs(t, err, ErrDatabaseError)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestStatsRepository_CompletionBuckets menguji pengelompokan penyelesaian per periode
+func TestStatsRepository_CompletionBuckets(t *testing.T) {
+	db, mock := setupMockDB(t)
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	repo := NewStatsRepository(db)
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 10, 8, 0, 0, 0, 0, time.UTC)
+
+	rows := sqlmock.NewRows([]string{"bucket_start", "created", "completed"}).
+		AddRow(from, 3, 1).
+		AddRow(from.AddDate(0, 0, 1), 2, 2)
+	mock.ExpectQuery("SELECT bucket_start, SUM\\(created\\)").
+		WithArgs("week", "Europe/Berlin", -1, -1, "Europe/Berlin", 5, from, to,
+			"week", "Europe/Berlin", -1, -1, "Europe/Berlin", 5, from, to).
+		WillReturnRows(rows)
+
+	// Minggu dimulai hari Minggu (0), sehingga waktu digeser satu hari
+	buckets, err := repo.CompletionBuckets(context.Background(), 5, "week", from, to, "Europe/Berlin", 0)
+	assert.NoError(t, err)
+	assert.Len(t, buckets, 2)
+	assert.Equal(t, int64(2), buckets[1].Completed)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-todo/internal/entity"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SyncRepository mendefinisikan query untuk sinkronisasi todo dengan klien offline.
+type SyncRepository interface {
+	ChangedTodos(ctx context.Context, userID, since int64, limit int) ([]entity.Todo, error)
+	Tombstones(ctx context.Context, userID, since int64, limit int) ([]entity.TodoTombstone, error)
+	LockTodo(ctx context.Context, userID, id int64, clientID string) (*entity.Todo, error)
+	FindTombstone(ctx context.Context, userID, id int64, clientID string) (*entity.TodoTombstone, error)
+	// DeleteTombstones menghapus tombstone todo yang dipulihkan dengan ID semula
+	DeleteTombstones(ctx context.Context, todoIDs []int64) error
+}
+
+var (
+	ErrTodoTidakDitemukan      = errors.New("todo tidak ditemukan")
+	ErrTombstoneTidakDitemukan = errors.New("tombstone tidak ditemukan")
+)
+
+type syncRepository struct {
+	db *gorm.DB
+}
+
+// NewSyncRepository inisialisasi SyncRepository baru.
+func NewSyncRepository(db *gorm.DB) SyncRepository {
+	return &syncRepository{db}
+}
+
+// ChangedTodos mengambil todo milik pengguna yang berubah setelah cursor since, terurut berdasarkan change_seq.
+func (r *syncRepository) ChangedTodos(ctx context.Context, userID, since int64, limit int) ([]entity.Todo, error) {
+	todos := make([]entity.Todo, 0)
+	err := dbFromContext(ctx, r.db).Select(todoColumns).
+		Where("user_id = ? AND change_seq > ?", userID, since).
+		Order("change_seq").
+		Limit(limit).
+		Find(&todos).Error
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return todos, nil
+}
+
+// Tombstones mengambil todo milik pengguna yang dihapus setelah cursor since, terurut berdasarkan change_seq.
+func (r *syncRepository) Tombstones(ctx context.Context, userID, since int64, limit int) ([]entity.TodoTombstone, error) {
+	tombstones := make([]entity.TodoTombstone, 0)
+	err := dbFromContext(ctx, r.db).
+		Where("user_id = ? AND change_seq > ?", userID, since).
+		Order("change_seq").
+		Limit(limit).
+		Find(&tombstones).Error
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return tombstones, nil
+}
+
+// LockTodo mengambil todo milik pengguna berdasarkan ID, atau client_id jika ID kosong,
+// dan menguncinya hingga transaksi selesai. Harus dipanggil di dalam transaksi.
+func (r *syncRepository) LockTodo(ctx context.Context, userID, id int64, clientID string) (*entity.Todo, error) {
+	todo := new(entity.Todo)
+	query := dbFromContext(ctx, r.db).Select(todoColumns).
+		Clauses(clause.Locking{Strength: "UPDATE", Table: clause.Table{Name: "todos"}}).
+		Where("user_id = ?", userID)
+	if id != 0 {
+		query = query.Where("id = ?", id)
+	} else {
+		query = query.Where("client_id = ?", clientID)
+	}
+	if err := query.First(todo).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTodoTidakDitemukan
+		}
+		return nil, fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return todo, nil
+}
+
+// FindTombstone mencari tombstone milik pengguna berdasarkan ID todo, atau client_id jika ID kosong.
+func (r *syncRepository) FindTombstone(ctx context.Context, userID, id int64, clientID string) (*entity.TodoTombstone, error) {
+	tombstone := new(entity.TodoTombstone)
+	query := dbFromContext(ctx, r.db).Where("user_id = ?", userID)
+	if id != 0 {
+		query = query.Where("todo_id = ?", id)
+	} else {
+		query = query.Where("client_id = ?", clientID)
+	}
+	if err := query.First(tombstone).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTombstoneTidakDitemukan
+		}
+		return nil, fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return tombstone, nil
+}
+
+// DeleteTombstones menghapus tombstone berdasarkan ID todo. Todo yang dipulihkan dengan ID
+// yang sama tidak lagi dianggap terhapus, dan penghapusan berikutnya dapat mencatat
+// tombstone baru tanpa melanggar primary key todo_id.
+func (r *syncRepository) DeleteTombstones(ctx context.Context, todoIDs []int64) error {
+	if len(todoIDs) == 0 {
+		return nil
+	}
+	if err := dbFromContext(ctx, r.db).Where("todo_id IN ?", todoIDs).Delete(&entity.TodoTombstone{}).Error; err != nil {
+		return fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return nil
+}
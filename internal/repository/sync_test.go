@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"go-todo/internal/entity"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSyncRepository_ChangedTodos menguji pengambilan todo yang berubah setelah cursor
+func TestSyncRepository_ChangedTodos(t *testing.T) {
+	db, mock := setupMockDB(t)
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	repo := NewSyncRepository(db)
+
+	rows := sqlmock.NewRows([]string{"id", "title", "user_id", "version", "change_seq"}).
+		AddRow(1, "Todo", 5, 3, 42)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT "+todoColumns+" FROM `todos` WHERE user_id = ? AND change_seq > ? ORDER BY change_seq LIMIT ?")).
+		WithArgs(5, 40, 101).
+		WillReturnRows(rows)
+
+	todos, err := repo.ChangedTodos(context.Background(), 5, 40, 101)
+	assert.NoError(t, err)
+	assert.Len(t, todos, 1)
+	assert.Equal(t, int64(3), todos[0].Version)
+	assert.Equal(t, int64(42), todos[0].ChangeSeq)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestSyncRepository_LockTodo_ByClientID menguji penguncian todo berdasarkan client_id
+func TestSyncRepository_LockTodo_ByClientID(t *testing.T) {
+	db, mock := setupMockDB(t)
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	repo := NewSyncRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT "+todoColumns+" FROM `todos` WHERE user_id = ? AND client_id = ? ORDER BY `todos`.`id` LIMIT ? FOR UPDATE OF `todos`")).
+		WithArgs(5, "c-1", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	_, err := repo.LockTodo(context.Background(), 5, 0, "c-1")
+	assert.ErrorIs(t, err, ErrTodoTidakDitemukan)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestSyncRepository_FindTombstone menguji pencarian tombstone berdasarkan ID todo
+func TestSyncRepository_FindTombstone(t *testing.T) {
+	db, mock := setupMockDB(t)
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	repo := NewSyncRepository(db)
+
+	rows := sqlmock.NewRows([]string{"todo_id", "user_id", "version", "change_seq"}).AddRow(9, 5, 4, 50)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `todo_tombstones` WHERE user_id = ? AND todo_id = ?")).
+		WithArgs(5, 9, 1).
+		WillReturnRows(rows)
+
+	tombstone, err := repo.FindTombstone(context.Background(), 5, 9, "")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(50), tombstone.ChangeSeq)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestSyncRepository_DeleteTombstones_DeleteUndoDelete menguji urutan hapus, undo, lalu hapus
+// lagi. Tombstone dari penghapusan pertama dibuang saat undo sehingga trigger
+// todos_track_delete dapat mencatat tombstone baru tanpa duplikasi primary key todo_id.
+func TestSyncRepository_DeleteTombstones_DeleteUndoDelete(t *testing.T) {
+	db, mock := setupMockDB(t)
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	todoRepo := NewTodoRepository(db)
+	syncRepo := NewSyncRepository(db)
+	ctx := context.Background()
+	todo := entity.Todo{ID: 9, Title: "Rilis", UserID: 5}
+
+	// Hapus pertama: trigger mencatat tombstone todo 9
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `todos` WHERE id = ?")).
+		WithArgs(9).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	// Undo: tombstone dibuang lalu todo disisipkan kembali dengan ID semula
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `todo_tombstones` WHERE todo_id IN (?)")).
+		WithArgs(9).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `todos`")).
+		WillReturnResult(sqlmock.NewResult(9, 1))
+	mock.ExpectCommit()
+
+	// Hapus kedua berhasil karena tidak ada tombstone lama untuk todo 9
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `todos` WHERE id = ?")).
+		WithArgs(9).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	assert.NoError(t, todoRepo.Delete(ctx, 9))
+	assert.NoError(t, syncRepo.DeleteTombstones(ctx, []int64{9}))
+	_, err := todoRepo.Create(ctx, todo)
+	assert.NoError(t, err)
+	assert.NoError(t, todoRepo.Delete(ctx, 9))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestSyncRepository_DeleteTombstones_Empty menguji daftar ID kosong tanpa query
+func TestSyncRepository_DeleteTombstones_Empty(t *testing.T) {
+	db, mock := setupMockDB(t)
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	repo := NewSyncRepository(db)
+
+	assert.NoError(t, repo.DeleteTombstones(context.Background(), nil))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
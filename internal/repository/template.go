@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-todo/internal/entity"
+
+	"gorm.io/gorm"
+)
+
+// TemplateRepository mendefinisikan operasi database untuk template todo milik pengguna.
+// Setiap operasi dibatasi pada user_id sehingga template milik pengguna lain dianggap tidak ada.
+type TemplateRepository interface {
+	FindByUserID(ctx context.Context, userID int64) ([]entity.TodoTemplate, error)
+	FindByID(ctx context.Context, userID, id int64) (*entity.TodoTemplate, error)
+	Create(ctx context.Context, template *entity.TodoTemplate) (*entity.TodoTemplate, error)
+	Update(ctx context.Context, template *entity.TodoTemplate) (*entity.TodoTemplate, error)
+	Delete(ctx context.Context, userID, id int64) error
+}
+
+var ErrTemplateTidakDitemukan = errors.New("template tidak ditemukan")
+
+type templateRepository struct {
+	db *gorm.DB
+}
+
+// NewTemplateRepository inisialisasi TemplateRepository baru.
+func NewTemplateRepository(db *gorm.DB) TemplateRepository {
+	return &templateRepository{db}
+}
+
+// FindByUserID mengambil semua template todo milik pengguna.
+func (r *templateRepository) FindByUserID(ctx context.Context, userID int64) ([]entity.TodoTemplate, error) {
+	templates := make([]entity.TodoTemplate, 0)
+	if err := dbFromContext(ctx, r.db).Where("user_id = ?", userID).Order("name, id").Find(&templates).Error; err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return templates, nil
+}
+
+// FindByID mencari template todo milik pengguna berdasarkan ID.
+func (r *templateRepository) FindByID(ctx context.Context, userID, id int64) (*entity.TodoTemplate, error) {
+	template := new(entity.TodoTemplate)
+	if err := dbFromContext(ctx, r.db).Where("id = ? AND user_id = ?", id, userID).First(template).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTemplateTidakDitemukan
+		}
+		return nil, fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return template, nil
+}
+
+// Create menambahkan template todo baru.
+func (r *templateRepository) Create(ctx context.Context, template *entity.TodoTemplate) (*entity.TodoTemplate, error) {
+	if err := dbFromContext(ctx, r.db).Create(template).Error; err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return template, nil
+}
+
+// Update memperbarui nama dan definisi template todo.
+func (r *templateRepository) Update(ctx context.Context, template *entity.TodoTemplate) (*entity.TodoTemplate, error) {
+	result := dbFromContext(ctx, r.db).Model(template).
+		Where("user_id = ?", template.UserID).
+		Select("Name", "Description", "Items", "UpdatedAt").
+		Updates(template)
+	if result.Error != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDatabaseError, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrTemplateTidakDitemukan
+	}
+	return template, nil
+}
+
+// Delete menghapus template todo milik pengguna.
+func (r *templateRepository) Delete(ctx context.Context, userID, id int64) error {
+	result := dbFromContext(ctx, r.db).Where("user_id = ?", userID).Delete(&entity.TodoTemplate{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("%w: %v", ErrDatabaseError, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrTemplateTidakDitemukan
+	}
+	return nil
+}
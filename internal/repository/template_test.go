@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestTemplateRepository_FindByUserID menguji pembacaan item template beserta subtask dari kolom JSONB
+func TestTemplateRepository_FindByUserID(t *testing.T) {
+	db, mock := setupMockDB(t)
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	repo := NewTemplateRepository(db)
+
+	rows := sqlmock.NewRows([]string{"id", "user_id", "name", "items"}).
+		AddRow(1, 5, "Rilis", []byte(`[{"title":"Rilis {{version}}","due_offset":"+3d","subtasks":[{"title":"Tag versi"}]}]`))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `todo_templates` WHERE user_id = ? ORDER BY name, id")).
+		WithArgs(5).
+		WillReturnRows(rows)
+
+	templates, err := repo.FindByUserID(context.Background(), 5)
+	assert.NoError(t, err)
+	assert.Len(t, templates, 1)
+	assert.Equal(t, "+3d", templates[0].Items[0].DueOffset)
+	assert.Equal(t, "Tag versi", templates[0].Items[0].Subtasks[0].Title)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-todo/internal/entity"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// TimeEntryRepository mendefinisikan operasi database untuk entitas TimeEntry.
+type TimeEntryRepository interface {
+	FindByID(ctx context.Context, id int64) (*entity.TimeEntry, error)
+	FindByTodoID(ctx context.Context, todoID int64) ([]entity.TimeEntry, error)
+	FindRunningByUserID(ctx context.Context, userID int64) (*entity.TimeEntry, error)
+	Create(ctx context.Context, entry *entity.TimeEntry) (*entity.TimeEntry, error)
+	Update(ctx context.Context, entry *entity.TimeEntry) (*entity.TimeEntry, error)
+	Delete(ctx context.Context, id int64) error
+	Report(ctx context.Context, filter entity.TimeReportFilter) ([]entity.TimeReportRow, error)
+}
+
+var (
+	ErrTimeEntryTidakDitemukan = errors.New("catatan waktu tidak ditemukan")
+	// ErrTimerMasihBerjalan dikembalikan ketika pengguna sudah memiliki timer yang berjalan
+	ErrTimerMasihBerjalan = errors.New("pengguna masih memiliki timer yang berjalan")
+)
+
+// runningTimerConstraint adalah partial unique index yang membatasi satu timer berjalan per pengguna
+const runningTimerConstraint = "uq_time_entries_running_per_user"
+
+type timeEntryRepository struct {
+	db *gorm.DB
+}
+
+// NewTimeEntryRepository inisialisasi TimeEntryRepository baru.
+func NewTimeEntryRepository(db *gorm.DB) TimeEntryRepository {
+	return &timeEntryRepository{db}
+}
+
+// FindByID mencari catatan waktu berdasarkan ID.
+func (r *timeEntryRepository) FindByID(ctx context.Context, id int64) (*entity.TimeEntry, error) {
+	entry := new(entity.TimeEntry)
+	if err := dbFromContext(ctx, r.db).Where("id = ?", id).First(entry).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTimeEntryTidakDitemukan
+		}
+		return nil, fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return entry, nil
+}
+
+// FindByTodoID mengambil semua catatan waktu milik sebuah todo.
+func (r *timeEntryRepository) FindByTodoID(ctx context.Context, todoID int64) ([]entity.TimeEntry, error) {
+	entries := make([]entity.TimeEntry, 0)
+	if err := dbFromContext(ctx, r.db).Where("todo_id = ?", todoID).Order("started_at").Find(&entries).Error; err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return entries, nil
+}
+
+// FindRunningByUserID mencari timer yang masih berjalan milik pengguna.
+func (r *timeEntryRepository) FindRunningByUserID(ctx context.Context, userID int64) (*entity.TimeEntry, error) {
+	entry := new(entity.TimeEntry)
+	if err := dbFromContext(ctx, r.db).Where("user_id = ? AND ended_at IS NULL", userID).First(entry).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTimeEntryTidakDitemukan
+		}
+		return nil, fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return entry, nil
+}
+
+// Create menambahkan catatan waktu baru ke database. Timer kedua yang dimulai bersamaan
+// ditolak oleh unique index dan dikembalikan sebagai ErrTimerMasihBerjalan.
+func (r *timeEntryRepository) Create(ctx context.Context, entry *entity.TimeEntry) (*entity.TimeEntry, error) {
+	if err := dbFromContext(ctx, r.db).Create(entry).Error; err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == runningTimerConstraint {
+			return nil, ErrTimerMasihBerjalan
+		}
+		return nil, fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return entry, nil
+}
+
+// Update memperbarui catatan waktu yang ada.
+func (r *timeEntryRepository) Update(ctx context.Context, entry *entity.TimeEntry) (*entity.TimeEntry, error) {
+	result := dbFromContext(ctx, r.db).Model(entry).
+		Select("StartedAt", "EndedAt", "DurationSeconds", "Note").
+		Updates(entry)
+	if result.Error != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDatabaseError, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrTimeEntryTidakDitemukan
+	}
+	return entry, nil
+}
+
+// Delete menghapus catatan waktu berdasarkan ID.
+func (r *timeEntryRepository) Delete(ctx context.Context, id int64) error {
+	result := dbFromContext(ctx, r.db).Where("id = ?", id).Delete(&entity.TimeEntry{})
+	if result.Error != nil {
+		return fmt.Errorf("%w: %v", ErrDatabaseError, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrTimeEntryTidakDitemukan
+	}
+	return nil
+}
+
+// Report menjumlahkan waktu yang tercatat per pengguna dan per project.
+// Timer yang masih berjalan tidak ikut dihitung.
+func (r *timeEntryRepository) Report(ctx context.Context, filter entity.TimeReportFilter) ([]entity.TimeReportRow, error) {
+	query := dbFromContext(ctx, r.db).
+		Table("time_entries AS te").
+		Select("te.user_id, u.username, COALESCE(t.project, '') AS project, " +
+			"COUNT(te.id) AS entry_count, SUM(te.duration_seconds) AS total_seconds").
+		Joins("JOIN todos t ON t.id = te.todo_id").
+		Joins("JOIN users u ON u.id = te.user_id").
+		Where("te.ended_at IS NOT NULL")
+
+	if filter.UserID > 0 {
+		query = query.Where("te.user_id = ?", filter.UserID)
+	}
+	if filter.Project != "" {
+		query = query.Where("t.project = ?", filter.Project)
+	}
+	if !filter.From.IsZero() {
+		query = query.Where("te.started_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("te.started_at < ?", filter.To)
+	}
+
+	rows := make([]entity.TimeReportRow, 0)
+	if err := query.Group("te.user_id, u.username, t.project").
+		Order("u.username, project").
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return rows, nil
+}
@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"go-todo/internal/entity"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestTimeEntryRepository_FindRunningByUserID menguji pencarian timer yang sedang berjalan
+func TestTimeEntryRepository_FindRunningByUserID(t *testing.T) {
+	db, mock := setupMockDB(t)
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	repo := NewTimeEntryRepository(db)
+
+	rows := sqlmock.NewRows([]string{"id", "todo_id", "user_id"}).AddRow(1, 10, 5)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `time_entries` WHERE user_id = ? AND ended_at IS NULL ORDER BY `time_entries`.`id` LIMIT ?")).
+		WithArgs(5, 1).
+		WillReturnRows(rows)
+
+	entry, err := repo.FindRunningByUserID(context.Background(), 5)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10), entry.TodoID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestTimeEntryRepository_FindRunningByUserID_NotFound menguji kondisi tidak ada timer yang berjalan
+func TestTimeEntryRepository_FindRunningByUserID_NotFound(t *testing.T) {
+	db, mock := setupMockDB(t)
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	repo := NewTimeEntryRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `time_entries` WHERE user_id = ? AND ended_at IS NULL")).
+		WithArgs(5, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	_, err := repo.FindRunningByUserID(context.Background(), 5)
+	assert.ErrorIs(t, err, ErrTimeEntryTidakDitemukan)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestTimeEntryRepository_Create menguji penambahan catatan waktu
+func TestTimeEntryRepository_Create(t *testing.T) {
+	db, mock := setupMockDB(t)
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	repo := NewTimeEntryRepository(db)
+
+	entry := &entity.TimeEntry{TodoID: 10, UserID: 5, StartedAt: time.Now(), Note: "coding"}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `time_entries` (`todo_id`,`user_id`,`started_at`,`ended_at`,`duration_seconds`,`note`) VALUES (?,?,?,?,?,?)")).
+		WithArgs(entry.TodoID, entry.UserID, entry.StartedAt, nil, int64(0), entry.Note).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	createdEntry, err := repo.Create(context.Background(), entry)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), createdEntry.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestTimeEntryRepository_Create_RunningTimerConflict menguji timer kedua yang ditolak unique index
+func TestTimeEntryRepository_Create_RunningTimerConflict(t *testing.T) {
+	db, mock := setupMockDB(t)
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	repo := NewTimeEntryRepository(db)
+
+	entry := &entity.TimeEntry{TodoID: 10, UserID: 5, StartedAt: time.Now()}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `time_entries`")).
+		WillReturnError(&pgconn.PgError{Code: "23505", ConstraintName: "uq_time_entries_running_per_user"})
+	mock.ExpectRollback()
+
+	_, err := repo.Create(context.Background(), entry)
+	assert.ErrorIs(t, err, ErrTimerMasihBerjalan)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestTimeEntryRepository_Delete_NotFound menguji penghapusan catatan waktu yang tidak ada
+func TestTimeEntryRepository_Delete_NotFound(t *testing.T) {
+	db, mock := setupMockDB(t)
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	repo := NewTimeEntryRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `time_entries` WHERE id = ?")).
+		WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	err := repo.Delete(context.Background(), 1)
+	assert.ErrorIs(t, err, ErrTimeEntryTidakDitemukan)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestTimeEntryRepository_Report menguji laporan waktu dengan filter
+func TestTimeEntryRepository_Report(t *testing.T) {
+	db, mock := setupMockDB(t)
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	repo := NewTimeEntryRepository(db)
+
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+
+	rows := sqlmock.NewRows([]string{"user_id", "username", "project", "entry_count", "total_seconds"}).
+		AddRow(5, "budi", "acme", 3, 5400)
+
+	mock.ExpectQuery(regexp.QuoteMeta("FROM time_entries AS te JOIN todos t ON t.id = te.todo_id JOIN users u ON u.id = te.user_id "+
+		"WHERE te.ended_at IS NOT NULL AND te.user_id = ? AND t.project = ? AND te.started_at >= ? AND te.started_at < ? "+
+		"GROUP BY te.user_id, u.username, t.project")).
+		WithArgs(5, "acme", from, to).
+		WillReturnRows(rows)
+
+	result, err := repo.Report(context.Background(), entity.TimeReportFilter{UserID: 5, Project: "acme", From: from, To: to})
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, int64(5400), result[0].TotalSeconds)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestTimeEntryRepository_Report_Error menguji laporan waktu ketika terjadi error database
+func TestTimeEntryRepository_Report_Error(t *testing.T) {
+	db, mock := setupMockDB(t)
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	repo := NewTimeEntryRepository(db)
+
+	mock.ExpectQuery("SELECT (.+) FROM time_entries").WillReturnError(errors.New("database error"))
+
+	_, err := repo.Report(context.Background(), entity.TimeReportFilter{})
+	assert.ErrorIs(t, err, ErrDatabaseError)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -2,20 +2,35 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"go-todo/internal/entity"
+	"go-todo/pkg/filter"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// ErrKondisiFieldKustomTidakValid dikembalikan ketika operator atau tipe field kustom tidak dikenal.
+var ErrKondisiFieldKustomTidakValid = errors.New("kondisi field kustom tidak valid")
+
 // TodoRepository mendefinisikan operasi CRUD untuk entity Todo.
 type TodoRepository interface {
 	FindAll(ctx context.Context) ([]entity.Todo, error)
 	FindByID(ctx context.Context, id int64) (*entity.Todo, error)
+	FindByCondition(ctx context.Context, userID int64, condition string, args ...interface{}) ([]entity.Todo, error)
+	FindByAssigneeID(ctx context.Context, assigneeID int64) ([]entity.Todo, error)
+	FindByCustomFields(ctx context.Context, userID int64, conditions []entity.CustomFieldCondition, sort *entity.CustomFieldSort) ([]entity.Todo, error)
 	Create(ctx context.Context, todo entity.Todo) (entity.Todo, error)
 	Update(ctx context.Context, todo entity.Todo) (entity.Todo, error)
+	UpdateAssignee(ctx context.Context, id int64, assigneeID *int64) error
 	Delete(ctx context.Context, id int64) error
 }
 
+// todoColumns memilih seluruh kolom todo beserta total waktu yang tercatat dari time_entries.
+const todoColumns = "todos.*, COALESCE((SELECT SUM(te.duration_seconds) FROM time_entries te " +
+	"WHERE te.todo_id = todos.id AND te.ended_at IS NOT NULL), 0) AS tracked_seconds"
+
 type todoRepository struct {
 	db *gorm.DB
 }
@@ -28,7 +43,7 @@ func NewTodoRepository(db *gorm.DB) TodoRepository {
 // FindAll mengambil semua todo dari database.
 func (r *todoRepository) FindAll(ctx context.Context) ([]entity.Todo, error) {
 	var todos []entity.Todo
-	if err := r.db.WithContext(ctx).Find(&todos).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Select(todoColumns).Find(&todos).Error; err != nil {
 		return nil, err
 	}
 	return todos, nil
@@ -37,15 +52,121 @@ func (r *todoRepository) FindAll(ctx context.Context) ([]entity.Todo, error) {
 // FindByID mengambil satu todo berdasarkan ID dari database.
 func (r *todoRepository) FindByID(ctx context.Context, id int64) (*entity.Todo, error) {
 	todo := new(entity.Todo)
-	if err := r.db.WithContext(ctx).Where("id = ?", id).First(todo).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Select(todoColumns).Where("id = ?", id).First(todo).Error; err != nil {
 		return nil, err
 	}
 	return todo, nil
 }
 
+// FindByCondition mengambil todo milik pengguna yang memenuhi kondisi WHERE berparameter,
+// misalnya hasil kompilasi filter tersimpan. Kondisi tidak boleh berisi nilai dari pengguna.
+func (r *todoRepository) FindByCondition(ctx context.Context, userID int64, condition string, args ...interface{}) ([]entity.Todo, error) {
+	todos := make([]entity.Todo, 0)
+	err := dbFromContext(ctx, r.db).Select(todoColumns).
+		Where("user_id = ?", userID).
+		Where(condition, args...).
+		Order("due_date, id").
+		Find(&todos).Error
+	if err != nil {
+		return nil, err
+	}
+	return todos, nil
+}
+
+// FindByAssigneeID mengambil todo yang ditugaskan kepada pengguna, termasuk milik pengguna lain.
+func (r *todoRepository) FindByAssigneeID(ctx context.Context, assigneeID int64) ([]entity.Todo, error) {
+	todos := make([]entity.Todo, 0)
+	err := dbFromContext(ctx, r.db).Select(todoColumns).
+		Where("assignee_id = ?", assigneeID).
+		Order("completed, due_date, id").
+		Find(&todos).Error
+	if err != nil {
+		return nil, err
+	}
+	return todos, nil
+}
+
+// customFieldOperators memetakan operator pencarian field kustom ke operator SQL.
+var customFieldOperators = map[string]string{
+	"eq":  "=",
+	"neq": "<>",
+	"lt":  "<",
+	"lte": "<=",
+	"gt":  ">",
+	"gte": ">=",
+}
+
+// customFieldExpr mengembalikan ekspresi SQL untuk membaca nilai field kustom sesuai tipenya.
+// Key selalu dikirim sebagai parameter sehingga aman dari injeksi.
+func customFieldExpr(fieldType string) (string, error) {
+	switch fieldType {
+	case entity.CustomFieldText, entity.CustomFieldSelect:
+		return "(custom_fields->>?)", nil
+	case entity.CustomFieldNumber:
+		return "(custom_fields->>?)::numeric", nil
+	case entity.CustomFieldDate:
+		return "(custom_fields->>?)::date", nil
+	case entity.CustomFieldCheckbox:
+		return "(custom_fields->>?)::boolean", nil
+	}
+	return "", fmt.Errorf("%w: tipe %q", ErrKondisiFieldKustomTidakValid, fieldType)
+}
+
+// FindByCustomFields mengambil todo milik pengguna yang memenuhi seluruh kondisi field kustom,
+// diurutkan berdasarkan field kustom jika sort tidak nil. Todo tanpa nilai diletakkan di akhir.
+func (r *todoRepository) FindByCustomFields(ctx context.Context, userID int64, conditions []entity.CustomFieldCondition, sort *entity.CustomFieldSort) ([]entity.Todo, error) {
+	query := dbFromContext(ctx, r.db).Select(todoColumns).Where("user_id = ?", userID)
+	for _, cond := range conditions {
+		expr, err := customFieldExpr(cond.Type)
+		if err != nil {
+			return nil, err
+		}
+		switch cond.Op {
+		case "exists":
+			query = query.Where("custom_fields->>? IS NOT NULL", cond.Key)
+		case "missing":
+			query = query.Where("custom_fields->>? IS NULL", cond.Key)
+		case "contains":
+			if cond.Type != entity.CustomFieldText {
+				return nil, fmt.Errorf("%w: operator contains hanya untuk text", ErrKondisiFieldKustomTidakValid)
+			}
+			query = query.Where(expr+` ILIKE ? ESCAPE '\'`, cond.Key, "%"+filter.EscapeLike(fmt.Sprint(cond.Value))+"%")
+		default:
+			op, ok := customFieldOperators[cond.Op]
+			if !ok {
+				return nil, fmt.Errorf("%w: operator %q", ErrKondisiFieldKustomTidakValid, cond.Op)
+			}
+			query = query.Where(expr+" "+op+" ?", cond.Key, cond.Value)
+		}
+	}
+	if sort != nil {
+		expr, err := customFieldExpr(sort.Type)
+		if err != nil {
+			return nil, err
+		}
+		direction := "ASC"
+		if sort.Desc {
+			direction = "DESC"
+		}
+		query = query.Clauses(clause.OrderBy{Expression: clause.Expr{
+			SQL:                expr + " " + direction + " NULLS LAST, id",
+			Vars:               []interface{}{sort.Key},
+			WithoutParentheses: true,
+		}})
+	} else {
+		query = query.Order("due_date, id")
+	}
+
+	todos := make([]entity.Todo, 0)
+	if err := query.Find(&todos).Error; err != nil {
+		return nil, err
+	}
+	return todos, nil
+}
+
 // Create menambahkan todo baru ke dalam database.
 func (r *todoRepository) Create(ctx context.Context, todo entity.Todo) (entity.Todo, error) {
-	if err := r.db.WithContext(ctx).Create(&todo).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Create(&todo).Error; err != nil {
 		return entity.Todo{}, err
 	}
 	return todo, nil
@@ -54,17 +175,25 @@ func (r *todoRepository) Create(ctx context.Context, todo entity.Todo) (entity.T
 // Update memperbarui todo yang ada di database.
 func (r *todoRepository) Update(ctx context.Context, todo entity.Todo) (entity.Todo, error) {
 	// Menghapus kondisi `Where` yang eksplisit
-	if err := r.db.WithContext(ctx).Model(&todo).
-		Select("Title", "Content", "DueDate", "Completed", "UserID").
+	if err := dbFromContext(ctx, r.db).Model(&todo).
+		Select("Title", "Content", "DueDate", "Completed", "UserID", "Project", "Tags", "CustomFields", "Priority", "CompletedAt").
 		Updates(todo).Error; err != nil {
 		return entity.Todo{}, err
 	}
 	return todo, nil
 }
 
+// UpdateAssignee mengganti penanggung jawab todo. Nilai nil menghapus penanggung jawab.
+func (r *todoRepository) UpdateAssignee(ctx context.Context, id int64, assigneeID *int64) error {
+	if err := dbFromContext(ctx, r.db).Model(&entity.Todo{ID: id}).Update("assignee_id", assigneeID).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
 // Delete menghapus todo berdasarkan ID dari database.
 func (r *todoRepository) Delete(ctx context.Context, id int64) error {
-	if err := r.db.WithContext(ctx).Where("id = ?", id).Delete(&entity.Todo{}).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Where("id = ?", id).Delete(&entity.Todo{}).Error; err != nil {
 		return err
 	}
 	return nil
@@ -45,7 +45,7 @@ func TestTodoRepository_FindAll(t *testing.T) {
 		AddRow(1, "Test Todo 1", "Content 1").
 		AddRow(2, "Test Todo 2", "Content 2")
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `todos`")).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT " + todoColumns + " FROM `todos`")).
 		WillReturnRows(rows)
 
 	todos, err := repo.FindAll(context.Background())
@@ -68,7 +68,7 @@ func TestTodoRepository_FindByID(t *testing.T) {
 	row := sqlmock.NewRows([]string{"id", "title", "content"}).
 		AddRow(1, "Test Todo", "Content")
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `todos` WHERE id = ? ORDER BY `todos`.`id` LIMIT ?")).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT "+todoColumns+" FROM `todos` WHERE id = ? ORDER BY `todos`.`id` LIMIT ?")).
 		WithArgs(1, 1).
 		WillReturnRows(row)
 
@@ -98,8 +98,8 @@ func TestTodoRepository_Create(t *testing.T) {
 	}
 
 	mock.ExpectBegin()
-	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `todos` (`title`,`content`,`due_date`,`completed`,`user_id`) VALUES (?,?,?,?,?)")).
-		WithArgs(todo.Title, todo.Content, todo.DueDate, todo.Completed, todo.UserID).
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `todos` (`title`,`content`,`due_date`,`completed`,`user_id`,`parent_id`,`project`,`tags`,`custom_fields`,`priority`,`created_at`,`completed_at`,`client_id`) VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?)")).
+		WithArgs(todo.Title, todo.Content, todo.DueDate, todo.Completed, todo.UserID, todo.ParentID, todo.Project, todo.Tags, todo.CustomFields, todo.Priority, sqlmock.AnyArg(), todo.CompletedAt, todo.ClientID).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
@@ -118,7 +118,7 @@ func TestTodoRepository_FindAll_Error(t *testing.T) {
 	repo := NewTodoRepository(db)
 
 	// Simulasi error saat query `FindAll`
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `todos`")).WillReturnError(errors.New("database error"))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT " + todoColumns + " FROM `todos`")).WillReturnError(errors.New("database error"))
 
 	_, err := repo.FindAll(context.Background())
 	assert.Error(t, err)
@@ -136,7 +136,7 @@ func TestTodoRepository_FindByID_Error(t *testing.T) {
 	repo := NewTodoRepository(db)
 
 	// Simulasi error saat query `FindByID`
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `todos` WHERE id = ? ORDER BY `todos`.`id` LIMIT ?")).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT "+todoColumns+" FROM `todos` WHERE id = ? ORDER BY `todos`.`id` LIMIT ?")).
 		WithArgs(1, 1).
 		WillReturnError(errors.New("database error"))
 
@@ -164,8 +164,8 @@ func TestTodoRepository_Create_Error(t *testing.T) {
 
 	// Simulasi error saat `Create`
 	mock.ExpectBegin()
-	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `todos` (`title`,`content`,`due_date`,`completed`,`user_id`) VALUES (?,?,?,?,?)")).
-		WithArgs(todo.Title, todo.Content, todo.DueDate, todo.Completed, todo.UserID).
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `todos` (`title`,`content`,`due_date`,`completed`,`user_id`,`parent_id`,`project`,`tags`,`custom_fields`,`priority`,`created_at`,`completed_at`,`client_id`) VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?)")).
+		WithArgs(todo.Title, todo.Content, todo.DueDate, todo.Completed, todo.UserID, todo.ParentID, todo.Project, todo.Tags, todo.CustomFields, todo.Priority, sqlmock.AnyArg(), todo.CompletedAt, todo.ClientID).
 		WillReturnError(errors.New("insert error"))
 	mock.ExpectRollback()
 
@@ -194,8 +194,8 @@ func TestTodoRepository_Update(t *testing.T) {
 	}
 
 	mock.ExpectBegin()
-	mock.ExpectExec(regexp.QuoteMeta("UPDATE `todos` SET `title`=?,`content`=?,`due_date`=?,`completed`=?,`user_id`=? WHERE `id` = ?")).
-		WithArgs(todo.Title, todo.Content, todo.DueDate, todo.Completed, todo.UserID, todo.ID).
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `todos` SET `title`=?,`content`=?,`due_date`=?,`completed`=?,`user_id`=?,`project`=?,`tags`=?,`custom_fields`=?,`priority`=?,`completed_at`=? WHERE `id` = ?")).
+		WithArgs(todo.Title, todo.Content, todo.DueDate, todo.Completed, todo.UserID, todo.Project, todo.Tags, todo.CustomFields, todo.Priority, todo.CompletedAt, todo.ID).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
@@ -225,8 +225,8 @@ func TestTodoRepository_Update_Error(t *testing.T) {
 
 	// Simulate an error during the `Update` operation
 	mock.ExpectBegin()
-	mock.ExpectExec(regexp.QuoteMeta("UPDATE `todos` SET `title`=?,`content`=?,`due_date`=?,`completed`=?,`user_id`=? WHERE `id` = ?")).
-		WithArgs(todo.Title, todo.Content, todo.DueDate, todo.Completed, todo.UserID, todo.ID).
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `todos` SET `title`=?,`content`=?,`due_date`=?,`completed`=?,`user_id`=?,`project`=?,`tags`=?,`custom_fields`=?,`priority`=?,`completed_at`=? WHERE `id` = ?")).
+		WithArgs(todo.Title, todo.Content, todo.DueDate, todo.Completed, todo.UserID, todo.Project, todo.Tags, todo.CustomFields, todo.Priority, todo.CompletedAt, todo.ID).
 		WillReturnError(errors.New("update error"))
 	mock.ExpectRollback()
 
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// Transactor menjalankan beberapa operasi repository dalam satu transaksi database.
+// Repository yang dipanggil dengan ctx dari fn otomatis menggunakan transaksi tersebut.
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// txKey adalah kunci context untuk menyimpan transaksi yang sedang berjalan
+type txKey struct{}
+
+type transactor struct {
+	db *gorm.DB
+}
+
+// NewTransactor inisialisasi Transactor baru.
+func NewTransactor(db *gorm.DB) Transactor {
+	return &transactor{db}
+}
+
+// WithinTransaction menjalankan fn dalam transaksi. Transaksi di-commit jika fn tidak
+// mengembalikan error dan di-rollback jika sebaliknya. Pemanggilan bersarang
+// bergabung dengan transaksi yang sudah ada.
+func (t *transactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return fn(ctx)
+	}
+	return t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// dbFromContext mengembalikan transaksi dari ctx jika ada, atau koneksi db biasa
+func dbFromContext(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"go-todo/internal/entity"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -14,9 +15,11 @@ type UserRepository interface {
 	FindAll(ctx context.Context) ([]entity.User, error)                        
 	FindByID(ctx context.Context, id int64) (*entity.User, error)              
 	FindByUsername(ctx context.Context, username string) (*entity.User, error) 
+	FindByEmail(ctx context.Context, email string) (*entity.User, error)
 	Create(ctx context.Context, user *entity.User) (*entity.User, error)       
 	Update(ctx context.Context, user *entity.User) (*entity.User, error)       
 	Delete(ctx context.Context, id int64) error                                
+	SetEmailVerifiedAt(ctx context.Context, id int64, verifiedAt *time.Time) error
 }
 
 var (
@@ -37,7 +40,7 @@ func NewUserRepository(db *gorm.DB) UserRepository {
 // FindAll mengambil semua pengguna dari database.
 func (r *userRepository) FindAll(ctx context.Context) ([]entity.User, error) {
 	users := make([]entity.User, 0)
-	if err := r.db.WithContext(ctx).Find(&users).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Find(&users).Error; err != nil {
 		return nil, fmt.Errorf("%w: %v", ErrDatabaseError, err)
 	}
 	return users, nil
@@ -46,7 +49,7 @@ func (r *userRepository) FindAll(ctx context.Context) ([]entity.User, error) {
 // FindByID mencari pengguna berdasarkan ID.
 func (r *userRepository) FindByID(ctx context.Context, id int64) (*entity.User, error) {
 	user := new(entity.User)
-	if err := r.db.WithContext(ctx).Where("id = ?", id).First(user).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Where("id = ?", id).First(user).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, ErrPenggunaTidakDitemukan
 		}
@@ -58,7 +61,19 @@ func (r *userRepository) FindByID(ctx context.Context, id int64) (*entity.User,
 // FindByUsername mencari pengguna berdasarkan username.
 func (r *userRepository) FindByUsername(ctx context.Context, username string) (*entity.User, error) {
 	user := new(entity.User)
-	if err := r.db.WithContext(ctx).Where("username = ?", username).First(user).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Where("username = ?", username).First(user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPenggunaTidakDitemukan
+		}
+		return nil, fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return user, nil
+}
+
+// FindByEmail mencari pengguna berdasarkan email yang sudah dinormalisasi ke huruf kecil.
+func (r *userRepository) FindByEmail(ctx context.Context, email string) (*entity.User, error) {
+	user := new(entity.User)
+	if err := dbFromContext(ctx, r.db).Where("email = ?", email).First(user).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, ErrPenggunaTidakDitemukan
 		}
@@ -69,7 +84,7 @@ func (r *userRepository) FindByUsername(ctx context.Context, username string) (*
 
 // Create menambahkan pengguna baru ke database.
 func (r *userRepository) Create(ctx context.Context, user *entity.User) (*entity.User, error) {
-	if err := r.db.WithContext(ctx).Create(user).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Create(user).Error; err != nil {
 		return nil, fmt.Errorf("%w: %v", ErrDatabaseError, err)
 	}
 	return user, nil
@@ -81,8 +96,8 @@ func (r *userRepository) Update(ctx context.Context, user *entity.User) (*entity
 	updates := map[string]interface{}{
 		"username": user.Username,
 		"full_name": user.FullName,
-		"role":      user.Role,
 		"password":  user.Password,
+		"email":     user.Email,
 	}
 
 	// Hapus field yang kosong atau nil
@@ -93,7 +108,7 @@ func (r *userRepository) Update(ctx context.Context, user *entity.User) (*entity
 	}
 
 	// Lakukan update
-	result := r.db.WithContext(ctx).
+	result := dbFromContext(ctx, r.db).
 		Model(&entity.User{}).
 		Where("id = ?", user.ID).
 		Updates(updates)
@@ -117,7 +132,20 @@ func (r *userRepository) Update(ctx context.Context, user *entity.User) (*entity
 
 // Delete menghapus pengguna berdasarkan ID.
 func (r *userRepository) Delete(ctx context.Context, id int64) error {
-	result := r.db.WithContext(ctx).Where("id = ?", id).Delete(&entity.User{})
+	result := dbFromContext(ctx, r.db).Where("id = ?", id).Delete(&entity.User{})
+	if result.Error != nil {
+		return fmt.Errorf("%w: %v", ErrDatabaseError, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrPenggunaTidakDitemukan
+	}
+	return nil
+}
+
+// SetEmailVerifiedAt mengisi waktu verifikasi email, atau mengosongkannya dengan nil
+// ketika email pengguna berganti.
+func (r *userRepository) SetEmailVerifiedAt(ctx context.Context, id int64, verifiedAt *time.Time) error {
+	result := dbFromContext(ctx, r.db).Model(&entity.User{}).Where("id = ?", id).Update("email_verified_at", verifiedAt)
 	if result.Error != nil {
 		return fmt.Errorf("%w: %v", ErrDatabaseError, result.Error)
 	}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-todo/internal/entity"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// UserPreferenceRepository mendefinisikan operasi database untuk preferensi pengguna.
+type UserPreferenceRepository interface {
+	FindByUserID(ctx context.Context, userID int64) (*entity.UserPreference, error)
+	Upsert(ctx context.Context, preference *entity.UserPreference) (*entity.UserPreference, error)
+}
+
+var ErrPreferensiTidakDitemukan = errors.New("preferensi tidak ditemukan")
+
+type userPreferenceRepository struct {
+	db *gorm.DB
+}
+
+// NewUserPreferenceRepository inisialisasi UserPreferenceRepository baru.
+func NewUserPreferenceRepository(db *gorm.DB) UserPreferenceRepository {
+	return &userPreferenceRepository{db}
+}
+
+// FindByUserID mencari preferensi milik pengguna.
+func (r *userPreferenceRepository) FindByUserID(ctx context.Context, userID int64) (*entity.UserPreference, error) {
+	preference := new(entity.UserPreference)
+	if err := dbFromContext(ctx, r.db).Where("user_id = ?", userID).First(preference).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPreferensiTidakDitemukan
+		}
+		return nil, fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return preference, nil
+}
+
+// Upsert menyimpan preferensi baru atau memperbarui yang sudah ada.
+func (r *userPreferenceRepository) Upsert(ctx context.Context, preference *entity.UserPreference) (*entity.UserPreference, error) {
+	err := dbFromContext(ctx, r.db).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"timezone", "locale", "week_start", "date_format"}),
+	}).Create(preference).Error
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return preference, nil
+}
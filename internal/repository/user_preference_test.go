@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"go-todo/internal/entity"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestUserPreferenceRepository_FindByUserID_NotFound menguji pengguna yang belum menyimpan preferensi
+func TestUserPreferenceRepository_FindByUserID_NotFound(t *testing.T) {
+	db, mock := setupMockDB(t)
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	repo := NewUserPreferenceRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `user_preferences` WHERE user_id = ?")).
+		WithArgs(5, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}))
+
+	_, err := repo.FindByUserID(context.Background(), 5)
+	assert.ErrorIs(t, err, ErrPreferensiTidakDitemukan)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestUserPreferenceRepository_Upsert menguji penyimpanan preferensi dengan upsert
+func TestUserPreferenceRepository_Upsert(t *testing.T) {
+	db, mock := setupMockDB(t)
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	repo := NewUserPreferenceRepository(db)
+	preference := &entity.UserPreference{UserID: 5, Timezone: "Europe/Berlin", Locale: "de-DE", WeekStart: 1, DateFormat: "DD.MM.YYYY"}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `user_preferences`")+".*ON DUPLICATE KEY UPDATE").
+		WithArgs(5, "Europe/Berlin", "de-DE", 1, "DD.MM.YYYY").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	saved, err := repo.Upsert(context.Background(), preference)
+	assert.NoError(t, err)
+	assert.Equal(t, "Europe/Berlin", saved.Timezone)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
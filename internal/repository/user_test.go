@@ -6,6 +6,7 @@ import (
 	"go-todo/internal/entity"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
@@ -20,9 +21,9 @@ func TestUserRepository_FindAll(t *testing.T) {
 
 	repo := NewUserRepository(db)
 
-	rows := sqlmock.NewRows([]string{"id", "username", "full_name", "role"}).
-		AddRow(1, "user1", "User One", "admin").
-		AddRow(2, "user2", "User Two", "user")
+	rows := sqlmock.NewRows([]string{"id", "username", "full_name"}).
+		AddRow(1, "user1", "User One").
+		AddRow(2, "user2", "User Two")
 
 	mock.ExpectQuery("SELECT \\* FROM `users`").WillReturnRows(rows)
 
@@ -40,8 +41,8 @@ func TestUserRepository_FindByID(t *testing.T) {
 
 	repo := NewUserRepository(db)
 
-	row := sqlmock.NewRows([]string{"id", "username", "full_name", "role"}).
-		AddRow(1, "user1", "User One", "admin")
+	row := sqlmock.NewRows([]string{"id", "username", "full_name"}).
+		AddRow(1, "user1", "User One")
 
 	// Gunakan regexp.QuoteMeta untuk menghindari masalah escaping pada regex
 	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE id = ? ORDER BY `users`.`id` LIMIT ?")).
@@ -80,8 +81,8 @@ func TestUserRepository_FindByUsername(t *testing.T) {
 
 	repo := NewUserRepository(db)
 
-	row := sqlmock.NewRows([]string{"id", "username", "full_name", "role"}).
-		AddRow(1, "user1", "User One", "admin")
+	row := sqlmock.NewRows([]string{"id", "username", "full_name"}).
+		AddRow(1, "user1", "User One")
 
 	// Gunakan regexp.QuoteMeta dan tambahkan dua argumen pada WithArgs
 	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE username = ? ORDER BY `users`.`id` LIMIT ?")).
@@ -112,6 +113,69 @@ func TestUserRepository_FindByUsername_NotFound(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+// TestUserRepository_FindByEmail menguji fungsi FindByEmail pada UserRepository
+func TestUserRepository_FindByEmail(t *testing.T) {
+	db, mock := setupMockDB(t)
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	repo := NewUserRepository(db)
+
+	row := sqlmock.NewRows([]string{"id", "username", "email"}).
+		AddRow(1, "user1", "user1@example.com")
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE email = ? ORDER BY `users`.`id` LIMIT ?")).
+		WithArgs("user1@example.com", 1).
+		WillReturnRows(row)
+
+	user, err := repo.FindByEmail(context.Background(), "user1@example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, "user1", user.Username)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestUserRepository_FindByEmail_NotFound menguji fungsi FindByEmail ketika email belum terdaftar
+func TestUserRepository_FindByEmail_NotFound(t *testing.T) {
+	db, mock := setupMockDB(t)
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	repo := NewUserRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE email = ? ORDER BY `users`.`id` LIMIT ?")).
+		WithArgs("user1@example.com", 1).
+		WillReturnError(gorm.ErrRecordNotFound)
+
+	_, err := repo.FindByEmail(context.Background(), "user1@example.com")
+	assert.ErrorIs(t, err, ErrPenggunaTidakDitemukan)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestUserRepository_SetEmailVerifiedAt menguji pengisian dan pengosongan waktu verifikasi email
+func TestUserRepository_SetEmailVerifiedAt(t *testing.T) {
+	db, mock := setupMockDB(t)
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	repo := NewUserRepository(db)
+	now := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `users` SET `email_verified_at`=? WHERE id = ?")).
+		WithArgs(&now, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	assert.NoError(t, repo.SetEmailVerifiedAt(context.Background(), 1, &now))
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `users` SET `email_verified_at`=? WHERE id = ?")).
+		WithArgs(nil, 2).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+	assert.ErrorIs(t, repo.SetEmailVerifiedAt(context.Background(), 2, nil), ErrPenggunaTidakDitemukan)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 // TestUserRepository_Create menguji fungsi Create pada UserRepository
 func TestUserRepository_Create(t *testing.T) {
 	db, mock := setupMockDB(t)
@@ -124,13 +188,12 @@ func TestUserRepository_Create(t *testing.T) {
 		Username: "newuser",
 		Password: "password123",
 		FullName: "New User",
-		Role:     "user",
 	}
 
-	// Urutan kolom sesuai dengan query sebenarnya: `username`, `password`, `role`, `full_name`
+	// Urutan kolom sesuai dengan query sebenarnya: `username`, `password`, `full_name`
 	mock.ExpectBegin()
-	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `users` (`username`,`password`,`role`,`full_name`) VALUES (?,?,?,?)")).
-		WithArgs(user.Username, user.Password, user.Role, user.FullName).
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `users` (`username`,`password`,`full_name`) VALUES (?,?,?)")).
+		WithArgs(user.Username, user.Password, user.FullName).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
@@ -152,20 +215,19 @@ func TestUserRepository_Update(t *testing.T) {
 		ID:       1,
 		Username: "updateduser",
 		FullName: "Updated User",
-		Role:     "user",
 		Password: "newpassword123",
 	}
 
 	// Ekspektasi untuk query `UPDATE`
 	mock.ExpectBegin()
-	mock.ExpectExec(regexp.QuoteMeta("UPDATE `users` SET `full_name`=?,`password`=?,`role`=?,`username`=? WHERE id = ?")).
-		WithArgs(user.FullName, user.Password, user.Role, user.Username, user.ID).
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `users` SET `full_name`=?,`password`=?,`username`=? WHERE id = ?")).
+		WithArgs(user.FullName, user.Password, user.Username, user.ID).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
 	// Ekspektasi untuk query `SELECT` setelah `UPDATE`
-	row := sqlmock.NewRows([]string{"id", "username", "full_name", "role", "password"}).
-		AddRow(1, "updateduser", "Updated User", "user", "newpassword123")
+	row := sqlmock.NewRows([]string{"id", "username", "full_name", "password"}).
+		AddRow(1, "updateduser", "Updated User", "newpassword123")
 	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE id = ? ORDER BY `users`.`id` LIMIT ?")).
 		WithArgs(1, 1).
 		WillReturnRows(row)
@@ -188,13 +250,12 @@ func TestUserRepository_Update_NotFound(t *testing.T) {
 		ID:       1,
 		Username: "updateduser",
 		FullName: "Updated User",
-		Role:     "user",
 	}
 
 	// Sesuaikan urutan kolom dan simulasi "not found" dengan RowsAffected = 0
 	mock.ExpectBegin()
-	mock.ExpectExec(regexp.QuoteMeta("UPDATE `users` SET `full_name`=?,`role`=?,`username`=? WHERE id = ?")).
-		WithArgs(user.FullName, user.Role, user.Username, user.ID).
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `users` SET `full_name`=?,`username`=? WHERE id = ?")).
+		WithArgs(user.FullName, user.Username, user.ID).
 		WillReturnResult(sqlmock.NewResult(0, 0)) // Simulasi RowsAffected = 0 untuk not found
 	mock.ExpectCommit()
 
@@ -268,13 +329,12 @@ func TestUserRepository_Create_Error(t *testing.T) {
 		Username: "newuser",
 		Password: "password123",
 		FullName: "New User",
-		Role:     "user",
 	}
 
 	// Simulasikan error saat insert `Create`
 	mock.ExpectBegin()
-	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `users` (`username`,`password`,`role`,`full_name`) VALUES (?,?,?,?)")).
-		WithArgs(user.Username, user.Password, user.Role, user.FullName).
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `users` (`username`,`password`,`full_name`) VALUES (?,?,?)")).
+		WithArgs(user.Username, user.Password, user.FullName).
 		WillReturnError(errors.New("insert error"))
 	mock.ExpectRollback()
 
@@ -296,14 +356,13 @@ func TestUserRepository_Update_Error(t *testing.T) {
 		ID:       1,
 		Username: "updateduser",
 		FullName: "Updated User",
-		Role:     "user",
 		Password: "newpassword123",
 	}
 
 	// Simulasikan error saat `Update`
 	mock.ExpectBegin()
-	mock.ExpectExec(regexp.QuoteMeta("UPDATE `users` SET `full_name`=?,`password`=?,`role`=?,`username`=? WHERE id = ?")).
-		WithArgs(user.FullName, user.Password, user.Role, user.Username, user.ID).
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `users` SET `full_name`=?,`password`=?,`username`=? WHERE id = ?")).
+		WithArgs(user.FullName, user.Password, user.Username, user.ID).
 		WillReturnError(errors.New("update error"))
 	mock.ExpectRollback()
 
@@ -363,14 +422,13 @@ func TestUserRepository_Update_FindError(t *testing.T) {
 		ID:       1,
 		Username: "updateduser",
 		FullName: "Updated User",
-		Role:     "user",
 		Password: "newpassword123",
 	}
 
 	// Simulasi pembaruan berhasil
 	mock.ExpectBegin()
-	mock.ExpectExec(regexp.QuoteMeta("UPDATE `users` SET `full_name`=?,`password`=?,`role`=?,`username`=? WHERE id = ?")).
-		WithArgs(user.FullName, user.Password, user.Role, user.Username, user.ID).
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `users` SET `full_name`=?,`password`=?,`username`=? WHERE id = ?")).
+		WithArgs(user.FullName, user.Password, user.Username, user.ID).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-todo/internal/entity"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// WebhookRepository mendefinisikan operasi database untuk langganan webhook dan log pengirimannya.
+type WebhookRepository interface {
+	FindAll(ctx context.Context) ([]entity.Webhook, error)
+	FindByID(ctx context.Context, id int64) (*entity.Webhook, error)
+	FindActiveByEventType(ctx context.Context, eventType string) ([]entity.Webhook, error)
+	Create(ctx context.Context, webhook *entity.Webhook) (*entity.Webhook, error)
+	Update(ctx context.Context, webhook *entity.Webhook) (*entity.Webhook, error)
+	Delete(ctx context.Context, id int64) error
+
+	FindDeliveryByID(ctx context.Context, id int64) (*entity.WebhookDelivery, error)
+	FindDeliveriesByWebhookID(ctx context.Context, webhookID int64, limit int) ([]entity.WebhookDelivery, error)
+	CreateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) (*entity.WebhookDelivery, error)
+	UpdateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error
+	ClaimDueDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]entity.WebhookDelivery, error)
+}
+
+var (
+	ErrWebhookTidakDitemukan    = errors.New("webhook tidak ditemukan")
+	ErrPengirimanTidakDitemukan = errors.New("pengiriman webhook tidak ditemukan")
+)
+
+type webhookRepository struct {
+	db *gorm.DB
+}
+
+// NewWebhookRepository inisialisasi WebhookRepository baru.
+func NewWebhookRepository(db *gorm.DB) WebhookRepository {
+	return &webhookRepository{db}
+}
+
+// FindAll mengambil semua langganan webhook.
+func (r *webhookRepository) FindAll(ctx context.Context) ([]entity.Webhook, error) {
+	webhooks := make([]entity.Webhook, 0)
+	if err := dbFromContext(ctx, r.db).Order("id").Find(&webhooks).Error; err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return webhooks, nil
+}
+
+// FindByID mencari webhook berdasarkan ID.
+func (r *webhookRepository) FindByID(ctx context.Context, id int64) (*entity.Webhook, error) {
+	webhook := new(entity.Webhook)
+	if err := dbFromContext(ctx, r.db).Where("id = ?", id).First(webhook).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWebhookTidakDitemukan
+		}
+		return nil, fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return webhook, nil
+}
+
+// FindActiveByEventType mengambil webhook aktif yang melanggan jenis event tertentu.
+func (r *webhookRepository) FindActiveByEventType(ctx context.Context, eventType string) ([]entity.Webhook, error) {
+	webhooks := make([]entity.Webhook, 0)
+	err := dbFromContext(ctx, r.db).
+		Where("active AND event_types @> ?", entity.StringList{eventType}).
+		Find(&webhooks).Error
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return webhooks, nil
+}
+
+// Create menambahkan webhook baru.
+func (r *webhookRepository) Create(ctx context.Context, webhook *entity.Webhook) (*entity.Webhook, error) {
+	if err := dbFromContext(ctx, r.db).Create(webhook).Error; err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return webhook, nil
+}
+
+// Update memperbarui URL, jenis event, secret, dan status aktif webhook.
+func (r *webhookRepository) Update(ctx context.Context, webhook *entity.Webhook) (*entity.Webhook, error) {
+	result := dbFromContext(ctx, r.db).Model(webhook).
+		Select("URL", "EventTypes", "Secret", "Active").
+		Updates(webhook)
+	if result.Error != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDatabaseError, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrWebhookTidakDitemukan
+	}
+	return webhook, nil
+}
+
+// Delete menghapus webhook beserta log pengirimannya.
+func (r *webhookRepository) Delete(ctx context.Context, id int64) error {
+	result := dbFromContext(ctx, r.db).Delete(&entity.Webhook{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("%w: %v", ErrDatabaseError, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrWebhookTidakDitemukan
+	}
+	return nil
+}
+
+// FindDeliveryByID mencari pengiriman webhook berdasarkan ID.
+func (r *webhookRepository) FindDeliveryByID(ctx context.Context, id int64) (*entity.WebhookDelivery, error) {
+	delivery := new(entity.WebhookDelivery)
+	if err := dbFromContext(ctx, r.db).Where("id = ?", id).First(delivery).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPengirimanTidakDitemukan
+		}
+		return nil, fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return delivery, nil
+}
+
+// FindDeliveriesByWebhookID mengambil log pengiriman terbaru milik sebuah webhook.
+func (r *webhookRepository) FindDeliveriesByWebhookID(ctx context.Context, webhookID int64, limit int) ([]entity.WebhookDelivery, error) {
+	deliveries := make([]entity.WebhookDelivery, 0)
+	err := dbFromContext(ctx, r.db).
+		Where("webhook_id = ?", webhookID).
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Find(&deliveries).Error
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return deliveries, nil
+}
+
+// CreateDelivery menambahkan pengiriman webhook baru ke antrean.
+func (r *webhookRepository) CreateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) (*entity.WebhookDelivery, error) {
+	if err := dbFromContext(ctx, r.db).Create(delivery).Error; err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return delivery, nil
+}
+
+// UpdateDelivery menyimpan hasil percobaan pengiriman.
+func (r *webhookRepository) UpdateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	err := dbFromContext(ctx, r.db).Model(delivery).
+		Select("Status", "Attempts", "NextAttemptAt", "ResponseStatus", "LastError", "UpdatedAt", "DeliveredAt").
+		Updates(delivery).Error
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return nil
+}
+
+// ClaimDueDeliveries mengambil pengiriman pending yang sudah jatuh waktu dan menundanya hingga
+// leaseUntil, sehingga beberapa instance aplikasi tidak mengirim event yang sama bersamaan.
+func (r *webhookRepository) ClaimDueDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]entity.WebhookDelivery, error) {
+	deliveries := make([]entity.WebhookDelivery, 0)
+	err := dbFromContext(ctx, r.db).Raw(`
+		UPDATE webhook_deliveries SET next_attempt_at = @lease_until, updated_at = @now
+		WHERE id IN (
+			SELECT id FROM webhook_deliveries
+			WHERE status = @status AND next_attempt_at <= @now
+			ORDER BY next_attempt_at
+			LIMIT @limit
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`,
+		map[string]interface{}{
+			"lease_until": leaseUntil,
+			"now":         now,
+			"status":      entity.DeliveryStatusPending,
+			"limit":       limit,
+		},
+	).Scan(&deliveries).Error
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDatabaseError, err)
+	}
+	return deliveries, nil
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"go-todo/internal/entity"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestWebhookRepository_FindActiveByEventType menguji pencarian webhook aktif berdasarkan jenis event
+func TestWebhookRepository_FindActiveByEventType(t *testing.T) {
+	db, mock := setupMockDB(t)
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	repo := NewWebhookRepository(db)
+
+	rows := sqlmock.NewRows([]string{"id", "url", "event_types", "active"}).
+		AddRow(1, "https://example.com/hook", `["todo.created","todo.deleted"]`, true)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `webhooks` WHERE active AND event_types @> ?")).
+		WithArgs(`["todo.created"]`).
+		WillReturnRows(rows)
+
+	webhooks, err := repo.FindActiveByEventType(context.Background(), entity.EventTodoCreated)
+	assert.NoError(t, err)
+	assert.Len(t, webhooks, 1)
+	assert.True(t, webhooks[0].SubscribesTo(entity.EventTodoDeleted))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestWebhookRepository_ClaimDueDeliveries menguji pengambilan antrean pengiriman yang jatuh waktu
+func TestWebhookRepository_ClaimDueDeliveries(t *testing.T) {
+	db, mock := setupMockDB(t)
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	repo := NewWebhookRepository(db)
+	now := time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC)
+	leaseUntil := now.Add(20 * time.Second)
+
+	rows := sqlmock.NewRows([]string{"id", "webhook_id", "event_id", "status", "attempts"}).
+		AddRow(10, 1, "evt_1", "pending", 0)
+	mock.ExpectQuery("UPDATE webhook_deliveries SET next_attempt_at = \\?, updated_at = \\? WHERE id IN .*FOR UPDATE SKIP LOCKED").
+		WithArgs(leaseUntil, now, "pending", now, 50).
+		WillReturnRows(rows)
+
+	deliveries, err := repo.ClaimDueDeliveries(context.Background(), now, leaseUntil, 50)
+	assert.NoError(t, err)
+	assert.Len(t, deliveries, 1)
+	assert.Equal(t, "evt_1", deliveries[0].EventID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-todo/internal/entity"
+	"go-todo/internal/repository"
+	"time"
+)
+
+var (
+	ErrRentangTanggalTidakValid = errors.New("tanggal akhir harus setelah tanggal awal")
+	ErrLimitTidakValid          = errors.New("limit harus antara 1 dan 100")
+)
+
+type AnalyticsService interface {
+	ActiveUsers(ctx context.Context, from, to time.Time, loc *time.Location) ([]entity.DailyCount, error)
+	TodoActivity(ctx context.Context, from, to time.Time, loc *time.Location) ([]entity.DailyTodoActivity, error)
+	TopUsers(ctx context.Context, from, to time.Time, loc *time.Location, limit int) ([]entity.TopUser, error)
+	LoginActivity(ctx context.Context, from, to time.Time, loc *time.Location) ([]entity.DailyLoginActivity, error)
+}
+
+type analyticsService struct {
+	analyticsRepository repository.AnalyticsRepository
+}
+
+// NewAnalyticsService membuat instance baru dari AnalyticsService
+func NewAnalyticsService(analyticsRepository repository.AnalyticsRepository) AnalyticsService {
+	return &analyticsService{analyticsRepository: analyticsRepository}
+}
+
+// ActiveUsers mengambil jumlah pengguna aktif per hari
+func (s *analyticsService) ActiveUsers(ctx context.Context, from, to time.Time, loc *time.Location) ([]entity.DailyCount, error) {
+	from, to, err := resolveDateRange(from, to, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := s.analyticsRepository.ActiveUsers(ctx, from, to, loc.String())
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil pengguna aktif: %w", err)
+	}
+	return counts, nil
+}
+
+// TodoActivity mengambil jumlah todo yang dibuat dan diselesaikan per hari
+func (s *analyticsService) TodoActivity(ctx context.Context, from, to time.Time, loc *time.Location) ([]entity.DailyTodoActivity, error) {
+	from, to, err := resolveDateRange(from, to, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	activity, err := s.analyticsRepository.TodoActivity(ctx, from, to, loc.String())
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil aktivitas todo: %w", err)
+	}
+	return activity, nil
+}
+
+// TopUsers mengambil pengguna dengan jumlah todo terbanyak
+func (s *analyticsService) TopUsers(ctx context.Context, from, to time.Time, loc *time.Location, limit int) ([]entity.TopUser, error) {
+	if limit == 0 {
+		limit = 10
+	}
+	if limit < 1 || limit > 100 {
+		return nil, ErrLimitTidakValid
+	}
+
+	from, to, err := resolveDateRange(from, to, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := s.analyticsRepository.TopUsers(ctx, from, to, limit)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil pengguna teratas: %w", err)
+	}
+	return users, nil
+}
+
+// LoginActivity mengambil jumlah login berhasil dan gagal per hari
+func (s *analyticsService) LoginActivity(ctx context.Context, from, to time.Time, loc *time.Location) ([]entity.DailyLoginActivity, error) {
+	from, to, err := resolveDateRange(from, to, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	activity, err := s.analyticsRepository.LoginActivity(ctx, from, to, loc.String())
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil aktivitas login: %w", err)
+	}
+	return activity, nil
+}
+
+// resolveDateRange mengisi rentang default 30 hari terakhir pada zona waktu loc
+// dan memvalidasi urutan tanggal
+func resolveDateRange(from, to time.Time, loc *time.Location) (time.Time, time.Time, error) {
+	if to.IsZero() {
+		to = startOfDay(time.Now().In(loc)).AddDate(0, 0, 1)
+	}
+	if from.IsZero() {
+		from = to.AddDate(0, 0, -30)
+	}
+	if !to.After(from) {
+		return time.Time{}, time.Time{}, ErrRentangTanggalTidakValid
+	}
+	return from, to, nil
+}
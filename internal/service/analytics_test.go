@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"go-todo/internal/entity"
+	mock_repository "go-todo/test/mock/repository"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalyticsService_ActiveUsers_DefaultRange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockAnalyticsRepository(ctrl)
+	service := NewAnalyticsService(mockRepo)
+
+	ctx := context.Background()
+	expected := []entity.DailyCount{{Day: time.Now(), Count: 3}}
+
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+
+	mockRepo.EXPECT().ActiveUsers(ctx, gomock.Any(), gomock.Any(), "Asia/Tokyo").DoAndReturn(
+		func(_ context.Context, from, to time.Time, _ string) ([]entity.DailyCount, error) {
+			// Rentang default adalah 30 hari terakhir yang berakhir di tengah malam zona waktu pemanggil
+			assert.Equal(t, 30*24*time.Hour, to.Sub(from))
+			assert.Equal(t, tokyo, to.Location())
+			assert.Equal(t, 0, to.Hour())
+			return expected, nil
+		})
+
+	counts, err := service.ActiveUsers(ctx, time.Time{}, time.Time{}, tokyo)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, counts)
+}
+
+func TestAnalyticsService_TodoActivity_InvalidRange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := NewAnalyticsService(mock_repository.NewMockAnalyticsRepository(ctrl))
+
+	from := time.Date(2026, 10, 10, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	_, err := service.TodoActivity(context.Background(), from, to, time.UTC)
+	assert.ErrorIs(t, err, ErrRentangTanggalTidakValid)
+}
+
+func TestAnalyticsService_TopUsers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockAnalyticsRepository(ctrl)
+	service := NewAnalyticsService(mockRepo)
+
+	ctx := context.Background()
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+
+	// Limit default adalah 10
+	mockRepo.EXPECT().TopUsers(ctx, from, to, 10).Return([]entity.TopUser{{UserID: 1, Created: 12}}, nil)
+	users, err := service.TopUsers(ctx, from, to, time.UTC, 0)
+	assert.NoError(t, err)
+	assert.Len(t, users, 1)
+
+	_, err = service.TopUsers(ctx, from, to, time.UTC, 500)
+	assert.ErrorIs(t, err, ErrLimitTidakValid)
+
+	mockRepo.EXPECT().TopUsers(ctx, from, to, 5).Return(nil, errors.New("database error"))
+	_, err = service.TopUsers(ctx, from, to, time.UTC, 5)
+	assert.Error(t, err)
+}
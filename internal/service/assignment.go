@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-todo/internal/entity"
+	"go-todo/internal/repository"
+	"go-todo/pkg/cache"
+)
+
+// AssignmentService mengelola penanggung jawab todo. Hanya pemilik yang boleh menugaskan
+// dan mengubah isi todo; penanggung jawab hanya boleh mengubah status selesai.
+type AssignmentService interface {
+	Assign(ctx context.Context, actorID, todoID, assigneeID int64) (*entity.Todo, error)
+	Unassign(ctx context.Context, actorID, todoID int64) (*entity.Todo, error)
+	FindAssigned(ctx context.Context, userID int64) ([]entity.Todo, error)
+	UpdateStatus(ctx context.Context, actorID, todoID int64, completed bool) (entity.Todo, error)
+	AuthorizeEdit(ctx context.Context, actorID, todoID int64) error
+}
+
+type assignmentService struct {
+	todoRepository repository.TodoRepository
+	userRepository repository.UserRepository
+	todoService    TodoService
+	cacheable      cache.Cacheable
+	transactor     repository.Transactor
+	events         EventRecorder
+	authorization  AuthorizationService
+}
+
+// NewAssignmentService membuat instance baru dari AssignmentService
+func NewAssignmentService(
+	todoRepository repository.TodoRepository,
+	userRepository repository.UserRepository,
+	todoService TodoService,
+	cacheable cache.Cacheable,
+	transactor repository.Transactor,
+	events EventRecorder,
+	authorization AuthorizationService,
+) AssignmentService {
+	return &assignmentService{
+		todoRepository: todoRepository,
+		userRepository: userRepository,
+		todoService:    todoService,
+		cacheable:      cacheable,
+		transactor:     transactor,
+		events:         events,
+		authorization:  authorization,
+	}
+}
+
+// Assign menugaskan todo kepada pengguna lain dan mencatat event todo.assigned
+// yang menjadi dasar notifikasi untuk penanggung jawab
+func (s *assignmentService) Assign(ctx context.Context, actorID, todoID, assigneeID int64) (*entity.Todo, error) {
+	if _, err := s.userRepository.FindByID(ctx, assigneeID); err != nil {
+		if errors.Is(err, repository.ErrPenggunaTidakDitemukan) {
+			return nil, ErrPenggunaTidakDitemukan
+		}
+		return nil, fmt.Errorf("gagal memeriksa pengguna: %w", err)
+	}
+	return s.changeAssignee(ctx, actorID, todoID, &assigneeID)
+}
+
+// Unassign menghapus penanggung jawab todo dan mencatat event todo.unassigned
+func (s *assignmentService) Unassign(ctx context.Context, actorID, todoID int64) (*entity.Todo, error) {
+	return s.changeAssignee(ctx, actorID, todoID, nil)
+}
+
+func (s *assignmentService) changeAssignee(ctx context.Context, actorID, todoID int64, assigneeID *int64) (*entity.Todo, error) {
+	var todo *entity.Todo
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		todo, err = s.findTodo(ctx, todoID)
+		if err != nil {
+			return err
+		}
+		if !todo.CanEdit(actorID) {
+			return ErrAksesDitolak
+		}
+
+		previous := todo.AssigneeID
+		if sameAssignee(previous, assigneeID) {
+			return nil
+		}
+		if err := s.todoRepository.UpdateAssignee(ctx, todoID, assigneeID); err != nil {
+			return err
+		}
+		todo.AssigneeID = assigneeID
+
+		eventType := entity.EventTodoAssigned
+		if assigneeID == nil {
+			eventType = entity.EventTodoUnassigned
+		}
+		return s.events.Record(ctx, entity.DomainEvent{
+			Type:          eventType,
+			AggregateType: entity.AggregateTodo,
+			AggregateID:   todo.ID,
+			Data:          entity.TodoAssignment{Todo: *todo, ActorID: actorID, PreviousAssigneeID: previous},
+		})
+	})
+	if err != nil {
+		if errors.Is(err, ErrTodoTidakDitemukan) || errors.Is(err, ErrAksesDitolak) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("gagal mengubah penanggung jawab todo: %w", err)
+	}
+
+	// Menghapus cache untuk menjaga konsistensi data
+	s.cacheable.Delete("go-todo-api:todos:find-all")
+	return todo, nil
+}
+
+// FindAssigned mengambil todo yang ditugaskan kepada pengguna
+func (s *assignmentService) FindAssigned(ctx context.Context, userID int64) ([]entity.Todo, error) {
+	todos, err := s.todoRepository.FindByAssigneeID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil todo yang ditugaskan: %w", err)
+	}
+	return todos, nil
+}
+
+// UpdateStatus mengubah status selesai todo oleh pemilik atau penanggung jawabnya
+func (s *assignmentService) UpdateStatus(ctx context.Context, actorID, todoID int64, completed bool) (entity.Todo, error) {
+	todo, err := s.findTodo(ctx, todoID)
+	if err != nil {
+		return entity.Todo{}, err
+	}
+	if !todo.CanUpdateStatus(actorID) {
+		return entity.Todo{}, ErrAksesDitolak
+	}
+	return s.todoService.Update(ctx, todoID, entity.Todo{Completed: completed})
+}
+
+// AuthorizeEdit memeriksa apakah pengguna boleh mengubah isi todo: pemilik todo, atau
+// pengguna dengan izin todo:update:any
+func (s *assignmentService) AuthorizeEdit(ctx context.Context, actorID, todoID int64) error {
+	todo, err := s.findTodo(ctx, todoID)
+	if err != nil {
+		return err
+	}
+	if todo.CanEdit(actorID) {
+		return nil
+	}
+	allowed, err := s.authorization.HasPermission(ctx, actorID, entity.PermissionTodoUpdateAny)
+	if err != nil {
+		return fmt.Errorf("gagal memeriksa izin: %w", err)
+	}
+	if !allowed {
+		return ErrAksesDitolak
+	}
+	return nil
+}
+
+func (s *assignmentService) findTodo(ctx context.Context, todoID int64) (*entity.Todo, error) {
+	todo, err := s.todoRepository.FindByID(ctx, todoID)
+	if err != nil {
+		return nil, ErrTodoTidakDitemukan
+	}
+	return todo, nil
+}
+
+// sameAssignee membandingkan dua penanggung jawab yang dapat bernilai nil
+func sameAssignee(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
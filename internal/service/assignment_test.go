@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"go-todo/internal/entity"
+	"go-todo/internal/repository"
+	mock_cache "go-todo/test/mock/pkg/cache"
+	mock_repository "go-todo/test/mock/repository"
+	mock_service "go-todo/test/mock/service"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// assignmentServiceMocks mengelompokkan semua dependensi mock dari AssignmentService
+type assignmentServiceMocks struct {
+	todo   *mock_repository.MockTodoRepository
+	user   *mock_repository.MockUserRepository
+	todos  *mock_service.MockTodoService
+	cache  *mock_cache.MockCacheable
+	events *mock_service.MockEventRecorder
+	authz  *mock_service.MockAuthorizationService
+}
+
+func setupAssignmentService(t *testing.T) (*gomock.Controller, AssignmentService, *assignmentServiceMocks) {
+	ctrl := gomock.NewController(t)
+	m := &assignmentServiceMocks{
+		todo:   mock_repository.NewMockTodoRepository(ctrl),
+		user:   mock_repository.NewMockUserRepository(ctrl),
+		todos:  mock_service.NewMockTodoService(ctrl),
+		cache:  mock_cache.NewMockCacheable(ctrl),
+		events: mock_service.NewMockEventRecorder(ctrl),
+		authz:  mock_service.NewMockAuthorizationService(ctrl),
+	}
+	service := NewAssignmentService(m.todo, m.user, m.todos, m.cache, passThroughTransactor(ctrl), m.events, m.authz)
+	return ctrl, service, m
+}
+
+func TestAssignmentService_Assign(t *testing.T) {
+	ctrl, service, m := setupAssignmentService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	assigneeID := int64(9)
+	m.user.EXPECT().FindByID(ctx, assigneeID).Return(&entity.User{ID: 9}, nil)
+	m.todo.EXPECT().FindByID(ctx, int64(7)).Return(&entity.Todo{ID: 7, UserID: 5, Title: "Laporan"}, nil)
+	m.todo.EXPECT().UpdateAssignee(ctx, int64(7), &assigneeID).Return(nil)
+	m.events.EXPECT().Record(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, event entity.DomainEvent) error {
+		assert.Equal(t, entity.EventTodoAssigned, event.Type)
+		assignment := event.Data.(entity.TodoAssignment)
+		assert.Equal(t, int64(5), assignment.ActorID)
+		assert.Equal(t, int64(9), *assignment.AssigneeID)
+		assert.Nil(t, assignment.PreviousAssigneeID)
+		return nil
+	})
+	m.cache.EXPECT().Delete("go-todo-api:todos:find-all").Return(nil)
+
+	todo, err := service.Assign(ctx, 5, 7, assigneeID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(9), *todo.AssigneeID)
+}
+
+func TestAssignmentService_Assign_Validation(t *testing.T) {
+	ctrl, service, m := setupAssignmentService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	// Penanggung jawab harus pengguna yang terdaftar
+	m.user.EXPECT().FindByID(ctx, int64(99)).Return(nil, repository.ErrPenggunaTidakDitemukan)
+	_, err := service.Assign(ctx, 5, 7, 99)
+	assert.ErrorIs(t, err, ErrPenggunaTidakDitemukan)
+
+	// Hanya pemilik yang boleh menugaskan todo
+	m.user.EXPECT().FindByID(ctx, int64(9)).Return(&entity.User{ID: 9}, nil)
+	m.todo.EXPECT().FindByID(ctx, int64(7)).Return(&entity.Todo{ID: 7, UserID: 5}, nil)
+	_, err = service.Assign(ctx, 6, 7, 9)
+	assert.ErrorIs(t, err, ErrAksesDitolak)
+}
+
+func TestAssignmentService_Unassign_AlreadyUnassigned(t *testing.T) {
+	ctrl, service, m := setupAssignmentService(t)
+	defer ctrl.Finish()
+
+	// Tidak ada perubahan sehingga tidak ada event yang dicatat
+	ctx := context.Background()
+	m.todo.EXPECT().FindByID(ctx, int64(7)).Return(&entity.Todo{ID: 7, UserID: 5}, nil)
+	m.cache.EXPECT().Delete("go-todo-api:todos:find-all").Return(nil)
+
+	todo, err := service.Unassign(ctx, 5, 7)
+	assert.NoError(t, err)
+	assert.Nil(t, todo.AssigneeID)
+}
+
+func TestAssignmentService_UpdateStatus(t *testing.T) {
+	ctrl, service, m := setupAssignmentService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	assigneeID := int64(9)
+	todo := &entity.Todo{ID: 7, UserID: 5, AssigneeID: &assigneeID}
+	m.todo.EXPECT().FindByID(ctx, int64(7)).Return(todo, nil).Times(2)
+
+	// Penanggung jawab boleh mengubah status
+	m.todos.EXPECT().Update(ctx, int64(7), entity.Todo{Completed: true}).Return(entity.Todo{ID: 7, Completed: true}, nil)
+	updated, err := service.UpdateStatus(ctx, 9, 7, true)
+	assert.NoError(t, err)
+	assert.True(t, updated.Completed)
+
+	// Pengguna lain tidak boleh
+	_, err = service.UpdateStatus(ctx, 10, 7, true)
+	assert.ErrorIs(t, err, ErrAksesDitolak)
+}
+
+func TestAssignmentService_AuthorizeEdit(t *testing.T) {
+	ctrl, service, m := setupAssignmentService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	assigneeID := int64(9)
+	m.todo.EXPECT().FindByID(ctx, int64(7)).Return(&entity.Todo{ID: 7, UserID: 5, AssigneeID: &assigneeID}, nil).Times(3)
+	m.authz.EXPECT().HasPermission(ctx, int64(9), entity.PermissionTodoUpdateAny).Return(false, nil)
+	m.authz.EXPECT().HasPermission(ctx, int64(1), entity.PermissionTodoUpdateAny).Return(true, nil)
+
+	assert.NoError(t, service.AuthorizeEdit(ctx, 5, 7))
+	// Penanggung jawab tidak boleh mengubah isi todo
+	assert.ErrorIs(t, service.AuthorizeEdit(ctx, 9, 7), ErrAksesDitolak)
+	// Pengguna dengan izin todo:update:any boleh mengubah todo milik siapa pun
+	assert.NoError(t, service.AuthorizeEdit(ctx, 1, 7))
+}
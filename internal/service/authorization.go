@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-todo/configs"
+	"go-todo/internal/entity"
+	"go-todo/internal/repository"
+	"go-todo/pkg/cache"
+	"sort"
+	"strconv"
+	"time"
+)
+
+const (
+	authorizationCacheTTL        = 5 * time.Minute
+	authorizationVersionCacheKey = "go-todo-api:authorization:version"
+)
+
+// AuthorizationService menyediakan role dan izin pengguna dari cache Redis. Perubahan
+// keanggotaan role menghapus cache pengguna terkait, sedangkan perubahan isi role menaikkan
+// versi cache sehingga seluruh cache lama tidak dipakai lagi tanpa harus dihapus satu per satu.
+type AuthorizationService interface {
+	Lookup(ctx context.Context, userID int64) (*entity.Authorization, error)
+	// Permissions dipakai oleh middleware untuk memeriksa izin route
+	Permissions(ctx context.Context, userID int64) ([]string, error)
+	HasPermission(ctx context.Context, userID int64, permission string) (bool, error)
+	// Require mengembalikan ErrAksesDitolak jika pengguna tidak memiliki izin
+	Require(ctx context.Context, userID int64, permission string) error
+	InvalidateUser(userID int64)
+	InvalidateAll()
+}
+
+type authorizationService struct {
+	roleRepository    repository.RoleRepository
+	userRepository    repository.UserRepository
+	mfaRepository     repository.MFARepository
+	cacheable         cache.Cacheable
+	emailVerification configs.EmailVerificationConfig
+	mfa               configs.MFAConfig
+}
+
+// NewAuthorizationService membuat instance baru dari AuthorizationService. Kebijakan
+// verifikasi email menentukan apakah izin pengguna yang emailnya belum diverifikasi dibatasi,
+// sedangkan role pada kebijakan MFA hanya memberi izin setelah pemiliknya mengaktifkan TOTP.
+func NewAuthorizationService(
+	roleRepository repository.RoleRepository,
+	userRepository repository.UserRepository,
+	mfaRepository repository.MFARepository,
+	cacheable cache.Cacheable,
+	emailVerification configs.EmailVerificationConfig,
+	mfa configs.MFAConfig,
+) AuthorizationService {
+	return &authorizationService{
+		roleRepository:    roleRepository,
+		userRepository:    userRepository,
+		mfaRepository:     mfaRepository,
+		cacheable:         cacheable,
+		emailVerification: emailVerification,
+		mfa:               mfa,
+	}
+}
+
+// Lookup mengambil role dan gabungan izin pengguna, terlebih dahulu dari cache
+func (s *authorizationService) Lookup(ctx context.Context, userID int64) (*entity.Authorization, error) {
+	cacheKey := s.cacheKey(userID)
+	if cached, err := s.cacheable.Get(cacheKey); err == nil && cached != "" {
+		var authorization entity.Authorization
+		if err := json.Unmarshal([]byte(cached), &authorization); err == nil {
+			return &authorization, nil
+		}
+	}
+
+	roles, err := s.roleRepository.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil role pengguna: %w", err)
+	}
+
+	authorization := &entity.Authorization{Roles: []string{}, Permissions: []string{}}
+	for _, role := range roles {
+		authorization.Roles = append(authorization.Roles, role.Name)
+	}
+	if err := s.requireMFA(ctx, userID, authorization); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	for _, role := range roles {
+		// Izin role yang mewajibkan TOTP ditahan hingga pengguna mengaktifkannya
+		if authorization.MFARequired && requiresMFA([]string{role.Name}, s.mfa.RequiredRoles) {
+			continue
+		}
+		for _, permission := range role.Permissions {
+			if !seen[permission] {
+				seen[permission] = true
+				authorization.Permissions = append(authorization.Permissions, permission)
+			}
+		}
+	}
+	sort.Strings(authorization.Permissions)
+
+	if err := s.restrictUnverified(ctx, userID, authorization); err != nil {
+		return nil, err
+	}
+
+	if err := s.cacheable.Set(cacheKey, authorization, authorizationCacheTTL); err != nil {
+		fmt.Printf("kesalahan menyimpan cache: %v\n", err)
+	}
+	return authorization, nil
+}
+
+// requireMFA menandai MFARequired jika pengguna memiliki role yang mewajibkan TOTP tetapi
+// belum mengaktifkannya. Pengguna tersebut tetap dapat login untuk mendaftarkan TOTP.
+func (s *authorizationService) requireMFA(ctx context.Context, userID int64, authorization *entity.Authorization) error {
+	if !requiresMFA(authorization.Roles, s.mfa.RequiredRoles) {
+		return nil
+	}
+	totp, err := s.mfaRepository.FindByUserID(ctx, userID)
+	if err != nil && !errors.Is(err, repository.ErrMFATidakDitemukan) {
+		return fmt.Errorf("gagal mengambil status autentikasi dua faktor: %w", err)
+	}
+	authorization.MFARequired = totp == nil || totp.ConfirmedAt == nil
+	return nil
+}
+
+// restrictUnverified membatasi izin pengguna yang emailnya belum diverifikasi pada izin yang
+// diperbolehkan konfigurasi. Kebijakan block ikut membatasi izin sehingga sesi yang sudah
+// berjalan sebelum email diganti tidak tetap memiliki akses penuh.
+func (s *authorizationService) restrictUnverified(ctx context.Context, userID int64, authorization *entity.Authorization) error {
+	if s.emailVerification.Policy == "" || s.emailVerification.Policy == EmailVerificationPolicyNone {
+		return nil
+	}
+	user, err := s.userRepository.FindByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("gagal mengambil status verifikasi email: %w", err)
+	}
+	if !user.EmailUnverified() {
+		return nil
+	}
+
+	allowed := make([]string, 0, len(s.emailVerification.AllowedPermissions))
+	for _, permission := range s.emailVerification.AllowedPermissions {
+		if authorization.Has(permission) {
+			allowed = append(allowed, permission)
+		}
+	}
+	sort.Strings(allowed)
+	authorization.Permissions = allowed
+	authorization.EmailUnverified = true
+	return nil
+}
+
+// Permissions mengembalikan gabungan izin dari seluruh role pengguna
+func (s *authorizationService) Permissions(ctx context.Context, userID int64) ([]string, error) {
+	authorization, err := s.Lookup(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return authorization.Permissions, nil
+}
+
+// HasPermission memeriksa apakah pengguna memiliki izin tertentu
+func (s *authorizationService) HasPermission(ctx context.Context, userID int64, permission string) (bool, error) {
+	authorization, err := s.Lookup(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return authorization.Has(permission), nil
+}
+
+// Require memastikan pengguna memiliki izin tertentu
+func (s *authorizationService) Require(ctx context.Context, userID int64, permission string) error {
+	allowed, err := s.HasPermission(ctx, userID, permission)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return ErrAksesDitolak
+	}
+	return nil
+}
+
+// InvalidateUser menghapus cache izin pengguna setelah keanggotaan role-nya berubah
+func (s *authorizationService) InvalidateUser(userID int64) {
+	if err := s.cacheable.Delete(s.cacheKey(userID)); err != nil {
+		fmt.Printf("kesalahan menghapus cache: %v\n", err)
+	}
+}
+
+// InvalidateAll membuat seluruh cache izin kedaluwarsa setelah isi sebuah role berubah
+func (s *authorizationService) InvalidateAll() {
+	if err := s.cacheable.Set(authorizationVersionCacheKey, time.Now().UnixNano(), 0); err != nil {
+		fmt.Printf("kesalahan menyimpan cache: %v\n", err)
+	}
+}
+
+// cacheKey menyusun key cache izin pengguna berdasarkan versi cache saat ini
+func (s *authorizationService) cacheKey(userID int64) string {
+	version, _ := s.cacheable.Get(authorizationVersionCacheKey)
+	return "go-todo-api:authorization:" + version + ":" + strconv.FormatInt(userID, 10)
+}
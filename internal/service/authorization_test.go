@@ -0,0 +1,218 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"go-todo/configs"
+	"go-todo/internal/entity"
+	mock_cache "go-todo/test/mock/pkg/cache"
+	mock_repository "go-todo/test/mock/repository"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthorizationService_Lookup_CacheMiss(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	roleRepo := mock_repository.NewMockRoleRepository(ctrl)
+	cacheable := mock_cache.NewMockCacheable(ctrl)
+	service := NewAuthorizationService(roleRepo, mock_repository.NewMockUserRepository(ctrl), mock_repository.NewMockMFARepository(ctrl), cacheable, configs.EmailVerificationConfig{Policy: EmailVerificationPolicyNone}, configs.MFAConfig{})
+	ctx := context.Background()
+
+	cacheable.EXPECT().Get(authorizationVersionCacheKey).Return("v1", nil)
+	cacheable.EXPECT().Get("go-todo-api:authorization:v1:5").Return("", nil)
+	roleRepo.EXPECT().FindByUserID(ctx, int64(5)).Return([]entity.Role{
+		{Name: "editor", Permissions: entity.StringList{entity.PermissionTodoUpdateAny, entity.PermissionTodoRead}},
+		{Name: "user", Permissions: entity.StringList{entity.PermissionTodoRead, entity.PermissionTodoWrite}},
+	}, nil)
+	expected := &entity.Authorization{
+		Roles:       []string{"editor", "user"},
+		Permissions: []string{entity.PermissionTodoRead, entity.PermissionTodoUpdateAny, entity.PermissionTodoWrite},
+	}
+	cacheable.EXPECT().Set("go-todo-api:authorization:v1:5", expected, authorizationCacheTTL).Return(nil)
+
+	// Izin dari beberapa role digabung tanpa duplikasi
+	authorization, err := service.Lookup(ctx, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, authorization)
+}
+
+func TestAuthorizationService_HasPermission_CacheHit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	roleRepo := mock_repository.NewMockRoleRepository(ctrl)
+	cacheable := mock_cache.NewMockCacheable(ctrl)
+	service := NewAuthorizationService(roleRepo, mock_repository.NewMockUserRepository(ctrl), mock_repository.NewMockMFARepository(ctrl), cacheable, configs.EmailVerificationConfig{Policy: EmailVerificationPolicyNone}, configs.MFAConfig{})
+	ctx := context.Background()
+
+	cached, _ := json.Marshal(entity.Authorization{Roles: []string{"admin"}, Permissions: []string{entity.PermissionAll}})
+	cacheable.EXPECT().Get(authorizationVersionCacheKey).Return("", nil).Times(2)
+	cacheable.EXPECT().Get("go-todo-api:authorization::1").Return(string(cached), nil).Times(2)
+
+	// Izin wildcard admin mencakup seluruh izin tanpa query ke database
+	allowed, err := service.HasPermission(ctx, 1, entity.PermissionWebhookManage)
+	assert.NoError(t, err)
+	assert.True(t, allowed)
+	assert.NoError(t, service.Require(ctx, 1, entity.PermissionRoleManage))
+}
+
+func TestAuthorizationService_Require_Denied(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	roleRepo := mock_repository.NewMockRoleRepository(ctrl)
+	cacheable := mock_cache.NewMockCacheable(ctrl)
+	service := NewAuthorizationService(roleRepo, mock_repository.NewMockUserRepository(ctrl), mock_repository.NewMockMFARepository(ctrl), cacheable, configs.EmailVerificationConfig{Policy: EmailVerificationPolicyNone}, configs.MFAConfig{})
+	ctx := context.Background()
+
+	cached, _ := json.Marshal(entity.Authorization{Roles: []string{"user"}, Permissions: []string{entity.PermissionTodoRead}})
+	cacheable.EXPECT().Get(authorizationVersionCacheKey).Return("", nil)
+	cacheable.EXPECT().Get("go-todo-api:authorization::2").Return(string(cached), nil)
+
+	assert.ErrorIs(t, service.Require(ctx, 2, entity.PermissionRoleManage), ErrAksesDitolak)
+}
+
+func TestAuthorizationService_Invalidate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	roleRepo := mock_repository.NewMockRoleRepository(ctrl)
+	cacheable := mock_cache.NewMockCacheable(ctrl)
+	service := NewAuthorizationService(roleRepo, mock_repository.NewMockUserRepository(ctrl), mock_repository.NewMockMFARepository(ctrl), cacheable, configs.EmailVerificationConfig{Policy: EmailVerificationPolicyNone}, configs.MFAConfig{})
+
+	cacheable.EXPECT().Get(authorizationVersionCacheKey).Return("v1", nil)
+	cacheable.EXPECT().Delete("go-todo-api:authorization:v1:3").Return(nil)
+	service.InvalidateUser(3)
+
+	// Perubahan isi role mengganti versi cache sehingga seluruh cache lama tidak terpakai
+	cacheable.EXPECT().Set(authorizationVersionCacheKey, gomock.Any(), gomock.Any()).Return(nil)
+	service.InvalidateAll()
+}
+
+func TestAuthorizationService_Lookup_RestrictsUnverifiedEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	roleRepo := mock_repository.NewMockRoleRepository(ctrl)
+	userRepo := mock_repository.NewMockUserRepository(ctrl)
+	cacheable := mock_cache.NewMockCacheable(ctrl)
+	config := configs.EmailVerificationConfig{
+		Policy:             EmailVerificationPolicyRestrict,
+		AllowedPermissions: []string{entity.PermissionTodoRead, entity.PermissionUserRead},
+	}
+	service := NewAuthorizationService(roleRepo, userRepo, mock_repository.NewMockMFARepository(ctrl), cacheable, config, configs.MFAConfig{})
+	ctx := context.Background()
+
+	cacheable.EXPECT().Get(authorizationVersionCacheKey).Return("", nil)
+	cacheable.EXPECT().Get("go-todo-api:authorization::5").Return("", nil)
+	roleRepo.EXPECT().FindByUserID(ctx, int64(5)).Return([]entity.Role{
+		{Name: "user", Permissions: entity.StringList{entity.PermissionTodoRead, entity.PermissionTodoWrite}},
+	}, nil)
+	userRepo.EXPECT().FindByID(ctx, int64(5)).Return(&entity.User{ID: 5, Email: "budi@example.com"}, nil)
+	expected := &entity.Authorization{
+		Roles:           []string{"user"},
+		Permissions:     []string{entity.PermissionTodoRead},
+		EmailUnverified: true,
+	}
+	cacheable.EXPECT().Set("go-todo-api:authorization::5", expected, authorizationCacheTTL).Return(nil)
+
+	// Izin yang diperbolehkan tidak menambah izin yang memang tidak dimiliki role pengguna
+	authorization, err := service.Lookup(ctx, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, authorization)
+}
+
+func TestAuthorizationService_Lookup_VerifiedEmailNotRestricted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	roleRepo := mock_repository.NewMockRoleRepository(ctrl)
+	userRepo := mock_repository.NewMockUserRepository(ctrl)
+	cacheable := mock_cache.NewMockCacheable(ctrl)
+	config := configs.EmailVerificationConfig{Policy: EmailVerificationPolicyBlock, AllowedPermissions: []string{entity.PermissionTodoRead}}
+	service := NewAuthorizationService(roleRepo, userRepo, mock_repository.NewMockMFARepository(ctrl), cacheable, config, configs.MFAConfig{})
+	ctx := context.Background()
+
+	verifiedAt := time.Now()
+	cacheable.EXPECT().Get(authorizationVersionCacheKey).Return("", nil)
+	cacheable.EXPECT().Get("go-todo-api:authorization::5").Return("", nil)
+	roleRepo.EXPECT().FindByUserID(ctx, int64(5)).Return([]entity.Role{
+		{Name: "user", Permissions: entity.StringList{entity.PermissionTodoRead, entity.PermissionTodoWrite}},
+	}, nil)
+	userRepo.EXPECT().FindByID(ctx, int64(5)).Return(&entity.User{ID: 5, Email: "budi@example.com", EmailVerifiedAt: &verifiedAt}, nil)
+	expected := &entity.Authorization{
+		Roles:       []string{"user"},
+		Permissions: []string{entity.PermissionTodoRead, entity.PermissionTodoWrite},
+	}
+	cacheable.EXPECT().Set("go-todo-api:authorization::5", expected, authorizationCacheTTL).Return(nil)
+
+	authorization, err := service.Lookup(ctx, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, authorization)
+}
+
+func TestAuthorizationService_Lookup_WithholdsRoleRequiringMFA(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	roleRepo := mock_repository.NewMockRoleRepository(ctrl)
+	mfaRepo := mock_repository.NewMockMFARepository(ctrl)
+	cacheable := mock_cache.NewMockCacheable(ctrl)
+	config := configs.MFAConfig{RequiredRoles: []string{entity.RoleAdmin}}
+	service := NewAuthorizationService(roleRepo, mock_repository.NewMockUserRepository(ctrl), mfaRepo, cacheable, configs.EmailVerificationConfig{Policy: EmailVerificationPolicyNone}, config)
+	ctx := context.Background()
+
+	cacheable.EXPECT().Get(authorizationVersionCacheKey).Return("", nil)
+	cacheable.EXPECT().Get("go-todo-api:authorization::1").Return("", nil)
+	roleRepo.EXPECT().FindByUserID(ctx, int64(1)).Return([]entity.Role{
+		{Name: entity.RoleAdmin, Permissions: entity.StringList{entity.PermissionAll}},
+		{Name: "user", Permissions: entity.StringList{entity.PermissionTodoRead, entity.PermissionTodoWrite}},
+	}, nil)
+	// TOTP yang belum dikonfirmasi belum dianggap aktif
+	mfaRepo.EXPECT().FindByUserID(ctx, int64(1)).Return(&entity.UserTOTP{UserID: 1}, nil)
+	expected := &entity.Authorization{
+		Roles:       []string{entity.RoleAdmin, "user"},
+		Permissions: []string{entity.PermissionTodoRead, entity.PermissionTodoWrite},
+		MFARequired: true,
+	}
+	cacheable.EXPECT().Set("go-todo-api:authorization::1", expected, authorizationCacheTTL).Return(nil)
+
+	// Admin tetap memiliki izin role lain sehingga dapat login dan mendaftarkan TOTP
+	authorization, err := service.Lookup(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, authorization)
+}
+
+func TestAuthorizationService_Lookup_GrantsRoleRequiringMFAWhenEnabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	roleRepo := mock_repository.NewMockRoleRepository(ctrl)
+	mfaRepo := mock_repository.NewMockMFARepository(ctrl)
+	cacheable := mock_cache.NewMockCacheable(ctrl)
+	config := configs.MFAConfig{RequiredRoles: []string{entity.RoleAdmin}}
+	service := NewAuthorizationService(roleRepo, mock_repository.NewMockUserRepository(ctrl), mfaRepo, cacheable, configs.EmailVerificationConfig{Policy: EmailVerificationPolicyNone}, config)
+	ctx := context.Background()
+
+	confirmedAt := time.Now()
+	cacheable.EXPECT().Get(authorizationVersionCacheKey).Return("", nil)
+	cacheable.EXPECT().Get("go-todo-api:authorization::1").Return("", nil)
+	roleRepo.EXPECT().FindByUserID(ctx, int64(1)).Return([]entity.Role{
+		{Name: entity.RoleAdmin, Permissions: entity.StringList{entity.PermissionAll}},
+	}, nil)
+	mfaRepo.EXPECT().FindByUserID(ctx, int64(1)).Return(&entity.UserTOTP{UserID: 1, ConfirmedAt: &confirmedAt}, nil)
+	expected := &entity.Authorization{
+		Roles:       []string{entity.RoleAdmin},
+		Permissions: []string{entity.PermissionAll},
+	}
+	cacheable.EXPECT().Set("go-todo-api:authorization::1", expected, authorizationCacheTTL).Return(nil)
+
+	authorization, err := service.Lookup(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, authorization)
+}
@@ -0,0 +1,406 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-todo/internal/entity"
+	"go-todo/internal/repository"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+var (
+	ErrFieldKustomTidakDitemukan      = errors.New("field kustom tidak ditemukan")
+	ErrDefinisiFieldKustomTidakValid  = errors.New("definisi field kustom tidak valid")
+	ErrKeyFieldKustomDipakai          = errors.New("key field kustom sudah digunakan")
+	ErrNilaiFieldKustomTidakValid     = errors.New("nilai field kustom tidak valid")
+	ErrPencarianFieldKustomTidakValid = errors.New("kondisi pencarian field kustom tidak valid")
+)
+
+var (
+	customFieldKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+	// customFieldOrderedOperators hanya berlaku untuk number dan date
+	customFieldOrderedOperators = map[string]bool{"lt": true, "lte": true, "gt": true, "gte": true}
+	customFieldSearchOperators  = map[string]bool{"eq": true, "neq": true, "lt": true, "lte": true, "gt": true, "gte": true, "contains": true, "exists": true, "missing": true}
+)
+
+const (
+	// maxCustomFields membatasi jumlah definisi field kustom per pengguna
+	maxCustomFields = 50
+	// maxCustomFieldOptions membatasi jumlah pilihan field select
+	maxCustomFieldOptions = 50
+	// maxCustomTextLength adalah panjang maksimal nilai text jika max_length tidak diatur
+	maxCustomTextLength = 1000
+	// maxCustomFieldFilters membatasi jumlah kondisi dalam satu pencarian
+	maxCustomFieldFilters = 10
+	customFieldDateLayout = "2006-01-02"
+)
+
+// CustomFieldValidator memvalidasi dan menormalkan nilai field kustom sebuah todo
+// terhadap definisi milik pemiliknya.
+type CustomFieldValidator interface {
+	ValidateValues(ctx context.Context, userID int64, values entity.CustomFieldValues) (entity.CustomFieldValues, error)
+}
+
+// CustomFieldService mengelola definisi field kustom milik pengguna, validasi nilainya,
+// serta pencarian todo berdasarkan nilai field kustom.
+type CustomFieldService interface {
+	CustomFieldValidator
+	FindAll(ctx context.Context, userID int64) ([]entity.CustomFieldDefinition, error)
+	Create(ctx context.Context, definition *entity.CustomFieldDefinition) (*entity.CustomFieldDefinition, error)
+	Update(ctx context.Context, definition *entity.CustomFieldDefinition) (*entity.CustomFieldDefinition, error)
+	Delete(ctx context.Context, userID, id int64) error
+	SearchTodos(ctx context.Context, userID int64, filters []entity.CustomFieldFilter, sort string) ([]entity.Todo, error)
+}
+
+type customFieldService struct {
+	customFieldRepository repository.CustomFieldRepository
+	todoRepository        repository.TodoRepository
+	transactor            repository.Transactor
+}
+
+// NewCustomFieldService membuat instance baru dari CustomFieldService
+func NewCustomFieldService(
+	customFieldRepository repository.CustomFieldRepository,
+	todoRepository repository.TodoRepository,
+	transactor repository.Transactor,
+) CustomFieldService {
+	return &customFieldService{
+		customFieldRepository: customFieldRepository,
+		todoRepository:        todoRepository,
+		transactor:            transactor,
+	}
+}
+
+// FindAll mengambil seluruh definisi field kustom milik pengguna
+func (s *customFieldService) FindAll(ctx context.Context, userID int64) ([]entity.CustomFieldDefinition, error) {
+	definitions, err := s.customFieldRepository.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil field kustom: %w", err)
+	}
+	return definitions, nil
+}
+
+// Create menyimpan definisi field kustom baru setelah divalidasi. Key harus unik per pengguna.
+func (s *customFieldService) Create(ctx context.Context, definition *entity.CustomFieldDefinition) (*entity.CustomFieldDefinition, error) {
+	definition.Key = strings.TrimSpace(definition.Key)
+	if !customFieldKeyPattern.MatchString(definition.Key) {
+		return nil, fmt.Errorf("%w: key hanya boleh huruf kecil, angka, dan garis bawah, diawali huruf", ErrDefinisiFieldKustomTidakValid)
+	}
+	if !entity.IsValidCustomFieldType(definition.Type) {
+		return nil, fmt.Errorf("%w: tipe harus text, number, date, select, atau checkbox", ErrDefinisiFieldKustomTidakValid)
+	}
+	if err := validateCustomFieldDefinition(definition); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.customFieldRepository.FindByUserID(ctx, definition.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil field kustom: %w", err)
+	}
+	if len(existing) >= maxCustomFields {
+		return nil, fmt.Errorf("%w: maksimal %d field kustom", ErrDefinisiFieldKustomTidakValid, maxCustomFields)
+	}
+	for _, other := range existing {
+		if other.Key == definition.Key {
+			return nil, ErrKeyFieldKustomDipakai
+		}
+	}
+
+	created, err := s.customFieldRepository.Create(ctx, definition)
+	if err != nil {
+		return nil, fmt.Errorf("gagal menyimpan field kustom: %w", err)
+	}
+	return created, nil
+}
+
+// Update memperbarui nama dan aturan validasi field kustom. Key dan tipe tetap seperti semula
+// karena nilai yang sudah tersimpan pada todo bergantung pada keduanya.
+func (s *customFieldService) Update(ctx context.Context, definition *entity.CustomFieldDefinition) (*entity.CustomFieldDefinition, error) {
+	existing, err := s.customFieldRepository.FindByID(ctx, definition.UserID, definition.ID)
+	if err != nil {
+		if errors.Is(err, repository.ErrFieldKustomTidakDitemukan) {
+			return nil, ErrFieldKustomTidakDitemukan
+		}
+		return nil, fmt.Errorf("gagal mengambil field kustom: %w", err)
+	}
+	if (definition.Key != "" && definition.Key != existing.Key) || (definition.Type != "" && definition.Type != existing.Type) {
+		return nil, fmt.Errorf("%w: key dan tipe tidak dapat diubah", ErrDefinisiFieldKustomTidakValid)
+	}
+	definition.Key = existing.Key
+	definition.Type = existing.Type
+	definition.CreatedAt = existing.CreatedAt
+	if err := validateCustomFieldDefinition(definition); err != nil {
+		return nil, err
+	}
+
+	definition.UpdatedAt = time.Now()
+	updated, err := s.customFieldRepository.Update(ctx, definition)
+	if err != nil {
+		if errors.Is(err, repository.ErrFieldKustomTidakDitemukan) {
+			return nil, ErrFieldKustomTidakDitemukan
+		}
+		return nil, fmt.Errorf("gagal memperbarui field kustom: %w", err)
+	}
+	return updated, nil
+}
+
+// Delete menghapus definisi field kustom beserta nilainya pada seluruh todo pengguna
+func (s *customFieldService) Delete(ctx context.Context, userID, id int64) error {
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		return s.customFieldRepository.Delete(ctx, userID, id)
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrFieldKustomTidakDitemukan) {
+			return ErrFieldKustomTidakDitemukan
+		}
+		return fmt.Errorf("gagal menghapus field kustom: %w", err)
+	}
+	return nil
+}
+
+// validateCustomFieldDefinition memeriksa nama dan aturan validasi sesuai tipe field
+func validateCustomFieldDefinition(definition *entity.CustomFieldDefinition) error {
+	definition.Name = strings.TrimSpace(definition.Name)
+	if definition.Name == "" || utf8.RuneCountInString(definition.Name) > 100 {
+		return fmt.Errorf("%w: nama wajib diisi dan maksimal 100 karakter", ErrDefinisiFieldKustomTidakValid)
+	}
+	if definition.Type != entity.CustomFieldSelect && len(definition.Options) > 0 {
+		return fmt.Errorf("%w: options hanya untuk tipe select", ErrDefinisiFieldKustomTidakValid)
+	}
+	if definition.Type != entity.CustomFieldNumber && (definition.Min != nil || definition.Max != nil) {
+		return fmt.Errorf("%w: min dan max hanya untuk tipe number", ErrDefinisiFieldKustomTidakValid)
+	}
+	if definition.Type != entity.CustomFieldText && definition.MaxLength != 0 {
+		return fmt.Errorf("%w: max_length hanya untuk tipe text", ErrDefinisiFieldKustomTidakValid)
+	}
+
+	switch definition.Type {
+	case entity.CustomFieldSelect:
+		if len(definition.Options) == 0 || len(definition.Options) > maxCustomFieldOptions {
+			return fmt.Errorf("%w: select membutuhkan 1 sampai %d pilihan", ErrDefinisiFieldKustomTidakValid, maxCustomFieldOptions)
+		}
+		seen := make(map[string]bool)
+		for i, option := range definition.Options {
+			option = strings.TrimSpace(option)
+			if option == "" || seen[option] {
+				return fmt.Errorf("%w: pilihan tidak boleh kosong atau duplikat", ErrDefinisiFieldKustomTidakValid)
+			}
+			seen[option] = true
+			definition.Options[i] = option
+		}
+	case entity.CustomFieldNumber:
+		if definition.Min != nil && definition.Max != nil && *definition.Min > *definition.Max {
+			return fmt.Errorf("%w: min tidak boleh lebih besar dari max", ErrDefinisiFieldKustomTidakValid)
+		}
+	case entity.CustomFieldText:
+		if definition.MaxLength < 0 || definition.MaxLength > maxCustomTextLength {
+			return fmt.Errorf("%w: max_length harus antara 0 dan %d", ErrDefinisiFieldKustomTidakValid, maxCustomTextLength)
+		}
+	}
+	if definition.Options == nil {
+		definition.Options = entity.StringList{}
+	}
+	return nil
+}
+
+// ValidateValues memeriksa setiap nilai terhadap definisinya, menormalkan formatnya,
+// dan memastikan field wajib terisi. Nilai null atau text kosong dianggap tidak diisi.
+func (s *customFieldService) ValidateValues(ctx context.Context, userID int64, values entity.CustomFieldValues) (entity.CustomFieldValues, error) {
+	definitions, err := s.customFieldRepository.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil field kustom: %w", err)
+	}
+	byKey := make(map[string]entity.CustomFieldDefinition, len(definitions))
+	for _, definition := range definitions {
+		byKey[definition.Key] = definition
+	}
+
+	result := make(entity.CustomFieldValues, len(values))
+	for key, raw := range values {
+		definition, ok := byKey[key]
+		if !ok {
+			return nil, fmt.Errorf("%w: field %q tidak dikenal", ErrNilaiFieldKustomTidakValid, key)
+		}
+		if raw == nil {
+			continue
+		}
+		value, err := normalizeCustomFieldValue(definition, raw)
+		if err != nil {
+			return nil, fmt.Errorf("%w: field %q %v", ErrNilaiFieldKustomTidakValid, key, err)
+		}
+		if value != nil {
+			result[key] = value
+		}
+	}
+	for _, definition := range definitions {
+		if _, ok := result[definition.Key]; definition.Required && !ok {
+			return nil, fmt.Errorf("%w: field %q wajib diisi", ErrNilaiFieldKustomTidakValid, definition.Key)
+		}
+	}
+	return result, nil
+}
+
+// normalizeCustomFieldValue mengubah nilai mentah dari JSON menjadi bentuk yang disimpan
+func normalizeCustomFieldValue(definition entity.CustomFieldDefinition, raw interface{}) (interface{}, error) {
+	switch definition.Type {
+	case entity.CustomFieldText:
+		text, ok := raw.(string)
+		if !ok {
+			return nil, errors.New("harus berupa teks")
+		}
+		if text = strings.TrimSpace(text); text == "" {
+			return nil, nil
+		}
+		limit := definition.MaxLength
+		if limit == 0 {
+			limit = maxCustomTextLength
+		}
+		if utf8.RuneCountInString(text) > limit {
+			return nil, fmt.Errorf("maksimal %d karakter", limit)
+		}
+		return text, nil
+	case entity.CustomFieldNumber:
+		number, err := customFieldNumber(raw)
+		if err != nil {
+			return nil, err
+		}
+		if definition.Min != nil && number < *definition.Min {
+			return nil, fmt.Errorf("minimal %v", *definition.Min)
+		}
+		if definition.Max != nil && number > *definition.Max {
+			return nil, fmt.Errorf("maksimal %v", *definition.Max)
+		}
+		return number, nil
+	case entity.CustomFieldDate:
+		text, ok := raw.(string)
+		if !ok {
+			return nil, errors.New("harus berupa tanggal YYYY-MM-DD")
+		}
+		date, err := time.Parse(customFieldDateLayout, text)
+		if err != nil {
+			return nil, errors.New("harus berupa tanggal YYYY-MM-DD")
+		}
+		return date.Format(customFieldDateLayout), nil
+	case entity.CustomFieldSelect:
+		text, ok := raw.(string)
+		if !ok {
+			return nil, errors.New("harus berupa salah satu pilihan")
+		}
+		for _, option := range definition.Options {
+			if option == text {
+				return text, nil
+			}
+		}
+		return nil, fmt.Errorf("harus salah satu dari %s", strings.Join(definition.Options, ", "))
+	case entity.CustomFieldCheckbox:
+		checked, ok := raw.(bool)
+		if !ok {
+			return nil, errors.New("harus berupa boolean")
+		}
+		return checked, nil
+	}
+	return nil, fmt.Errorf("memiliki tipe %q yang tidak dikenal", definition.Type)
+}
+
+// customFieldNumber membaca angka dari hasil decode JSON
+func customFieldNumber(raw interface{}) (float64, error) {
+	switch number := raw.(type) {
+	case float64:
+		return number, nil
+	case int:
+		return float64(number), nil
+	case int64:
+		return float64(number), nil
+	case json.Number:
+		return number.Float64()
+	}
+	return 0, errors.New("harus berupa angka")
+}
+
+// SearchTodos mencari todo pengguna berdasarkan nilai field kustom. Sort berisi key field,
+// diawali "-" untuk urutan menurun; kosong berarti urut berdasarkan tenggat.
+func (s *customFieldService) SearchTodos(ctx context.Context, userID int64, filters []entity.CustomFieldFilter, sort string) ([]entity.Todo, error) {
+	if len(filters) > maxCustomFieldFilters {
+		return nil, fmt.Errorf("%w: maksimal %d kondisi", ErrPencarianFieldKustomTidakValid, maxCustomFieldFilters)
+	}
+	definitions, err := s.customFieldRepository.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil field kustom: %w", err)
+	}
+	byKey := make(map[string]entity.CustomFieldDefinition, len(definitions))
+	for _, definition := range definitions {
+		byKey[definition.Key] = definition
+	}
+
+	conditions := make([]entity.CustomFieldCondition, 0, len(filters))
+	for _, f := range filters {
+		condition, err := customFieldCondition(byKey, f)
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, condition)
+	}
+
+	var order *entity.CustomFieldSort
+	if sort != "" {
+		key := strings.TrimPrefix(sort, "-")
+		definition, ok := byKey[key]
+		if !ok {
+			return nil, fmt.Errorf("%w: field %q tidak dikenal", ErrPencarianFieldKustomTidakValid, key)
+		}
+		order = &entity.CustomFieldSort{Key: key, Type: definition.Type, Desc: strings.HasPrefix(sort, "-")}
+	}
+
+	todos, err := s.todoRepository.FindByCustomFields(ctx, userID, conditions, order)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mencari todo: %w", err)
+	}
+	return todos, nil
+}
+
+// customFieldCondition memvalidasi operator dan mengubah nilai pencarian sesuai tipe field
+func customFieldCondition(byKey map[string]entity.CustomFieldDefinition, f entity.CustomFieldFilter) (entity.CustomFieldCondition, error) {
+	definition, ok := byKey[f.Key]
+	if !ok {
+		return entity.CustomFieldCondition{}, fmt.Errorf("%w: field %q tidak dikenal", ErrPencarianFieldKustomTidakValid, f.Key)
+	}
+	op := f.Op
+	if op == "" {
+		op = "eq"
+	}
+	condition := entity.CustomFieldCondition{Key: f.Key, Type: definition.Type, Op: op}
+	switch {
+	case !customFieldSearchOperators[op]:
+		return condition, fmt.Errorf("%w: operator %q tidak dikenal", ErrPencarianFieldKustomTidakValid, op)
+	case op == "exists" || op == "missing":
+		return condition, nil
+	case op == "contains" && definition.Type != entity.CustomFieldText:
+		return condition, fmt.Errorf("%w: operator contains hanya untuk text", ErrPencarianFieldKustomTidakValid)
+	case customFieldOrderedOperators[op] && definition.Type != entity.CustomFieldNumber && definition.Type != entity.CustomFieldDate:
+		return condition, fmt.Errorf("%w: operator %s hanya untuk number dan date", ErrPencarianFieldKustomTidakValid, op)
+	}
+
+	var err error
+	switch definition.Type {
+	case entity.CustomFieldNumber:
+		condition.Value, err = strconv.ParseFloat(f.Value, 64)
+	case entity.CustomFieldDate:
+		var date time.Time
+		date, err = time.Parse(customFieldDateLayout, f.Value)
+		condition.Value = date.Format(customFieldDateLayout)
+	case entity.CustomFieldCheckbox:
+		condition.Value, err = strconv.ParseBool(f.Value)
+	default:
+		condition.Value = f.Value
+	}
+	if err != nil {
+		return condition, fmt.Errorf("%w: nilai %q tidak sesuai tipe %s", ErrPencarianFieldKustomTidakValid, f.Value, definition.Type)
+	}
+	return condition, nil
+}
@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+	"go-todo/internal/entity"
+	mock_repository "go-todo/test/mock/repository"
+	mock_service "go-todo/test/mock/service"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// passThroughCustomFields mengembalikan validator yang menerima nilai field kustom apa adanya
+func passThroughCustomFields(ctrl *gomock.Controller) *mock_service.MockCustomFieldValidator {
+	validator := mock_service.NewMockCustomFieldValidator(ctrl)
+	validator.EXPECT().ValidateValues(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+		func(ctx context.Context, userID int64, values entity.CustomFieldValues) (entity.CustomFieldValues, error) {
+			return values, nil
+		})
+	return validator
+}
+
+func customFieldDefinitions() []entity.CustomFieldDefinition {
+	min, max := 0.0, 10.0
+	return []entity.CustomFieldDefinition{
+		{ID: 1, UserID: 5, Key: "estimasi", Type: entity.CustomFieldNumber, Min: &min, Max: &max},
+		{ID: 2, UserID: 5, Key: "status", Type: entity.CustomFieldSelect, Required: true, Options: entity.StringList{"draft", "review"}},
+		{ID: 3, UserID: 5, Key: "mulai", Type: entity.CustomFieldDate},
+		{ID: 4, UserID: 5, Key: "catatan", Type: entity.CustomFieldText, MaxLength: 5},
+		{ID: 5, UserID: 5, Key: "ditagih", Type: entity.CustomFieldCheckbox},
+	}
+}
+
+func TestCustomFieldService_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockCustomFieldRepository(ctrl)
+	service := NewCustomFieldService(mockRepo, nil, nil)
+
+	mockRepo.EXPECT().FindByUserID(gomock.Any(), int64(5)).Return(customFieldDefinitions(), nil)
+	mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, definition *entity.CustomFieldDefinition) (*entity.CustomFieldDefinition, error) {
+			definition.ID = 6
+			return definition, nil
+		})
+
+	created, err := service.Create(context.Background(), &entity.CustomFieldDefinition{
+		UserID:  5,
+		Key:     "prioritas_klien",
+		Name:    " Prioritas Klien ",
+		Type:    entity.CustomFieldSelect,
+		Options: entity.StringList{" A ", "B"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "Prioritas Klien", created.Name)
+	assert.Equal(t, entity.StringList{"A", "B"}, created.Options)
+}
+
+func TestCustomFieldService_Create_Invalid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockCustomFieldRepository(ctrl)
+	service := NewCustomFieldService(mockRepo, nil, nil)
+	min, max := 5.0, 1.0
+
+	cases := []entity.CustomFieldDefinition{
+		{Key: "Bukan Key", Name: "x", Type: entity.CustomFieldText},
+		{Key: "x", Name: "x", Type: "rich_text"},
+		{Key: "x", Name: "x", Type: entity.CustomFieldSelect},
+		{Key: "x", Name: "x", Type: entity.CustomFieldSelect, Options: entity.StringList{"a", "a"}},
+		{Key: "x", Name: "x", Type: entity.CustomFieldNumber, Min: &min, Max: &max},
+		{Key: "x", Name: "x", Type: entity.CustomFieldDate, MaxLength: 10},
+		{Key: "x", Name: "", Type: entity.CustomFieldCheckbox},
+	}
+	for _, definition := range cases {
+		_, err := service.Create(context.Background(), &definition)
+		assert.ErrorIs(t, err, ErrDefinisiFieldKustomTidakValid, definition)
+	}
+}
+
+func TestCustomFieldService_Create_DuplicateKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockCustomFieldRepository(ctrl)
+	service := NewCustomFieldService(mockRepo, nil, nil)
+
+	mockRepo.EXPECT().FindByUserID(gomock.Any(), int64(5)).Return(customFieldDefinitions(), nil)
+
+	_, err := service.Create(context.Background(), &entity.CustomFieldDefinition{
+		UserID: 5, Key: "status", Name: "Status", Type: entity.CustomFieldText,
+	})
+	assert.ErrorIs(t, err, ErrKeyFieldKustomDipakai)
+}
+
+func TestCustomFieldService_Update_TypeImmutable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockCustomFieldRepository(ctrl)
+	service := NewCustomFieldService(mockRepo, nil, nil)
+
+	existing := customFieldDefinitions()[0]
+	mockRepo.EXPECT().FindByID(gomock.Any(), int64(5), int64(1)).Return(&existing, nil)
+
+	_, err := service.Update(context.Background(), &entity.CustomFieldDefinition{
+		ID: 1, UserID: 5, Name: "Estimasi", Type: entity.CustomFieldText,
+	})
+	assert.ErrorIs(t, err, ErrDefinisiFieldKustomTidakValid)
+}
+
+func TestCustomFieldService_ValidateValues(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockCustomFieldRepository(ctrl)
+	service := NewCustomFieldService(mockRepo, nil, nil)
+	mockRepo.EXPECT().FindByUserID(gomock.Any(), int64(5)).Return(customFieldDefinitions(), nil).AnyTimes()
+
+	values, err := service.ValidateValues(context.Background(), 5, entity.CustomFieldValues{
+		"estimasi": 3.5,
+		"status":   "review",
+		"mulai":    "2026-10-18",
+		"catatan":  "  ",
+		"ditagih":  true,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, entity.CustomFieldValues{
+		"estimasi": 3.5,
+		"status":   "review",
+		"mulai":    "2026-10-18",
+		"ditagih":  true,
+	}, values)
+
+	invalid := []entity.CustomFieldValues{
+		{"estimasi": 1.0},                     // status wajib diisi
+		{"status": "selesai"},                 // bukan salah satu pilihan
+		{"status": "draft", "estimasi": 11.0}, // melebihi max
+		{"status": "draft", "mulai": "18/10/2026"},
+		{"status": "draft", "catatan": "terlalu panjang"},
+		{"status": "draft", "ditagih": "ya"},
+		{"status": "draft", "tidak_ada": "x"},
+	}
+	for _, value := range invalid {
+		_, err := service.ValidateValues(context.Background(), 5, value)
+		assert.ErrorIs(t, err, ErrNilaiFieldKustomTidakValid, value)
+	}
+}
+
+func TestCustomFieldService_SearchTodos(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockCustomFieldRepository(ctrl)
+	mockTodoRepo := mock_repository.NewMockTodoRepository(ctrl)
+	service := NewCustomFieldService(mockRepo, mockTodoRepo, nil)
+
+	mockRepo.EXPECT().FindByUserID(gomock.Any(), int64(5)).Return(customFieldDefinitions(), nil)
+	mockTodoRepo.EXPECT().FindByCustomFields(gomock.Any(), int64(5),
+		[]entity.CustomFieldCondition{
+			{Key: "estimasi", Type: entity.CustomFieldNumber, Op: "gte", Value: 3.0},
+			{Key: "status", Type: entity.CustomFieldSelect, Op: "eq", Value: "review"},
+		},
+		&entity.CustomFieldSort{Key: "mulai", Type: entity.CustomFieldDate, Desc: true},
+	).Return([]entity.Todo{{ID: 1}}, nil)
+
+	todos, err := service.SearchTodos(context.Background(), 5, []entity.CustomFieldFilter{
+		{Key: "estimasi", Op: "gte", Value: "3"},
+		{Key: "status", Value: "review"},
+	}, "-mulai")
+	assert.NoError(t, err)
+	assert.Len(t, todos, 1)
+}
+
+func TestCustomFieldService_SearchTodos_Invalid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockCustomFieldRepository(ctrl)
+	service := NewCustomFieldService(mockRepo, nil, nil)
+	mockRepo.EXPECT().FindByUserID(gomock.Any(), int64(5)).Return(customFieldDefinitions(), nil).AnyTimes()
+
+	invalid := [][]entity.CustomFieldFilter{
+		{{Key: "status", Op: "gt", Value: "draft"}},
+		{{Key: "estimasi", Value: "banyak"}},
+		{{Key: "estimasi", Op: "like", Value: "1"}},
+		{{Key: "tidak_ada", Value: "x"}},
+	}
+	for _, filters := range invalid {
+		_, err := service.SearchTodos(context.Background(), 5, filters, "")
+		assert.ErrorIs(t, err, ErrPencarianFieldKustomTidakValid, filters)
+	}
+
+	_, err := service.SearchTodos(context.Background(), 5, nil, "tidak_ada")
+	assert.ErrorIs(t, err, ErrPencarianFieldKustomTidakValid)
+}
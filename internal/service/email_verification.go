@@ -0,0 +1,279 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-todo/configs"
+	"go-todo/internal/entity"
+	"go-todo/internal/repository"
+	"go-todo/pkg/mail"
+	netmail "net/mail"
+	"strings"
+	"time"
+)
+
+// Kebijakan terhadap pengguna yang emailnya belum diverifikasi
+const (
+	EmailVerificationPolicyNone     = "none"
+	EmailVerificationPolicyRestrict = "restrict"
+	EmailVerificationPolicyBlock    = "block"
+)
+
+var (
+	ErrEmailTidakValid           = errors.New("format email tidak valid")
+	ErrEmailSudahAda             = errors.New("email sudah digunakan")
+	ErrEmailBelumDiverifikasi    = errors.New("email belum diverifikasi, periksa kotak masuk Anda")
+	ErrEmailSudahDiverifikasi    = errors.New("email sudah diverifikasi")
+	ErrEmailTidakAda             = errors.New("pengguna belum memiliki email")
+	ErrTokenVerifikasiTidakValid = errors.New("token verifikasi email tidak valid atau sudah kedaluwarsa")
+	ErrVerifikasiTerlaluSering   = errors.New("email verifikasi terlalu sering diminta, coba lagi nanti")
+)
+
+const (
+	defaultVerificationTokenTTL      = 24 * time.Hour
+	defaultVerificationResendGap     = time.Minute
+	defaultVerificationResendPerHour = 5
+)
+
+// EmailVerificationService memverifikasi kepemilikan email melalui token sekali pakai yang
+// dikirim ke alamat tersebut. Hanya hash token yang disimpan di database.
+type EmailVerificationService interface {
+	// Send mengirim token verifikasi ke email pengguna dan membatalkan token sebelumnya
+	Send(ctx context.Context, user *entity.User) error
+	// Resend mengirim ulang token verifikasi atas permintaan pengguna dengan pembatasan frekuensi
+	Resend(ctx context.Context, userID int64) error
+	// ResendByLogin mengirim ulang token verifikasi untuk pengguna yang belum dapat login,
+	// dicari berdasarkan username atau email. Akun yang tidak ditemukan, sudah terverifikasi,
+	// maupun permintaan yang melewati batas tetap berhasil tanpa mengirim apa pun agar
+	// keberadaan akun tidak bocor.
+	ResendByLogin(ctx context.Context, identifier string) error
+	// Verify menandai email terverifikasi jika token valid dan email pengguna belum berganti
+	Verify(ctx context.Context, verificationToken string) error
+	// CheckLogin mengembalikan ErrEmailBelumDiverifikasi jika kebijakan menolak login pengguna
+	CheckLogin(user *entity.User) error
+}
+
+type emailVerificationService struct {
+	userRepository              repository.UserRepository
+	emailVerificationRepository repository.EmailVerificationRepository
+	authorization               AuthorizationService
+	sender                      mail.Sender
+	transactor                  repository.Transactor
+	config                      configs.EmailVerificationConfig
+}
+
+// NewEmailVerificationService membuat instance baru dari EmailVerificationService.
+// Masa berlaku dan batas pengiriman ulang yang tidak diatur diganti dengan nilai default.
+func NewEmailVerificationService(
+	userRepository repository.UserRepository,
+	emailVerificationRepository repository.EmailVerificationRepository,
+	authorization AuthorizationService,
+	sender mail.Sender,
+	transactor repository.Transactor,
+	config configs.EmailVerificationConfig,
+) EmailVerificationService {
+	if config.TokenTTLHours <= 0 {
+		config.TokenTTLHours = int(defaultVerificationTokenTTL / time.Hour)
+	}
+	if config.ResendIntervalSeconds <= 0 {
+		config.ResendIntervalSeconds = int(defaultVerificationResendGap / time.Second)
+	}
+	if config.MaxResendsPerHour <= 0 {
+		config.MaxResendsPerHour = defaultVerificationResendPerHour
+	}
+	return &emailVerificationService{
+		userRepository:              userRepository,
+		emailVerificationRepository: emailVerificationRepository,
+		authorization:               authorization,
+		sender:                      sender,
+		transactor:                  transactor,
+		config:                      config,
+	}
+}
+
+// Send membuat token verifikasi baru untuk email pengguna saat ini. Email dikirim di
+// background agar pendaftaran tidak menunggu server SMTP.
+func (s *emailVerificationService) Send(ctx context.Context, user *entity.User) error {
+	if !user.EmailUnverified() {
+		return nil
+	}
+
+	now := time.Now()
+	ttl := time.Duration(s.config.TokenTTLHours) * time.Hour
+	verificationToken := newOpaqueToken()
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if _, err := s.emailVerificationRepository.InvalidateByUserID(ctx, user.ID, now); err != nil {
+			return err
+		}
+		return s.emailVerificationRepository.Create(ctx, &entity.EmailVerificationToken{
+			UserID:    user.ID,
+			Email:     user.Email,
+			TokenHash: hashToken(verificationToken),
+			ExpiresAt: now.Add(ttl),
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("gagal membuat token verifikasi email: %w", err)
+	}
+
+	msg := mail.Message{
+		To:      user.Email,
+		Subject: "Verifikasi email",
+		Body: fmt.Sprintf("Halo %s,\n\n"+
+			"Buka link berikut untuk memverifikasi email Anda. Link berlaku selama %d jam:\n\n"+
+			"%s\n\n"+
+			"Abaikan email ini jika Anda tidak mendaftar.\n",
+			user.FullName, s.config.TokenTTLHours, tokenURL(s.config.URL, verificationToken)),
+	}
+	go func() {
+		if err := s.sender.Send(context.WithoutCancel(ctx), msg); err != nil {
+			fmt.Printf("kesalahan mengirim email verifikasi: %v\n", err)
+		}
+	}()
+
+	return nil
+}
+
+// Resend mengirim ulang token verifikasi. Permintaan ditolak jika token terakhir dikirim
+// kurang dari ResendIntervalSeconds lalu atau sudah MaxResendsPerHour kali dalam satu jam.
+func (s *emailVerificationService) Resend(ctx context.Context, userID int64) error {
+	user, err := s.userRepository.FindByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrPenggunaTidakDitemukan) {
+			return ErrPenggunaTidakDitemukan
+		}
+		return fmt.Errorf("gagal mengirim ulang verifikasi email: %w", err)
+	}
+	return s.resend(ctx, user)
+}
+
+// ResendByLogin mencari pengguna berdasarkan email jika identifier mengandung "@", atau
+// berdasarkan username jika tidak, lalu menerapkan batas pengiriman ulang yang sama dengan Resend.
+func (s *emailVerificationService) ResendByLogin(ctx context.Context, identifier string) error {
+	var user *entity.User
+	var err error
+	if strings.Contains(identifier, "@") {
+		email, normErr := normalizeEmail(identifier)
+		if normErr != nil {
+			return nil
+		}
+		user, err = s.userRepository.FindByEmail(ctx, email)
+	} else {
+		user, err = s.userRepository.FindByUsername(ctx, identifier)
+	}
+	if err != nil {
+		if errors.Is(err, repository.ErrPenggunaTidakDitemukan) {
+			return nil
+		}
+		return fmt.Errorf("gagal mengirim ulang verifikasi email: %w", err)
+	}
+
+	err = s.resend(ctx, user)
+	switch {
+	case errors.Is(err, ErrEmailTidakAda), errors.Is(err, ErrEmailSudahDiverifikasi):
+		return nil
+	case errors.Is(err, ErrVerifikasiTerlaluSering):
+		fmt.Printf("peringatan: permintaan kirim ulang verifikasi email pengguna %d melewati batas\n", user.ID)
+		return nil
+	}
+	return err
+}
+
+// resend memeriksa status email dan batas pengiriman ulang sebelum mengirim token baru
+func (s *emailVerificationService) resend(ctx context.Context, user *entity.User) error {
+	if user.Email == "" {
+		return ErrEmailTidakAda
+	}
+	if user.EmailVerifiedAt != nil {
+		return ErrEmailSudahDiverifikasi
+	}
+
+	now := time.Now()
+	interval := time.Duration(s.config.ResendIntervalSeconds) * time.Second
+	recent, err := s.emailVerificationRepository.CountSince(ctx, user.ID, now.Add(-interval))
+	if err != nil {
+		return fmt.Errorf("gagal mengirim ulang verifikasi email: %w", err)
+	}
+	if recent > 0 {
+		return ErrVerifikasiTerlaluSering
+	}
+	hourly, err := s.emailVerificationRepository.CountSince(ctx, user.ID, now.Add(-time.Hour))
+	if err != nil {
+		return fmt.Errorf("gagal mengirim ulang verifikasi email: %w", err)
+	}
+	if hourly >= int64(s.config.MaxResendsPerHour) {
+		return ErrVerifikasiTerlaluSering
+	}
+
+	return s.Send(ctx, user)
+}
+
+// Verify menandai email terverifikasi dan membatalkan seluruh token verifikasi pengguna.
+// Token untuk alamat yang sudah diganti ditolak agar email lama tidak dapat memverifikasi
+// email baru.
+func (s *emailVerificationService) Verify(ctx context.Context, verificationToken string) error {
+	var userID int64
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		stored, err := s.emailVerificationRepository.FindByHashForUpdate(ctx, hashToken(verificationToken))
+		if err != nil {
+			if errors.Is(err, repository.ErrTokenVerifikasiTidakDitemukan) {
+				return ErrTokenVerifikasiTidakValid
+			}
+			return err
+		}
+		now := time.Now()
+		if stored.UsedAt != nil || !now.Before(stored.ExpiresAt) {
+			return ErrTokenVerifikasiTidakValid
+		}
+
+		user, err := s.userRepository.FindByID(ctx, stored.UserID)
+		if err != nil {
+			if errors.Is(err, repository.ErrPenggunaTidakDitemukan) {
+				return ErrTokenVerifikasiTidakValid
+			}
+			return err
+		}
+		if user.Email != stored.Email {
+			return ErrTokenVerifikasiTidakValid
+		}
+
+		if err := s.userRepository.SetEmailVerifiedAt(ctx, user.ID, &now); err != nil {
+			return err
+		}
+		if _, err := s.emailVerificationRepository.InvalidateByUserID(ctx, user.ID, now); err != nil {
+			return err
+		}
+		userID = user.ID
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, ErrTokenVerifikasiTidakValid) {
+			return err
+		}
+		return fmt.Errorf("gagal memverifikasi email: %w", err)
+	}
+
+	// Izin yang dibatasi selama email belum diverifikasi langsung dipulihkan
+	s.authorization.InvalidateUser(userID)
+	return nil
+}
+
+// CheckLogin menolak login pengguna yang emailnya belum diverifikasi pada kebijakan block
+func (s *emailVerificationService) CheckLogin(user *entity.User) error {
+	if s.config.Policy == EmailVerificationPolicyBlock && user.EmailUnverified() {
+		return ErrEmailBelumDiverifikasi
+	}
+	return nil
+}
+
+// normalizeEmail memvalidasi email dan mengubahnya ke huruf kecil agar keunikan tidak
+// bergantung pada kapitalisasi. Nama tampilan seperti "Budi <budi@example.com>" ditolak.
+func normalizeEmail(email string) (string, error) {
+	email = strings.TrimSpace(email)
+	address, err := netmail.ParseAddress(email)
+	if err != nil || address.Name != "" || address.Address != email {
+		return "", ErrEmailTidakValid
+	}
+	return strings.ToLower(email), nil
+}
@@ -0,0 +1,289 @@
+package service
+
+import (
+	"context"
+	"go-todo/configs"
+	"go-todo/internal/entity"
+	"go-todo/internal/repository"
+	"go-todo/pkg/mail"
+	mock_mail "go-todo/test/mock/pkg/mail"
+	mock_repository "go-todo/test/mock/repository"
+	mock_service "go-todo/test/mock/service"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// emailVerificationMocks mengelompokkan semua dependensi mock dari EmailVerificationService
+type emailVerificationMocks struct {
+	users         *mock_repository.MockUserRepository
+	verifications *mock_repository.MockEmailVerificationRepository
+	authz         *mock_service.MockAuthorizationService
+	sender        *mock_mail.MockSender
+}
+
+func setupEmailVerificationService(t *testing.T, policy string) (*gomock.Controller, EmailVerificationService, *emailVerificationMocks) {
+	ctrl := gomock.NewController(t)
+	m := &emailVerificationMocks{
+		users:         mock_repository.NewMockUserRepository(ctrl),
+		verifications: mock_repository.NewMockEmailVerificationRepository(ctrl),
+		authz:         mock_service.NewMockAuthorizationService(ctrl),
+		sender:        mock_mail.NewMockSender(ctrl),
+	}
+	config := configs.EmailVerificationConfig{
+		Policy:                policy,
+		TokenTTLHours:         24,
+		ResendIntervalSeconds: 60,
+		MaxResendsPerHour:     5,
+		URL:                   "https://todo.example.com/verify-email",
+	}
+	service := NewEmailVerificationService(m.users, m.verifications, m.authz, m.sender, passThroughTransactor(ctrl), config)
+	return ctrl, service, m
+}
+
+// Kasus uji untuk Send
+
+func TestEmailVerificationService_Send(t *testing.T) {
+	ctrl, service, m := setupEmailVerificationService(t, EmailVerificationPolicyRestrict)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	user := &entity.User{ID: 7, FullName: "Budi", Email: "budi@example.com"}
+	var stored *entity.EmailVerificationToken
+	sent := make(chan mail.Message, 1)
+
+	m.verifications.EXPECT().InvalidateByUserID(gomock.Any(), int64(7), gomock.Any()).Return(int64(0), nil)
+	m.verifications.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, verificationToken *entity.EmailVerificationToken) error {
+			stored = verificationToken
+			return nil
+		})
+	m.sender.EXPECT().Send(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, msg mail.Message) error {
+			sent <- msg
+			return nil
+		})
+
+	assert.NoError(t, service.Send(ctx, user))
+
+	var msg mail.Message
+	select {
+	case msg = <-sent:
+	case <-time.After(time.Second):
+		t.Fatal("email verifikasi tidak terkirim")
+	}
+	assert.Equal(t, "budi@example.com", msg.To)
+
+	start := strings.Index(msg.Body, "https://")
+	end := strings.Index(msg.Body[start:], "\n")
+	link, err := url.Parse(msg.Body[start : start+end])
+	assert.NoError(t, err)
+	assert.Equal(t, hashToken(link.Query().Get("token")), stored.TokenHash)
+	assert.Equal(t, "budi@example.com", stored.Email)
+	assert.WithinDuration(t, time.Now().Add(24*time.Hour), stored.ExpiresAt, 5*time.Second)
+}
+
+func TestEmailVerificationService_Send_SkipsVerifiedOrMissingEmail(t *testing.T) {
+	ctrl, service, _ := setupEmailVerificationService(t, EmailVerificationPolicyRestrict)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	verifiedAt := time.Now()
+	assert.NoError(t, service.Send(ctx, &entity.User{ID: 7}))
+	assert.NoError(t, service.Send(ctx, &entity.User{ID: 7, Email: "budi@example.com", EmailVerifiedAt: &verifiedAt}))
+}
+
+// Kasus uji untuk Resend
+
+func TestEmailVerificationService_Resend_TooSoon(t *testing.T) {
+	ctrl, service, m := setupEmailVerificationService(t, EmailVerificationPolicyRestrict)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m.users.EXPECT().FindByID(ctx, int64(7)).Return(&entity.User{ID: 7, Email: "budi@example.com"}, nil)
+	m.verifications.EXPECT().CountSince(ctx, int64(7), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, userID int64, since time.Time) (int64, error) {
+			assert.WithinDuration(t, time.Now().Add(-time.Minute), since, 5*time.Second)
+			return 1, nil
+		})
+
+	assert.ErrorIs(t, service.Resend(ctx, 7), ErrVerifikasiTerlaluSering)
+}
+
+func TestEmailVerificationService_Resend_HourlyLimit(t *testing.T) {
+	ctrl, service, m := setupEmailVerificationService(t, EmailVerificationPolicyRestrict)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m.users.EXPECT().FindByID(ctx, int64(7)).Return(&entity.User{ID: 7, Email: "budi@example.com"}, nil)
+	gomock.InOrder(
+		m.verifications.EXPECT().CountSince(ctx, int64(7), gomock.Any()).Return(int64(0), nil),
+		m.verifications.EXPECT().CountSince(ctx, int64(7), gomock.Any()).Return(int64(5), nil),
+	)
+
+	assert.ErrorIs(t, service.Resend(ctx, 7), ErrVerifikasiTerlaluSering)
+}
+
+func TestEmailVerificationService_Resend_AlreadyVerified(t *testing.T) {
+	ctrl, service, m := setupEmailVerificationService(t, EmailVerificationPolicyRestrict)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	verifiedAt := time.Now()
+	m.users.EXPECT().FindByID(ctx, int64(7)).Return(&entity.User{ID: 7, Email: "budi@example.com", EmailVerifiedAt: &verifiedAt}, nil)
+
+	assert.ErrorIs(t, service.Resend(ctx, 7), ErrEmailSudahDiverifikasi)
+}
+
+func TestEmailVerificationService_Resend_Success(t *testing.T) {
+	ctrl, service, m := setupEmailVerificationService(t, EmailVerificationPolicyRestrict)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	sent := make(chan struct{})
+	m.users.EXPECT().FindByID(ctx, int64(7)).Return(&entity.User{ID: 7, Email: "budi@example.com"}, nil)
+	m.verifications.EXPECT().CountSince(ctx, int64(7), gomock.Any()).Return(int64(0), nil).Times(2)
+	m.verifications.EXPECT().InvalidateByUserID(ctx, int64(7), gomock.Any()).Return(int64(1), nil)
+	m.verifications.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+	m.sender.EXPECT().Send(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, msg mail.Message) error {
+			close(sent)
+			return nil
+		})
+
+	assert.NoError(t, service.Resend(ctx, 7))
+	select {
+	case <-sent:
+	case <-time.After(time.Second):
+		t.Fatal("email verifikasi tidak terkirim")
+	}
+}
+
+func TestEmailVerificationService_ResendByLogin_Email(t *testing.T) {
+	ctrl, service, m := setupEmailVerificationService(t, EmailVerificationPolicyBlock)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	sent := make(chan mail.Message, 1)
+	m.users.EXPECT().FindByEmail(ctx, "budi@example.com").Return(&entity.User{ID: 7, Email: "budi@example.com"}, nil)
+	m.verifications.EXPECT().CountSince(ctx, int64(7), gomock.Any()).Return(int64(0), nil).Times(2)
+	m.verifications.EXPECT().InvalidateByUserID(ctx, int64(7), gomock.Any()).Return(int64(1), nil)
+	m.verifications.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+	m.sender.EXPECT().Send(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, msg mail.Message) error {
+			sent <- msg
+			return nil
+		})
+
+	assert.NoError(t, service.ResendByLogin(ctx, "Budi@Example.com"))
+	select {
+	case msg := <-sent:
+		assert.Equal(t, "budi@example.com", msg.To)
+	case <-time.After(time.Second):
+		t.Fatal("email verifikasi tidak terkirim")
+	}
+}
+
+func TestEmailVerificationService_ResendByLogin_UnknownUser(t *testing.T) {
+	ctrl, service, m := setupEmailVerificationService(t, EmailVerificationPolicyBlock)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m.users.EXPECT().FindByUsername(ctx, "hantu").Return(nil, repository.ErrPenggunaTidakDitemukan)
+
+	assert.NoError(t, service.ResendByLogin(ctx, "hantu"))
+}
+
+func TestEmailVerificationService_ResendByLogin_SilentWhenThrottledOrVerified(t *testing.T) {
+	ctrl, service, m := setupEmailVerificationService(t, EmailVerificationPolicyBlock)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	verifiedAt := time.Now()
+	m.users.EXPECT().FindByUsername(ctx, "budi").Return(&entity.User{ID: 7, Email: "budi@example.com"}, nil)
+	m.verifications.EXPECT().CountSince(ctx, int64(7), gomock.Any()).Return(int64(1), nil)
+	m.users.EXPECT().FindByUsername(ctx, "sari").Return(&entity.User{ID: 8, Email: "sari@example.com", EmailVerifiedAt: &verifiedAt}, nil)
+
+	// Batas pengiriman ulang tetap berlaku, tetapi responsnya sama dengan permintaan yang dikirim
+	assert.NoError(t, service.ResendByLogin(ctx, "budi"))
+	assert.NoError(t, service.ResendByLogin(ctx, "sari"))
+}
+
+// Kasus uji untuk Verify
+
+func TestEmailVerificationService_Verify_Success(t *testing.T) {
+	ctrl, service, m := setupEmailVerificationService(t, EmailVerificationPolicyRestrict)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	stored := &entity.EmailVerificationToken{UserID: 7, Email: "budi@example.com", ExpiresAt: time.Now().Add(time.Hour)}
+	m.verifications.EXPECT().FindByHashForUpdate(ctx, hashToken("token-asli")).Return(stored, nil)
+	m.users.EXPECT().FindByID(ctx, int64(7)).Return(&entity.User{ID: 7, Email: "budi@example.com"}, nil)
+	m.users.EXPECT().SetEmailVerifiedAt(ctx, int64(7), gomock.Not(gomock.Nil())).Return(nil)
+	m.verifications.EXPECT().InvalidateByUserID(ctx, int64(7), gomock.Any()).Return(int64(1), nil)
+	// Izin yang dibatasi langsung dipulihkan
+	m.authz.EXPECT().InvalidateUser(int64(7))
+
+	assert.NoError(t, service.Verify(ctx, "token-asli"))
+}
+
+func TestEmailVerificationService_Verify_EmailChanged(t *testing.T) {
+	ctrl, service, m := setupEmailVerificationService(t, EmailVerificationPolicyRestrict)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	stored := &entity.EmailVerificationToken{UserID: 7, Email: "lama@example.com", ExpiresAt: time.Now().Add(time.Hour)}
+	m.verifications.EXPECT().FindByHashForUpdate(ctx, hashToken("token-lama")).Return(stored, nil)
+	m.users.EXPECT().FindByID(ctx, int64(7)).Return(&entity.User{ID: 7, Email: "baru@example.com"}, nil)
+
+	assert.ErrorIs(t, service.Verify(ctx, "token-lama"), ErrTokenVerifikasiTidakValid)
+}
+
+func TestEmailVerificationService_Verify_InvalidToken(t *testing.T) {
+	ctrl, service, m := setupEmailVerificationService(t, EmailVerificationPolicyRestrict)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	usedAt := time.Now()
+	m.verifications.EXPECT().FindByHashForUpdate(ctx, hashToken("palsu")).Return(nil, repository.ErrTokenVerifikasiTidakDitemukan)
+	m.verifications.EXPECT().FindByHashForUpdate(ctx, hashToken("terpakai")).Return(&entity.EmailVerificationToken{UsedAt: &usedAt, ExpiresAt: time.Now().Add(time.Hour)}, nil)
+	m.verifications.EXPECT().FindByHashForUpdate(ctx, hashToken("kedaluwarsa")).Return(&entity.EmailVerificationToken{ExpiresAt: time.Now().Add(-time.Second)}, nil)
+
+	assert.ErrorIs(t, service.Verify(ctx, "palsu"), ErrTokenVerifikasiTidakValid)
+	assert.ErrorIs(t, service.Verify(ctx, "terpakai"), ErrTokenVerifikasiTidakValid)
+	assert.ErrorIs(t, service.Verify(ctx, "kedaluwarsa"), ErrTokenVerifikasiTidakValid)
+}
+
+// Kasus uji untuk CheckLogin
+
+func TestEmailVerificationService_CheckLogin(t *testing.T) {
+	unverified := &entity.User{ID: 7, Email: "budi@example.com"}
+	verifiedAt := time.Now()
+	verified := &entity.User{ID: 7, Email: "budi@example.com", EmailVerifiedAt: &verifiedAt}
+	withoutEmail := &entity.User{ID: 1}
+
+	ctrl, block, _ := setupEmailVerificationService(t, EmailVerificationPolicyBlock)
+	defer ctrl.Finish()
+	assert.ErrorIs(t, block.CheckLogin(unverified), ErrEmailBelumDiverifikasi)
+	assert.NoError(t, block.CheckLogin(verified))
+	assert.NoError(t, block.CheckLogin(withoutEmail))
+
+	// Kebijakan restrict tetap mengizinkan login; pembatasan dilakukan melalui izin
+	_, restrict, _ := setupEmailVerificationService(t, EmailVerificationPolicyRestrict)
+	assert.NoError(t, restrict.CheckLogin(unverified))
+}
+
+func TestNormalizeEmail(t *testing.T) {
+	email, err := normalizeEmail("  Budi.Santoso@Example.COM ")
+	assert.NoError(t, err)
+	assert.Equal(t, "budi.santoso@example.com", email)
+
+	for _, invalid := range []string{"budi", "budi@", "Budi <budi@example.com>", "budi@example.com\r\nBcc: x@example.com"} {
+		_, err := normalizeEmail(invalid)
+		assert.ErrorIs(t, err, ErrEmailTidakValid, invalid)
+	}
+}
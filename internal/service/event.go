@@ -0,0 +1,38 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"go-todo/internal/entity"
+)
+
+// EventRecorder mencatat domain event ke outbox. Record harus dipanggil dengan ctx dari
+// Transactor.WithinTransaction agar event hanya tersimpan jika perubahan entitasnya berhasil.
+type EventRecorder interface {
+	Record(ctx context.Context, event entity.DomainEvent) error
+}
+
+// EventSubscriber menerima domain event dari relay outbox. Event dapat diterima lebih
+// dari sekali, sehingga subscriber perlu idempoten terhadap ID event. Error yang
+// dikembalikan membuat event dikirim ulang kemudian.
+type EventSubscriber interface {
+	HandleEvent(ctx context.Context, event entity.Event) error
+}
+
+// EventSubscriberFunc memungkinkan fungsi biasa digunakan sebagai EventSubscriber di dalam proses
+type EventSubscriberFunc func(ctx context.Context, event entity.Event) error
+
+// HandleEvent memanggil f(ctx, event)
+func (f EventSubscriberFunc) HandleEvent(ctx context.Context, event entity.Event) error {
+	return f(ctx, event)
+}
+
+// newEventID membuat ID event acak yang unik, misalnya "evt_3f2a..."
+func newEventID() string {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		panic(err)
+	}
+	return "evt_" + hex.EncodeToString(buf)
+}
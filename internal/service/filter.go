@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-todo/internal/entity"
+	"go-todo/internal/repository"
+	"go-todo/pkg/filter"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrFilterTidakDitemukan = errors.New("filter tidak ditemukan")
+	ErrNamaFilterTidakValid = errors.New("nama filter wajib diisi dan maksimal 100 karakter")
+	ErrFilterBawaan         = errors.New("filter bawaan tidak dapat diubah atau dihapus")
+)
+
+// builtInFilters adalah smart list yang tersedia untuk setiap pengguna tanpa perlu disimpan
+var builtInFilters = []entity.SavedFilter{
+	{Key: entity.FilterToday, Name: "Today", Definition: filter.Expr{And: []filter.Expr{
+		{Field: "completed", Op: filter.OpEq, Value: false},
+		{Field: "due_date", Op: filter.OpEq, Value: "today"},
+	}}},
+	{Key: entity.FilterUpcoming, Name: "Upcoming", Definition: filter.Expr{And: []filter.Expr{
+		{Field: "completed", Op: filter.OpEq, Value: false},
+		{Field: "due_date", Op: filter.OpGte, Value: "tomorrow"},
+		{Field: "due_date", Op: filter.OpLt, Value: "+8d"},
+	}}},
+	{Key: entity.FilterOverdue, Name: "Overdue", Definition: filter.Expr{And: []filter.Expr{
+		{Field: "completed", Op: filter.OpEq, Value: false},
+		{Field: "due_date", Op: filter.OpLt, Value: "today"},
+	}}},
+	{Key: entity.FilterSomeday, Name: "Someday", Definition: filter.Expr{And: []filter.Expr{
+		{Field: "completed", Op: filter.OpEq, Value: false},
+		{Field: "due_date", Op: filter.OpIsNotSet},
+	}}},
+}
+
+// FilterService mengelola filter tersimpan (smart list) dan menjalankannya terhadap todo pengguna.
+// Filter bawaan dirujuk dengan key (today, upcoming, overdue, someday), filter milik
+// pengguna dengan ID numeriknya.
+type FilterService interface {
+	FindAll(ctx context.Context, userID int64) ([]entity.SavedFilter, error)
+	Find(ctx context.Context, userID int64, key string) (*entity.SavedFilter, error)
+	Create(ctx context.Context, savedFilter *entity.SavedFilter) (*entity.SavedFilter, error)
+	Update(ctx context.Context, savedFilter *entity.SavedFilter) (*entity.SavedFilter, error)
+	Delete(ctx context.Context, userID, id int64) error
+	Todos(ctx context.Context, userID int64, key string) ([]entity.Todo, error)
+}
+
+type filterService struct {
+	savedFilterRepository repository.SavedFilterRepository
+	todoRepository        repository.TodoRepository
+	userPreferenceService UserPreferenceService
+}
+
+// NewFilterService membuat instance baru dari FilterService
+func NewFilterService(
+	savedFilterRepository repository.SavedFilterRepository,
+	todoRepository repository.TodoRepository,
+	userPreferenceService UserPreferenceService,
+) FilterService {
+	return &filterService{
+		savedFilterRepository: savedFilterRepository,
+		todoRepository:        todoRepository,
+		userPreferenceService: userPreferenceService,
+	}
+}
+
+// FindAll mengambil filter bawaan diikuti filter milik pengguna
+func (s *filterService) FindAll(ctx context.Context, userID int64) ([]entity.SavedFilter, error) {
+	saved, err := s.savedFilterRepository.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil filter: %w", err)
+	}
+
+	filters := make([]entity.SavedFilter, 0, len(builtInFilters)+len(saved))
+	for _, builtIn := range builtInFilters {
+		filters = append(filters, withBuiltIn(builtIn, userID))
+	}
+	return append(filters, saved...), nil
+}
+
+// Find mengambil filter bawaan berdasarkan key atau filter milik pengguna berdasarkan ID
+func (s *filterService) Find(ctx context.Context, userID int64, key string) (*entity.SavedFilter, error) {
+	for _, builtIn := range builtInFilters {
+		if strings.EqualFold(builtIn.Key, key) {
+			found := withBuiltIn(builtIn, userID)
+			return &found, nil
+		}
+	}
+
+	id, err := strconv.ParseInt(key, 10, 64)
+	if err != nil || id <= 0 {
+		return nil, ErrFilterTidakDitemukan
+	}
+	found, err := s.savedFilterRepository.FindByID(ctx, userID, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrFilterTidakDitemukan) {
+			return nil, ErrFilterTidakDitemukan
+		}
+		return nil, fmt.Errorf("gagal mengambil filter: %w", err)
+	}
+	return found, nil
+}
+
+// Create menyimpan filter baru milik pengguna setelah definisinya divalidasi
+func (s *filterService) Create(ctx context.Context, savedFilter *entity.SavedFilter) (*entity.SavedFilter, error) {
+	if err := validateSavedFilter(savedFilter); err != nil {
+		return nil, err
+	}
+
+	created, err := s.savedFilterRepository.Create(ctx, savedFilter)
+	if err != nil {
+		return nil, fmt.Errorf("gagal membuat filter: %w", err)
+	}
+	return created, nil
+}
+
+// Update memperbarui nama dan definisi filter milik pengguna
+func (s *filterService) Update(ctx context.Context, savedFilter *entity.SavedFilter) (*entity.SavedFilter, error) {
+	if err := validateSavedFilter(savedFilter); err != nil {
+		return nil, err
+	}
+
+	savedFilter.UpdatedAt = time.Now()
+	updated, err := s.savedFilterRepository.Update(ctx, savedFilter)
+	if err != nil {
+		if errors.Is(err, repository.ErrFilterTidakDitemukan) {
+			return nil, ErrFilterTidakDitemukan
+		}
+		return nil, fmt.Errorf("gagal memperbarui filter: %w", err)
+	}
+	return updated, nil
+}
+
+// Delete menghapus filter milik pengguna
+func (s *filterService) Delete(ctx context.Context, userID, id int64) error {
+	if err := s.savedFilterRepository.Delete(ctx, userID, id); err != nil {
+		if errors.Is(err, repository.ErrFilterTidakDitemukan) {
+			return ErrFilterTidakDitemukan
+		}
+		return fmt.Errorf("gagal menghapus filter: %w", err)
+	}
+	return nil
+}
+
+// Todos menjalankan filter terhadap todo milik pengguna. Tanggal relatif seperti
+// today dihitung pada zona waktu pengguna.
+func (s *filterService) Todos(ctx context.Context, userID int64, key string) ([]entity.Todo, error) {
+	savedFilter, err := s.Find(ctx, userID, key)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().In(s.userPreferenceService.Location(ctx, userID))
+	condition, args, err := savedFilter.Definition.Compile(now)
+	if err != nil {
+		return nil, err
+	}
+
+	todos, err := s.todoRepository.FindByCondition(ctx, userID, condition, args...)
+	if err != nil {
+		return nil, fmt.Errorf("gagal menjalankan filter: %w", err)
+	}
+	return todos, nil
+}
+
+// validateSavedFilter memeriksa nama dan definisi filter sebelum disimpan
+func validateSavedFilter(savedFilter *entity.SavedFilter) error {
+	savedFilter.Name = strings.TrimSpace(savedFilter.Name)
+	if savedFilter.Name == "" || len(savedFilter.Name) > 100 {
+		return ErrNamaFilterTidakValid
+	}
+	return savedFilter.Definition.Validate()
+}
+
+// withBuiltIn menyalin filter bawaan untuk ditampilkan kepada pengguna
+func withBuiltIn(builtIn entity.SavedFilter, userID int64) entity.SavedFilter {
+	builtIn.UserID = userID
+	builtIn.BuiltIn = true
+	return builtIn
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"go-todo/internal/entity"
+	"go-todo/internal/repository"
+	"go-todo/pkg/filter"
+	mock_repository "go-todo/test/mock/repository"
+	mock_service "go-todo/test/mock/service"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// filterServiceMocks mengelompokkan semua dependensi mock dari FilterService
+type filterServiceMocks struct {
+	savedFilter *mock_repository.MockSavedFilterRepository
+	todo        *mock_repository.MockTodoRepository
+	preference  *mock_service.MockUserPreferenceService
+}
+
+func setupFilterService(t *testing.T) (*gomock.Controller, FilterService, *filterServiceMocks) {
+	ctrl := gomock.NewController(t)
+	m := &filterServiceMocks{
+		savedFilter: mock_repository.NewMockSavedFilterRepository(ctrl),
+		todo:        mock_repository.NewMockTodoRepository(ctrl),
+		preference:  mock_service.NewMockUserPreferenceService(ctrl),
+	}
+	return ctrl, NewFilterService(m.savedFilter, m.todo, m.preference), m
+}
+
+func TestFilterService_FindAll_BuiltInsFirst(t *testing.T) {
+	ctrl, service, m := setupFilterService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m.savedFilter.EXPECT().FindByUserID(ctx, int64(5)).Return([]entity.SavedFilter{{ID: 3, UserID: 5, Name: "Rumah"}}, nil)
+
+	filters, err := service.FindAll(ctx, 5)
+	assert.NoError(t, err)
+	assert.Len(t, filters, 5)
+	assert.Equal(t, entity.FilterToday, filters[0].Key)
+	assert.True(t, filters[0].BuiltIn)
+	assert.Equal(t, int64(3), filters[4].ID)
+}
+
+func TestFilterService_Todos_BuiltInUsesUserTimezone(t *testing.T) {
+	ctrl, service, m := setupFilterService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	jakarta, _ := time.LoadLocation("Asia/Jakarta")
+	m.preference.EXPECT().Location(ctx, int64(5)).Return(jakarta)
+	m.todo.EXPECT().FindByCondition(ctx, int64(5), "(completed = ?) AND (due_date < ? AND EXTRACT(YEAR FROM due_date) > 1)", false, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ int64, _ string, args ...interface{}) ([]entity.Todo, error) {
+			// Awal hari ini dihitung pada zona waktu pengguna
+			today := args[1].(time.Time)
+			assert.Equal(t, jakarta, today.Location())
+			assert.Equal(t, 0, today.Hour())
+			return []entity.Todo{{ID: 1}}, nil
+		})
+
+	todos, err := service.Todos(ctx, 5, "overdue")
+	assert.NoError(t, err)
+	assert.Len(t, todos, 1)
+}
+
+func TestFilterService_Todos_SavedFilter(t *testing.T) {
+	ctrl, service, m := setupFilterService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m.savedFilter.EXPECT().FindByID(ctx, int64(5), int64(3)).Return(&entity.SavedFilter{
+		ID:         3,
+		UserID:     5,
+		Definition: filter.Expr{Field: "tags", Op: filter.OpContains, Value: "home"},
+	}, nil)
+	m.preference.EXPECT().Location(ctx, int64(5)).Return(time.UTC)
+	m.todo.EXPECT().FindByCondition(ctx, int64(5), "tags @> ?", `["home"]`).Return([]entity.Todo{}, nil)
+
+	_, err := service.Todos(ctx, 5, "3")
+	assert.NoError(t, err)
+}
+
+func TestFilterService_Todos_NotFound(t *testing.T) {
+	ctrl, service, m := setupFilterService(t)
+	defer ctrl.Finish()
+
+	// Filter milik pengguna lain tidak dapat dijalankan
+	ctx := context.Background()
+	m.savedFilter.EXPECT().FindByID(ctx, int64(6), int64(3)).Return(nil, repository.ErrFilterTidakDitemukan)
+
+	_, err := service.Todos(ctx, 6, "3")
+	assert.ErrorIs(t, err, ErrFilterTidakDitemukan)
+
+	_, err = service.Todos(ctx, 6, "tomorrow")
+	assert.ErrorIs(t, err, ErrFilterTidakDitemukan)
+}
+
+func TestFilterService_Create_Validation(t *testing.T) {
+	ctrl, service, _ := setupFilterService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	_, err := service.Create(ctx, &entity.SavedFilter{
+		UserID:     5,
+		Name:       " ",
+		Definition: filter.Expr{Field: "title", Op: filter.OpEq, Value: "a"},
+	})
+	assert.ErrorIs(t, err, ErrNamaFilterTidakValid)
+
+	_, err = service.Create(ctx, &entity.SavedFilter{
+		UserID:     5,
+		Name:       "Rahasia",
+		Definition: filter.Expr{Field: "user_id", Op: filter.OpEq, Value: "1"},
+	})
+	assert.ErrorIs(t, err, filter.ErrTidakValid)
+}
+
+func TestFilterService_Update_NotFound(t *testing.T) {
+	ctrl, service, m := setupFilterService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m.savedFilter.EXPECT().Update(ctx, gomock.Any()).Return(nil, repository.ErrFilterTidakDitemukan)
+
+	_, err := service.Update(ctx, &entity.SavedFilter{
+		ID:         3,
+		UserID:     6,
+		Name:       "Rumah",
+		Definition: filter.Expr{Field: "completed", Op: filter.OpEq, Value: true},
+	})
+	assert.ErrorIs(t, err, ErrFilterTidakDitemukan)
+}
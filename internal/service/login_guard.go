@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-todo/configs"
+	"go-todo/pkg/throttle"
+	"strings"
+	"time"
+)
+
+var ErrTerlaluBanyakPercobaan = errors.New("terlalu banyak percobaan login, coba lagi nanti")
+
+// LoginLockedError dikembalikan ketika login ditolak karena jeda atau lockout masih berlaku.
+// Pesannya sama untuk username yang terdaftar maupun tidak.
+type LoginLockedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LoginLockedError) Error() string {
+	return ErrTerlaluBanyakPercobaan.Error()
+}
+
+func (e *LoginLockedError) Unwrap() error {
+	return ErrTerlaluBanyakPercobaan
+}
+
+// LoginGuard membatasi percobaan login per username dan per IP. Counter dihitung berdasarkan
+// username yang dikirim, bukan pengguna di database, sehingga username yang tidak terdaftar
+// diperlakukan sama persis dengan yang terdaftar.
+type LoginGuard interface {
+	// Check mengembalikan *LoginLockedError jika username atau IP sedang dikunci
+	Check(ctx context.Context, username, ip string) error
+	RecordFailure(ctx context.Context, username, ip string)
+	RecordSuccess(ctx context.Context, username string)
+	// Unlock menghapus kegagalan dan kunci username
+	Unlock(ctx context.Context, username string) error
+}
+
+type loginGuard struct {
+	store  throttle.AttemptStore
+	config configs.LoginConfig
+}
+
+// NewLoginGuard membuat instance baru dari LoginGuard
+func NewLoginGuard(store throttle.AttemptStore, config configs.LoginConfig) LoginGuard {
+	return &loginGuard{store: store, config: config}
+}
+
+// Check memeriksa kunci username dan IP, lalu mengembalikan sisa waktu tunggu terlama
+func (g *loginGuard) Check(ctx context.Context, username, ip string) error {
+	var retryAfter time.Duration
+	for _, key := range []string{usernameAttemptKey(username), ipAttemptKey(ip)} {
+		remaining, err := g.store.LockedFor(ctx, key)
+		if err != nil {
+			return fmt.Errorf("gagal memeriksa percobaan login: %w", err)
+		}
+		if remaining > retryAfter {
+			retryAfter = remaining
+		}
+	}
+	if retryAfter > 0 {
+		return &LoginLockedError{RetryAfter: retryAfter}
+	}
+	return nil
+}
+
+// RecordFailure mencatat login gagal. Username mendapat jeda yang berlipat dua setelah
+// DelayAfter kegagalan dan dikunci setelah MaxFailuresPerUsername kegagalan, sedangkan IP
+// hanya dikunci setelah MaxFailuresPerIP kegagalan karena satu IP dapat dipakai banyak
+// pengguna sekaligus. Kegagalan pencatatan hanya dilog agar respons login tetap sama.
+func (g *loginGuard) RecordFailure(ctx context.Context, username, ip string) {
+	window := time.Duration(g.config.WindowSeconds) * time.Second
+	lockout := time.Duration(g.config.LockoutSeconds) * time.Second
+
+	userKey := usernameAttemptKey(username)
+	failures, err := g.store.RecordFailure(ctx, userKey, window)
+	if err != nil {
+		fmt.Printf("kesalahan mencatat login gagal: %v\n", err)
+	} else if failures >= int64(g.config.MaxFailuresPerUsername) {
+		g.lock(ctx, userKey, lockout)
+		fmt.Printf("peringatan: username %q dikunci selama %s setelah %d login gagal (ip %s)\n", username, lockout, failures, ip)
+	} else if delay := g.delay(failures); delay > 0 {
+		g.lock(ctx, userKey, delay)
+	}
+
+	ipKey := ipAttemptKey(ip)
+	failures, err = g.store.RecordFailure(ctx, ipKey, window)
+	if err != nil {
+		fmt.Printf("kesalahan mencatat login gagal: %v\n", err)
+	} else if failures >= int64(g.config.MaxFailuresPerIP) {
+		g.lock(ctx, ipKey, lockout)
+		fmt.Printf("peringatan: ip %s dikunci selama %s setelah %d login gagal\n", ip, lockout, failures)
+	}
+}
+
+// RecordSuccess menghapus kegagalan username setelah login berhasil. Counter IP sengaja
+// tidak dihapus agar penyerang tidak dapat mereset counter dengan login ke akunnya sendiri.
+func (g *loginGuard) RecordSuccess(ctx context.Context, username string) {
+	if err := g.store.Reset(ctx, usernameAttemptKey(username)); err != nil {
+		fmt.Printf("kesalahan menghapus percobaan login: %v\n", err)
+	}
+}
+
+// Unlock membuka kunci username sebelum lockout berakhir
+func (g *loginGuard) Unlock(ctx context.Context, username string) error {
+	return g.store.Reset(ctx, usernameAttemptKey(username))
+}
+
+func (g *loginGuard) lock(ctx context.Context, key string, duration time.Duration) {
+	if err := g.store.Lock(ctx, key, duration); err != nil {
+		fmt.Printf("kesalahan mengunci percobaan login: %v\n", err)
+	}
+}
+
+// delay menghitung jeda progresif: DelayBase, 2×DelayBase, 4×DelayBase, ... hingga DelayMax
+func (g *loginGuard) delay(failures int64) time.Duration {
+	exceeded := failures - int64(g.config.DelayAfter)
+	if exceeded <= 0 {
+		return 0
+	}
+	maxDelay := time.Duration(g.config.DelayMaxSeconds) * time.Second
+	delay := time.Duration(g.config.DelayBaseSeconds) * time.Second
+	for i := int64(1); i < exceeded && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxDelay {
+		delay = maxDelay
+	}
+	return delay
+}
+
+// usernameAttemptKey menormalkan username agar variasi huruf besar atau spasi tidak
+// menghasilkan counter baru
+func usernameAttemptKey(username string) string {
+	return "login:username:" + strings.ToLower(strings.TrimSpace(username))
+}
+
+func ipAttemptKey(ip string) string {
+	return "login:ip:" + ip
+}
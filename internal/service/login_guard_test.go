@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"go-todo/configs"
+	mock_throttle "go-todo/test/mock/pkg/throttle"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var testLoginConfig = configs.LoginConfig{
+	WindowSeconds:          900,
+	DelayAfter:             3,
+	DelayBaseSeconds:       1,
+	DelayMaxSeconds:        8,
+	MaxFailuresPerUsername: 10,
+	MaxFailuresPerIP:       50,
+	LockoutSeconds:         900,
+}
+
+func TestLoginGuard_Check(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mock_throttle.NewMockAttemptStore(ctrl)
+	guard := NewLoginGuard(store, testLoginConfig)
+	ctx := context.Background()
+
+	store.EXPECT().LockedFor(ctx, "login:username:budi").Return(time.Duration(0), nil)
+	store.EXPECT().LockedFor(ctx, "login:ip:10.0.0.1").Return(time.Duration(0), nil)
+	assert.NoError(t, guard.Check(ctx, "budi", "10.0.0.1"))
+
+	// Username dinormalkan dan sisa waktu terlama yang dikembalikan
+	store.EXPECT().LockedFor(ctx, "login:username:budi").Return(2*time.Second, nil)
+	store.EXPECT().LockedFor(ctx, "login:ip:10.0.0.1").Return(5*time.Minute, nil)
+	err := guard.Check(ctx, " Budi ", "10.0.0.1")
+	var locked *LoginLockedError
+	assert.ErrorAs(t, err, &locked)
+	assert.Equal(t, 5*time.Minute, locked.RetryAfter)
+}
+
+func TestLoginGuard_RecordFailure_ProgressiveDelay(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mock_throttle.NewMockAttemptStore(ctrl)
+	guard := NewLoginGuard(store, testLoginConfig)
+	ctx := context.Background()
+	window := 900 * time.Second
+
+	// Kegagalan di bawah DELAY_AFTER tidak memberi jeda
+	store.EXPECT().RecordFailure(ctx, "login:username:budi", window).Return(int64(3), nil)
+	store.EXPECT().RecordFailure(ctx, "login:ip:10.0.0.1", window).Return(int64(3), nil)
+	guard.RecordFailure(ctx, "budi", "10.0.0.1")
+
+	// Jeda berlipat dua di setiap kegagalan berikutnya hingga DELAY_MAX
+	for failures, delay := range map[int64]time.Duration{4: time.Second, 5: 2 * time.Second, 6: 4 * time.Second, 9: 8 * time.Second} {
+		store.EXPECT().RecordFailure(ctx, "login:username:budi", window).Return(failures, nil)
+		store.EXPECT().Lock(ctx, "login:username:budi", delay).Return(nil)
+		store.EXPECT().RecordFailure(ctx, "login:ip:10.0.0.1", window).Return(failures, nil)
+		guard.RecordFailure(ctx, "budi", "10.0.0.1")
+	}
+}
+
+func TestLoginGuard_RecordFailure_Lockout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mock_throttle.NewMockAttemptStore(ctrl)
+	guard := NewLoginGuard(store, testLoginConfig)
+	ctx := context.Background()
+	window := 900 * time.Second
+
+	store.EXPECT().RecordFailure(ctx, "login:username:budi", window).Return(int64(10), nil)
+	store.EXPECT().Lock(ctx, "login:username:budi", 900*time.Second).Return(nil)
+	store.EXPECT().RecordFailure(ctx, "login:ip:10.0.0.1", window).Return(int64(50), nil)
+	store.EXPECT().Lock(ctx, "login:ip:10.0.0.1", 900*time.Second).Return(nil)
+	guard.RecordFailure(ctx, "budi", "10.0.0.1")
+}
+
+func TestLoginGuard_RecordSuccessAndUnlock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mock_throttle.NewMockAttemptStore(ctrl)
+	guard := NewLoginGuard(store, testLoginConfig)
+	ctx := context.Background()
+
+	// Hanya counter username yang direset; counter IP tetap berjalan
+	store.EXPECT().Reset(ctx, "login:username:budi").Return(nil).Times(2)
+	guard.RecordSuccess(ctx, "budi")
+	assert.NoError(t, guard.Unlock(ctx, "BUDI"))
+}
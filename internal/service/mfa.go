@@ -0,0 +1,463 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"go-todo/configs"
+	"go-todo/internal/entity"
+	"go-todo/internal/repository"
+	"go-todo/pkg/cache"
+	"go-todo/pkg/secretbox"
+	"go-todo/pkg/throttle"
+	"go-todo/pkg/totp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrMFASudahAktif          = errors.New("autentikasi dua faktor sudah aktif")
+	ErrMFATidakAktif          = errors.New("autentikasi dua faktor belum aktif")
+	ErrMFABelumDidaftarkan    = errors.New("TOTP belum didaftarkan, lakukan pendaftaran terlebih dahulu")
+	ErrMFAWajib               = errors.New("autentikasi dua faktor wajib untuk role pengguna ini")
+	ErrKodeMFATidakValid      = errors.New("kode autentikasi tidak valid")
+	ErrChallengeMFATidakValid = errors.New("sesi login dua faktor tidak valid atau sudah kedaluwarsa, silakan login ulang")
+)
+
+const (
+	mfaChallengeCacheKeyPrefix = "go-todo-api:mfa:challenge:"
+	defaultMFAChallengeTTL     = 5 * time.Minute
+	defaultMFAChallengeTries   = 5
+	defaultRecoveryCodes       = 10
+	// totpSkew mentoleransi selisih jam perangkat satu periode sebelum dan sesudahnya
+	totpSkew = 1
+)
+
+// MFAService mengatur autentikasi dua faktor berbasis TOTP (RFC 6238) beserta recovery code.
+// Secret TOTP disimpan terenkripsi, sedangkan recovery code hanya disimpan dalam bentuk hash.
+type MFAService interface {
+	Status(ctx context.Context, userID int64) (*entity.MFAStatus, error)
+	// Enroll membuat secret baru yang belum berlaku hingga dikonfirmasi dengan kode pertama
+	Enroll(ctx context.Context, userID int64) (*entity.TOTPEnrollment, error)
+	// Confirm mengaktifkan TOTP dan mengembalikan recovery code yang hanya ditampilkan sekali
+	Confirm(ctx context.Context, userID int64, code string) ([]string, error)
+	// Disable menonaktifkan TOTP dengan kode TOTP atau recovery code
+	Disable(ctx context.Context, userID int64, code string) error
+	RegenerateRecoveryCodes(ctx context.Context, userID int64, code string) ([]string, error)
+	// Reset menghapus TOTP pengguna lain yang kehilangan perangkatnya
+	Reset(ctx context.Context, actorID, userID int64) error
+	Enabled(ctx context.Context, userID int64) (bool, error)
+	// CreateChallenge membuat token sementara untuk langkah kedua login
+	CreateChallenge(ctx context.Context, userID int64) (*entity.MFAChallenge, error)
+	// ChallengeUser mengembalikan pemilik challenge tanpa memakainya
+	ChallengeUser(ctx context.Context, challengeToken string) (int64, error)
+	// VerifyChallenge memakai challenge jika kode benar. Challenge dibatalkan setelah
+	// MaxChallengeAttempts kode salah.
+	VerifyChallenge(ctx context.Context, challengeToken, code string) error
+}
+
+type mfaService struct {
+	mfaRepository  repository.MFARepository
+	userRepository repository.UserRepository
+	authorization  AuthorizationService
+	cacheable      cache.Cacheable
+	attempts       throttle.AttemptStore
+	box            secretbox.Box
+	transactor     repository.Transactor
+	config         configs.MFAConfig
+}
+
+// NewMFAService membuat instance baru dari MFAService.
+// Masa berlaku challenge dan batas percobaan yang tidak diatur diganti dengan nilai default.
+func NewMFAService(
+	mfaRepository repository.MFARepository,
+	userRepository repository.UserRepository,
+	authorization AuthorizationService,
+	cacheable cache.Cacheable,
+	attempts throttle.AttemptStore,
+	box secretbox.Box,
+	transactor repository.Transactor,
+	config configs.MFAConfig,
+) MFAService {
+	if config.Issuer == "" {
+		config.Issuer = "Go Todo"
+	}
+	if config.ChallengeTTLSeconds <= 0 {
+		config.ChallengeTTLSeconds = int(defaultMFAChallengeTTL / time.Second)
+	}
+	if config.MaxChallengeAttempts <= 0 {
+		config.MaxChallengeAttempts = defaultMFAChallengeTries
+	}
+	if config.RecoveryCodes <= 0 {
+		config.RecoveryCodes = defaultRecoveryCodes
+	}
+	return &mfaService{
+		mfaRepository:  mfaRepository,
+		userRepository: userRepository,
+		authorization:  authorization,
+		cacheable:      cacheable,
+		attempts:       attempts,
+		box:            box,
+		transactor:     transactor,
+		config:         config,
+	}
+}
+
+// Status mengembalikan status TOTP dan jumlah recovery code yang tersisa
+func (s *mfaService) Status(ctx context.Context, userID int64) (*entity.MFAStatus, error) {
+	status := &entity.MFAStatus{}
+	required, err := s.required(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	status.Required = required
+
+	record, err := s.confirmed(ctx, userID)
+	if errors.Is(err, ErrMFATidakAktif) {
+		return status, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	remaining, err := s.mfaRepository.CountUnusedRecoveryCodes(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil status autentikasi dua faktor: %w", err)
+	}
+	status.Enabled = true
+	status.ConfirmedAt = record.ConfirmedAt
+	status.RecoveryCodesRemaining = remaining
+	return status, nil
+}
+
+// Enroll membuat secret TOTP baru. Pendaftaran yang belum dikonfirmasi ditimpa sehingga
+// pengguna dapat mengulang pemindaian kode QR.
+func (s *mfaService) Enroll(ctx context.Context, userID int64) (*entity.TOTPEnrollment, error) {
+	user, err := s.userRepository.FindByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrPenggunaTidakDitemukan) {
+			return nil, ErrPenggunaTidakDitemukan
+		}
+		return nil, fmt.Errorf("gagal mendaftarkan TOTP: %w", err)
+	}
+	if _, err := s.confirmed(ctx, userID); err == nil {
+		return nil, ErrMFASudahAktif
+	} else if !errors.Is(err, ErrMFATidakAktif) {
+		return nil, err
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+	sealed, err := s.box.Seal(secret)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengenkripsi secret TOTP: %w", err)
+	}
+	if err := s.mfaRepository.Save(ctx, &entity.UserTOTP{UserID: userID, Secret: sealed}); err != nil {
+		return nil, fmt.Errorf("gagal mendaftarkan TOTP: %w", err)
+	}
+
+	return &entity.TOTPEnrollment{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(s.config.Issuer, user.Username, secret),
+	}, nil
+}
+
+// Confirm memvalidasi kode pertama dari aplikasi authenticator lalu mengaktifkan TOTP dan
+// membuat recovery code dalam satu transaksi
+func (s *mfaService) Confirm(ctx context.Context, userID int64, code string) ([]string, error) {
+	record, err := s.mfaRepository.FindByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrMFATidakDitemukan) {
+			return nil, ErrMFABelumDidaftarkan
+		}
+		return nil, fmt.Errorf("gagal mengaktifkan TOTP: %w", err)
+	}
+	if record.ConfirmedAt != nil {
+		return nil, ErrMFASudahAktif
+	}
+	secret, err := s.box.Open(record.Secret)
+	if err != nil {
+		return nil, fmt.Errorf("gagal membaca secret TOTP: %w", err)
+	}
+	step, ok := totp.Validate(secret, normalizeTOTPCode(code), time.Now(), totpSkew)
+	if !ok {
+		return nil, ErrKodeMFATidakValid
+	}
+
+	codes, hashes := s.newRecoveryCodes()
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.mfaRepository.Confirm(ctx, userID, time.Now(), step); err != nil {
+			return err
+		}
+		return s.mfaRepository.ReplaceRecoveryCodes(ctx, userID, hashes)
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrMFATidakDitemukan) {
+			return nil, ErrMFASudahAktif
+		}
+		return nil, fmt.Errorf("gagal mengaktifkan TOTP: %w", err)
+	}
+
+	// Izin role yang mewajibkan TOTP langsung berlaku
+	s.authorization.InvalidateUser(userID)
+	return codes, nil
+}
+
+// Disable menghapus TOTP beserta recovery code. Pengguna dengan role yang mewajibkan TOTP
+// tidak dapat menonaktifkannya sendiri.
+func (s *mfaService) Disable(ctx context.Context, userID int64, code string) error {
+	required, err := s.required(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if required {
+		return ErrMFAWajib
+	}
+	record, err := s.confirmed(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := s.verify(ctx, record, code); err != nil {
+		return err
+	}
+	if err := s.mfaRepository.Delete(ctx, userID); err != nil {
+		return fmt.Errorf("gagal menonaktifkan TOTP: %w", err)
+	}
+	s.authorization.InvalidateUser(userID)
+	return nil
+}
+
+// RegenerateRecoveryCodes mengganti seluruh recovery code; kode lama tidak berlaku lagi
+func (s *mfaService) RegenerateRecoveryCodes(ctx context.Context, userID int64, code string) ([]string, error) {
+	record, err := s.confirmed(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.verify(ctx, record, code); err != nil {
+		return nil, err
+	}
+	codes, hashes := s.newRecoveryCodes()
+	if err := s.mfaRepository.ReplaceRecoveryCodes(ctx, userID, hashes); err != nil {
+		return nil, fmt.Errorf("gagal membuat recovery code: %w", err)
+	}
+	return codes, nil
+}
+
+// Reset menghapus TOTP pengguna lain, misalnya setelah kehilangan perangkat dan recovery code
+func (s *mfaService) Reset(ctx context.Context, actorID, userID int64) error {
+	if err := s.authorization.Require(ctx, actorID, entity.PermissionUserWrite); err != nil {
+		return err
+	}
+	if _, err := s.userRepository.FindByID(ctx, userID); err != nil {
+		if errors.Is(err, repository.ErrPenggunaTidakDitemukan) {
+			return ErrPenggunaTidakDitemukan
+		}
+		return fmt.Errorf("gagal mereset TOTP: %w", err)
+	}
+	if err := s.mfaRepository.Delete(ctx, userID); err != nil {
+		return fmt.Errorf("gagal mereset TOTP: %w", err)
+	}
+	s.authorization.InvalidateUser(userID)
+	fmt.Printf("TOTP pengguna %d direset oleh pengguna %d\n", userID, actorID)
+	return nil
+}
+
+// Enabled memeriksa apakah pengguna sudah mengaktifkan TOTP
+func (s *mfaService) Enabled(ctx context.Context, userID int64) (bool, error) {
+	_, err := s.confirmed(ctx, userID)
+	if errors.Is(err, ErrMFATidakAktif) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// CreateChallenge menyimpan pemilik challenge di cache; hanya hash token yang dipakai sebagai key
+func (s *mfaService) CreateChallenge(ctx context.Context, userID int64) (*entity.MFAChallenge, error) {
+	ttl := time.Duration(s.config.ChallengeTTLSeconds) * time.Second
+	challengeToken := newOpaqueToken()
+	if err := s.cacheable.Set(mfaChallengeCacheKeyPrefix+hashToken(challengeToken), userID, ttl); err != nil {
+		return nil, fmt.Errorf("gagal membuat challenge autentikasi dua faktor: %w", err)
+	}
+	return &entity.MFAChallenge{Token: challengeToken, ExpiresAt: time.Now().Add(ttl)}, nil
+}
+
+// ChallengeUser mencari pemilik challenge
+func (s *mfaService) ChallengeUser(ctx context.Context, challengeToken string) (int64, error) {
+	if challengeToken == "" {
+		return 0, ErrChallengeMFATidakValid
+	}
+	cached, err := s.cacheable.Get(mfaChallengeCacheKeyPrefix + hashToken(challengeToken))
+	if err != nil {
+		return 0, fmt.Errorf("gagal membaca challenge autentikasi dua faktor: %w", err)
+	}
+	userID, err := strconv.ParseInt(cached, 10, 64)
+	if err != nil {
+		return 0, ErrChallengeMFATidakValid
+	}
+	return userID, nil
+}
+
+// VerifyChallenge memvalidasi kode untuk challenge. Challenge dihapus setelah berhasil
+// agar tidak dapat ditukar dua kali.
+func (s *mfaService) VerifyChallenge(ctx context.Context, challengeToken, code string) error {
+	userID, err := s.ChallengeUser(ctx, challengeToken)
+	if err != nil {
+		return err
+	}
+	cacheKey := mfaChallengeCacheKeyPrefix + hashToken(challengeToken)
+
+	record, err := s.confirmed(ctx, userID)
+	if err != nil {
+		if errors.Is(err, ErrMFATidakAktif) {
+			// TOTP direset setelah challenge dibuat
+			return ErrChallengeMFATidakValid
+		}
+		return err
+	}
+
+	if err := s.verify(ctx, record, code); err != nil {
+		if errors.Is(err, ErrKodeMFATidakValid) {
+			s.recordChallengeFailure(ctx, challengeToken, cacheKey)
+		}
+		return err
+	}
+
+	if err := s.cacheable.Delete(cacheKey); err != nil {
+		fmt.Printf("kesalahan menghapus cache: %v\n", err)
+	}
+	return nil
+}
+
+// recordChallengeFailure membatalkan challenge setelah terlalu banyak kode salah sehingga
+// kode enam digit tidak dapat ditebak dalam satu challenge
+func (s *mfaService) recordChallengeFailure(ctx context.Context, challengeToken, cacheKey string) {
+	ttl := time.Duration(s.config.ChallengeTTLSeconds) * time.Second
+	attemptKey := "mfa:challenge:" + hashToken(challengeToken)
+	failures, err := s.attempts.RecordFailure(ctx, attemptKey, ttl)
+	if err != nil {
+		fmt.Printf("kesalahan mencatat kode autentikasi gagal: %v\n", err)
+		return
+	}
+	if failures < int64(s.config.MaxChallengeAttempts) {
+		return
+	}
+	if err := s.cacheable.Delete(cacheKey); err != nil {
+		fmt.Printf("kesalahan menghapus cache: %v\n", err)
+	}
+	if err := s.attempts.Reset(ctx, attemptKey); err != nil {
+		fmt.Printf("kesalahan menghapus percobaan: %v\n", err)
+	}
+}
+
+// confirmed mengambil TOTP yang sudah dikonfirmasi; pendaftaran yang belum dikonfirmasi
+// dianggap belum aktif
+func (s *mfaService) confirmed(ctx context.Context, userID int64) (*entity.UserTOTP, error) {
+	record, err := s.mfaRepository.FindByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrMFATidakDitemukan) {
+			return nil, ErrMFATidakAktif
+		}
+		return nil, fmt.Errorf("gagal mengambil TOTP: %w", err)
+	}
+	if record.ConfirmedAt == nil {
+		return nil, ErrMFATidakAktif
+	}
+	return record, nil
+}
+
+// verify menerima kode TOTP enam digit atau recovery code. Periode kode TOTP yang diterima
+// dicatat sehingga kode yang sama tidak dapat dipakai ulang.
+func (s *mfaService) verify(ctx context.Context, record *entity.UserTOTP, code string) error {
+	if totpCode := normalizeTOTPCode(code); len(totpCode) == totp.Digits && isDigits(totpCode) {
+		secret, err := s.box.Open(record.Secret)
+		if err != nil {
+			return fmt.Errorf("gagal membaca secret TOTP: %w", err)
+		}
+		step, ok := totp.Validate(secret, totpCode, time.Now(), totpSkew)
+		if !ok {
+			return ErrKodeMFATidakValid
+		}
+		used, err := s.mfaRepository.UseStep(ctx, record.UserID, step)
+		if err != nil {
+			return fmt.Errorf("gagal memverifikasi kode autentikasi: %w", err)
+		}
+		if !used {
+			return ErrKodeMFATidakValid
+		}
+		return nil
+	}
+
+	used, err := s.mfaRepository.UseRecoveryCode(ctx, record.UserID, hashToken(normalizeRecoveryCode(code)), time.Now())
+	if err != nil {
+		return fmt.Errorf("gagal memverifikasi recovery code: %w", err)
+	}
+	if !used {
+		return ErrKodeMFATidakValid
+	}
+	return nil
+}
+
+// required memeriksa apakah salah satu role pengguna mewajibkan TOTP
+func (s *mfaService) required(ctx context.Context, userID int64) (bool, error) {
+	authorization, err := s.authorization.Lookup(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return requiresMFA(authorization.Roles, s.config.RequiredRoles), nil
+}
+
+// newRecoveryCodes membuat recovery code acak 80-bit berformat xxxxx-xxxxx-xxxxx-xxxxx
+// beserta hash-nya
+func (s *mfaService) newRecoveryCodes() ([]string, []string) {
+	codes := make([]string, s.config.RecoveryCodes)
+	hashes := make([]string, s.config.RecoveryCodes)
+	for i := range codes {
+		buf := make([]byte, 10)
+		if _, err := rand.Read(buf); err != nil {
+			panic(err)
+		}
+		raw := hex.EncodeToString(buf)
+		codes[i] = raw[0:5] + "-" + raw[5:10] + "-" + raw[10:15] + "-" + raw[15:20]
+		hashes[i] = hashToken(raw)
+	}
+	return codes, hashes
+}
+
+// requiresMFA memeriksa apakah salah satu role termasuk role yang mewajibkan TOTP
+func requiresMFA(roles, requiredRoles []string) bool {
+	for _, role := range roles {
+		for _, required := range requiredRoles {
+			if role == required {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// normalizeTOTPCode membuang spasi yang sering ikut tersalin dari aplikasi authenticator
+func normalizeTOTPCode(code string) string {
+	return strings.ReplaceAll(strings.TrimSpace(code), " ", "")
+}
+
+// normalizeRecoveryCode membuang tanda hubung dan spasi serta mengubah ke huruf kecil
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
+
+func isDigits(value string) bool {
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,315 @@
+package service
+
+import (
+	"context"
+	"go-todo/configs"
+	"go-todo/internal/entity"
+	"go-todo/internal/repository"
+	"go-todo/pkg/secretbox"
+	"go-todo/pkg/totp"
+	mock_cache "go-todo/test/mock/pkg/cache"
+	mock_throttle "go-todo/test/mock/pkg/throttle"
+	mock_repository "go-todo/test/mock/repository"
+	mock_service "go-todo/test/mock/service"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// mfaMocks mengelompokkan semua dependensi mock dari MFAService
+type mfaMocks struct {
+	mfa      *mock_repository.MockMFARepository
+	users    *mock_repository.MockUserRepository
+	authz    *mock_service.MockAuthorizationService
+	cache    *mock_cache.MockCacheable
+	attempts *mock_throttle.MockAttemptStore
+	box      secretbox.Box
+}
+
+const testTOTPSecret = "JBSWY3DPEHPK3PXP"
+
+func setupMFAService(t *testing.T) (*gomock.Controller, MFAService, *mfaMocks) {
+	ctrl := gomock.NewController(t)
+	box, _ := secretbox.New("kunci-uji")
+	m := &mfaMocks{
+		mfa:      mock_repository.NewMockMFARepository(ctrl),
+		users:    mock_repository.NewMockUserRepository(ctrl),
+		authz:    mock_service.NewMockAuthorizationService(ctrl),
+		cache:    mock_cache.NewMockCacheable(ctrl),
+		attempts: mock_throttle.NewMockAttemptStore(ctrl),
+		box:      box,
+	}
+	config := configs.MFAConfig{
+		Issuer:               "Go Todo",
+		RequiredRoles:        []string{entity.RoleAdmin},
+		ChallengeTTLSeconds:  300,
+		MaxChallengeAttempts: 3,
+		RecoveryCodes:        4,
+	}
+	service := NewMFAService(m.mfa, m.users, m.authz, m.cache, m.attempts, box, passThroughTransactor(ctrl), config)
+	return ctrl, service, m
+}
+
+// confirmedTOTP menyusun TOTP aktif dengan secret uji yang sudah dienkripsi
+func confirmedTOTP(m *mfaMocks) *entity.UserTOTP {
+	sealed, _ := m.box.Seal(testTOTPSecret)
+	confirmedAt := time.Now()
+	return &entity.UserTOTP{UserID: 7, Secret: sealed, ConfirmedAt: &confirmedAt}
+}
+
+// Kasus uji untuk Enroll
+
+func TestMFAService_Enroll(t *testing.T) {
+	ctrl, service, m := setupMFAService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	var saved *entity.UserTOTP
+	m.users.EXPECT().FindByID(ctx, int64(7)).Return(&entity.User{ID: 7, Username: "budi"}, nil)
+	m.mfa.EXPECT().FindByUserID(ctx, int64(7)).Return(nil, repository.ErrMFATidakDitemukan)
+	m.mfa.EXPECT().Save(ctx, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, record *entity.UserTOTP) error {
+			saved = record
+			return nil
+		})
+
+	enrollment, err := service.Enroll(ctx, 7)
+	assert.NoError(t, err)
+
+	// Secret disimpan terenkripsi dan belum dikonfirmasi
+	assert.NotEqual(t, enrollment.Secret, saved.Secret)
+	assert.Nil(t, saved.ConfirmedAt)
+	opened, err := m.box.Open(saved.Secret)
+	assert.NoError(t, err)
+	assert.Equal(t, enrollment.Secret, opened)
+
+	uri, err := url.Parse(enrollment.ProvisioningURI)
+	assert.NoError(t, err)
+	assert.Equal(t, "/Go Todo:budi", uri.Path)
+	assert.Equal(t, enrollment.Secret, uri.Query().Get("secret"))
+}
+
+func TestMFAService_Enroll_AlreadyEnabled(t *testing.T) {
+	ctrl, service, m := setupMFAService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m.users.EXPECT().FindByID(ctx, int64(7)).Return(&entity.User{ID: 7, Username: "budi"}, nil)
+	m.mfa.EXPECT().FindByUserID(ctx, int64(7)).Return(confirmedTOTP(m), nil)
+
+	_, err := service.Enroll(ctx, 7)
+	assert.ErrorIs(t, err, ErrMFASudahAktif)
+}
+
+// Kasus uji untuk Confirm
+
+func TestMFAService_Confirm(t *testing.T) {
+	ctrl, service, m := setupMFAService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	record := confirmedTOTP(m)
+	record.ConfirmedAt = nil
+	code, _ := totp.Code(testTOTPSecret, time.Now())
+	var storedHashes []string
+
+	m.mfa.EXPECT().FindByUserID(ctx, int64(7)).Return(record, nil)
+	m.mfa.EXPECT().Confirm(ctx, int64(7), gomock.Any(), gomock.Any()).Return(nil)
+	m.mfa.EXPECT().ReplaceRecoveryCodes(ctx, int64(7), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, userID int64, codeHashes []string) error {
+			storedHashes = codeHashes
+			return nil
+		})
+	// Izin admin yang ditahan langsung berlaku
+	m.authz.EXPECT().InvalidateUser(int64(7))
+
+	codes, err := service.Confirm(ctx, 7, code)
+	assert.NoError(t, err)
+	assert.Len(t, codes, 4)
+	for i, recoveryCode := range codes {
+		assert.Len(t, recoveryCode, 23)
+		assert.Equal(t, hashToken(strings.ReplaceAll(recoveryCode, "-", "")), storedHashes[i])
+	}
+}
+
+func TestMFAService_Confirm_InvalidCode(t *testing.T) {
+	ctrl, service, m := setupMFAService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	record := confirmedTOTP(m)
+	record.ConfirmedAt = nil
+	m.mfa.EXPECT().FindByUserID(ctx, int64(7)).Return(record, nil)
+
+	_, err := service.Confirm(ctx, 7, "000000")
+	assert.ErrorIs(t, err, ErrKodeMFATidakValid)
+}
+
+func TestMFAService_Confirm_NotEnrolled(t *testing.T) {
+	ctrl, service, m := setupMFAService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m.mfa.EXPECT().FindByUserID(ctx, int64(7)).Return(nil, repository.ErrMFATidakDitemukan)
+
+	_, err := service.Confirm(ctx, 7, "123456")
+	assert.ErrorIs(t, err, ErrMFABelumDidaftarkan)
+}
+
+// Kasus uji untuk Disable
+
+func TestMFAService_Disable_WithRecoveryCode(t *testing.T) {
+	ctrl, service, m := setupMFAService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m.authz.EXPECT().Lookup(ctx, int64(7)).Return(&entity.Authorization{Roles: []string{entity.RoleUser}}, nil)
+	m.mfa.EXPECT().FindByUserID(ctx, int64(7)).Return(confirmedTOTP(m), nil)
+	// Recovery code diterima tanpa memperhatikan kapitalisasi dan tanda hubung
+	m.mfa.EXPECT().UseRecoveryCode(ctx, int64(7), hashToken("abcde12345abcde12345"), gomock.Any()).Return(true, nil)
+	m.mfa.EXPECT().Delete(ctx, int64(7)).Return(nil)
+	m.authz.EXPECT().InvalidateUser(int64(7))
+
+	assert.NoError(t, service.Disable(ctx, 7, "ABCDE-12345-abcde-12345"))
+}
+
+func TestMFAService_Disable_RequiredRole(t *testing.T) {
+	ctrl, service, m := setupMFAService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m.authz.EXPECT().Lookup(ctx, int64(7)).Return(&entity.Authorization{Roles: []string{entity.RoleAdmin}}, nil)
+
+	assert.ErrorIs(t, service.Disable(ctx, 7, "123456"), ErrMFAWajib)
+}
+
+// Kasus uji untuk Reset
+
+func TestMFAService_Reset_RequiresPermission(t *testing.T) {
+	ctrl, service, m := setupMFAService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m.authz.EXPECT().Require(ctx, int64(2), entity.PermissionUserWrite).Return(ErrAksesDitolak)
+
+	assert.ErrorIs(t, service.Reset(ctx, 2, 7), ErrAksesDitolak)
+}
+
+func TestMFAService_Reset(t *testing.T) {
+	ctrl, service, m := setupMFAService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m.authz.EXPECT().Require(ctx, int64(1), entity.PermissionUserWrite).Return(nil)
+	m.users.EXPECT().FindByID(ctx, int64(7)).Return(&entity.User{ID: 7}, nil)
+	m.mfa.EXPECT().Delete(ctx, int64(7)).Return(nil)
+	m.authz.EXPECT().InvalidateUser(int64(7))
+
+	assert.NoError(t, service.Reset(ctx, 1, 7))
+}
+
+// Kasus uji untuk challenge login
+
+func TestMFAService_CreateChallenge(t *testing.T) {
+	ctrl, service, m := setupMFAService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	var cacheKey string
+	m.cache.EXPECT().Set(gomock.Any(), int64(7), 300*time.Second).DoAndReturn(
+		func(key string, value interface{}, duration time.Duration) error {
+			cacheKey = key
+			return nil
+		})
+
+	challenge, err := service.CreateChallenge(ctx, 7)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, challenge.Token)
+	// Token challenge tidak tersimpan apa adanya di cache
+	assert.Equal(t, mfaChallengeCacheKeyPrefix+hashToken(challenge.Token), cacheKey)
+	assert.WithinDuration(t, time.Now().Add(5*time.Minute), challenge.ExpiresAt, 5*time.Second)
+}
+
+func TestMFAService_ChallengeUser_Expired(t *testing.T) {
+	ctrl, service, m := setupMFAService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m.cache.EXPECT().Get(mfaChallengeCacheKeyPrefix+hashToken("kedaluwarsa")).Return("", nil)
+
+	_, err := service.ChallengeUser(ctx, "kedaluwarsa")
+	assert.ErrorIs(t, err, ErrChallengeMFATidakValid)
+}
+
+func TestMFAService_VerifyChallenge_Success(t *testing.T) {
+	ctrl, service, m := setupMFAService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	cacheKey := mfaChallengeCacheKeyPrefix + hashToken("challenge")
+	code, _ := totp.Code(testTOTPSecret, time.Now())
+
+	m.cache.EXPECT().Get(cacheKey).Return("7", nil)
+	m.mfa.EXPECT().FindByUserID(ctx, int64(7)).Return(confirmedTOTP(m), nil)
+	m.mfa.EXPECT().UseStep(ctx, int64(7), gomock.Any()).Return(true, nil)
+	// Challenge hanya dapat ditukar sekali
+	m.cache.EXPECT().Delete(cacheKey).Return(nil)
+
+	assert.NoError(t, service.VerifyChallenge(ctx, "challenge", code))
+}
+
+func TestMFAService_VerifyChallenge_ReplayedCode(t *testing.T) {
+	ctrl, service, m := setupMFAService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	cacheKey := mfaChallengeCacheKeyPrefix + hashToken("challenge")
+	code, _ := totp.Code(testTOTPSecret, time.Now())
+
+	m.cache.EXPECT().Get(cacheKey).Return("7", nil)
+	m.mfa.EXPECT().FindByUserID(ctx, int64(7)).Return(confirmedTOTP(m), nil)
+	// Periode kode sudah dipakai pada login sebelumnya
+	m.mfa.EXPECT().UseStep(ctx, int64(7), gomock.Any()).Return(false, nil)
+	m.attempts.EXPECT().RecordFailure(ctx, "mfa:challenge:"+hashToken("challenge"), 300*time.Second).Return(int64(1), nil)
+
+	assert.ErrorIs(t, service.VerifyChallenge(ctx, "challenge", code), ErrKodeMFATidakValid)
+}
+
+func TestMFAService_VerifyChallenge_TooManyAttempts(t *testing.T) {
+	ctrl, service, m := setupMFAService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	cacheKey := mfaChallengeCacheKeyPrefix + hashToken("challenge")
+	attemptKey := "mfa:challenge:" + hashToken("challenge")
+
+	m.cache.EXPECT().Get(cacheKey).Return("7", nil)
+	m.mfa.EXPECT().FindByUserID(ctx, int64(7)).Return(confirmedTOTP(m), nil)
+	m.mfa.EXPECT().UseRecoveryCode(ctx, int64(7), gomock.Any(), gomock.Any()).Return(false, nil)
+	m.attempts.EXPECT().RecordFailure(ctx, attemptKey, 300*time.Second).Return(int64(3), nil)
+	// Challenge dibatalkan sehingga pengguna harus login ulang dengan password
+	m.cache.EXPECT().Delete(cacheKey).Return(nil)
+	m.attempts.EXPECT().Reset(ctx, attemptKey).Return(nil)
+
+	assert.ErrorIs(t, service.VerifyChallenge(ctx, "challenge", "salah-salah"), ErrKodeMFATidakValid)
+}
+
+// Kasus uji untuk Status
+
+func TestMFAService_Status(t *testing.T) {
+	ctrl, service, m := setupMFAService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m.authz.EXPECT().Lookup(ctx, int64(7)).Return(&entity.Authorization{Roles: []string{entity.RoleAdmin}}, nil)
+	m.mfa.EXPECT().FindByUserID(ctx, int64(7)).Return(nil, repository.ErrMFATidakDitemukan)
+
+	status, err := service.Status(ctx, 7)
+	assert.NoError(t, err)
+	assert.False(t, status.Enabled)
+	assert.True(t, status.Required)
+}
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-todo/internal/entity"
+	"go-todo/internal/repository"
+	"go-todo/pkg/realtime"
+	"time"
+)
+
+var ErrNotifikasiTidakDitemukan = errors.New("notifikasi tidak ditemukan")
+
+// notificationListLimit membatasi jumlah notifikasi yang ditampilkan
+const notificationListLimit = 100
+
+// EventNotificationCreated dikirim melalui SSE atau WebSocket saat notifikasi baru dibuat
+const EventNotificationCreated = "notification.created"
+
+// NotificationService membuat notifikasi dari event outbox dan mengelola kotak masuk pengguna
+type NotificationService interface {
+	HandleEvent(ctx context.Context, event entity.Event) error
+	FindAll(ctx context.Context, userID int64, unreadOnly bool) ([]entity.Notification, error)
+	MarkRead(ctx context.Context, userID, id int64) error
+	MarkAllRead(ctx context.Context, userID int64) (int64, error)
+}
+
+type notificationService struct {
+	notificationRepository repository.NotificationRepository
+	broker                 realtime.Broker
+}
+
+// NewNotificationService membuat instance baru dari NotificationService
+func NewNotificationService(notificationRepository repository.NotificationRepository, broker realtime.Broker) NotificationService {
+	return &notificationService{notificationRepository: notificationRepository, broker: broker}
+}
+
+// HandleEvent membuat notifikasi untuk penanggung jawab baru dan lama dari event
+// todo.assigned dan todo.unassigned. Event lain diabaikan.
+func (s *notificationService) HandleEvent(ctx context.Context, event entity.Event) error {
+	if event.Type != entity.EventTodoAssigned && event.Type != entity.EventTodoUnassigned {
+		return nil
+	}
+	data, ok := event.Data.(json.RawMessage)
+	if !ok {
+		return nil
+	}
+	var assignment entity.TodoAssignment
+	if err := json.Unmarshal(data, &assignment); err != nil {
+		return fmt.Errorf("gagal membaca payload event %s: %w", event.ID, err)
+	}
+
+	for _, notification := range assignmentNotifications(event, assignment) {
+		if err := s.notify(ctx, notification); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// notify menyimpan notifikasi lalu mendorongnya ke klien realtime milik penerima
+func (s *notificationService) notify(ctx context.Context, notification entity.Notification) error {
+	created, err := s.notificationRepository.Create(ctx, &notification)
+	if err != nil {
+		return fmt.Errorf("gagal membuat notifikasi: %w", err)
+	}
+	if !created {
+		return nil
+	}
+	// Notifikasi sudah tersimpan sehingga kegagalan push tidak perlu mengulang event
+	if err := s.broker.Publish(ctx, notification.UserID, EventNotificationCreated, notification); err != nil {
+		fmt.Printf("kesalahan mengirim notifikasi %d secara realtime: %v\n", notification.ID, err)
+	}
+	return nil
+}
+
+// FindAll mengambil notifikasi terbaru milik pengguna
+func (s *notificationService) FindAll(ctx context.Context, userID int64, unreadOnly bool) ([]entity.Notification, error) {
+	notifications, err := s.notificationRepository.FindByUserID(ctx, userID, unreadOnly, notificationListLimit)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil notifikasi: %w", err)
+	}
+	return notifications, nil
+}
+
+// MarkRead menandai notifikasi milik pengguna sebagai sudah dibaca
+func (s *notificationService) MarkRead(ctx context.Context, userID, id int64) error {
+	if err := s.notificationRepository.MarkRead(ctx, userID, id, time.Now()); err != nil {
+		if errors.Is(err, repository.ErrNotifikasiTidakDitemukan) {
+			return ErrNotifikasiTidakDitemukan
+		}
+		return fmt.Errorf("gagal menandai notifikasi: %w", err)
+	}
+	return nil
+}
+
+// MarkAllRead menandai semua notifikasi pengguna sebagai sudah dibaca
+func (s *notificationService) MarkAllRead(ctx context.Context, userID int64) (int64, error) {
+	updated, err := s.notificationRepository.MarkAllRead(ctx, userID, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("gagal menandai notifikasi: %w", err)
+	}
+	return updated, nil
+}
+
+// assignmentNotifications menentukan penerima notifikasi dari perubahan penanggung jawab.
+// Pengguna tidak diberi notifikasi atas perubahan yang ia lakukan sendiri.
+func assignmentNotifications(event entity.Event, assignment entity.TodoAssignment) []entity.Notification {
+	todoID := assignment.ID
+	actorID := assignment.ActorID
+	newNotification := func(userID int64, message string) entity.Notification {
+		return entity.Notification{
+			UserID:    userID,
+			Type:      event.Type,
+			TodoID:    &todoID,
+			ActorID:   &actorID,
+			Message:   message,
+			EventID:   event.ID,
+			CreatedAt: time.Now(),
+		}
+	}
+
+	var notifications []entity.Notification
+	assignee := assignment.AssigneeID
+	if assignee != nil && *assignee != actorID {
+		notifications = append(notifications, newNotification(*assignee,
+			fmt.Sprintf("Anda ditugaskan ke todo %q", assignment.Title)))
+	}
+	previous := assignment.PreviousAssigneeID
+	if previous != nil && *previous != actorID && !sameAssignee(previous, assignee) {
+		notifications = append(notifications, newNotification(*previous,
+			fmt.Sprintf("Anda tidak lagi menjadi penanggung jawab todo %q", assignment.Title)))
+	}
+	return notifications
+}
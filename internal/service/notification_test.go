@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"go-todo/internal/entity"
+	mock_realtime "go-todo/test/mock/pkg/realtime"
+	mock_repository "go-todo/test/mock/repository"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotificationService_HandleEvent_Reassigned(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockNotificationRepository(ctrl)
+	mockBroker := mock_realtime.NewMockBroker(ctrl)
+	service := NewNotificationService(mockRepo, mockBroker)
+
+	ctx := context.Background()
+	var recipients []int64
+	mockRepo.EXPECT().Create(ctx, gomock.Any()).Times(2).DoAndReturn(func(_ context.Context, notification *entity.Notification) (bool, error) {
+		assert.Equal(t, "evt_1", notification.EventID)
+		assert.Equal(t, int64(7), *notification.TodoID)
+		recipients = append(recipients, notification.UserID)
+		return true, nil
+	})
+	// Kegagalan push realtime tidak membuat event diulang
+	mockBroker.EXPECT().Publish(ctx, int64(9), EventNotificationCreated, gomock.Any()).Return(nil)
+	mockBroker.EXPECT().Publish(ctx, int64(8), EventNotificationCreated, gomock.Any()).Return(errors.New("redis error"))
+
+	err := service.HandleEvent(ctx, entity.Event{
+		ID:            "evt_1",
+		Type:          entity.EventTodoAssigned,
+		AggregateType: entity.AggregateTodo,
+		Data:          json.RawMessage(`{"id":7,"user_id":5,"title":"Laporan","assignee_id":9,"actor_id":5,"previous_assignee_id":8}`),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{9, 8}, recipients)
+}
+
+func TestNotificationService_HandleEvent_SkipsActorAndDuplicates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockNotificationRepository(ctrl)
+	service := NewNotificationService(mockRepo, mock_realtime.NewMockBroker(ctrl))
+
+	ctx := context.Background()
+
+	// Pengguna yang menugaskan dirinya sendiri tidak menerima notifikasi
+	err := service.HandleEvent(ctx, entity.Event{
+		ID:   "evt_1",
+		Type: entity.EventTodoAssigned,
+		Data: json.RawMessage(`{"id":7,"user_id":5,"assignee_id":5,"actor_id":5}`),
+	})
+	assert.NoError(t, err)
+
+	// Event yang diteruskan ulang tidak mengirim notifikasi realtime kedua kalinya
+	mockRepo.EXPECT().Create(ctx, gomock.Any()).Return(false, nil)
+	err = service.HandleEvent(ctx, entity.Event{
+		ID:   "evt_2",
+		Type: entity.EventTodoUnassigned,
+		Data: json.RawMessage(`{"id":7,"user_id":5,"assignee_id":null,"actor_id":5,"previous_assignee_id":9}`),
+	})
+	assert.NoError(t, err)
+
+	// Event selain penugasan diabaikan
+	assert.NoError(t, service.HandleEvent(ctx, entity.Event{ID: "evt_3", Type: entity.EventTodoCreated}))
+}
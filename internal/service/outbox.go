@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go-todo/configs"
+	"go-todo/internal/entity"
+	"go-todo/internal/repository"
+	"go-todo/pkg/eventstream"
+	"go-todo/pkg/webhook"
+	"time"
+)
+
+type outboxRecorder struct {
+	outboxRepository repository.OutboxRepository
+}
+
+// NewEventRecorder membuat EventRecorder yang menulis event ke tabel outbox
+func NewEventRecorder(outboxRepository repository.OutboxRepository) EventRecorder {
+	return &outboxRecorder{outboxRepository: outboxRepository}
+}
+
+// Record menyimpan event ke outbox menggunakan transaksi yang dibawa ctx
+func (r *outboxRecorder) Record(ctx context.Context, event entity.DomainEvent) error {
+	payload, err := json.Marshal(event.Data)
+	if err != nil {
+		return fmt.Errorf("gagal menyusun payload event %s: %w", event.Type, err)
+	}
+
+	err = r.outboxRepository.Create(ctx, &entity.OutboxEvent{
+		EventID:       newEventID(),
+		AggregateType: event.AggregateType,
+		AggregateID:   event.AggregateID,
+		EventType:     event.Type,
+		Payload:       payload,
+		CreatedAt:     time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("gagal mencatat event %s: %w", event.Type, err)
+	}
+	return nil
+}
+
+// OutboxRelay meneruskan event dari outbox ke seluruh subscriber minimal sekali
+type OutboxRelay interface {
+	RelayPending(ctx context.Context) (int, error)
+	PurgePublished(ctx context.Context) (int64, error)
+}
+
+type outboxRelay struct {
+	transactor       repository.Transactor
+	outboxRepository repository.OutboxRepository
+	subscribers      []EventSubscriber
+	config           configs.OutboxConfig
+}
+
+// NewOutboxRelay membuat instance baru dari OutboxRelay.
+// Nilai konfigurasi yang kosong diganti dengan nilai default.
+func NewOutboxRelay(
+	transactor repository.Transactor,
+	outboxRepository repository.OutboxRepository,
+	config configs.OutboxConfig,
+	subscribers ...EventSubscriber,
+) OutboxRelay {
+	if config.BatchSize <= 0 {
+		config.BatchSize = 100
+	}
+	if config.BackoffBaseSeconds <= 0 {
+		config.BackoffBaseSeconds = 5
+	}
+	if config.BackoffMaxSeconds <= 0 {
+		config.BackoffMaxSeconds = 300
+	}
+	if config.RetentionHours <= 0 {
+		config.RetentionHours = 168
+	}
+	return &outboxRelay{
+		transactor:       transactor,
+		outboxRepository: outboxRepository,
+		subscribers:      subscribers,
+		config:           config,
+	}
+}
+
+// RelayPending meneruskan satu batch event dan mengembalikan jumlah event yang terkirim.
+// Setiap batch hanya berisi event terlama dari tiap aggregate, sehingga event berikutnya
+// dari aggregate yang sama menunggu hingga event sebelumnya terkirim. Event yang gagal
+// dijadwalkan ulang dengan exponential backoff dan tidak pernah dibuang.
+func (r *outboxRelay) RelayPending(ctx context.Context) (int, error) {
+	published := 0
+	err := r.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Hanya satu relay yang berjalan agar urutan per aggregate tidak tertukar antar instance
+		locked, err := r.outboxRepository.TryLock(ctx)
+		if err != nil || !locked {
+			return err
+		}
+
+		events, err := r.outboxRepository.FindPending(ctx, time.Now(), r.config.BatchSize)
+		if err != nil {
+			return err
+		}
+
+		for i := range events {
+			event := &events[i]
+			if err := r.dispatch(ctx, event.Event()); err != nil {
+				if err := r.scheduleRetry(ctx, event, err); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := r.outboxRepository.MarkPublished(ctx, event.ID, time.Now()); err != nil {
+				return err
+			}
+			published++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("gagal meneruskan event outbox: %w", err)
+	}
+	return published, nil
+}
+
+// PurgePublished menghapus event terkirim yang melewati masa simpan
+func (r *outboxRelay) PurgePublished(ctx context.Context) (int64, error) {
+	before := time.Now().Add(-time.Duration(r.config.RetentionHours) * time.Hour)
+	deleted, err := r.outboxRepository.DeletePublishedBefore(ctx, before)
+	if err != nil {
+		return 0, fmt.Errorf("gagal membersihkan outbox: %w", err)
+	}
+	return deleted, nil
+}
+
+// dispatch meneruskan event ke setiap subscriber secara berurutan dan berhenti pada kegagalan pertama
+func (r *outboxRelay) dispatch(ctx context.Context, event entity.Event) error {
+	for _, subscriber := range r.subscribers {
+		if err := subscriber.HandleEvent(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// scheduleRetry mencatat kegagalan dan menunda percobaan berikutnya
+func (r *outboxRelay) scheduleRetry(ctx context.Context, event *entity.OutboxEvent, cause error) error {
+	fmt.Printf("kesalahan meneruskan event %s: %v\n", event.EventID, cause)
+
+	event.Attempts++
+	next := time.Now().Add(webhook.Backoff(event.Attempts,
+		time.Duration(r.config.BackoffBaseSeconds)*time.Second,
+		time.Duration(r.config.BackoffMaxSeconds)*time.Second))
+	event.NextAttemptAt = &next
+	event.LastError = cause.Error()
+	return r.outboxRepository.MarkFailed(ctx, event)
+}
+
+type streamSubscriber struct {
+	writer eventstream.Writer
+}
+
+// NewStreamSubscriber membuat EventSubscriber yang menulis setiap event ke Redis Stream
+func NewStreamSubscriber(writer eventstream.Writer) EventSubscriber {
+	return &streamSubscriber{writer: writer}
+}
+
+// HandleEvent menulis amplop event lengkap sebagai payload entri stream
+func (s *streamSubscriber) HandleEvent(ctx context.Context, event entity.Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("gagal menyusun payload event %s: %w", event.Type, err)
+	}
+	return s.writer.Write(ctx, eventstream.Entry{
+		ID:            event.ID,
+		Type:          event.Type,
+		AggregateType: event.AggregateType,
+		AggregateID:   event.AggregateID,
+		Payload:       payload,
+	})
+}
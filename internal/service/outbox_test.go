@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"go-todo/configs"
+	"go-todo/internal/entity"
+	"go-todo/pkg/eventstream"
+	mock_eventstream "go-todo/test/mock/pkg/eventstream"
+	mock_repository "go-todo/test/mock/repository"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// passThroughTransactor membuat Transactor mock yang langsung menjalankan fn dengan ctx yang sama
+func passThroughTransactor(ctrl *gomock.Controller) *mock_repository.MockTransactor {
+	transactor := mock_repository.NewMockTransactor(ctrl)
+	transactor.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+		func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+	return transactor
+}
+
+func TestEventRecorder_Record(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockOutboxRepository(ctrl)
+	recorder := NewEventRecorder(mockRepo)
+
+	ctx := context.Background()
+	mockRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, event *entity.OutboxEvent) error {
+		assert.Regexp(t, "^evt_[0-9a-f]{32}$", event.EventID)
+		assert.Equal(t, entity.AggregateTodo, event.AggregateType)
+		assert.Equal(t, int64(7), event.AggregateID)
+		assert.Equal(t, entity.EventTodoCreated, event.EventType)
+		assert.JSONEq(t, `{"id":7,"title":"Todo"}`, string(event.Payload))
+		return nil
+	})
+
+	err := recorder.Record(ctx, entity.DomainEvent{
+		Type:          entity.EventTodoCreated,
+		AggregateType: entity.AggregateTodo,
+		AggregateID:   7,
+		Data:          map[string]interface{}{"id": 7, "title": "Todo"},
+	})
+	assert.NoError(t, err)
+}
+
+func TestOutboxRelay_RelayPending(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockOutboxRepository(ctrl)
+	var received []string
+	subscriber := EventSubscriberFunc(func(_ context.Context, event entity.Event) error {
+		if event.ID == "evt_2" {
+			return errors.New("subscriber error")
+		}
+		received = append(received, event.ID)
+		return nil
+	})
+	relay := NewOutboxRelay(passThroughTransactor(ctrl), mockRepo, configs.OutboxConfig{BatchSize: 10}, subscriber)
+
+	ctx := context.Background()
+	mockRepo.EXPECT().TryLock(ctx).Return(true, nil)
+	mockRepo.EXPECT().FindPending(ctx, gomock.Any(), 10).Return([]entity.OutboxEvent{
+		{ID: 1, EventID: "evt_1", AggregateType: entity.AggregateTodo, AggregateID: 7, EventType: entity.EventTodoCreated, Payload: json.RawMessage(`{}`)},
+		{ID: 2, EventID: "evt_2", AggregateType: entity.AggregateTodo, AggregateID: 8, EventType: entity.EventTodoCreated, Payload: json.RawMessage(`{}`)},
+	}, nil)
+	mockRepo.EXPECT().MarkPublished(ctx, int64(1), gomock.Any()).Return(nil)
+	mockRepo.EXPECT().MarkFailed(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, event *entity.OutboxEvent) error {
+		// Event gagal dijadwalkan ulang dengan backoff, tidak ditandai terkirim
+		assert.Equal(t, int64(2), event.ID)
+		assert.Equal(t, 1, event.Attempts)
+		assert.Equal(t, "subscriber error", event.LastError)
+		assert.True(t, event.NextAttemptAt.After(time.Now()))
+		return nil
+	})
+
+	published, err := relay.RelayPending(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, published)
+	assert.Equal(t, []string{"evt_1"}, received)
+}
+
+func TestOutboxRelay_RelayPending_LockedByAnotherRelay(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockOutboxRepository(ctrl)
+	relay := NewOutboxRelay(passThroughTransactor(ctrl), mockRepo, configs.OutboxConfig{})
+
+	// Outbox tidak dibaca selama relay lain memegang lock
+	ctx := context.Background()
+	mockRepo.EXPECT().TryLock(ctx).Return(false, nil)
+
+	published, err := relay.RelayPending(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, published)
+}
+
+func TestOutboxRelay_RelayPending_MarkPublishedError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockOutboxRepository(ctrl)
+	relay := NewOutboxRelay(passThroughTransactor(ctrl), mockRepo, configs.OutboxConfig{})
+
+	// Kegagalan menandai event membatalkan transaksi sehingga event dikirim ulang
+	ctx := context.Background()
+	mockRepo.EXPECT().TryLock(ctx).Return(true, nil)
+	mockRepo.EXPECT().FindPending(ctx, gomock.Any(), 100).Return([]entity.OutboxEvent{{ID: 1, EventID: "evt_1"}}, nil)
+	mockRepo.EXPECT().MarkPublished(ctx, int64(1), gomock.Any()).Return(errors.New("database error"))
+
+	_, err := relay.RelayPending(ctx)
+	assert.Error(t, err)
+}
+
+func TestStreamSubscriber_HandleEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWriter := mock_eventstream.NewMockWriter(ctrl)
+	subscriber := NewStreamSubscriber(mockWriter)
+
+	ctx := context.Background()
+	event := entity.Event{
+		ID:            "evt_1",
+		Type:          entity.EventUserDeleted,
+		AggregateType: entity.AggregateUser,
+		AggregateID:   3,
+		Data:          json.RawMessage(`{"id":3}`),
+	}
+	mockWriter.EXPECT().Write(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, entry eventstream.Entry) error {
+		assert.Equal(t, "evt_1", entry.ID)
+		assert.Equal(t, entity.AggregateUser, entry.AggregateType)
+		assert.Equal(t, int64(3), entry.AggregateID)
+
+		var decoded entity.Event
+		assert.NoError(t, json.Unmarshal(entry.Payload, &decoded))
+		assert.Equal(t, entity.EventUserDeleted, decoded.Type)
+		return nil
+	})
+
+	assert.NoError(t, subscriber.HandleEvent(ctx, event))
+}
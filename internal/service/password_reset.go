@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-todo/configs"
+	"go-todo/internal/entity"
+	"go-todo/internal/repository"
+	"go-todo/pkg/mail"
+	"go-todo/pkg/password"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+var ErrTokenResetTidakValid = errors.New("token reset password tidak valid atau sudah kedaluwarsa")
+
+const (
+	defaultResetTokenTTL         = 30 * time.Minute
+	defaultResetRequestsPerHour  = 3
+	passwordResetRateLimitWindow = time.Hour
+)
+
+// PasswordResetService mengatur lupa password melalui token sekali pakai yang dikirim lewat
+// email. Hanya hash token yang disimpan sehingga isi database tidak dapat dipakai untuk reset.
+type PasswordResetService interface {
+	// Forgot mengirim link reset ke email pengguna. Username yang tidak terdaftar, pengguna
+	// tanpa email, maupun permintaan yang melewati batas tetap berhasil tanpa mengirim apa pun
+	// agar keberadaan akun tidak bocor.
+	Forgot(ctx context.Context, username string) error
+	// Reset mengganti password dengan token reset lalu mencabut seluruh sesi pengguna
+	Reset(ctx context.Context, resetToken, newPassword string) error
+}
+
+type passwordResetService struct {
+	userRepository          repository.UserRepository
+	passwordResetRepository repository.PasswordResetRepository
+	tokenService            TokenService
+	loginGuard              LoginGuard
+	passwordPolicy          *password.Policy
+	sender                  mail.Sender
+	transactor              repository.Transactor
+	config                  configs.PasswordResetConfig
+}
+
+// NewPasswordResetService membuat instance baru dari PasswordResetService.
+// Masa berlaku dan batas permintaan yang tidak diatur diganti dengan nilai default.
+func NewPasswordResetService(
+	userRepository repository.UserRepository,
+	passwordResetRepository repository.PasswordResetRepository,
+	tokenService TokenService,
+	loginGuard LoginGuard,
+	passwordPolicy *password.Policy,
+	sender mail.Sender,
+	transactor repository.Transactor,
+	config configs.PasswordResetConfig,
+) PasswordResetService {
+	if config.TokenTTLMinutes <= 0 {
+		config.TokenTTLMinutes = int(defaultResetTokenTTL / time.Minute)
+	}
+	if config.MaxRequestsPerHour <= 0 {
+		config.MaxRequestsPerHour = defaultResetRequestsPerHour
+	}
+	return &passwordResetService{
+		userRepository:          userRepository,
+		passwordResetRepository: passwordResetRepository,
+		tokenService:            tokenService,
+		loginGuard:              loginGuard,
+		passwordPolicy:          passwordPolicy,
+		sender:                  sender,
+		transactor:              transactor,
+		config:                  config,
+	}
+}
+
+// Forgot membuat token reset baru dan membatalkan token sebelumnya. Email dikirim di
+// background agar waktu respons tidak bergantung pada server SMTP.
+func (s *passwordResetService) Forgot(ctx context.Context, username string) error {
+	user, err := s.userRepository.FindByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, repository.ErrPenggunaTidakDitemukan) {
+			return nil
+		}
+		return fmt.Errorf("gagal memproses lupa password: %w", err)
+	}
+	if user.Email == "" {
+		fmt.Printf("peringatan: pengguna %d meminta reset password tetapi tidak memiliki email\n", user.ID)
+		return nil
+	}
+
+	now := time.Now()
+	requests, err := s.passwordResetRepository.CountSince(ctx, user.ID, now.Add(-passwordResetRateLimitWindow))
+	if err != nil {
+		return fmt.Errorf("gagal memproses lupa password: %w", err)
+	}
+	if requests >= int64(s.config.MaxRequestsPerHour) {
+		fmt.Printf("peringatan: permintaan reset password pengguna %d melewati batas %d per jam\n", user.ID, s.config.MaxRequestsPerHour)
+		return nil
+	}
+
+	ttl := time.Duration(s.config.TokenTTLMinutes) * time.Minute
+	resetToken := newOpaqueToken()
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if _, err := s.passwordResetRepository.InvalidateByUserID(ctx, user.ID, now); err != nil {
+			return err
+		}
+		return s.passwordResetRepository.Create(ctx, &entity.PasswordResetToken{
+			UserID:    user.ID,
+			TokenHash: hashToken(resetToken),
+			ExpiresAt: now.Add(ttl),
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("gagal membuat token reset password: %w", err)
+	}
+
+	msg := mail.Message{
+		To:      user.Email,
+		Subject: "Reset password",
+		Body: fmt.Sprintf("Halo %s,\n\n"+
+			"Buka link berikut untuk mengatur ulang password Anda. Link berlaku selama %d menit dan hanya dapat dipakai sekali:\n\n"+
+			"%s\n\n"+
+			"Abaikan email ini jika Anda tidak meminta reset password.\n",
+			user.FullName, s.config.TokenTTLMinutes, tokenURL(s.config.URL, resetToken)),
+	}
+	go func() {
+		if err := s.sender.Send(context.WithoutCancel(ctx), msg); err != nil {
+			fmt.Printf("kesalahan mengirim email reset password: %v\n", err)
+		}
+	}()
+
+	return nil
+}
+
+// Reset memvalidasi token, mengganti password, membatalkan seluruh token reset pengguna, dan
+// mencabut seluruh sesinya dalam satu transaksi. Token dikunci selama transaksi sehingga
+// permintaan bersamaan dengan token yang sama hanya berhasil sekali.
+func (s *passwordResetService) Reset(ctx context.Context, resetToken, newPassword string) error {
+	var user *entity.User
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		stored, err := s.passwordResetRepository.FindByHashForUpdate(ctx, hashToken(resetToken))
+		if err != nil {
+			if errors.Is(err, repository.ErrTokenResetTidakDitemukan) {
+				return ErrTokenResetTidakValid
+			}
+			return err
+		}
+		now := time.Now()
+		if stored.UsedAt != nil || !now.Before(stored.ExpiresAt) {
+			return ErrTokenResetTidakValid
+		}
+
+		user, err = s.userRepository.FindByID(ctx, stored.UserID)
+		if err != nil {
+			if errors.Is(err, repository.ErrPenggunaTidakDitemukan) {
+				return ErrTokenResetTidakValid
+			}
+			return err
+		}
+
+		// Password yang ditolak kebijakan membatalkan transaksi sehingga token masih dapat dipakai
+		if err := s.passwordPolicy.Validate(newPassword, user.Username); err != nil {
+			return err
+		}
+		hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+		if err != nil {
+			return fmt.Errorf("gagal mengenkripsi password: %w", err)
+		}
+
+		if _, err := s.userRepository.Update(ctx, &entity.User{ID: user.ID, Password: string(hashedPassword)}); err != nil {
+			return err
+		}
+		if _, err := s.passwordResetRepository.InvalidateByUserID(ctx, user.ID, now); err != nil {
+			return err
+		}
+		return s.tokenService.RevokeAll(ctx, user.ID)
+	})
+	if err != nil {
+		if errors.Is(err, ErrTokenResetTidakValid) || errors.Is(err, password.ErrTidakMemenuhiKebijakan) {
+			return err
+		}
+		return fmt.Errorf("gagal mereset password: %w", err)
+	}
+
+	// Pemilik akun yang terkunci karena percobaan login dapat langsung login dengan password baru
+	if err := s.loginGuard.Unlock(ctx, user.Username); err != nil {
+		fmt.Printf("kesalahan membuka kunci login: %v\n", err)
+	}
+	return nil
+}
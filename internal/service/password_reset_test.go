@@ -0,0 +1,235 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"go-todo/configs"
+	"go-todo/internal/entity"
+	"go-todo/internal/repository"
+	"go-todo/pkg/mail"
+	"go-todo/pkg/password"
+	mock_mail "go-todo/test/mock/pkg/mail"
+	mock_repository "go-todo/test/mock/repository"
+	mock_service "go-todo/test/mock/service"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// passwordResetMocks mengelompokkan semua dependensi mock dari PasswordResetService
+type passwordResetMocks struct {
+	users  *mock_repository.MockUserRepository
+	resets *mock_repository.MockPasswordResetRepository
+	token  *mock_service.MockTokenService
+	guard  *mock_service.MockLoginGuard
+	sender *mock_mail.MockSender
+}
+
+func setupPasswordResetService(t *testing.T) (*gomock.Controller, PasswordResetService, *passwordResetMocks) {
+	ctrl := gomock.NewController(t)
+	m := &passwordResetMocks{
+		users:  mock_repository.NewMockUserRepository(ctrl),
+		resets: mock_repository.NewMockPasswordResetRepository(ctrl),
+		token:  mock_service.NewMockTokenService(ctrl),
+		guard:  mock_service.NewMockLoginGuard(ctrl),
+		sender: mock_mail.NewMockSender(ctrl),
+	}
+	config := configs.PasswordResetConfig{TokenTTLMinutes: 30, MaxRequestsPerHour: 3, URL: "https://todo.example.com/reset-password"}
+	service := NewPasswordResetService(m.users, m.resets, m.token, m.guard, testPasswordPolicy, m.sender, passThroughTransactor(ctrl), config)
+	return ctrl, service, m
+}
+
+// Kasus uji untuk Forgot
+
+func TestPasswordResetService_Forgot_SendsSingleUseToken(t *testing.T) {
+	ctrl, service, m := setupPasswordResetService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	user := &entity.User{ID: 7, Username: "budi", FullName: "Budi", Email: "budi@example.com"}
+	var stored *entity.PasswordResetToken
+	sent := make(chan mail.Message, 1)
+
+	m.users.EXPECT().FindByUsername(ctx, "budi").Return(user, nil)
+	m.resets.EXPECT().CountSince(gomock.Any(), int64(7), gomock.Any()).Return(int64(1), nil)
+	m.resets.EXPECT().InvalidateByUserID(gomock.Any(), int64(7), gomock.Any()).Return(int64(1), nil)
+	m.resets.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, resetToken *entity.PasswordResetToken) error {
+			stored = resetToken
+			return nil
+		})
+	m.sender.EXPECT().Send(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, msg mail.Message) error {
+			sent <- msg
+			return nil
+		})
+
+	err := service.Forgot(ctx, "budi")
+	assert.NoError(t, err)
+
+	var msg mail.Message
+	select {
+	case msg = <-sent:
+	case <-time.After(time.Second):
+		t.Fatal("email reset password tidak terkirim")
+	}
+	assert.Equal(t, "budi@example.com", msg.To)
+
+	// Link berisi token asli, sedangkan database hanya menyimpan hash-nya
+	start := strings.Index(msg.Body, "https://")
+	end := strings.Index(msg.Body[start:], "\n")
+	link, err := url.Parse(msg.Body[start : start+end])
+	assert.NoError(t, err)
+	resetToken := link.Query().Get("token")
+	assert.NotEmpty(t, resetToken)
+	assert.Equal(t, "/reset-password", link.Path)
+	assert.Equal(t, hashToken(resetToken), stored.TokenHash)
+	assert.NotContains(t, msg.Body, stored.TokenHash)
+	assert.WithinDuration(t, time.Now().Add(30*time.Minute), stored.ExpiresAt, 5*time.Second)
+}
+
+func TestPasswordResetService_Forgot_UnknownUsername(t *testing.T) {
+	ctrl, service, m := setupPasswordResetService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m.users.EXPECT().FindByUsername(ctx, "hantu").Return(nil, repository.ErrPenggunaTidakDitemukan)
+
+	err := service.Forgot(ctx, "hantu")
+	assert.NoError(t, err)
+}
+
+func TestPasswordResetService_Forgot_WithoutEmail(t *testing.T) {
+	ctrl, service, m := setupPasswordResetService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m.users.EXPECT().FindByUsername(ctx, "budi").Return(&entity.User{ID: 7, Username: "budi"}, nil)
+
+	err := service.Forgot(ctx, "budi")
+	assert.NoError(t, err)
+}
+
+func TestPasswordResetService_Forgot_RateLimited(t *testing.T) {
+	ctrl, service, m := setupPasswordResetService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m.users.EXPECT().FindByUsername(ctx, "budi").Return(&entity.User{ID: 7, Username: "budi", Email: "budi@example.com"}, nil)
+	m.resets.EXPECT().CountSince(ctx, int64(7), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, userID int64, since time.Time) (int64, error) {
+			assert.WithinDuration(t, time.Now().Add(-time.Hour), since, 5*time.Second)
+			return 3, nil
+		})
+
+	// Respons tetap berhasil agar batas permintaan tidak membocorkan keberadaan akun
+	err := service.Forgot(ctx, "budi")
+	assert.NoError(t, err)
+}
+
+func TestPasswordResetService_Forgot_DatabaseError(t *testing.T) {
+	ctrl, service, m := setupPasswordResetService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m.users.EXPECT().FindByUsername(ctx, "budi").Return(nil, repository.ErrDatabaseError)
+
+	err := service.Forgot(ctx, "budi")
+	assert.ErrorIs(t, err, repository.ErrDatabaseError)
+}
+
+// Kasus uji untuk Reset
+
+func TestPasswordResetService_Reset_Success(t *testing.T) {
+	ctrl, service, m := setupPasswordResetService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	stored := &entity.PasswordResetToken{ID: 1, UserID: 7, TokenHash: hashToken("token-asli"), ExpiresAt: time.Now().Add(10 * time.Minute)}
+
+	m.resets.EXPECT().FindByHashForUpdate(ctx, hashToken("token-asli")).Return(stored, nil)
+	m.users.EXPECT().FindByID(ctx, int64(7)).Return(&entity.User{ID: 7, Username: "budi"}, nil)
+	m.users.EXPECT().Update(ctx, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, user *entity.User) (*entity.User, error) {
+			assert.Equal(t, int64(7), user.ID)
+			assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(user.Password), []byte("Kopi-Pagi-2026")))
+			return user, nil
+		})
+	m.resets.EXPECT().InvalidateByUserID(ctx, int64(7), gomock.Any()).Return(int64(1), nil)
+	m.token.EXPECT().RevokeAll(ctx, int64(7)).Return(nil)
+	m.guard.EXPECT().Unlock(ctx, "budi").Return(nil)
+
+	err := service.Reset(ctx, "token-asli", "Kopi-Pagi-2026")
+	assert.NoError(t, err)
+}
+
+func TestPasswordResetService_Reset_UnknownToken(t *testing.T) {
+	ctrl, service, m := setupPasswordResetService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m.resets.EXPECT().FindByHashForUpdate(ctx, hashToken("palsu")).Return(nil, repository.ErrTokenResetTidakDitemukan)
+
+	err := service.Reset(ctx, "palsu", "Kopi-Pagi-2026")
+	assert.ErrorIs(t, err, ErrTokenResetTidakValid)
+}
+
+func TestPasswordResetService_Reset_UsedToken(t *testing.T) {
+	ctrl, service, m := setupPasswordResetService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	usedAt := time.Now().Add(-time.Minute)
+	stored := &entity.PasswordResetToken{UserID: 7, ExpiresAt: time.Now().Add(10 * time.Minute), UsedAt: &usedAt}
+	m.resets.EXPECT().FindByHashForUpdate(ctx, hashToken("token-asli")).Return(stored, nil)
+
+	err := service.Reset(ctx, "token-asli", "Kopi-Pagi-2026")
+	assert.ErrorIs(t, err, ErrTokenResetTidakValid)
+}
+
+func TestPasswordResetService_Reset_ExpiredToken(t *testing.T) {
+	ctrl, service, m := setupPasswordResetService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	stored := &entity.PasswordResetToken{UserID: 7, ExpiresAt: time.Now().Add(-time.Second)}
+	m.resets.EXPECT().FindByHashForUpdate(ctx, hashToken("token-asli")).Return(stored, nil)
+
+	err := service.Reset(ctx, "token-asli", "Kopi-Pagi-2026")
+	assert.ErrorIs(t, err, ErrTokenResetTidakValid)
+}
+
+func TestPasswordResetService_Reset_WeakPassword(t *testing.T) {
+	ctrl, service, m := setupPasswordResetService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	stored := &entity.PasswordResetToken{UserID: 7, ExpiresAt: time.Now().Add(10 * time.Minute)}
+	m.resets.EXPECT().FindByHashForUpdate(ctx, hashToken("token-asli")).Return(stored, nil)
+	m.users.EXPECT().FindByID(ctx, int64(7)).Return(&entity.User{ID: 7, Username: "budi"}, nil)
+
+	err := service.Reset(ctx, "token-asli", "pendek")
+	var policyErr *password.ValidationError
+	assert.True(t, errors.As(err, &policyErr))
+}
+
+func TestPasswordResetService_Reset_RevokeFails(t *testing.T) {
+	ctrl, service, m := setupPasswordResetService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	stored := &entity.PasswordResetToken{UserID: 7, ExpiresAt: time.Now().Add(10 * time.Minute)}
+	m.resets.EXPECT().FindByHashForUpdate(ctx, hashToken("token-asli")).Return(stored, nil)
+	m.users.EXPECT().FindByID(ctx, int64(7)).Return(&entity.User{ID: 7, Username: "budi"}, nil)
+	m.users.EXPECT().Update(ctx, gomock.Any()).Return(&entity.User{ID: 7}, nil)
+	m.resets.EXPECT().InvalidateByUserID(ctx, int64(7), gomock.Any()).Return(int64(1), nil)
+	m.token.EXPECT().RevokeAll(ctx, int64(7)).Return(repository.ErrDatabaseError)
+
+	err := service.Reset(ctx, "token-asli", "Kopi-Pagi-2026")
+	assert.ErrorIs(t, err, repository.ErrDatabaseError)
+}
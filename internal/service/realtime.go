@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go-todo/internal/entity"
+	"go-todo/pkg/realtime"
+	"slices"
+)
+
+// RealtimeService mendorong perubahan todo ke klien yang terhubung melalui SSE atau WebSocket
+type RealtimeService interface {
+	HandleEvent(ctx context.Context, event entity.Event) error
+	Subscribe(ctx context.Context, userID int64, lastEventID string) (<-chan realtime.Message, error)
+}
+
+type realtimeService struct {
+	broker realtime.Broker
+}
+
+// NewRealtimeService membuat instance baru dari RealtimeService
+func NewRealtimeService(broker realtime.Broker) RealtimeService {
+	return &realtimeService{broker: broker}
+}
+
+// HandleEvent meneruskan event todo kepada pemilik, penanggung jawab, dan penanggung jawab
+// sebelumnya jika todo baru dilepas atau dialihkan. Event lain diabaikan. Jika pengiriman ke
+// salah satu pengguna gagal, relay mengulang event sehingga pengguna lain dapat menerimanya
+// lebih dari sekali.
+func (s *realtimeService) HandleEvent(ctx context.Context, event entity.Event) error {
+	for _, userID := range todoAudience(event) {
+		if err := s.broker.Publish(ctx, userID, event.Type, event.Data); err != nil {
+			return fmt.Errorf("gagal mengirim event realtime ke pengguna %d: %w", userID, err)
+		}
+	}
+	return nil
+}
+
+// Subscribe mengembalikan aliran event milik pengguna hingga ctx dibatalkan
+func (s *realtimeService) Subscribe(ctx context.Context, userID int64, lastEventID string) (<-chan realtime.Message, error) {
+	messages, err := s.broker.Subscribe(ctx, userID, lastEventID)
+	if err != nil {
+		return nil, fmt.Errorf("gagal berlangganan event realtime: %w", err)
+	}
+	return messages, nil
+}
+
+// todoAudience mengambil pengguna yang terdampak dari payload event todo yang berasal dari
+// outbox: pemilik, penanggung jawab, dan penanggung jawab sebelumnya, tanpa duplikasi
+func todoAudience(event entity.Event) []int64 {
+	data, ok := event.Data.(json.RawMessage)
+	if event.AggregateType != entity.AggregateTodo || !ok {
+		return nil
+	}
+
+	var todo struct {
+		UserID             int64  `json:"user_id"`
+		AssigneeID         *int64 `json:"assignee_id"`
+		PreviousAssigneeID *int64 `json:"previous_assignee_id"`
+	}
+	if err := json.Unmarshal(data, &todo); err != nil {
+		return nil
+	}
+
+	audience := make([]int64, 0, 3)
+	for _, userID := range []*int64{&todo.UserID, todo.AssigneeID, todo.PreviousAssigneeID} {
+		if userID == nil || *userID == 0 || slices.Contains(audience, *userID) {
+			continue
+		}
+		audience = append(audience, *userID)
+	}
+	return audience
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"go-todo/internal/entity"
+	"go-todo/pkg/realtime"
+	mock_realtime "go-todo/test/mock/pkg/realtime"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRealtimeService_HandleEvent_TodoOwner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBroker := mock_realtime.NewMockBroker(ctrl)
+	service := NewRealtimeService(mockBroker)
+
+	ctx := context.Background()
+	data := json.RawMessage(`{"id":1,"user_id":5}`)
+	event := entity.Event{ID: "evt_1", Type: entity.EventTodoCreated, AggregateType: entity.AggregateTodo, AggregateID: 1, Data: data}
+
+	mockBroker.EXPECT().Publish(ctx, int64(5), entity.EventTodoCreated, data).Return(nil)
+	assert.NoError(t, service.HandleEvent(ctx, event))
+
+	// Kegagalan broker dikembalikan agar relay mencoba ulang
+	mockBroker.EXPECT().Publish(ctx, int64(5), entity.EventTodoCreated, data).Return(errors.New("redis error"))
+	assert.Error(t, service.HandleEvent(ctx, event))
+}
+
+func TestRealtimeService_HandleEvent_AssigneeAndPreviousAssignee(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBroker := mock_realtime.NewMockBroker(ctrl)
+	service := NewRealtimeService(mockBroker)
+	ctx := context.Background()
+
+	// Perubahan oleh pemilik juga dikirim ke penanggung jawab
+	updated := json.RawMessage(`{"id":1,"user_id":5,"assignee_id":8}`)
+	mockBroker.EXPECT().Publish(ctx, int64(5), entity.EventTodoUpdated, updated).Return(nil)
+	mockBroker.EXPECT().Publish(ctx, int64(8), entity.EventTodoUpdated, updated).Return(nil)
+	assert.NoError(t, service.HandleEvent(ctx, entity.Event{
+		Type: entity.EventTodoUpdated, AggregateType: entity.AggregateTodo, AggregateID: 1, Data: updated,
+	}))
+
+	// Penanggung jawab sebelumnya mengetahui todo dilepas; pengguna yang sama hanya dikirimi sekali
+	unassigned := json.RawMessage(`{"id":1,"user_id":5,"assignee_id":null,"previous_assignee_id":8}`)
+	mockBroker.EXPECT().Publish(ctx, int64(5), entity.EventTodoUpdated, unassigned).Return(nil)
+	mockBroker.EXPECT().Publish(ctx, int64(8), entity.EventTodoUpdated, unassigned).Return(nil)
+	assert.NoError(t, service.HandleEvent(ctx, entity.Event{
+		Type: entity.EventTodoUpdated, AggregateType: entity.AggregateTodo, AggregateID: 1, Data: unassigned,
+	}))
+
+	selfAssigned := json.RawMessage(`{"id":1,"user_id":5,"assignee_id":5}`)
+	mockBroker.EXPECT().Publish(ctx, int64(5), entity.EventTodoUpdated, selfAssigned).Return(nil)
+	assert.NoError(t, service.HandleEvent(ctx, entity.Event{
+		Type: entity.EventTodoUpdated, AggregateType: entity.AggregateTodo, AggregateID: 1, Data: selfAssigned,
+	}))
+}
+
+func TestRealtimeService_HandleEvent_IgnoresOtherEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Broker tidak boleh dipanggil untuk event pengguna
+	service := NewRealtimeService(mock_realtime.NewMockBroker(ctrl))
+	err := service.HandleEvent(context.Background(), entity.Event{
+		Type:          entity.EventUserCreated,
+		AggregateType: entity.AggregateUser,
+		AggregateID:   5,
+		Data:          json.RawMessage(`{"id":5}`),
+	})
+	assert.NoError(t, err)
+}
+
+func TestRealtimeService_Subscribe(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBroker := mock_realtime.NewMockBroker(ctrl)
+	service := NewRealtimeService(mockBroker)
+
+	ctx := context.Background()
+	messages := make(chan realtime.Message)
+	mockBroker.EXPECT().Subscribe(ctx, int64(5), "1700000000000-0").Return((<-chan realtime.Message)(messages), nil)
+
+	received, err := service.Subscribe(ctx, 5, "1700000000000-0")
+	assert.NoError(t, err)
+	assert.NotNil(t, received)
+
+	mockBroker.EXPECT().Subscribe(ctx, int64(5), "").Return(nil, errors.New("redis error"))
+	_, err = service.Subscribe(ctx, 5, "")
+	assert.Error(t, err)
+}
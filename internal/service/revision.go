@@ -0,0 +1,210 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-todo/internal/entity"
+	"go-todo/internal/repository"
+	"reflect"
+	"sort"
+	"time"
+)
+
+var ErrRevisiTidakDitemukan = errors.New("revisi tidak ditemukan")
+
+// RevisionRecorder menyimpan snapshot isi todo sebagai revisi baru. Record harus dipanggil
+// dengan ctx dari Transactor.WithinTransaction setelah baris todo diperbarui, sehingga
+// penomoran revisi untuk todo yang sama tidak berjalan bersamaan.
+type RevisionRecorder interface {
+	Record(ctx context.Context, before *entity.Todo, after entity.Todo, revertedFrom *int) error
+}
+
+type revisionRecorder struct {
+	revisionRepository repository.RevisionRepository
+}
+
+// NewRevisionRecorder membuat RevisionRecorder yang menulis ke tabel todo_revisions
+func NewRevisionRecorder(revisionRepository repository.RevisionRepository) RevisionRecorder {
+	return &revisionRecorder{revisionRepository: revisionRepository}
+}
+
+// Record menyimpan isi todo setelah perubahan sebagai revisi berikutnya. Todo lama yang
+// belum memiliki revisi mendapat revisi awal dari isi sebelum perubahan. Perubahan yang
+// tidak menyentuh isi, misalnya hanya status selesai, tidak membuat revisi baru.
+func (r *revisionRecorder) Record(ctx context.Context, before *entity.Todo, after entity.Todo, revertedFrom *int) error {
+	next := 1
+	latest, err := r.revisionRepository.FindLatest(ctx, after.ID)
+	switch {
+	case err == nil:
+		if revertedFrom == nil && len(diffSnapshots(latest.Snapshot, after.Snapshot())) == 0 {
+			return nil
+		}
+		next = latest.Revision + 1
+	case errors.Is(err, repository.ErrRevisiTidakDitemukan):
+		if before != nil && len(diffSnapshots(before.Snapshot(), after.Snapshot())) > 0 {
+			if err := r.create(ctx, after.ID, next, before.Snapshot(), nil); err != nil {
+				return err
+			}
+			next++
+		}
+	default:
+		return fmt.Errorf("gagal mengambil revisi todo: %w", err)
+	}
+	return r.create(ctx, after.ID, next, after.Snapshot(), revertedFrom)
+}
+
+func (r *revisionRecorder) create(ctx context.Context, todoID int64, number int, snapshot entity.TodoSnapshot, revertedFrom *int) error {
+	err := r.revisionRepository.Create(ctx, &entity.TodoRevision{
+		TodoID:       todoID,
+		Revision:     number,
+		Snapshot:     snapshot,
+		RevertedFrom: revertedFrom,
+		CreatedAt:    time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("gagal menyimpan revisi todo: %w", err)
+	}
+	return nil
+}
+
+// RevisionService menampilkan riwayat revisi todo, perbedaan antar revisi, dan memulihkan
+// revisi lama. Pemulihan selalu membuat revisi baru sehingga riwayat tidak pernah ditulis ulang.
+type RevisionService interface {
+	FindAll(ctx context.Context, todoID int64) ([]entity.TodoRevision, error)
+	Diff(ctx context.Context, todoID int64, from, to int) ([]entity.RevisionChange, error)
+	Revert(ctx context.Context, todoID int64, revision int) (entity.Todo, error)
+}
+
+type revisionService struct {
+	revisionRepository repository.RevisionRepository
+	todoRepository     repository.TodoRepository
+	todoService        TodoService
+}
+
+// NewRevisionService membuat instance baru dari RevisionService
+func NewRevisionService(
+	revisionRepository repository.RevisionRepository,
+	todoRepository repository.TodoRepository,
+	todoService TodoService,
+) RevisionService {
+	return &revisionService{
+		revisionRepository: revisionRepository,
+		todoRepository:     todoRepository,
+		todoService:        todoService,
+	}
+}
+
+// FindAll mengambil seluruh revisi todo, dimulai dari yang terbaru
+func (s *revisionService) FindAll(ctx context.Context, todoID int64) ([]entity.TodoRevision, error) {
+	if _, err := s.todoRepository.FindByID(ctx, todoID); err != nil {
+		return nil, ErrTodoTidakDitemukan
+	}
+	revisions, err := s.revisionRepository.FindByTodoID(ctx, todoID)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil revisi todo: %w", err)
+	}
+	return revisions, nil
+}
+
+// Diff membandingkan isi dua revisi per field. Field kustom dibandingkan per key
+// dengan nama field custom_fields.<key>.
+func (s *revisionService) Diff(ctx context.Context, todoID int64, from, to int) ([]entity.RevisionChange, error) {
+	fromRevision, err := s.findRevision(ctx, todoID, from)
+	if err != nil {
+		return nil, err
+	}
+	toRevision, err := s.findRevision(ctx, todoID, to)
+	if err != nil {
+		return nil, err
+	}
+	return diffSnapshots(fromRevision.Snapshot, toRevision.Snapshot), nil
+}
+
+// Revert memulihkan isi todo ke sebuah revisi melalui TodoService, yang mencatat
+// hasilnya sebagai revisi baru
+func (s *revisionService) Revert(ctx context.Context, todoID int64, revision int) (entity.Todo, error) {
+	found, err := s.findRevision(ctx, todoID, revision)
+	if err != nil {
+		return entity.Todo{}, err
+	}
+	todo, err := s.todoService.Restore(ctx, todoID, *found)
+	if err != nil {
+		return entity.Todo{}, err
+	}
+	return todo, nil
+}
+
+func (s *revisionService) findRevision(ctx context.Context, todoID int64, revision int) (*entity.TodoRevision, error) {
+	found, err := s.revisionRepository.FindByRevision(ctx, todoID, revision)
+	if err != nil {
+		if errors.Is(err, repository.ErrRevisiTidakDitemukan) {
+			return nil, ErrRevisiTidakDitemukan
+		}
+		return nil, fmt.Errorf("gagal mengambil revisi todo: %w", err)
+	}
+	return found, nil
+}
+
+// diffSnapshots mengembalikan field yang berbeda antara dua snapshot dengan urutan tetap
+func diffSnapshots(from, to entity.TodoSnapshot) []entity.RevisionChange {
+	changes := make([]entity.RevisionChange, 0)
+	add := func(field string, a, b interface{}) {
+		if !reflect.DeepEqual(a, b) {
+			changes = append(changes, entity.RevisionChange{Field: field, From: a, To: b})
+		}
+	}
+	add("title", from.Title, to.Title)
+	add("content", from.Content, to.Content)
+	if !from.DueDate.Equal(to.DueDate) {
+		changes = append(changes, entity.RevisionChange{Field: "due_date", From: from.DueDate, To: to.DueDate})
+	}
+	add("project", from.Project, to.Project)
+	if !reflect.DeepEqual(normalizeTags(from.Tags), normalizeTags(to.Tags)) {
+		changes = append(changes, entity.RevisionChange{Field: "tags", From: normalizeTags(from.Tags), To: normalizeTags(to.Tags)})
+	}
+	add("priority", from.Priority, to.Priority)
+
+	keys := make(map[string]bool)
+	for key := range from.CustomFields {
+		keys[key] = true
+	}
+	for key := range to.CustomFields {
+		keys[key] = true
+	}
+	sorted := make([]string, 0, len(keys))
+	for key := range keys {
+		sorted = append(sorted, key)
+	}
+	sort.Strings(sorted)
+	for _, key := range sorted {
+		add("custom_fields."+key, jsonValue(from.CustomFields[key]), jsonValue(to.CustomFields[key]))
+	}
+	return changes
+}
+
+// normalizeTags menyamakan tag kosong dan nil agar tidak dianggap sebagai perubahan
+func normalizeTags(tags entity.StringList) entity.StringList {
+	if tags == nil {
+		return entity.StringList{}
+	}
+	return tags
+}
+
+// jsonValue menyamakan tipe nilai field kustom dari memori dan dari database, misalnya
+// int dan float64, dengan melewatkannya melalui JSON
+func jsonValue(value interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+	var normalized interface{}
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return value
+	}
+	return normalized
+}
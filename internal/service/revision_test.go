@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"go-todo/internal/entity"
+	"go-todo/internal/repository"
+	mock_cache "go-todo/test/mock/pkg/cache"
+	mock_repository "go-todo/test/mock/repository"
+	mock_service "go-todo/test/mock/service"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// noopRevisions mengembalikan RevisionRecorder yang menerima semua revisi tanpa menyimpannya
+func noopRevisions(ctrl *gomock.Controller) *mock_service.MockRevisionRecorder {
+	revisions := mock_service.NewMockRevisionRecorder(ctrl)
+	revisions.EXPECT().Record(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return(nil)
+	return revisions
+}
+
+func TestRevisionRecorder_Record_BaselineForLegacyTodo(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockRevisionRepository(ctrl)
+	recorder := NewRevisionRecorder(mockRepo)
+
+	before := entity.Todo{ID: 1, Title: "Lama"}
+	after := entity.Todo{ID: 1, Title: "Baru"}
+
+	// Todo tanpa revisi mendapat revisi awal dari isi sebelum perubahan
+	mockRepo.EXPECT().FindLatest(gomock.Any(), int64(1)).Return(nil, repository.ErrRevisiTidakDitemukan)
+	gomock.InOrder(
+		mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, revision *entity.TodoRevision) error {
+			assert.Equal(t, 1, revision.Revision)
+			assert.Equal(t, "Lama", revision.Snapshot.Title)
+			return nil
+		}),
+		mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, revision *entity.TodoRevision) error {
+			assert.Equal(t, 2, revision.Revision)
+			assert.Equal(t, "Baru", revision.Snapshot.Title)
+			return nil
+		}),
+	)
+
+	assert.NoError(t, recorder.Record(context.Background(), &before, after, nil))
+}
+
+func TestRevisionRecorder_Record_SkipsUnchangedContent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockRevisionRepository(ctrl)
+	recorder := NewRevisionRecorder(mockRepo)
+
+	dueDate := time.Date(2026, 10, 20, 9, 0, 0, 0, time.UTC)
+	latest := &entity.TodoRevision{TodoID: 1, Revision: 3, Snapshot: entity.TodoSnapshot{
+		Title: "Laporan", DueDate: dueDate, CustomFields: entity.CustomFieldValues{"estimasi": 2.0},
+	}}
+	after := entity.Todo{ID: 1, Title: "Laporan", Completed: true, DueDate: dueDate.In(time.FixedZone("WIB", 7*3600)),
+		Tags: entity.StringList{}, CustomFields: entity.CustomFieldValues{"estimasi": 2}}
+
+	// Hanya status selesai yang berubah sehingga tidak ada revisi baru
+	mockRepo.EXPECT().FindLatest(gomock.Any(), int64(1)).Return(latest, nil)
+
+	assert.NoError(t, recorder.Record(context.Background(), &entity.Todo{ID: 1}, after, nil))
+}
+
+func TestRevisionRecorder_Record_Revert(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockRevisionRepository(ctrl)
+	recorder := NewRevisionRecorder(mockRepo)
+
+	latest := &entity.TodoRevision{TodoID: 1, Revision: 4, Snapshot: entity.TodoSnapshot{Title: "Laporan"}}
+	revertedFrom := 2
+
+	mockRepo.EXPECT().FindLatest(gomock.Any(), int64(1)).Return(latest, nil)
+	mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, revision *entity.TodoRevision) error {
+		assert.Equal(t, 5, revision.Revision)
+		assert.Equal(t, &revertedFrom, revision.RevertedFrom)
+		return nil
+	})
+
+	assert.NoError(t, recorder.Record(context.Background(), nil, entity.Todo{ID: 1, Title: "Laporan"}, &revertedFrom))
+}
+
+func TestRevisionService_Diff(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockRevisionRepository(ctrl)
+	service := NewRevisionService(mockRepo, nil, nil)
+
+	mockRepo.EXPECT().FindByRevision(gomock.Any(), int64(1), 1).Return(&entity.TodoRevision{Revision: 1, Snapshot: entity.TodoSnapshot{
+		Title: "Draf", Content: "Isi", Tags: entity.StringList{"kerja"}, CustomFields: entity.CustomFieldValues{"status": "draft"},
+	}}, nil)
+	mockRepo.EXPECT().FindByRevision(gomock.Any(), int64(1), 3).Return(&entity.TodoRevision{Revision: 3, Snapshot: entity.TodoSnapshot{
+		Title: "Final", Content: "Isi", Tags: entity.StringList{"kerja"}, CustomFields: entity.CustomFieldValues{"status": "review", "estimasi": 2.0},
+	}}, nil)
+
+	changes, err := service.Diff(context.Background(), 1, 1, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, []entity.RevisionChange{
+		{Field: "title", From: "Draf", To: "Final"},
+		{Field: "custom_fields.estimasi", From: nil, To: 2.0},
+		{Field: "custom_fields.status", From: "draft", To: "review"},
+	}, changes)
+}
+
+func TestRevisionService_Revert_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockRevisionRepository(ctrl)
+	service := NewRevisionService(mockRepo, nil, nil)
+
+	mockRepo.EXPECT().FindByRevision(gomock.Any(), int64(1), 9).Return(nil, repository.ErrRevisiTidakDitemukan)
+
+	_, err := service.Revert(context.Background(), 1, 9)
+	assert.ErrorIs(t, err, ErrRevisiTidakDitemukan)
+}
+
+func TestTodoService_Restore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockTodoRepository(ctrl)
+	mockCache := mock_cache.NewMockCacheable(ctrl)
+	mockEvents := mock_service.NewMockEventRecorder(ctrl)
+	mockRevisions := mock_service.NewMockRevisionRecorder(ctrl)
+	service := NewTodoService(mockRepo, mockCache, passThroughTransactor(ctrl), mockEvents, passThroughCustomFields(ctrl), mockRevisions)
+
+	ctx := context.Background()
+	existing := entity.Todo{ID: 1, UserID: 5, Title: "Final", Content: "", Completed: true}
+	revision := entity.TodoRevision{TodoID: 1, Revision: 2, Snapshot: entity.TodoSnapshot{Title: "Draf", Content: "Isi lama"}}
+	restored := entity.Todo{ID: 1, UserID: 5, Title: "Draf", Content: "Isi lama", Completed: true}
+
+	// Isi dipulihkan sepenuhnya, termasuk field kosong, tanpa mengubah status selesai
+	mockRepo.EXPECT().FindByID(ctx, int64(1)).Return(&existing, nil)
+	mockRepo.EXPECT().Update(ctx, restored).Return(restored, nil)
+	mockRevisions.EXPECT().Record(ctx, gomock.Any(), restored, &revision.Revision).Return(nil)
+	mockEvents.EXPECT().Record(ctx, todoEvent(entity.EventTodoUpdated, restored)).Return(nil)
+	mockCache.EXPECT().Delete("go-todo-api:todos:find-all").Return(nil)
+
+	todo, err := service.Restore(ctx, 1, revision)
+	assert.NoError(t, err)
+	assert.Equal(t, restored, todo)
+}
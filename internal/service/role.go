@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-todo/internal/entity"
+	"go-todo/internal/repository"
+	"time"
+)
+
+var (
+	ErrRoleTidakDitemukan = errors.New("role tidak ditemukan")
+	ErrRoleSudahAda       = errors.New("nama role sudah digunakan")
+	ErrRoleBawaan         = errors.New("role bawaan tidak dapat diubah atau dihapus")
+	ErrIzinTidakValid     = errors.New("izin tidak dikenal")
+)
+
+// RoleService mengelola definisi role beserta izinnya. Seluruh operasi memeriksa sendiri
+// izin role:manage milik pelaku sehingga tetap aman meskipun dipanggil di luar route HTTP.
+type RoleService interface {
+	FindAll(ctx context.Context, actorID int64) ([]entity.Role, error)
+	Create(ctx context.Context, actorID int64, role *entity.Role) (*entity.Role, error)
+	Update(ctx context.Context, actorID int64, name string, role *entity.Role) (*entity.Role, error)
+	Delete(ctx context.Context, actorID int64, name string) error
+}
+
+type roleService struct {
+	roleRepository repository.RoleRepository
+	authorization  AuthorizationService
+	transactor     repository.Transactor
+}
+
+// NewRoleService membuat instance baru dari RoleService
+func NewRoleService(
+	roleRepository repository.RoleRepository,
+	authorization AuthorizationService,
+	transactor repository.Transactor,
+) RoleService {
+	return &roleService{
+		roleRepository: roleRepository,
+		authorization:  authorization,
+		transactor:     transactor,
+	}
+}
+
+// FindAll mengambil seluruh role
+func (s *roleService) FindAll(ctx context.Context, actorID int64) ([]entity.Role, error) {
+	if err := s.authorization.Require(ctx, actorID, entity.PermissionRoleManage); err != nil {
+		return nil, err
+	}
+	roles, err := s.roleRepository.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil role: %w", err)
+	}
+	return roles, nil
+}
+
+// Create membuat role baru dengan kumpulan izin yang sudah divalidasi
+func (s *roleService) Create(ctx context.Context, actorID int64, role *entity.Role) (*entity.Role, error) {
+	if err := s.authorization.Require(ctx, actorID, entity.PermissionRoleManage); err != nil {
+		return nil, err
+	}
+	if !entity.IsValidRoleName(role.Name) {
+		return nil, ErrRoleTidakValid
+	}
+	if err := validatePermissions(role.Permissions); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.roleRepository.FindByName(ctx, role.Name); err == nil {
+		return nil, ErrRoleSudahAda
+	} else if !errors.Is(err, repository.ErrRoleTidakDitemukan) {
+		return nil, fmt.Errorf("gagal memeriksa role: %w", err)
+	}
+
+	now := time.Now()
+	role.ID = 0
+	role.BuiltIn = false
+	role.CreatedAt = now
+	role.UpdatedAt = now
+	if err := s.roleRepository.Create(ctx, role); err != nil {
+		return nil, fmt.Errorf("gagal membuat role: %w", err)
+	}
+	return role, nil
+}
+
+// Update mengganti deskripsi dan izin role. Izin role admin tidak dapat diubah agar
+// selalu ada role yang dapat memulihkan konfigurasi izin.
+func (s *roleService) Update(ctx context.Context, actorID int64, name string, role *entity.Role) (*entity.Role, error) {
+	if err := s.authorization.Require(ctx, actorID, entity.PermissionRoleManage); err != nil {
+		return nil, err
+	}
+	if err := validatePermissions(role.Permissions); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.findRole(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if existing.Name == entity.RoleAdmin {
+		return nil, ErrRoleBawaan
+	}
+
+	existing.Description = role.Description
+	existing.Permissions = role.Permissions
+	existing.UpdatedAt = time.Now()
+	if err := s.roleRepository.Update(ctx, existing); err != nil {
+		return nil, fmt.Errorf("gagal memperbarui role: %w", err)
+	}
+
+	s.authorization.InvalidateAll()
+	return existing, nil
+}
+
+// Delete menghapus role buatan admin beserta keanggotaannya
+func (s *roleService) Delete(ctx context.Context, actorID int64, name string) error {
+	if err := s.authorization.Require(ctx, actorID, entity.PermissionRoleManage); err != nil {
+		return err
+	}
+	existing, err := s.findRole(ctx, name)
+	if err != nil {
+		return err
+	}
+	if existing.BuiltIn {
+		return ErrRoleBawaan
+	}
+
+	if err := s.roleRepository.Delete(ctx, existing.ID); err != nil {
+		return fmt.Errorf("gagal menghapus role: %w", err)
+	}
+
+	s.authorization.InvalidateAll()
+	return nil
+}
+
+func (s *roleService) findRole(ctx context.Context, name string) (*entity.Role, error) {
+	role, err := s.roleRepository.FindByName(ctx, name)
+	if errors.Is(err, repository.ErrRoleTidakDitemukan) {
+		return nil, ErrRoleTidakDitemukan
+	}
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil role: %w", err)
+	}
+	return role, nil
+}
+
+// validatePermissions memastikan seluruh izin dikenal. Izin wildcard hanya dimiliki role admin.
+func validatePermissions(permissions []string) error {
+	for _, permission := range permissions {
+		if !entity.IsValidPermission(permission) {
+			return fmt.Errorf("%w: %s", ErrIzinTidakValid, permission)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"go-todo/internal/entity"
+	"go-todo/internal/repository"
+	mock_repository "go-todo/test/mock/repository"
+	mock_service "go-todo/test/mock/service"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupRoleService(t *testing.T) (*gomock.Controller, RoleService, *mock_repository.MockRoleRepository, *mock_service.MockAuthorizationService) {
+	ctrl := gomock.NewController(t)
+	roleRepo := mock_repository.NewMockRoleRepository(ctrl)
+	authz := mock_service.NewMockAuthorizationService(ctrl)
+	return ctrl, NewRoleService(roleRepo, authz, passThroughTransactor(ctrl)), roleRepo, authz
+}
+
+func TestRoleService_Create(t *testing.T) {
+	ctrl, service, roleRepo, authz := setupRoleService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	authz.EXPECT().Require(ctx, int64(1), entity.PermissionRoleManage).Return(nil).Times(4)
+	roleRepo.EXPECT().FindByName(ctx, "editor").Return(nil, repository.ErrRoleTidakDitemukan)
+	roleRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+
+	role, err := service.Create(ctx, 1, &entity.Role{Name: "editor", Permissions: entity.StringList{entity.PermissionTodoUpdateAny}, BuiltIn: true})
+	assert.NoError(t, err)
+	// Role buatan admin tidak pernah dianggap role bawaan
+	assert.False(t, role.BuiltIn)
+
+	_, err = service.Create(ctx, 1, &entity.Role{Name: "Editor Baru"})
+	assert.ErrorIs(t, err, ErrRoleTidakValid)
+
+	// Izin wildcard hanya dimiliki role admin bawaan
+	_, err = service.Create(ctx, 1, &entity.Role{Name: "super", Permissions: entity.StringList{entity.PermissionAll}})
+	assert.ErrorIs(t, err, ErrIzinTidakValid)
+
+	roleRepo.EXPECT().FindByName(ctx, "user").Return(&entity.Role{ID: 2, Name: "user"}, nil)
+	_, err = service.Create(ctx, 1, &entity.Role{Name: "user"})
+	assert.ErrorIs(t, err, ErrRoleSudahAda)
+}
+
+func TestRoleService_Update(t *testing.T) {
+	ctrl, service, roleRepo, authz := setupRoleService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	authz.EXPECT().Require(ctx, int64(1), entity.PermissionRoleManage).Return(nil).Times(2)
+	roleRepo.EXPECT().FindByName(ctx, "user").Return(&entity.Role{ID: 2, Name: "user", BuiltIn: true}, nil)
+	roleRepo.EXPECT().Update(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, role *entity.Role) error {
+		assert.Equal(t, entity.StringList{entity.PermissionTodoRead}, role.Permissions)
+		return nil
+	})
+	// Perubahan izin role berlaku untuk seluruh pemiliknya
+	authz.EXPECT().InvalidateAll()
+
+	_, err := service.Update(ctx, 1, "user", &entity.Role{Permissions: entity.StringList{entity.PermissionTodoRead}})
+	assert.NoError(t, err)
+
+	// Role admin tidak dapat diubah agar selalu ada role pengelola izin
+	roleRepo.EXPECT().FindByName(ctx, entity.RoleAdmin).Return(&entity.Role{ID: 1, Name: entity.RoleAdmin, BuiltIn: true}, nil)
+	_, err = service.Update(ctx, 1, entity.RoleAdmin, &entity.Role{})
+	assert.ErrorIs(t, err, ErrRoleBawaan)
+}
+
+func TestRoleService_Delete(t *testing.T) {
+	ctrl, service, roleRepo, authz := setupRoleService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	authz.EXPECT().Require(ctx, int64(1), entity.PermissionRoleManage).Return(nil).Times(3)
+	roleRepo.EXPECT().FindByName(ctx, "editor").Return(&entity.Role{ID: 3, Name: "editor"}, nil)
+	roleRepo.EXPECT().Delete(ctx, int64(3)).Return(nil)
+	authz.EXPECT().InvalidateAll()
+	assert.NoError(t, service.Delete(ctx, 1, "editor"))
+
+	roleRepo.EXPECT().FindByName(ctx, "user").Return(&entity.Role{ID: 2, Name: "user", BuiltIn: true}, nil)
+	assert.ErrorIs(t, service.Delete(ctx, 1, "user"), ErrRoleBawaan)
+
+	roleRepo.EXPECT().FindByName(ctx, "hilang").Return(nil, repository.ErrRoleTidakDitemukan)
+	assert.ErrorIs(t, service.Delete(ctx, 1, "hilang"), ErrRoleTidakDitemukan)
+}
+
+func TestRoleService_Forbidden(t *testing.T) {
+	ctrl, service, _, authz := setupRoleService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	authz.EXPECT().Require(ctx, int64(7), entity.PermissionRoleManage).Return(ErrAksesDitolak)
+
+	_, err := service.FindAll(ctx, 7)
+	assert.ErrorIs(t, err, ErrAksesDitolak)
+}
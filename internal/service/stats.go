@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-todo/internal/entity"
+	"go-todo/internal/repository"
+	"go-todo/pkg/cache"
+	"time"
+)
+
+var ErrBucketTidakValid = errors.New("bucket harus bernilai day atau week")
+
+type StatsService interface {
+	GetUserStats(ctx context.Context, userID int64, bucket string, from, to time.Time) (*entity.UserStats, error)
+}
+
+type statsService struct {
+	statsRepository       repository.StatsRepository
+	userPreferenceService UserPreferenceService
+	cacheable             cache.Cacheable
+}
+
+// NewStatsService membuat instance baru dari StatsService
+func NewStatsService(
+	statsRepository repository.StatsRepository,
+	userPreferenceService UserPreferenceService,
+	cacheable cache.Cacheable,
+) StatsService {
+	return &statsService{
+		statsRepository:       statsRepository,
+		userPreferenceService: userPreferenceService,
+		cacheable:             cacheable,
+	}
+}
+
+// GetUserStats menghitung statistik produktivitas pengguna dengan cache per pengguna.
+// Periode, "hari ini", dan streak dihitung pada zona waktu pengguna.
+func (s *statsService) GetUserStats(ctx context.Context, userID int64, bucket string, from, to time.Time) (*entity.UserStats, error) {
+	if bucket == "" {
+		bucket = "day"
+	}
+	if bucket != "day" && bucket != "week" {
+		return nil, ErrBucketTidakValid
+	}
+
+	preference, err := s.userPreferenceService.Get(ctx, userID)
+	if err != nil {
+		preference = entity.DefaultUserPreference(userID)
+	}
+	loc, err := time.LoadLocation(preference.Timezone)
+	if err != nil {
+		loc = defaultLocation()
+	}
+
+	// Rentang default: 30 hari terakhir untuk bucket harian, 12 minggu untuk mingguan
+	now := time.Now().In(loc)
+	if to.IsZero() {
+		to = startOfDay(now).AddDate(0, 0, 1)
+	}
+	if from.IsZero() {
+		if bucket == "week" {
+			from = to.AddDate(0, 0, -7*12)
+		} else {
+			from = to.AddDate(0, 0, -30)
+		}
+	}
+
+	cacheKey := fmt.Sprintf("go-todo-api:stats:%d:%s:%s:%s:%s:%d", userID, bucket,
+		from.In(loc).Format(time.DateOnly), to.In(loc).Format(time.DateOnly), preference.Timezone, preference.WeekStart)
+
+	if cachedData, err := s.cacheable.Get(cacheKey); err == nil && cachedData != "" {
+		var stats entity.UserStats
+		if err := json.Unmarshal([]byte(cachedData), &stats); err == nil {
+			return &stats, nil
+		}
+	}
+
+	counts, err := s.statsRepository.StatusCounts(ctx, userID, now)
+	if err != nil {
+		return nil, fmt.Errorf("gagal menghitung status todo: %w", err)
+	}
+
+	buckets, err := s.statsRepository.CompletionBuckets(ctx, userID, bucket, from, to, preference.Timezone, preference.WeekStart)
+	if err != nil {
+		return nil, fmt.Errorf("gagal menghitung tingkat penyelesaian: %w", err)
+	}
+	for i := range buckets {
+		if buckets[i].Created > 0 {
+			buckets[i].CompletionRate = float64(buckets[i].Completed) / float64(buckets[i].Created)
+		}
+	}
+
+	leadTime, err := s.statsRepository.AverageLeadTime(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("gagal menghitung lead time: %w", err)
+	}
+
+	days, err := s.statsRepository.CompletionDays(ctx, userID, preference.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("gagal menghitung streak: %w", err)
+	}
+	current, longest := computeStreaks(days, now)
+
+	stats := &entity.UserStats{
+		UserID:                 userID,
+		Bucket:                 bucket,
+		From:                   from,
+		To:                     to,
+		Counts:                 counts,
+		CompletionRate:         buckets,
+		AverageLeadTimeSeconds: leadTime,
+		CurrentStreakDays:      current,
+		LongestStreakDays:      longest,
+	}
+
+	// Statistik cepat berubah, sehingga cache disimpan singkat
+	if err := s.cacheable.Set(cacheKey, stats, time.Minute); err != nil {
+		fmt.Printf("kesalahan menyimpan cache: %v\n", err)
+	}
+
+	return stats, nil
+}
+
+// computeStreaks menghitung streak saat ini dan streak terpanjang dari tanggal penyelesaian
+// yang sudah terurut menurun. Streak saat ini tetap dihitung jika hari terakhir adalah kemarin.
+func computeStreaks(days []time.Time, now time.Time) (int, int) {
+	if len(days) == 0 {
+		return 0, 0
+	}
+
+	current, longest, run := 0, 1, 1
+	for i := 1; i < len(days); i++ {
+		if isPreviousDay(days[i], days[i-1]) {
+			run++
+		} else {
+			run = 1
+		}
+		if run > longest {
+			longest = run
+		}
+	}
+
+	today := startOfDay(now)
+	latest := time.Date(days[0].Year(), days[0].Month(), days[0].Day(), 0, 0, 0, 0, now.Location())
+	if latest.Equal(today) || latest.Equal(today.AddDate(0, 0, -1)) {
+		current = 1
+		for i := 1; i < len(days) && isPreviousDay(days[i], days[i-1]); i++ {
+			current++
+		}
+	}
+
+	return current, longest
+}
+
+// isPreviousDay memeriksa apakah day tepat satu hari sebelum next
+func isPreviousDay(day, next time.Time) bool {
+	y1, m1, d1 := day.AddDate(0, 0, 1).Date()
+	y2, m2, d2 := next.Date()
+	return y1 == y2 && m1 == m2 && d1 == d2
+}
+
+// startOfDay mengembalikan awal hari (00:00) dari waktu yang diberikan
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"go-todo/internal/entity"
+	mock_cache "go-todo/test/mock/pkg/cache"
+	mock_repository "go-todo/test/mock/repository"
+	mock_service "go-todo/test/mock/service"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupStatsService(t *testing.T) (*gomock.Controller, StatsService, *mock_repository.MockStatsRepository, *mock_service.MockUserPreferenceService, *mock_cache.MockCacheable) {
+	ctrl := gomock.NewController(t)
+	mockRepo := mock_repository.NewMockStatsRepository(ctrl)
+	mockPreference := mock_service.NewMockUserPreferenceService(ctrl)
+	mockCache := mock_cache.NewMockCacheable(ctrl)
+	service := NewStatsService(mockRepo, mockPreference, mockCache)
+	return ctrl, service, mockRepo, mockPreference, mockCache
+}
+
+func TestStatsService_GetUserStats_CacheHit(t *testing.T) {
+	ctrl, service, _, mockPreference, mockCache := setupStatsService(t)
+	defer ctrl.Finish()
+
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 10, 8, 0, 0, 0, 0, time.UTC)
+	expected := entity.UserStats{UserID: 5, Bucket: "day", Counts: entity.TodoStatusCounts{Open: 2}}
+	cachedData, _ := json.Marshal(expected)
+
+	mockPreference.EXPECT().Get(gomock.Any(), int64(5)).Return(entity.DefaultUserPreference(5), nil)
+	mockCache.EXPECT().Get("go-todo-api:stats:5:day:2026-10-01:2026-10-08:Asia/Jakarta:1").Return(string(cachedData), nil)
+
+	stats, err := service.GetUserStats(context.Background(), 5, "day", from, to)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), stats.Counts.Open)
+}
+
+func TestStatsService_GetUserStats_CacheMiss(t *testing.T) {
+	ctrl, service, mockRepo, mockPreference, mockCache := setupStatsService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	newYork, _ := time.LoadLocation("America/New_York")
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, newYork)
+	to := time.Date(2026, 10, 8, 0, 0, 0, 0, newYork)
+	key := "go-todo-api:stats:5:week:2026-10-01:2026-10-08:America/New_York:0"
+	preference := &entity.UserPreference{UserID: 5, Timezone: "America/New_York", WeekStart: 0}
+
+	mockPreference.EXPECT().Get(ctx, int64(5)).Return(preference, nil)
+	mockCache.EXPECT().Get(key).Return("", nil)
+	mockRepo.EXPECT().StatusCounts(ctx, int64(5), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ int64, now time.Time) (entity.TodoStatusCounts, error) {
+			// "Sekarang" dihitung pada zona waktu pengguna
+			assert.Equal(t, "America/New_York", now.Location().String())
+			return entity.TodoStatusCounts{Open: 3, Completed: 1, Overdue: 1}, nil
+		})
+	mockRepo.EXPECT().CompletionBuckets(ctx, int64(5), "week", from, to, "America/New_York", 0).
+		Return([]entity.CompletionBucket{{BucketStart: from, Created: 4, Completed: 1}}, nil)
+	mockRepo.EXPECT().AverageLeadTime(ctx, int64(5)).Return(3600.0, nil)
+	mockRepo.EXPECT().CompletionDays(ctx, int64(5), "America/New_York").Return([]time.Time{}, nil)
+	mockCache.EXPECT().Set(key, gomock.Any(), time.Minute).Return(nil)
+
+	stats, err := service.GetUserStats(ctx, 5, "week", from, to)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), stats.Counts.Overdue)
+	assert.Equal(t, 0.25, stats.CompletionRate[0].CompletionRate)
+	assert.Equal(t, 3600.0, stats.AverageLeadTimeSeconds)
+}
+
+func TestStatsService_GetUserStats_InvalidBucket(t *testing.T) {
+	ctrl, service, _, _, _ := setupStatsService(t)
+	defer ctrl.Finish()
+
+	_, err := service.GetUserStats(context.Background(), 5, "month", time.Time{}, time.Time{})
+	assert.ErrorIs(t, err, ErrBucketTidakValid)
+}
+
+func TestStatsService_GetUserStats_RepoError(t *testing.T) {
+	ctrl, service, mockRepo, mockPreference, mockCache := setupStatsService(t)
+	defer ctrl.Finish()
+
+	// Preferensi gagal diambil, statistik tetap dihitung dengan zona waktu default
+	mockPreference.EXPECT().Get(gomock.Any(), int64(5)).Return(nil, errors.New("database error"))
+	mockCache.EXPECT().Get(gomock.Any()).Return("", nil)
+	mockRepo.EXPECT().StatusCounts(gomock.Any(), int64(5), gomock.Any()).Return(entity.TodoStatusCounts{}, errors.New("database error"))
+
+	_, err := service.GetUserStats(context.Background(), 5, "", time.Time{}, time.Time{})
+	assert.Error(t, err)
+}
+
+func TestComputeStreaks(t *testing.T) {
+	now := time.Date(2026, 10, 18, 15, 0, 0, 0, time.UTC)
+	day := func(d int) time.Time { return time.Date(2026, 10, d, 0, 0, 0, 0, time.UTC) }
+
+	// Streak saat ini 3 hari (16-18), streak terpanjang 4 hari (5-8)
+	current, longest := computeStreaks([]time.Time{day(18), day(17), day(16), day(8), day(7), day(6), day(5)}, now)
+	assert.Equal(t, 3, current)
+	assert.Equal(t, 4, longest)
+
+	// Penyelesaian terakhir kemarin tetap dihitung sebagai streak aktif
+	current, _ = computeStreaks([]time.Time{day(17), day(16)}, now)
+	assert.Equal(t, 2, current)
+
+	// Streak putus jika hari terakhir lebih dari satu hari lalu
+	current, longest = computeStreaks([]time.Time{day(15), day(14)}, now)
+	assert.Equal(t, 0, current)
+	assert.Equal(t, 2, longest)
+
+	current, longest = computeStreaks(nil, now)
+	assert.Equal(t, 0, current)
+	assert.Equal(t, 0, longest)
+}
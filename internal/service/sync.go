@@ -0,0 +1,261 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-todo/internal/entity"
+	"go-todo/internal/repository"
+	"sort"
+)
+
+var (
+	ErrCursorTidakValid    = errors.New("cursor sinkronisasi tidak valid")
+	ErrMutasiTerlaluBanyak = errors.New("jumlah mutasi melebihi batas per permintaan")
+)
+
+const (
+	// defaultSyncLimit dan maxSyncLimit membatasi jumlah perubahan per halaman GET /sync
+	defaultSyncLimit = 500
+	maxSyncLimit     = 1000
+	// maxSyncMutations membatasi jumlah mutasi per permintaan POST /sync
+	maxSyncMutations = 500
+)
+
+// SyncService menyediakan sinkronisasi delta untuk klien offline. Lihat entity.SyncMutation
+// dan konstanta SyncStatus untuk kebijakan penyelesaian konflik.
+type SyncService interface {
+	Pull(ctx context.Context, userID, since int64, limit int) (*entity.SyncChanges, error)
+	Push(ctx context.Context, userID int64, mutations []entity.SyncMutation) ([]entity.SyncResult, error)
+}
+
+type syncService struct {
+	syncRepository repository.SyncRepository
+	todoRepository repository.TodoRepository
+	todoService    TodoService
+	transactor     repository.Transactor
+}
+
+// NewSyncService membuat instance baru dari SyncService
+func NewSyncService(
+	syncRepository repository.SyncRepository,
+	todoRepository repository.TodoRepository,
+	todoService TodoService,
+	transactor repository.Transactor,
+) SyncService {
+	return &syncService{
+		syncRepository: syncRepository,
+		todoRepository: todoRepository,
+		todoService:    todoService,
+		transactor:     transactor,
+	}
+}
+
+// Pull mengambil perubahan todo pengguna setelah cursor since, termasuk tombstone untuk
+// todo yang dihapus. Perubahan diurutkan berdasarkan seq dan cursor berikutnya adalah
+// seq perubahan terakhir pada halaman ini.
+func (s *syncService) Pull(ctx context.Context, userID, since int64, limit int) (*entity.SyncChanges, error) {
+	if since < 0 {
+		return nil, ErrCursorTidakValid
+	}
+	if limit <= 0 {
+		limit = defaultSyncLimit
+	}
+	if limit > maxSyncLimit {
+		limit = maxSyncLimit
+	}
+
+	// Mengambil satu baris lebih banyak dari masing-masing sumber untuk mengetahui has_more
+	todos, err := s.syncRepository.ChangedTodos(ctx, userID, since, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil perubahan todo: %w", err)
+	}
+	tombstones, err := s.syncRepository.Tombstones(ctx, userID, since, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil todo yang dihapus: %w", err)
+	}
+
+	changes := make([]entity.SyncChange, 0, len(todos)+len(tombstones))
+	for i := range todos {
+		todo := todos[i]
+		changes = append(changes, entity.SyncChange{
+			Seq:      todo.ChangeSeq,
+			Type:     entity.SyncChangeUpsert,
+			ID:       todo.ID,
+			ClientID: todo.ClientID,
+			Todo:     &todo,
+		})
+	}
+	for i := range tombstones {
+		tombstone := tombstones[i]
+		changes = append(changes, entity.SyncChange{
+			Seq:       tombstone.ChangeSeq,
+			Type:      entity.SyncChangeDelete,
+			ID:        tombstone.TodoID,
+			ClientID:  tombstone.ClientID,
+			DeletedAt: &tombstone.DeletedAt,
+		})
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Seq < changes[j].Seq })
+
+	result := &entity.SyncChanges{Cursor: since, Changes: changes}
+	if len(changes) > limit {
+		result.Changes = changes[:limit]
+		result.HasMore = true
+	}
+	if len(result.Changes) > 0 {
+		result.Cursor = result.Changes[len(result.Changes)-1].Seq
+	}
+	return result, nil
+}
+
+// Push menerapkan mutasi dari klien secara berurutan. Setiap mutasi berjalan dalam
+// transaksinya sendiri sehingga hasilnya dilaporkan per mutasi. Kesalahan tak terduga
+// menghentikan batch; mengirim ulang batch yang sama aman karena create idempoten dan
+// update yang sudah diterapkan akan dilaporkan sebagai conflict beserta state server.
+func (s *syncService) Push(ctx context.Context, userID int64, mutations []entity.SyncMutation) ([]entity.SyncResult, error) {
+	if len(mutations) > maxSyncMutations {
+		return nil, ErrMutasiTerlaluBanyak
+	}
+
+	results := make([]entity.SyncResult, 0, len(mutations))
+	for i, mutation := range mutations {
+		result := entity.SyncResult{Index: i, Op: mutation.Op, ClientID: mutation.ClientID}
+		err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			return s.apply(ctx, userID, mutation, &result)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("gagal menerapkan mutasi ke-%d: %w", i, err)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// apply menerapkan satu mutasi dan mengisi result. Error hanya dikembalikan untuk
+// kesalahan tak terduga; penolakan mutasi dilaporkan melalui result.Status.
+func (s *syncService) apply(ctx context.Context, userID int64, mutation entity.SyncMutation, result *entity.SyncResult) error {
+	switch mutation.Op {
+	case entity.SyncOpCreate:
+		return s.applyCreate(ctx, userID, mutation, result)
+	case entity.SyncOpUpdate, entity.SyncOpDelete:
+		return s.applyChange(ctx, userID, mutation, result)
+	}
+	result.Status = entity.SyncStatusInvalid
+	result.Error = "operasi harus salah satu dari create, update, atau delete"
+	return nil
+}
+
+func (s *syncService) applyCreate(ctx context.Context, userID int64, mutation entity.SyncMutation, result *entity.SyncResult) error {
+	if mutation.ClientID == "" || len(mutation.ClientID) > 64 {
+		result.Status = entity.SyncStatusInvalid
+		result.Error = "client_id wajib diisi dan maksimal 64 karakter"
+		return nil
+	}
+
+	// Create yang dikirim ulang mengembalikan todo yang sudah dibuat sebelumnya
+	existing, err := s.syncRepository.LockTodo(ctx, userID, 0, mutation.ClientID)
+	if err == nil {
+		result.Status = entity.SyncStatusApplied
+		result.Todo = existing
+		return nil
+	}
+	if !errors.Is(err, repository.ErrTodoTidakDitemukan) {
+		return err
+	}
+	if _, err := s.syncRepository.FindTombstone(ctx, userID, 0, mutation.ClientID); err == nil {
+		result.Status = entity.SyncStatusDeleted
+		return nil
+	} else if !errors.Is(err, repository.ErrTombstoneTidakDitemukan) {
+		return err
+	}
+
+	todo := mutation.Todo
+	todo.ID = 0
+	todo.UserID = userID
+	todo.ClientID = &mutation.ClientID
+	created, err := s.todoService.Create(ctx, todo)
+	if err != nil {
+		if errors.Is(err, ErrPrioritasTidakValid) || errors.Is(err, ErrIndukTidakValid) || errors.Is(err, ErrNilaiFieldKustomTidakValid) {
+			result.Status = entity.SyncStatusInvalid
+			result.Error = err.Error()
+			return nil
+		}
+		return err
+	}
+	return s.applied(ctx, created.ID, result)
+}
+
+func (s *syncService) applyChange(ctx context.Context, userID int64, mutation entity.SyncMutation, result *entity.SyncResult) error {
+	if mutation.ID == 0 && mutation.ClientID == "" {
+		result.Status = entity.SyncStatusInvalid
+		result.Error = "id atau client_id wajib diisi"
+		return nil
+	}
+	if mutation.BaseVersion <= 0 {
+		result.Status = entity.SyncStatusInvalid
+		result.Error = "base_version wajib diisi"
+		return nil
+	}
+
+	current, err := s.syncRepository.LockTodo(ctx, userID, mutation.ID, mutation.ClientID)
+	if errors.Is(err, repository.ErrTodoTidakDitemukan) {
+		return s.resolveMissing(ctx, userID, mutation, result)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Server menang jika klien mengubah versi yang sudah usang
+	if current.Version != mutation.BaseVersion {
+		result.Status = entity.SyncStatusConflict
+		result.Todo = current
+		return nil
+	}
+
+	if mutation.Op == entity.SyncOpDelete {
+		if err := s.todoService.Delete(ctx, current.ID); err != nil {
+			return err
+		}
+		result.Status = entity.SyncStatusApplied
+		return nil
+	}
+
+	if _, err := s.todoService.Update(ctx, current.ID, mutation.Todo); err != nil {
+		if errors.Is(err, ErrPrioritasTidakValid) || errors.Is(err, ErrNilaiFieldKustomTidakValid) {
+			result.Status = entity.SyncStatusInvalid
+			result.Error = err.Error()
+			return nil
+		}
+		return err
+	}
+	return s.applied(ctx, current.ID, result)
+}
+
+// resolveMissing menentukan hasil mutasi untuk todo yang tidak ada: penghapusan
+// selalu menang, sehingga delete yang diulang tetap applied
+func (s *syncService) resolveMissing(ctx context.Context, userID int64, mutation entity.SyncMutation, result *entity.SyncResult) error {
+	_, err := s.syncRepository.FindTombstone(ctx, userID, mutation.ID, mutation.ClientID)
+	switch {
+	case errors.Is(err, repository.ErrTombstoneTidakDitemukan):
+		result.Status = entity.SyncStatusNotFound
+	case err != nil:
+		return err
+	case mutation.Op == entity.SyncOpDelete:
+		result.Status = entity.SyncStatusApplied
+	default:
+		result.Status = entity.SyncStatusDeleted
+	}
+	return nil
+}
+
+// applied membaca ulang todo di dalam transaksi agar versi dari server ikut dikembalikan
+func (s *syncService) applied(ctx context.Context, id int64, result *entity.SyncResult) error {
+	todo, err := s.todoRepository.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	result.Status = entity.SyncStatusApplied
+	result.Todo = todo
+	return nil
+}
@@ -0,0 +1,194 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"go-todo/internal/entity"
+	"go-todo/internal/repository"
+	mock_repository "go-todo/test/mock/repository"
+	mock_service "go-todo/test/mock/service"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// syncServiceMocks mengelompokkan semua dependensi mock dari SyncService
+type syncServiceMocks struct {
+	sync  *mock_repository.MockSyncRepository
+	todo  *mock_repository.MockTodoRepository
+	todos *mock_service.MockTodoService
+}
+
+func setupSyncService(t *testing.T) (*gomock.Controller, SyncService, *syncServiceMocks) {
+	ctrl := gomock.NewController(t)
+	m := &syncServiceMocks{
+		sync:  mock_repository.NewMockSyncRepository(ctrl),
+		todo:  mock_repository.NewMockTodoRepository(ctrl),
+		todos: mock_service.NewMockTodoService(ctrl),
+	}
+	service := NewSyncService(m.sync, m.todo, m.todos, passThroughTransactor(ctrl))
+	return ctrl, service, m
+}
+
+func TestSyncService_Pull_MergesChangesAndTombstones(t *testing.T) {
+	ctrl, service, m := setupSyncService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m.sync.EXPECT().ChangedTodos(ctx, int64(5), int64(10), 3).
+		Return([]entity.Todo{{ID: 1, ChangeSeq: 11}, {ID: 2, ChangeSeq: 14}}, nil)
+	m.sync.EXPECT().Tombstones(ctx, int64(5), int64(10), 3).
+		Return([]entity.TodoTombstone{{TodoID: 3, ChangeSeq: 12, DeletedAt: time.Now()}}, nil)
+
+	changes, err := service.Pull(ctx, 5, 10, 2)
+	assert.NoError(t, err)
+	// Perubahan digabung berdasarkan seq dan dipotong sesuai limit
+	assert.Len(t, changes.Changes, 2)
+	assert.Equal(t, entity.SyncChangeUpsert, changes.Changes[0].Type)
+	assert.Equal(t, entity.SyncChangeDelete, changes.Changes[1].Type)
+	assert.Equal(t, int64(3), changes.Changes[1].ID)
+	assert.Equal(t, int64(12), changes.Cursor)
+	assert.True(t, changes.HasMore)
+}
+
+func TestSyncService_Pull_NoChanges(t *testing.T) {
+	ctrl, service, m := setupSyncService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m.sync.EXPECT().ChangedTodos(ctx, int64(5), int64(10), defaultSyncLimit+1).Return([]entity.Todo{}, nil)
+	m.sync.EXPECT().Tombstones(ctx, int64(5), int64(10), defaultSyncLimit+1).Return([]entity.TodoTombstone{}, nil)
+
+	changes, err := service.Pull(ctx, 5, 10, 0)
+	assert.NoError(t, err)
+	// Cursor tidak berubah jika tidak ada perubahan baru
+	assert.Equal(t, int64(10), changes.Cursor)
+	assert.False(t, changes.HasMore)
+
+	_, err = service.Pull(ctx, 5, -1, 0)
+	assert.ErrorIs(t, err, ErrCursorTidakValid)
+}
+
+func TestSyncService_Push_Create(t *testing.T) {
+	ctrl, service, m := setupSyncService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m.sync.EXPECT().LockTodo(ctx, int64(5), int64(0), "c-1").Return(nil, repository.ErrTodoTidakDitemukan)
+	m.sync.EXPECT().FindTombstone(ctx, int64(5), int64(0), "c-1").Return(nil, repository.ErrTombstoneTidakDitemukan)
+	m.todos.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, todo entity.Todo) (entity.Todo, error) {
+		// Todo selalu dibuat untuk pengguna yang sedang login dengan client_id dari klien
+		assert.Equal(t, int64(5), todo.UserID)
+		assert.Equal(t, int64(0), todo.ID)
+		assert.Equal(t, "c-1", *todo.ClientID)
+		todo.ID = 7
+		return todo, nil
+	})
+	m.todo.EXPECT().FindByID(ctx, int64(7)).Return(&entity.Todo{ID: 7, Version: 1}, nil)
+
+	results, err := service.Push(ctx, 5, []entity.SyncMutation{
+		{Op: entity.SyncOpCreate, ClientID: "c-1", Todo: entity.Todo{ID: 99, UserID: 1, Title: "Offline"}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, entity.SyncStatusApplied, results[0].Status)
+	assert.Equal(t, int64(7), results[0].Todo.ID)
+}
+
+func TestSyncService_Push_CreateIsIdempotent(t *testing.T) {
+	ctrl, service, m := setupSyncService(t)
+	defer ctrl.Finish()
+
+	// Create yang dikirim ulang tidak membuat todo baru
+	ctx := context.Background()
+	m.sync.EXPECT().LockTodo(ctx, int64(5), int64(0), "c-1").Return(&entity.Todo{ID: 7, Version: 2}, nil)
+
+	results, err := service.Push(ctx, 5, []entity.SyncMutation{{Op: entity.SyncOpCreate, ClientID: "c-1"}})
+	assert.NoError(t, err)
+	assert.Equal(t, entity.SyncStatusApplied, results[0].Status)
+	assert.Equal(t, int64(2), results[0].Todo.Version)
+}
+
+func TestSyncService_Push_UpdateConflict(t *testing.T) {
+	ctrl, service, m := setupSyncService(t)
+	defer ctrl.Finish()
+
+	// Versi dasar usang: server menang dan todo versi server dikembalikan
+	ctx := context.Background()
+	server := &entity.Todo{ID: 7, Title: "Versi server", Version: 4}
+	m.sync.EXPECT().LockTodo(ctx, int64(5), int64(7), "").Return(server, nil)
+
+	results, err := service.Push(ctx, 5, []entity.SyncMutation{
+		{Op: entity.SyncOpUpdate, ID: 7, BaseVersion: 3, Todo: entity.Todo{Title: "Versi klien"}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, entity.SyncStatusConflict, results[0].Status)
+	assert.Equal(t, "Versi server", results[0].Todo.Title)
+}
+
+func TestSyncService_Push_UpdateApplied(t *testing.T) {
+	ctrl, service, m := setupSyncService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m.sync.EXPECT().LockTodo(ctx, int64(5), int64(0), "c-1").Return(&entity.Todo{ID: 7, Version: 3}, nil)
+	m.todos.EXPECT().Update(ctx, int64(7), entity.Todo{Title: "Versi klien"}).Return(entity.Todo{ID: 7}, nil)
+	m.todo.EXPECT().FindByID(ctx, int64(7)).Return(&entity.Todo{ID: 7, Title: "Versi klien", Version: 4}, nil)
+
+	results, err := service.Push(ctx, 5, []entity.SyncMutation{
+		{Op: entity.SyncOpUpdate, ClientID: "c-1", BaseVersion: 3, Todo: entity.Todo{Title: "Versi klien"}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, entity.SyncStatusApplied, results[0].Status)
+	assert.Equal(t, int64(4), results[0].Todo.Version)
+}
+
+func TestSyncService_Push_DeletedOnServer(t *testing.T) {
+	ctrl, service, m := setupSyncService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m.sync.EXPECT().LockTodo(ctx, int64(5), int64(7), "").Return(nil, repository.ErrTodoTidakDitemukan).Times(2)
+	m.sync.EXPECT().FindTombstone(ctx, int64(5), int64(7), "").Return(&entity.TodoTombstone{TodoID: 7}, nil).Times(2)
+
+	results, err := service.Push(ctx, 5, []entity.SyncMutation{
+		{Op: entity.SyncOpUpdate, ID: 7, BaseVersion: 3},
+		{Op: entity.SyncOpDelete, ID: 7, BaseVersion: 3},
+	})
+	assert.NoError(t, err)
+	// Penghapusan menang atas perubahan, dan delete yang diulang tetap applied
+	assert.Equal(t, entity.SyncStatusDeleted, results[0].Status)
+	assert.Equal(t, entity.SyncStatusApplied, results[1].Status)
+}
+
+func TestSyncService_Push_Invalid(t *testing.T) {
+	ctrl, service, _ := setupSyncService(t)
+	defer ctrl.Finish()
+
+	results, err := service.Push(context.Background(), 5, []entity.SyncMutation{
+		{Op: "archive", ID: 7},
+		{Op: entity.SyncOpCreate},
+		{Op: entity.SyncOpUpdate, ID: 7},
+	})
+	assert.NoError(t, err)
+	for i, result := range results {
+		assert.Equal(t, i, result.Index)
+		assert.Equal(t, entity.SyncStatusInvalid, result.Status)
+		assert.NotEmpty(t, result.Error)
+	}
+
+	_, err = service.Push(context.Background(), 5, make([]entity.SyncMutation, maxSyncMutations+1))
+	assert.ErrorIs(t, err, ErrMutasiTerlaluBanyak)
+}
+
+func TestSyncService_Push_UnexpectedError(t *testing.T) {
+	ctrl, service, m := setupSyncService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m.sync.EXPECT().LockTodo(ctx, int64(5), int64(7), "").Return(nil, errors.New("database error"))
+
+	_, err := service.Push(ctx, 5, []entity.SyncMutation{{Op: entity.SyncOpDelete, ID: 7, BaseVersion: 1}})
+	assert.Error(t, err)
+}
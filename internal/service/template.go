@@ -0,0 +1,426 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-todo/internal/entity"
+	"go-todo/internal/repository"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrTemplateTidakDitemukan  = errors.New("template tidak ditemukan")
+	ErrNamaTemplateTidakValid  = errors.New("nama template wajib diisi dan maksimal 100 karakter")
+	ErrItemTemplateTidakValid  = errors.New("item template tidak valid")
+	ErrVariabelTidakLengkap    = errors.New("variabel template belum diisi")
+	ErrTanggalMulaiTidakValid  = errors.New("tanggal mulai harus berformat YYYY-MM-DD")
+	ErrProyekTidakMemilikiTodo = errors.New("proyek tidak memiliki todo")
+)
+
+const (
+	// maxTemplateItems membatasi jumlah todo yang dibuat dari satu template, termasuk subtask
+	maxTemplateItems = 100
+	// maxTemplateDepth membatasi kedalaman subtask di dalam template
+	maxTemplateDepth = 3
+	// templateDateVariable selalu tersedia dan berisi tanggal mulai (YYYY-MM-DD)
+	templateDateVariable = "date"
+	// maxDueOffsetDays adalah offset jatuh tempo terbesar yang dapat ditulis dalam hari,
+	// sesuai batas lima digit pada dueOffsetPattern
+	maxDueOffsetDays = 99999
+)
+
+var (
+	templateVariablePattern = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_]+)\s*\}\}`)
+	dueOffsetPattern        = regexp.MustCompile(`^([+-]?)(\d{1,5})([dw])$`)
+)
+
+// TemplateService mengelola template todo dan membuat todo konkret darinya
+type TemplateService interface {
+	FindAll(ctx context.Context, userID int64) ([]entity.TodoTemplate, error)
+	FindByID(ctx context.Context, userID, id int64) (*entity.TodoTemplate, error)
+	Create(ctx context.Context, template *entity.TodoTemplate) (*entity.TodoTemplate, error)
+	CreateFromTodo(ctx context.Context, userID, todoID int64, name string) (*entity.TodoTemplate, error)
+	CreateFromProject(ctx context.Context, userID int64, project, name string) (*entity.TodoTemplate, error)
+	Update(ctx context.Context, template *entity.TodoTemplate) (*entity.TodoTemplate, error)
+	Delete(ctx context.Context, userID, id int64) error
+	Instantiate(ctx context.Context, userID, id int64, params entity.TemplateInstantiation) ([]entity.Todo, error)
+}
+
+type templateService struct {
+	templateRepository    repository.TemplateRepository
+	todoRepository        repository.TodoRepository
+	todoService           TodoService
+	userPreferenceService UserPreferenceService
+	transactor            repository.Transactor
+}
+
+// NewTemplateService membuat instance baru dari TemplateService
+func NewTemplateService(
+	templateRepository repository.TemplateRepository,
+	todoRepository repository.TodoRepository,
+	todoService TodoService,
+	userPreferenceService UserPreferenceService,
+	transactor repository.Transactor,
+) TemplateService {
+	return &templateService{
+		templateRepository:    templateRepository,
+		todoRepository:        todoRepository,
+		todoService:           todoService,
+		userPreferenceService: userPreferenceService,
+		transactor:            transactor,
+	}
+}
+
+// FindAll mengambil semua template milik pengguna
+func (s *templateService) FindAll(ctx context.Context, userID int64) ([]entity.TodoTemplate, error) {
+	templates, err := s.templateRepository.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil template: %w", err)
+	}
+	for i := range templates {
+		templates[i].Variables = templateVariables(templates[i].Items)
+	}
+	return templates, nil
+}
+
+// FindByID mengambil satu template milik pengguna beserta daftar variabelnya
+func (s *templateService) FindByID(ctx context.Context, userID, id int64) (*entity.TodoTemplate, error) {
+	template, err := s.templateRepository.FindByID(ctx, userID, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrTemplateTidakDitemukan) {
+			return nil, ErrTemplateTidakDitemukan
+		}
+		return nil, fmt.Errorf("gagal mengambil template: %w", err)
+	}
+	template.Variables = templateVariables(template.Items)
+	return template, nil
+}
+
+// Create menyimpan template baru setelah seluruh item divalidasi
+func (s *templateService) Create(ctx context.Context, template *entity.TodoTemplate) (*entity.TodoTemplate, error) {
+	if err := validateTemplate(template); err != nil {
+		return nil, err
+	}
+
+	created, err := s.templateRepository.Create(ctx, template)
+	if err != nil {
+		return nil, fmt.Errorf("gagal membuat template: %w", err)
+	}
+	created.Variables = templateVariables(created.Items)
+	return created, nil
+}
+
+// CreateFromTodo menyimpan todo beserta subtask-nya sebagai template. Offset jatuh tempo
+// dihitung terhadap tanggal todo dibuat.
+func (s *templateService) CreateFromTodo(ctx context.Context, userID, todoID int64, name string) (*entity.TodoTemplate, error) {
+	todo, err := s.todoRepository.FindByID(ctx, todoID)
+	if err != nil || todo.UserID != userID {
+		return nil, ErrTodoTidakDitemukan
+	}
+
+	loc := s.userPreferenceService.Location(ctx, userID)
+	items, err := s.templateItems(ctx, userID, []entity.Todo{*todo}, startOfDay(todo.CreatedAt.In(loc)), 1)
+	if err != nil {
+		return nil, err
+	}
+	return s.Create(ctx, &entity.TodoTemplate{UserID: userID, Name: name, Items: items})
+}
+
+// CreateFromProject menyimpan seluruh todo utama dalam proyek beserta subtask-nya sebagai
+// template. Offset jatuh tempo dihitung terhadap tanggal todo tertua di proyek dibuat.
+func (s *templateService) CreateFromProject(ctx context.Context, userID int64, project, name string) (*entity.TodoTemplate, error) {
+	todos, err := s.todoRepository.FindByCondition(ctx, userID, "project = ? AND parent_id IS NULL", project)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil todo proyek: %w", err)
+	}
+	if len(todos) == 0 {
+		return nil, ErrProyekTidakMemilikiTodo
+	}
+
+	loc := s.userPreferenceService.Location(ctx, userID)
+	reference := todos[0].CreatedAt
+	for _, todo := range todos[1:] {
+		if todo.CreatedAt.Before(reference) {
+			reference = todo.CreatedAt
+		}
+	}
+
+	items, err := s.templateItems(ctx, userID, todos, startOfDay(reference.In(loc)), 1)
+	if err != nil {
+		return nil, err
+	}
+	return s.Create(ctx, &entity.TodoTemplate{UserID: userID, Name: name, Description: "Proyek " + project, Items: items})
+}
+
+// Update memperbarui nama, deskripsi, dan item template milik pengguna
+func (s *templateService) Update(ctx context.Context, template *entity.TodoTemplate) (*entity.TodoTemplate, error) {
+	if err := validateTemplate(template); err != nil {
+		return nil, err
+	}
+
+	template.UpdatedAt = time.Now()
+	updated, err := s.templateRepository.Update(ctx, template)
+	if err != nil {
+		if errors.Is(err, repository.ErrTemplateTidakDitemukan) {
+			return nil, ErrTemplateTidakDitemukan
+		}
+		return nil, fmt.Errorf("gagal memperbarui template: %w", err)
+	}
+	updated.Variables = templateVariables(updated.Items)
+	return updated, nil
+}
+
+// Delete menghapus template milik pengguna
+func (s *templateService) Delete(ctx context.Context, userID, id int64) error {
+	if err := s.templateRepository.Delete(ctx, userID, id); err != nil {
+		if errors.Is(err, repository.ErrTemplateTidakDitemukan) {
+			return ErrTemplateTidakDitemukan
+		}
+		return fmt.Errorf("gagal menghapus template: %w", err)
+	}
+	return nil
+}
+
+// Instantiate membuat todo konkret dari template melalui TodoService dalam satu transaksi,
+// sehingga template tidak pernah dibuat sebagian. Todo induk dibuat sebelum subtask-nya.
+func (s *templateService) Instantiate(ctx context.Context, userID, id int64, params entity.TemplateInstantiation) ([]entity.Todo, error) {
+	template, err := s.FindByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	loc := s.userPreferenceService.Location(ctx, userID)
+	start := startOfDay(time.Now().In(loc))
+	if params.StartDate != "" {
+		start, err = time.ParseInLocation(time.DateOnly, params.StartDate, loc)
+		if err != nil {
+			return nil, ErrTanggalMulaiTidakValid
+		}
+	}
+
+	variables := map[string]string{templateDateVariable: start.Format(time.DateOnly)}
+	for name, value := range params.Variables {
+		variables[name] = value
+	}
+	var missing []string
+	for _, name := range template.Variables {
+		if _, ok := variables[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrVariabelTidakLengkap, strings.Join(missing, ", "))
+	}
+
+	todos := make([]entity.Todo, 0)
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		return s.instantiateItems(ctx, userID, template.Items, nil, start, variables, &todos)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("gagal membuat todo dari template: %w", err)
+	}
+	return todos, nil
+}
+
+func (s *templateService) instantiateItems(
+	ctx context.Context,
+	userID int64,
+	items []entity.TemplateItem,
+	parentID *int64,
+	start time.Time,
+	variables map[string]string,
+	todos *[]entity.Todo,
+) error {
+	for _, item := range items {
+		todo := entity.Todo{
+			Title:    substituteVariables(item.Title, variables),
+			Content:  substituteVariables(item.Content, variables),
+			Project:  substituteVariables(item.Project, variables),
+			Priority: item.Priority,
+			UserID:   userID,
+			ParentID: parentID,
+		}
+		if item.Tags != nil {
+			todo.Tags = make(entity.StringList, 0, len(item.Tags))
+			for _, tag := range item.Tags {
+				todo.Tags = append(todo.Tags, substituteVariables(tag, variables))
+			}
+		}
+		if item.DueOffset != "" {
+			days, _ := parseDueOffset(item.DueOffset)
+			todo.DueDate = start.AddDate(0, 0, days)
+		}
+
+		created, err := s.todoService.Create(ctx, todo)
+		if err != nil {
+			return err
+		}
+		*todos = append(*todos, created)
+
+		if err := s.instantiateItems(ctx, userID, item.Subtasks, &created.ID, start, variables, todos); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// templateItems mengubah todo beserta subtask-nya menjadi item template dengan offset
+// jatuh tempo relatif terhadap reference
+func (s *templateService) templateItems(ctx context.Context, userID int64, todos []entity.Todo, reference time.Time, depth int) ([]entity.TemplateItem, error) {
+	children := make(map[int64][]entity.Todo)
+	if depth < maxTemplateDepth {
+		ids := make([]int64, 0, len(todos))
+		for _, todo := range todos {
+			ids = append(ids, todo.ID)
+		}
+		subtasks, err := s.todoRepository.FindByCondition(ctx, userID, "parent_id IN ?", ids)
+		if err != nil {
+			return nil, fmt.Errorf("gagal mengambil subtask: %w", err)
+		}
+		for _, subtask := range subtasks {
+			if subtask.ParentID != nil {
+				children[*subtask.ParentID] = append(children[*subtask.ParentID], subtask)
+			}
+		}
+	}
+
+	items := make([]entity.TemplateItem, 0, len(todos))
+	for _, todo := range todos {
+		item := entity.TemplateItem{
+			Title:    todo.Title,
+			Content:  todo.Content,
+			Project:  todo.Project,
+			Tags:     todo.Tags,
+			Priority: todo.Priority,
+		}
+		if todo.DueDate.Year() > 1 {
+			item.DueOffset = formatDueOffset(daysBetween(reference, startOfDay(todo.DueDate.In(reference.Location()))))
+		}
+		if len(children[todo.ID]) > 0 {
+			subtasks, err := s.templateItems(ctx, userID, children[todo.ID], reference, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			item.Subtasks = subtasks
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// validateTemplate memeriksa nama, jumlah, kedalaman, dan isi setiap item template
+func validateTemplate(template *entity.TodoTemplate) error {
+	template.Name = strings.TrimSpace(template.Name)
+	if template.Name == "" || len(template.Name) > 100 {
+		return ErrNamaTemplateTidakValid
+	}
+	if len(template.Items) == 0 {
+		return fmt.Errorf("%w: minimal satu item harus diisi", ErrItemTemplateTidakValid)
+	}
+	count := 0
+	return validateTemplateItems(template.Items, 1, &count)
+}
+
+func validateTemplateItems(items []entity.TemplateItem, depth int, count *int) error {
+	if depth > maxTemplateDepth {
+		return fmt.Errorf("%w: subtask maksimal %d tingkat", ErrItemTemplateTidakValid, maxTemplateDepth)
+	}
+	for i := range items {
+		item := &items[i]
+		*count++
+		if *count > maxTemplateItems {
+			return fmt.Errorf("%w: maksimal %d item", ErrItemTemplateTidakValid, maxTemplateItems)
+		}
+		item.Title = strings.TrimSpace(item.Title)
+		if item.Title == "" {
+			return fmt.Errorf("%w: judul wajib diisi", ErrItemTemplateTidakValid)
+		}
+		if !entity.IsValidPriority(item.Priority) {
+			return fmt.Errorf("%w: %v", ErrItemTemplateTidakValid, ErrPrioritasTidakValid)
+		}
+		if item.DueOffset != "" {
+			if _, err := parseDueOffset(item.DueOffset); err != nil {
+				return fmt.Errorf("%w: %v", ErrItemTemplateTidakValid, err)
+			}
+		}
+		if err := validateTemplateItems(item.Subtasks, depth+1, count); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// templateVariables mengumpulkan nama variabel yang dipakai item, tanpa variabel bawaan
+func templateVariables(items []entity.TemplateItem) []string {
+	seen := make(map[string]bool)
+	var collect func(items []entity.TemplateItem)
+	collect = func(items []entity.TemplateItem) {
+		for _, item := range items {
+			texts := append([]string{item.Title, item.Content, item.Project}, item.Tags...)
+			for _, text := range texts {
+				for _, match := range templateVariablePattern.FindAllStringSubmatch(text, -1) {
+					seen[match[1]] = true
+				}
+			}
+			collect(item.Subtasks)
+		}
+	}
+	collect(items)
+	delete(seen, templateDateVariable)
+
+	variables := make([]string, 0, len(seen))
+	for name := range seen {
+		variables = append(variables, name)
+	}
+	sort.Strings(variables)
+	return variables
+}
+
+// substituteVariables mengganti setiap {{nama}} dengan nilai variabelnya
+func substituteVariables(text string, variables map[string]string) string {
+	return templateVariablePattern.ReplaceAllStringFunc(text, func(match string) string {
+		name := templateVariablePattern.FindStringSubmatch(match)[1]
+		if value, ok := variables[name]; ok {
+			return value
+		}
+		return match
+	})
+}
+
+// parseDueOffset mengubah offset seperti "+3d" atau "+2w" menjadi jumlah hari
+func parseDueOffset(offset string) (int, error) {
+	match := dueOffsetPattern.FindStringSubmatch(offset)
+	if match == nil {
+		return 0, fmt.Errorf("offset jatuh tempo %q harus berformat +Nd atau +Nw", offset)
+	}
+	days, _ := strconv.Atoi(match[2])
+	if match[3] == "w" {
+		days *= 7
+	}
+	if match[1] == "-" {
+		days = -days
+	}
+	return days, nil
+}
+
+// formatDueOffset mengubah jumlah hari menjadi offset seperti "+3d". Jumlah hari dibatasi
+// maxDueOffsetDays agar offset yang dihasilkan selalu lolos validasi template.
+func formatDueOffset(days int) string {
+	days = max(-maxDueOffsetDays, min(days, maxDueOffsetDays))
+	if days < 0 {
+		return fmt.Sprintf("%dd", days)
+	}
+	return fmt.Sprintf("+%dd", days)
+}
+
+// daysBetween menghitung selisih hari kalender dari from ke to tanpa terpengaruh pergantian DST
+func daysBetween(from, to time.Time) int {
+	fromDate := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	toDate := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(toDate.Sub(fromDate).Hours() / 24)
+}
@@ -0,0 +1,228 @@
+package service
+
+import (
+	"context"
+	"go-todo/internal/entity"
+	"go-todo/internal/repository"
+	mock_repository "go-todo/test/mock/repository"
+	mock_service "go-todo/test/mock/service"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// templateServiceMocks mengelompokkan semua dependensi mock dari TemplateService
+type templateServiceMocks struct {
+	template   *mock_repository.MockTemplateRepository
+	todo       *mock_repository.MockTodoRepository
+	todos      *mock_service.MockTodoService
+	preference *mock_service.MockUserPreferenceService
+	transactor *mock_repository.MockTransactor
+}
+
+func setupTemplateService(t *testing.T) (*gomock.Controller, TemplateService, *templateServiceMocks) {
+	ctrl := gomock.NewController(t)
+	m := &templateServiceMocks{
+		template:   mock_repository.NewMockTemplateRepository(ctrl),
+		todo:       mock_repository.NewMockTodoRepository(ctrl),
+		todos:      mock_service.NewMockTodoService(ctrl),
+		preference: mock_service.NewMockUserPreferenceService(ctrl),
+		transactor: mock_repository.NewMockTransactor(ctrl),
+	}
+	service := NewTemplateService(m.template, m.todo, m.todos, m.preference, m.transactor)
+	return ctrl, service, m
+}
+
+func TestTemplateService_Instantiate(t *testing.T) {
+	ctrl, service, m := setupTemplateService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m.template.EXPECT().FindByID(ctx, int64(5), int64(3)).Return(&entity.TodoTemplate{
+		ID:     3,
+		UserID: 5,
+		Items: []entity.TemplateItem{{
+			Title:     "Onboarding {{name}}",
+			Project:   "onboarding",
+			Tags:      []string{"hr", "{{team}}"},
+			DueOffset: "+1w",
+			Subtasks: []entity.TemplateItem{
+				{Title: "Siapkan laptop untuk {{name}}", DueOffset: "+3d"},
+				{Title: "Kirim jadwal {{date}}"},
+			},
+		}},
+	}, nil)
+	m.preference.EXPECT().Location(ctx, int64(5)).Return(time.UTC)
+	m.transactor.EXPECT().WithinTransaction(ctx, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(ctx context.Context) error) error { return fn(ctx) })
+
+	start := time.Date(2026, 11, 2, 0, 0, 0, 0, time.UTC)
+	nextID := int64(10)
+	var created []entity.Todo
+	m.todos.EXPECT().Create(ctx, gomock.Any()).Times(3).DoAndReturn(func(_ context.Context, todo entity.Todo) (entity.Todo, error) {
+		todo.ID = nextID
+		nextID++
+		created = append(created, todo)
+		return todo, nil
+	})
+
+	todos, err := service.Instantiate(ctx, 5, 3, entity.TemplateInstantiation{
+		Variables: map[string]string{"name": "Budi", "team": "backend"},
+		StartDate: "2026-11-02",
+	})
+	assert.NoError(t, err)
+	assert.Len(t, todos, 3)
+
+	// Variabel diganti dan offset dihitung dari tanggal mulai
+	assert.Equal(t, "Onboarding Budi", created[0].Title)
+	assert.Equal(t, entity.StringList{"hr", "backend"}, created[0].Tags)
+	assert.Equal(t, start.AddDate(0, 0, 7), created[0].DueDate)
+	assert.Nil(t, created[0].ParentID)
+
+	// Subtask dibuat setelah induknya dan merujuk ke ID induk
+	assert.Equal(t, "Siapkan laptop untuk Budi", created[1].Title)
+	assert.Equal(t, int64(10), *created[1].ParentID)
+	assert.Equal(t, start.AddDate(0, 0, 3), created[1].DueDate)
+	assert.Equal(t, "Kirim jadwal 2026-11-02", created[2].Title)
+	assert.True(t, created[2].DueDate.IsZero())
+}
+
+func TestTemplateService_Instantiate_MissingVariables(t *testing.T) {
+	ctrl, service, m := setupTemplateService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m.template.EXPECT().FindByID(ctx, int64(5), int64(3)).Return(&entity.TodoTemplate{
+		Items: []entity.TemplateItem{{Title: "Rilis {{version}} untuk {{client}}"}},
+	}, nil)
+	m.preference.EXPECT().Location(ctx, int64(5)).Return(time.UTC)
+
+	// Tidak ada todo yang dibuat jika variabel belum lengkap
+	_, err := service.Instantiate(ctx, 5, 3, entity.TemplateInstantiation{Variables: map[string]string{"version": "1.2"}})
+	assert.ErrorIs(t, err, ErrVariabelTidakLengkap)
+	assert.Contains(t, err.Error(), "client")
+}
+
+func TestTemplateService_Instantiate_RollsBackOnError(t *testing.T) {
+	ctrl, service, m := setupTemplateService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m.template.EXPECT().FindByID(ctx, int64(5), int64(3)).Return(&entity.TodoTemplate{
+		Items: []entity.TemplateItem{{Title: "Satu"}, {Title: "Dua"}},
+	}, nil)
+	m.preference.EXPECT().Location(ctx, int64(5)).Return(time.UTC)
+	m.transactor.EXPECT().WithinTransaction(ctx, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(ctx context.Context) error) error { return fn(ctx) })
+	gomock.InOrder(
+		m.todos.EXPECT().Create(ctx, gomock.Any()).Return(entity.Todo{ID: 1}, nil),
+		m.todos.EXPECT().Create(ctx, gomock.Any()).Return(entity.Todo{}, ErrPrioritasTidakValid),
+	)
+
+	_, err := service.Instantiate(ctx, 5, 3, entity.TemplateInstantiation{})
+	assert.ErrorIs(t, err, ErrPrioritasTidakValid)
+}
+
+func TestTemplateService_CreateFromTodo(t *testing.T) {
+	ctrl, service, m := setupTemplateService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	createdAt := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	parentID := int64(7)
+	m.todo.EXPECT().FindByID(ctx, int64(7)).Return(&entity.Todo{
+		ID: 7, UserID: 5, Title: "Rilis", Tags: entity.StringList{"release"}, CreatedAt: createdAt,
+		DueDate: time.Date(2026, 10, 4, 17, 0, 0, 0, time.UTC),
+	}, nil)
+	m.preference.EXPECT().Location(ctx, int64(5)).Return(time.UTC)
+	m.todo.EXPECT().FindByCondition(ctx, int64(5), "parent_id IN ?", []int64{7}).
+		Return([]entity.Todo{{ID: 8, UserID: 5, ParentID: &parentID, Title: "Tag versi"}}, nil)
+	m.todo.EXPECT().FindByCondition(ctx, int64(5), "parent_id IN ?", []int64{8}).Return([]entity.Todo{}, nil)
+	m.template.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, template *entity.TodoTemplate) (*entity.TodoTemplate, error) {
+		return template, nil
+	})
+
+	template, err := service.CreateFromTodo(ctx, 5, 7, "Prosedur rilis")
+	assert.NoError(t, err)
+	assert.Equal(t, "+3d", template.Items[0].DueOffset)
+	assert.Equal(t, []string{"release"}, template.Items[0].Tags)
+	assert.Equal(t, "Tag versi", template.Items[0].Subtasks[0].Title)
+	assert.Empty(t, template.Items[0].Subtasks[0].DueOffset)
+}
+
+func TestTemplateService_CreateFromTodo_FarDueDate(t *testing.T) {
+	ctrl, service, m := setupTemplateService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	createdAt := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	m.todo.EXPECT().FindByID(ctx, int64(7)).Return(&entity.Todo{
+		ID: 7, UserID: 5, Title: "Perpanjang sertifikat", CreatedAt: createdAt,
+		DueDate: createdAt.AddDate(0, 0, 1200),
+	}, nil)
+	m.preference.EXPECT().Location(ctx, int64(5)).Return(time.UTC)
+	m.todo.EXPECT().FindByCondition(ctx, int64(5), "parent_id IN ?", []int64{7}).Return([]entity.Todo{}, nil)
+	m.template.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, template *entity.TodoTemplate) (*entity.TodoTemplate, error) {
+		return template, nil
+	})
+
+	// Offset lebih dari tiga digit yang dihasilkan service harus lolos validasinya sendiri
+	template, err := service.CreateFromTodo(ctx, 5, 7, "Sertifikat")
+	assert.NoError(t, err)
+	assert.Equal(t, "+1200d", template.Items[0].DueOffset)
+}
+
+func TestFormatDueOffset_Clamped(t *testing.T) {
+	assert.Equal(t, "+99999d", formatDueOffset(250000))
+	assert.Equal(t, "-99999d", formatDueOffset(-250000))
+	days, err := parseDueOffset(formatDueOffset(250000))
+	assert.NoError(t, err)
+	assert.Equal(t, maxDueOffsetDays, days)
+}
+
+func TestTemplateService_CreateFromTodo_AnotherUser(t *testing.T) {
+	ctrl, service, m := setupTemplateService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m.todo.EXPECT().FindByID(ctx, int64(7)).Return(&entity.Todo{ID: 7, UserID: 2}, nil)
+
+	_, err := service.CreateFromTodo(ctx, 5, 7, "Prosedur rilis")
+	assert.ErrorIs(t, err, ErrTodoTidakDitemukan)
+}
+
+func TestTemplateService_Create_Validation(t *testing.T) {
+	ctrl, service, _ := setupTemplateService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tests := []struct {
+		template *entity.TodoTemplate
+		err      error
+	}{
+		{&entity.TodoTemplate{Name: "", Items: []entity.TemplateItem{{Title: "a"}}}, ErrNamaTemplateTidakValid},
+		{&entity.TodoTemplate{Name: "Kosong"}, ErrItemTemplateTidakValid},
+		{&entity.TodoTemplate{Name: "Offset", Items: []entity.TemplateItem{{Title: "a", DueOffset: "3 hari"}}}, ErrItemTemplateTidakValid},
+		{&entity.TodoTemplate{Name: "Prioritas", Items: []entity.TemplateItem{{Title: "a", Priority: "critical"}}}, ErrItemTemplateTidakValid},
+		{&entity.TodoTemplate{Name: "Dalam", Items: []entity.TemplateItem{{Title: "a", Subtasks: []entity.TemplateItem{
+			{Title: "b", Subtasks: []entity.TemplateItem{{Title: "c", Subtasks: []entity.TemplateItem{{Title: "d"}}}}},
+		}}}}, ErrItemTemplateTidakValid},
+	}
+	for _, tt := range tests {
+		_, err := service.Create(ctx, tt.template)
+		assert.ErrorIs(t, err, tt.err, tt.template.Name)
+	}
+}
+
+func TestTemplateService_FindByID_NotFound(t *testing.T) {
+	ctrl, service, m := setupTemplateService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m.template.EXPECT().FindByID(ctx, int64(5), int64(3)).Return(nil, repository.ErrTemplateTidakDitemukan)
+
+	_, err := service.FindByID(ctx, 5, 3)
+	assert.ErrorIs(t, err, ErrTemplateTidakDitemukan)
+}
@@ -0,0 +1,233 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-todo/internal/entity"
+	"go-todo/internal/repository"
+	"go-todo/pkg/cache"
+	"time"
+)
+
+var (
+	ErrTodoTidakDitemukan         = errors.New("todo tidak ditemukan")
+	ErrTimerSudahBerjalan         = errors.New("masih ada timer yang berjalan, hentikan terlebih dahulu")
+	ErrTimerTidakBerjalan         = errors.New("tidak ada timer yang berjalan untuk todo ini")
+	ErrCatatanWaktuTidakValid     = errors.New("waktu selesai harus setelah waktu mulai")
+	ErrCatatanWaktuTidakDitemukan = errors.New("catatan waktu tidak ditemukan")
+	ErrAksesDitolak               = errors.New("anda tidak memiliki akses ke resource ini")
+)
+
+type TimeEntryService interface {
+	StartTimer(ctx context.Context, userID, todoID int64, note string) (*entity.TimeEntry, error)
+	StopTimer(ctx context.Context, userID, todoID int64) (*entity.TimeEntry, error)
+	FindByTodoID(ctx context.Context, userID, todoID int64) ([]entity.TimeEntry, error)
+	Create(ctx context.Context, entry *entity.TimeEntry) (*entity.TimeEntry, error)
+	Update(ctx context.Context, userID int64, entry *entity.TimeEntry) (*entity.TimeEntry, error)
+	Delete(ctx context.Context, userID, id int64) error
+	Report(ctx context.Context, filter entity.TimeReportFilter) ([]entity.TimeReportRow, error)
+}
+
+type timeEntryService struct {
+	timeEntryRepository repository.TimeEntryRepository
+	todoRepository      repository.TodoRepository
+	cacheable           cache.Cacheable
+}
+
+// NewTimeEntryService membuat instance baru dari TimeEntryService
+func NewTimeEntryService(
+	timeEntryRepository repository.TimeEntryRepository,
+	todoRepository repository.TodoRepository,
+	cacheable cache.Cacheable,
+) TimeEntryService {
+	return &timeEntryService{
+		timeEntryRepository: timeEntryRepository,
+		todoRepository:      todoRepository,
+		cacheable:           cacheable,
+	}
+}
+
+// StartTimer memulai timer baru pada todo milik atau yang ditugaskan kepada pengguna. Setiap
+// pengguna hanya boleh memiliki satu timer yang berjalan.
+func (s *timeEntryService) StartTimer(ctx context.Context, userID, todoID int64, note string) (*entity.TimeEntry, error) {
+	if err := s.authorizeTodo(ctx, userID, todoID); err != nil {
+		return nil, err
+	}
+
+	_, err := s.timeEntryRepository.FindRunningByUserID(ctx, userID)
+	if err == nil {
+		return nil, ErrTimerSudahBerjalan
+	}
+	if !errors.Is(err, repository.ErrTimeEntryTidakDitemukan) {
+		return nil, fmt.Errorf("gagal memeriksa timer: %w", err)
+	}
+
+	entry := &entity.TimeEntry{
+		TodoID:    todoID,
+		UserID:    userID,
+		StartedAt: time.Now(),
+		Note:      note,
+	}
+
+	// Pemeriksaan di atas dapat terlewati oleh permintaan bersamaan; unique index menjadi penentu akhirnya
+	createdEntry, err := s.timeEntryRepository.Create(ctx, entry)
+	if errors.Is(err, repository.ErrTimerMasihBerjalan) {
+		return nil, ErrTimerSudahBerjalan
+	}
+	if err != nil {
+		return nil, fmt.Errorf("gagal memulai timer: %w", err)
+	}
+	return createdEntry, nil
+}
+
+// StopTimer menghentikan timer pengguna yang sedang berjalan pada todo
+func (s *timeEntryService) StopTimer(ctx context.Context, userID, todoID int64) (*entity.TimeEntry, error) {
+	running, err := s.timeEntryRepository.FindRunningByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrTimeEntryTidakDitemukan) {
+			return nil, ErrTimerTidakBerjalan
+		}
+		return nil, fmt.Errorf("gagal memeriksa timer: %w", err)
+	}
+	if running.TodoID != todoID {
+		return nil, ErrTimerTidakBerjalan
+	}
+
+	endedAt := time.Now()
+	running.EndedAt = &endedAt
+	running.DurationSeconds = int64(endedAt.Sub(running.StartedAt).Seconds())
+
+	updatedEntry, err := s.timeEntryRepository.Update(ctx, running)
+	if err != nil {
+		return nil, fmt.Errorf("gagal menghentikan timer: %w", err)
+	}
+
+	// Total waktu pada todo berubah, hapus cache agar data konsisten
+	s.cacheable.Delete("go-todo-api:todos:find-all")
+	return updatedEntry, nil
+}
+
+// FindByTodoID mengambil semua catatan waktu dari todo milik atau yang ditugaskan kepada pengguna
+func (s *timeEntryService) FindByTodoID(ctx context.Context, userID, todoID int64) ([]entity.TimeEntry, error) {
+	if err := s.authorizeTodo(ctx, userID, todoID); err != nil {
+		return nil, err
+	}
+
+	entries, err := s.timeEntryRepository.FindByTodoID(ctx, todoID)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil catatan waktu: %w", err)
+	}
+	return entries, nil
+}
+
+// Create menambahkan catatan waktu secara manual atas nama entry.UserID pada todo milik atau
+// yang ditugaskan kepada pengguna tersebut
+func (s *timeEntryService) Create(ctx context.Context, entry *entity.TimeEntry) (*entity.TimeEntry, error) {
+	if entry.EndedAt == nil || !entry.EndedAt.After(entry.StartedAt) {
+		return nil, ErrCatatanWaktuTidakValid
+	}
+
+	if err := s.authorizeTodo(ctx, entry.UserID, entry.TodoID); err != nil {
+		return nil, err
+	}
+
+	entry.DurationSeconds = int64(entry.EndedAt.Sub(entry.StartedAt).Seconds())
+
+	createdEntry, err := s.timeEntryRepository.Create(ctx, entry)
+	if err != nil {
+		return nil, fmt.Errorf("gagal menambahkan catatan waktu: %w", err)
+	}
+
+	s.cacheable.Delete("go-todo-api:todos:find-all")
+	return createdEntry, nil
+}
+
+// Update memperbarui catatan waktu milik pengguna
+func (s *timeEntryService) Update(ctx context.Context, userID int64, entry *entity.TimeEntry) (*entity.TimeEntry, error) {
+	existingEntry, err := s.findOwned(ctx, userID, entry.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Memperbarui field hanya jika nilai baru diberikan
+	if !entry.StartedAt.IsZero() {
+		existingEntry.StartedAt = entry.StartedAt
+	}
+	if entry.EndedAt != nil {
+		existingEntry.EndedAt = entry.EndedAt
+	}
+	if entry.Note != "" {
+		existingEntry.Note = entry.Note
+	}
+
+	if existingEntry.EndedAt != nil {
+		if !existingEntry.EndedAt.After(existingEntry.StartedAt) {
+			return nil, ErrCatatanWaktuTidakValid
+		}
+		existingEntry.DurationSeconds = int64(existingEntry.EndedAt.Sub(existingEntry.StartedAt).Seconds())
+	}
+
+	updatedEntry, err := s.timeEntryRepository.Update(ctx, existingEntry)
+	if err != nil {
+		return nil, fmt.Errorf("gagal memperbarui catatan waktu: %w", err)
+	}
+
+	s.cacheable.Delete("go-todo-api:todos:find-all")
+	return updatedEntry, nil
+}
+
+// Delete menghapus catatan waktu milik pengguna
+func (s *timeEntryService) Delete(ctx context.Context, userID, id int64) error {
+	if _, err := s.findOwned(ctx, userID, id); err != nil {
+		return err
+	}
+
+	if err := s.timeEntryRepository.Delete(ctx, id); err != nil {
+		return fmt.Errorf("gagal menghapus catatan waktu: %w", err)
+	}
+
+	s.cacheable.Delete("go-todo-api:todos:find-all")
+	return nil
+}
+
+// Report membuat laporan total waktu per pengguna dan per project
+func (s *timeEntryService) Report(ctx context.Context, filter entity.TimeReportFilter) ([]entity.TimeReportRow, error) {
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.To.After(filter.From) {
+		return nil, ErrCatatanWaktuTidakValid
+	}
+
+	rows, err := s.timeEntryRepository.Report(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("gagal membuat laporan waktu: %w", err)
+	}
+	return rows, nil
+}
+
+// authorizeTodo memastikan todo ada dan pengguna adalah pemilik atau penanggung jawabnya,
+// sehingga catatan waktu todo pengguna lain tidak dapat dibaca maupun ditambah
+func (s *timeEntryService) authorizeTodo(ctx context.Context, userID, todoID int64) error {
+	todo, err := s.todoRepository.FindByID(ctx, todoID)
+	if err != nil {
+		return ErrTodoTidakDitemukan
+	}
+	if !todo.CanUpdateStatus(userID) {
+		return ErrAksesDitolak
+	}
+	return nil
+}
+
+// findOwned mengambil catatan waktu dan memastikan catatan tersebut milik pengguna
+func (s *timeEntryService) findOwned(ctx context.Context, userID, id int64) (*entity.TimeEntry, error) {
+	entry, err := s.timeEntryRepository.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrTimeEntryTidakDitemukan) {
+			return nil, ErrCatatanWaktuTidakDitemukan
+		}
+		return nil, fmt.Errorf("gagal mengambil catatan waktu: %w", err)
+	}
+	if entry.UserID != userID {
+		return nil, ErrAksesDitolak
+	}
+	return entry, nil
+}
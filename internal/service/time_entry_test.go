@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"go-todo/internal/entity"
+	"go-todo/internal/repository"
+	mock_cache "go-todo/test/mock/pkg/cache"
+	mock_repository "go-todo/test/mock/repository"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupTimeEntryService(t *testing.T) (*gomock.Controller, TimeEntryService, *mock_repository.MockTimeEntryRepository, *mock_repository.MockTodoRepository, *mock_cache.MockCacheable) {
+	ctrl := gomock.NewController(t)
+	mockEntryRepo := mock_repository.NewMockTimeEntryRepository(ctrl)
+	mockTodoRepo := mock_repository.NewMockTodoRepository(ctrl)
+	mockCache := mock_cache.NewMockCacheable(ctrl)
+	service := NewTimeEntryService(mockEntryRepo, mockTodoRepo, mockCache)
+	return ctrl, service, mockEntryRepo, mockTodoRepo, mockCache
+}
+
+func TestTimeEntryService_StartTimer(t *testing.T) {
+	ctrl, service, mockEntryRepo, mockTodoRepo, _ := setupTimeEntryService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockTodoRepo.EXPECT().FindByID(ctx, int64(10)).Return(&entity.Todo{ID: 10, UserID: 5}, nil)
+	mockEntryRepo.EXPECT().FindRunningByUserID(ctx, int64(5)).Return(nil, repository.ErrTimeEntryTidakDitemukan)
+	mockEntryRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, entry *entity.TimeEntry) (*entity.TimeEntry, error) {
+			entry.ID = 1
+			return entry, nil
+		})
+
+	entry, err := service.StartTimer(ctx, 5, 10, "coding")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10), entry.TodoID)
+	assert.Nil(t, entry.EndedAt)
+}
+
+func TestTimeEntryService_StartTimer_AlreadyRunning(t *testing.T) {
+	ctrl, service, mockEntryRepo, mockTodoRepo, _ := setupTimeEntryService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockTodoRepo.EXPECT().FindByID(ctx, int64(10)).Return(&entity.Todo{ID: 10, UserID: 5}, nil)
+	mockEntryRepo.EXPECT().FindRunningByUserID(ctx, int64(5)).Return(&entity.TimeEntry{ID: 2, TodoID: 11}, nil)
+
+	_, err := service.StartTimer(ctx, 5, 10, "")
+	assert.ErrorIs(t, err, ErrTimerSudahBerjalan)
+}
+
+func TestTimeEntryService_StartTimer_ConcurrentStart(t *testing.T) {
+	ctrl, service, mockEntryRepo, mockTodoRepo, _ := setupTimeEntryService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockTodoRepo.EXPECT().FindByID(ctx, int64(10)).Return(&entity.Todo{ID: 10, UserID: 5}, nil)
+	// Permintaan lain memulai timer setelah pemeriksaan, sehingga unique index yang menolak
+	mockEntryRepo.EXPECT().FindRunningByUserID(ctx, int64(5)).Return(nil, repository.ErrTimeEntryTidakDitemukan)
+	mockEntryRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil, repository.ErrTimerMasihBerjalan)
+
+	_, err := service.StartTimer(ctx, 5, 10, "")
+	assert.ErrorIs(t, err, ErrTimerSudahBerjalan)
+}
+
+func TestTimeEntryService_StartTimer_TodoNotFound(t *testing.T) {
+	ctrl, service, _, mockTodoRepo, _ := setupTimeEntryService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockTodoRepo.EXPECT().FindByID(ctx, int64(10)).Return(nil, errors.New("record not found"))
+
+	_, err := service.StartTimer(ctx, 5, 10, "")
+	assert.ErrorIs(t, err, ErrTodoTidakDitemukan)
+}
+
+func TestTimeEntryService_StopTimer(t *testing.T) {
+	ctrl, service, mockEntryRepo, _, mockCache := setupTimeEntryService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	running := &entity.TimeEntry{ID: 1, TodoID: 10, UserID: 5, StartedAt: time.Now().Add(-90 * time.Minute)}
+
+	mockEntryRepo.EXPECT().FindRunningByUserID(ctx, int64(5)).Return(running, nil)
+	mockEntryRepo.EXPECT().Update(ctx, running).Return(running, nil)
+	mockCache.EXPECT().Delete("go-todo-api:todos:find-all").Return(nil)
+
+	entry, err := service.StopTimer(ctx, 5, 10)
+	assert.NoError(t, err)
+	assert.NotNil(t, entry.EndedAt)
+	assert.InDelta(t, 5400, entry.DurationSeconds, 2)
+}
+
+func TestTimeEntryService_StopTimer_OtherTodo(t *testing.T) {
+	ctrl, service, mockEntryRepo, _, _ := setupTimeEntryService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockEntryRepo.EXPECT().FindRunningByUserID(ctx, int64(5)).Return(&entity.TimeEntry{ID: 1, TodoID: 11}, nil)
+
+	_, err := service.StopTimer(ctx, 5, 10)
+	assert.ErrorIs(t, err, ErrTimerTidakBerjalan)
+}
+
+func TestTimeEntryService_Create_InvalidRange(t *testing.T) {
+	ctrl, service, _, _, _ := setupTimeEntryService(t)
+	defer ctrl.Finish()
+
+	start := time.Now()
+	end := start.Add(-time.Hour)
+
+	_, err := service.Create(context.Background(), &entity.TimeEntry{TodoID: 10, StartedAt: start, EndedAt: &end})
+	assert.ErrorIs(t, err, ErrCatatanWaktuTidakValid)
+}
+
+func TestTimeEntryService_Create(t *testing.T) {
+	ctrl, service, mockEntryRepo, mockTodoRepo, mockCache := setupTimeEntryService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	start := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	end := start.Add(2 * time.Hour)
+	entry := &entity.TimeEntry{TodoID: 10, UserID: 5, StartedAt: start, EndedAt: &end}
+
+	mockTodoRepo.EXPECT().FindByID(ctx, int64(10)).Return(&entity.Todo{ID: 10, UserID: 5}, nil)
+	mockEntryRepo.EXPECT().Create(ctx, entry).Return(entry, nil)
+	mockCache.EXPECT().Delete("go-todo-api:todos:find-all").Return(nil)
+
+	createdEntry, err := service.Create(ctx, entry)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7200), createdEntry.DurationSeconds)
+}
+
+func TestTimeEntryService_OtherUsersTodo(t *testing.T) {
+	ctrl, service, _, mockTodoRepo, _ := setupTimeEntryService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	start := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	mockTodoRepo.EXPECT().FindByID(ctx, int64(10)).Return(&entity.Todo{ID: 10, UserID: 6}, nil).Times(3)
+
+	// Pengguna lain tidak boleh mencatat maupun membaca waktu pada todo yang bukan miliknya
+	_, err := service.StartTimer(ctx, 5, 10, "")
+	assert.ErrorIs(t, err, ErrAksesDitolak)
+	_, err = service.Create(ctx, &entity.TimeEntry{TodoID: 10, UserID: 5, StartedAt: start, EndedAt: &end})
+	assert.ErrorIs(t, err, ErrAksesDitolak)
+	_, err = service.FindByTodoID(ctx, 5, 10)
+	assert.ErrorIs(t, err, ErrAksesDitolak)
+}
+
+func TestTimeEntryService_FindByTodoID_Assignee(t *testing.T) {
+	ctrl, service, mockEntryRepo, mockTodoRepo, _ := setupTimeEntryService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	assignee := int64(5)
+	mockTodoRepo.EXPECT().FindByID(ctx, int64(10)).Return(&entity.Todo{ID: 10, UserID: 6, AssigneeID: &assignee}, nil)
+	mockEntryRepo.EXPECT().FindByTodoID(ctx, int64(10)).Return([]entity.TimeEntry{{ID: 1, TodoID: 10, UserID: 5}}, nil)
+
+	entries, err := service.FindByTodoID(ctx, 5, 10)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestTimeEntryService_Delete_NotOwner(t *testing.T) {
+	ctrl, service, mockEntryRepo, _, _ := setupTimeEntryService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockEntryRepo.EXPECT().FindByID(ctx, int64(1)).Return(&entity.TimeEntry{ID: 1, UserID: 6}, nil)
+
+	err := service.Delete(ctx, 5, 1)
+	assert.ErrorIs(t, err, ErrAksesDitolak)
+}
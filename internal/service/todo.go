@@ -10,24 +10,39 @@ import (
 	"time"
 )
 
+var (
+	ErrPrioritasTidakValid = errors.New("prioritas harus salah satu dari low, medium, high, atau urgent")
+	ErrIndukTidakValid     = errors.New("todo induk tidak ditemukan")
+)
+
 type TodoService interface {
 	FindAll(ctx context.Context) ([]entity.Todo, error)
 	Create(ctx context.Context, todo entity.Todo) (entity.Todo, error)
 	Update(ctx context.Context, id int64, todo entity.Todo) (entity.Todo, error)
+	Restore(ctx context.Context, id int64, revision entity.TodoRevision) (entity.Todo, error)
+	Replace(ctx context.Context, id int64, todo entity.Todo) (entity.Todo, error)
 	Delete(ctx context.Context, id int64) error
 }
 
 type todoService struct {
 	todoRepository repository.TodoRepository
 	cacheable      cache.Cacheable
+	transactor     repository.Transactor
+	events         EventRecorder
+	customFields   CustomFieldValidator
+	revisions      RevisionRecorder
 }
 
 // NewTodoService membuat instance baru dari TodoService
 func NewTodoService(
 	todoRepository repository.TodoRepository,
 	cacheable cache.Cacheable,
+	transactor repository.Transactor,
+	events EventRecorder,
+	customFields CustomFieldValidator,
+	revisions RevisionRecorder,
 ) TodoService {
-	return &todoService{todoRepository, cacheable}
+	return &todoService{todoRepository, cacheable, transactor, events, customFields, revisions}
 }
 
 // FindAll mengambil semua data todo, dengan menggunakan caching untuk meningkatkan performa
@@ -68,8 +83,39 @@ func (s *todoService) FindAll(ctx context.Context) ([]entity.Todo, error) {
 
 // Create menambahkan todo baru
 func (s *todoService) Create(ctx context.Context, todo entity.Todo) (entity.Todo, error) {
-	// Menyimpan data todo baru ke dalam repository
-	createdTodo, err := s.todoRepository.Create(ctx, todo)
+	if !entity.IsValidPriority(todo.Priority) {
+		return entity.Todo{}, ErrPrioritasTidakValid
+	}
+	if todo.Completed && todo.CompletedAt == nil {
+		completedAt := time.Now()
+		todo.CompletedAt = &completedAt
+	}
+	// Subtask hanya boleh dibuat di bawah todo milik pengguna yang sama
+	if todo.ParentID != nil {
+		parent, err := s.todoRepository.FindByID(ctx, *todo.ParentID)
+		if err != nil || parent.UserID != todo.UserID {
+			return entity.Todo{}, ErrIndukTidakValid
+		}
+	}
+	customFields, err := s.customFields.ValidateValues(ctx, todo.UserID, todo.CustomFields)
+	if err != nil {
+		return entity.Todo{}, err
+	}
+	todo.CustomFields = customFields
+
+	// Menyimpan data todo baru beserta event-nya dalam satu transaksi
+	var createdTodo entity.Todo
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		createdTodo, err = s.todoRepository.Create(ctx, todo)
+		if err != nil {
+			return err
+		}
+		if err := s.revisions.Record(ctx, nil, createdTodo, nil); err != nil {
+			return err
+		}
+		return s.events.Record(ctx, todoEvent(entity.EventTodoCreated, createdTodo))
+	})
 	if err != nil {
 		return entity.Todo{}, errors.New("gagal menambahkan todo")
 	}
@@ -87,6 +133,7 @@ func (s *todoService) Update(ctx context.Context, id int64, todo entity.Todo) (e
 	if err != nil {
 		return entity.Todo{}, errors.New("todo tidak ditemukan")
 	}
+	before := *existingTodo
 
 	// Memperbarui field dari todo yang ada hanya jika field baru tidak kosong
 	if todo.Title != "" {
@@ -98,11 +145,111 @@ func (s *todoService) Update(ctx context.Context, id int64, todo entity.Todo) (e
 	if !todo.DueDate.IsZero() {
 		existingTodo.DueDate = todo.DueDate
 	}
+	if todo.Project != "" {
+		existingTodo.Project = todo.Project
+	}
+	if todo.Tags != nil {
+		existingTodo.Tags = todo.Tags
+	}
+	if todo.Priority != "" {
+		if !entity.IsValidPriority(todo.Priority) {
+			return entity.Todo{}, ErrPrioritasTidakValid
+		}
+		existingTodo.Priority = todo.Priority
+	}
+	// Nilai field kustom digabung dengan yang sudah ada; nilai null menghapus field tersebut
+	if todo.CustomFields != nil {
+		merged := make(entity.CustomFieldValues, len(existingTodo.CustomFields)+len(todo.CustomFields))
+		for key, value := range existingTodo.CustomFields {
+			merged[key] = value
+		}
+		for key, value := range todo.CustomFields {
+			merged[key] = value
+		}
+		customFields, err := s.customFields.ValidateValues(ctx, existingTodo.UserID, merged)
+		if err != nil {
+			return entity.Todo{}, err
+		}
+		existingTodo.CustomFields = customFields
+	}
+	// Mencatat waktu penyelesaian hanya ketika status completed berubah
+	justCompleted := todo.Completed && !existingTodo.Completed
+	if justCompleted {
+		completedAt := time.Now()
+		existingTodo.CompletedAt = &completedAt
+	} else if !todo.Completed {
+		existingTodo.CompletedAt = nil
+	}
 	// Completed field should be updated directly as it is a boolean
 	existingTodo.Completed = todo.Completed
 
-	// Menyimpan data yang telah diperbarui ke dalam repository
-	updatedTodo, err := s.todoRepository.Update(ctx, *existingTodo)
+	return s.save(ctx, &before, *existingTodo, justCompleted, nil)
+}
+
+// Restore mengganti isi todo dengan snapshot sebuah revisi. Hasilnya dicatat sebagai
+// revisi baru yang merujuk revisi asal; status selesai tidak ikut berubah.
+func (s *todoService) Restore(ctx context.Context, id int64, revision entity.TodoRevision) (entity.Todo, error) {
+	existingTodo, err := s.todoRepository.FindByID(ctx, id)
+	if err != nil {
+		return entity.Todo{}, errors.New("todo tidak ditemukan")
+	}
+	before := *existingTodo
+
+	existingTodo.ApplySnapshot(revision.Snapshot)
+	customFields, err := s.customFields.ValidateValues(ctx, existingTodo.UserID, existingTodo.CustomFields)
+	if err != nil {
+		return entity.Todo{}, err
+	}
+	existingTodo.CustomFields = customFields
+
+	return s.save(ctx, &before, *existingTodo, false, &revision.Revision)
+}
+
+// Replace mengganti isi dan status selesai todo persis seperti nilai yang diberikan, misalnya
+// untuk membatalkan perubahan. Berbeda dengan Update, field kosong ikut disimpan.
+func (s *todoService) Replace(ctx context.Context, id int64, todo entity.Todo) (entity.Todo, error) {
+	if !entity.IsValidPriority(todo.Priority) {
+		return entity.Todo{}, ErrPrioritasTidakValid
+	}
+	existingTodo, err := s.todoRepository.FindByID(ctx, id)
+	if err != nil {
+		return entity.Todo{}, errors.New("todo tidak ditemukan")
+	}
+	before := *existingTodo
+
+	existingTodo.ApplySnapshot(todo.Snapshot())
+	customFields, err := s.customFields.ValidateValues(ctx, existingTodo.UserID, existingTodo.CustomFields)
+	if err != nil {
+		return entity.Todo{}, err
+	}
+	existingTodo.CustomFields = customFields
+	justCompleted := todo.Completed && !existingTodo.Completed
+	existingTodo.Completed = todo.Completed
+	existingTodo.CompletedAt = todo.CompletedAt
+
+	return s.save(ctx, &before, *existingTodo, justCompleted, nil)
+}
+
+// save menyimpan todo yang telah diperbarui beserta revisi dan event-nya dalam satu transaksi
+func (s *todoService) save(ctx context.Context, before *entity.Todo, todo entity.Todo, justCompleted bool, revertedFrom *int) (entity.Todo, error) {
+	var updatedTodo entity.Todo
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		updatedTodo, err = s.todoRepository.Update(ctx, todo)
+		if err != nil {
+			return err
+		}
+		if err := s.revisions.Record(ctx, before, updatedTodo, revertedFrom); err != nil {
+			return err
+		}
+		if err := s.events.Record(ctx, todoEvent(entity.EventTodoUpdated, updatedTodo)); err != nil {
+			return err
+		}
+		if justCompleted {
+			return s.events.Record(ctx, todoEvent(entity.EventTodoCompleted, updatedTodo))
+		}
+		return nil
+	})
 	if err != nil {
 		return entity.Todo{}, errors.New("gagal memperbarui todo")
 	}
@@ -116,13 +263,18 @@ func (s *todoService) Update(ctx context.Context, id int64, todo entity.Todo) (e
 // Delete menghapus todo berdasarkan ID
 func (s *todoService) Delete(ctx context.Context, id int64) error {
 	// Mengecek apakah todo yang ingin dihapus ada di database
-	_, err := s.todoRepository.FindByID(ctx, id)
+	existingTodo, err := s.todoRepository.FindByID(ctx, id)
 	if err != nil {
 		return errors.New("todo tidak ditemukan")
 	}
 
-	// Menghapus todo dari repository
-	err = s.todoRepository.Delete(ctx, id)
+	// Menghapus todo dari repository beserta mencatat event-nya dalam satu transaksi
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.todoRepository.Delete(ctx, id); err != nil {
+			return err
+		}
+		return s.events.Record(ctx, todoEvent(entity.EventTodoDeleted, *existingTodo))
+	})
 	if err != nil {
 		return errors.New("gagal menghapus todo")
 	}
@@ -131,3 +283,13 @@ func (s *todoService) Delete(ctx context.Context, id int64) error {
 	s.cacheable.Delete("go-todo-api:todos:find-all")
 	return nil
 }
+
+// todoEvent menyusun domain event untuk aggregate todo
+func todoEvent(eventType string, todo entity.Todo) entity.DomainEvent {
+	return entity.DomainEvent{
+		Type:          eventType,
+		AggregateType: entity.AggregateTodo,
+		AggregateID:   todo.ID,
+		Data:          todo,
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"go-todo/internal/entity"
 	mock_cache "go-todo/test/mock/pkg/cache"       // Mock untuk cache
 	mock_repository "go-todo/test/mock/repository" // Mock untuk repository
+	mock_service "go-todo/test/mock/service"       // Mock untuk pencatatan event
 	"testing"
 	"time"
 
@@ -20,7 +21,8 @@ func TestTodoService_FindAll_CacheHit(t *testing.T) {
 
 	mockCache := mock_cache.NewMockCacheable(ctrl)
 	mockRepo := mock_repository.NewMockTodoRepository(ctrl)
-	service := NewTodoService(mockRepo, mockCache)
+	mockEvents := mock_service.NewMockEventRecorder(ctrl)
+	service := NewTodoService(mockRepo, mockCache, passThroughTransactor(ctrl), mockEvents, passThroughCustomFields(ctrl), noopRevisions(ctrl))
 
 	ctx := context.Background()
 	expectedTodos := []entity.Todo{{ID: 1, Title: "Test Todo 1"}, {ID: 2, Title: "Test Todo 2"}}
@@ -39,7 +41,8 @@ func TestTodoService_FindAll_CacheMissAndRepoHit(t *testing.T) {
 
 	mockCache := mock_cache.NewMockCacheable(ctrl)
 	mockRepo := mock_repository.NewMockTodoRepository(ctrl)
-	service := NewTodoService(mockRepo, mockCache)
+	mockEvents := mock_service.NewMockEventRecorder(ctrl)
+	service := NewTodoService(mockRepo, mockCache, passThroughTransactor(ctrl), mockEvents, passThroughCustomFields(ctrl), noopRevisions(ctrl))
 
 	ctx := context.Background()
 	expectedTodos := []entity.Todo{{ID: 1, Title: "Test Todo 1"}, {ID: 2, Title: "Test Todo 2"}}
@@ -59,7 +62,8 @@ func TestTodoService_FindAll_CacheMissAndRepoNil(t *testing.T) {
 
 	mockCache := mock_cache.NewMockCacheable(ctrl)
 	mockRepo := mock_repository.NewMockTodoRepository(ctrl)
-	service := NewTodoService(mockRepo, mockCache)
+	mockEvents := mock_service.NewMockEventRecorder(ctrl)
+	service := NewTodoService(mockRepo, mockCache, passThroughTransactor(ctrl), mockEvents, passThroughCustomFields(ctrl), noopRevisions(ctrl))
 
 	ctx := context.Background()
 
@@ -78,7 +82,8 @@ func TestTodoService_FindAll_CacheError(t *testing.T) {
 
 	mockCache := mock_cache.NewMockCacheable(ctrl)
 	mockRepo := mock_repository.NewMockTodoRepository(ctrl)
-	service := NewTodoService(mockRepo, mockCache)
+	mockEvents := mock_service.NewMockEventRecorder(ctrl)
+	service := NewTodoService(mockRepo, mockCache, passThroughTransactor(ctrl), mockEvents, passThroughCustomFields(ctrl), noopRevisions(ctrl))
 
 	ctx := context.Background()
 
@@ -97,7 +102,8 @@ func TestTodoService_FindAll_RepoErrorOnCacheMiss(t *testing.T) {
 
 	mockCache := mock_cache.NewMockCacheable(ctrl)
 	mockRepo := mock_repository.NewMockTodoRepository(ctrl)
-	service := NewTodoService(mockRepo, mockCache)
+	mockEvents := mock_service.NewMockEventRecorder(ctrl)
+	service := NewTodoService(mockRepo, mockCache, passThroughTransactor(ctrl), mockEvents, passThroughCustomFields(ctrl), noopRevisions(ctrl))
 
 	ctx := context.Background()
 
@@ -115,7 +121,8 @@ func TestTodoService_FindAll_ErrorDuringCacheSet(t *testing.T) {
 
 	mockCache := mock_cache.NewMockCacheable(ctrl)
 	mockRepo := mock_repository.NewMockTodoRepository(ctrl)
-	service := NewTodoService(mockRepo, mockCache)
+	mockEvents := mock_service.NewMockEventRecorder(ctrl)
+	service := NewTodoService(mockRepo, mockCache, passThroughTransactor(ctrl), mockEvents, passThroughCustomFields(ctrl), noopRevisions(ctrl))
 
 	ctx := context.Background()
 	expectedTodos := []entity.Todo{{ID: 1, Title: "Test Todo 1"}, {ID: 2, Title: "Test Todo 2"}}
@@ -136,7 +143,8 @@ func TestTodoService_FindAll_ErrorDuringUnmarshal(t *testing.T) {
 
 	mockCache := mock_cache.NewMockCacheable(ctrl)
 	mockRepo := mock_repository.NewMockTodoRepository(ctrl)
-	service := NewTodoService(mockRepo, mockCache)
+	mockEvents := mock_service.NewMockEventRecorder(ctrl)
+	service := NewTodoService(mockRepo, mockCache, passThroughTransactor(ctrl), mockEvents, passThroughCustomFields(ctrl), noopRevisions(ctrl))
 
 	ctx := context.Background()
 	expectedTodos := []entity.Todo{
@@ -163,7 +171,8 @@ func TestTodoService_Create(t *testing.T) {
 
 	mockRepo := mock_repository.NewMockTodoRepository(ctrl)
 	mockCache := mock_cache.NewMockCacheable(ctrl)
-	service := NewTodoService(mockRepo, mockCache)
+	mockEvents := mock_service.NewMockEventRecorder(ctrl)
+	service := NewTodoService(mockRepo, mockCache, passThroughTransactor(ctrl), mockEvents, passThroughCustomFields(ctrl), noopRevisions(ctrl))
 
 	ctx := context.Background()
 	newTodo := entity.Todo{Title: "New Todo"}
@@ -172,6 +181,7 @@ func TestTodoService_Create(t *testing.T) {
 	// Test case 1: Successful creation
 	mockRepo.EXPECT().Create(ctx, newTodo).Return(expectedTodo, nil)
 	mockCache.EXPECT().Delete("go-todo-api:todos:find-all").Return(nil)
+	mockEvents.EXPECT().Record(ctx, todoEvent(entity.EventTodoCreated, expectedTodo)).Return(nil)
 
 	createdTodo, err := service.Create(ctx, newTodo)
 	assert.NoError(t, err)
@@ -183,6 +193,14 @@ func TestTodoService_Create(t *testing.T) {
 	_, err = service.Create(ctx, newTodo)
 	assert.Error(t, err)
 	assert.Equal(t, "gagal menambahkan todo", err.Error())
+
+	// Test case 3: Event gagal dicatat sehingga transaksi dibatalkan dan cache tidak dihapus
+	mockRepo.EXPECT().Create(ctx, newTodo).Return(expectedTodo, nil)
+	mockEvents.EXPECT().Record(ctx, gomock.Any()).Return(errors.New("outbox error"))
+
+	_, err = service.Create(ctx, newTodo)
+	assert.Error(t, err)
+	assert.Equal(t, "gagal menambahkan todo", err.Error())
 }
 
 func TestTodoService_Update(t *testing.T) {
@@ -191,7 +209,8 @@ func TestTodoService_Update(t *testing.T) {
 
 	mockRepo := mock_repository.NewMockTodoRepository(ctrl)
 	mockCache := mock_cache.NewMockCacheable(ctrl)
-	service := NewTodoService(mockRepo, mockCache)
+	mockEvents := mock_service.NewMockEventRecorder(ctrl)
+	service := NewTodoService(mockRepo, mockCache, passThroughTransactor(ctrl), mockEvents, passThroughCustomFields(ctrl), noopRevisions(ctrl))
 
 	ctx := context.Background()
 	existingTodo := entity.Todo{ID: 1, Title: "Old Title", Content: "Old Content", Completed: false}
@@ -202,6 +221,7 @@ func TestTodoService_Update(t *testing.T) {
 	mockRepo.EXPECT().FindByID(ctx, int64(1)).Return(&existingTodo, nil)
 	mockRepo.EXPECT().Update(ctx, expectedUpdatedTodo).Return(expectedUpdatedTodo, nil)
 	mockCache.EXPECT().Delete("go-todo-api:todos:find-all").Return(nil)
+	mockEvents.EXPECT().Record(ctx, todoEvent(entity.EventTodoUpdated, expectedUpdatedTodo)).Return(nil)
 
 	updatedTodo, err := service.Update(ctx, 1, updateData)
 	assert.NoError(t, err)
@@ -230,7 +250,8 @@ func TestTodoService_Delete(t *testing.T) {
 
 	mockRepo := mock_repository.NewMockTodoRepository(ctrl)
 	mockCache := mock_cache.NewMockCacheable(ctrl)
-	service := NewTodoService(mockRepo, mockCache)
+	mockEvents := mock_service.NewMockEventRecorder(ctrl)
+	service := NewTodoService(mockRepo, mockCache, passThroughTransactor(ctrl), mockEvents, passThroughCustomFields(ctrl), noopRevisions(ctrl))
 
 	ctx := context.Background()
 
@@ -238,6 +259,7 @@ func TestTodoService_Delete(t *testing.T) {
 	mockRepo.EXPECT().FindByID(ctx, int64(1)).Return(&entity.Todo{ID: 1}, nil)
 	mockRepo.EXPECT().Delete(ctx, int64(1)).Return(nil)
 	mockCache.EXPECT().Delete("go-todo-api:todos:find-all").Return(nil)
+	mockEvents.EXPECT().Record(ctx, todoEvent(entity.EventTodoDeleted, entity.Todo{ID: 1})).Return(nil)
 
 	err := service.Delete(ctx, 1)
 	assert.NoError(t, err)
@@ -257,3 +279,124 @@ func TestTodoService_Delete(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, "gagal menghapus todo", err.Error())
 }
+
+func TestTodoService_Create_InvalidPriority(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockTodoRepository(ctrl)
+	mockCache := mock_cache.NewMockCacheable(ctrl)
+	mockEvents := mock_service.NewMockEventRecorder(ctrl)
+	service := NewTodoService(mockRepo, mockCache, passThroughTransactor(ctrl), mockEvents, passThroughCustomFields(ctrl), noopRevisions(ctrl))
+
+	// Repository tidak boleh dipanggil untuk prioritas yang tidak dikenal
+	_, err := service.Create(context.Background(), entity.Todo{Title: "New Todo", Priority: "critical"})
+	assert.ErrorIs(t, err, ErrPrioritasTidakValid)
+}
+
+func TestTodoService_Create_ParentOfAnotherUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockTodoRepository(ctrl)
+	mockCache := mock_cache.NewMockCacheable(ctrl)
+	mockEvents := mock_service.NewMockEventRecorder(ctrl)
+	service := NewTodoService(mockRepo, mockCache, passThroughTransactor(ctrl), mockEvents, passThroughCustomFields(ctrl), noopRevisions(ctrl))
+
+	// Subtask tidak boleh dibuat di bawah todo milik pengguna lain
+	ctx := context.Background()
+	parentID := int64(7)
+	mockRepo.EXPECT().FindByID(ctx, parentID).Return(&entity.Todo{ID: 7, UserID: 2}, nil)
+
+	_, err := service.Create(ctx, entity.Todo{Title: "Subtask", UserID: 1, ParentID: &parentID})
+	assert.ErrorIs(t, err, ErrIndukTidakValid)
+}
+
+func TestTodoService_Update_RecordsCompletedEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockTodoRepository(ctrl)
+	mockCache := mock_cache.NewMockCacheable(ctrl)
+	mockEvents := mock_service.NewMockEventRecorder(ctrl)
+	service := NewTodoService(mockRepo, mockCache, passThroughTransactor(ctrl), mockEvents, passThroughCustomFields(ctrl), noopRevisions(ctrl))
+
+	ctx := context.Background()
+	mockRepo.EXPECT().FindByID(ctx, int64(1)).Return(&entity.Todo{ID: 1, Title: "Todo"}, nil)
+	mockRepo.EXPECT().Update(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, todo entity.Todo) (entity.Todo, error) {
+		return todo, nil
+	})
+	mockCache.EXPECT().Delete("go-todo-api:todos:find-all").Return(nil)
+
+	var recorded []string
+	mockEvents.EXPECT().Record(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, event entity.DomainEvent) error {
+		recorded = append(recorded, event.Type)
+		return nil
+	}).Times(2)
+
+	_, err := service.Update(ctx, 1, entity.Todo{Completed: true})
+	assert.NoError(t, err)
+	// Perubahan menjadi selesai mencatat todo.updated lalu todo.completed
+	assert.Equal(t, []string{entity.EventTodoUpdated, entity.EventTodoCompleted}, recorded)
+}
+
+func TestTodoService_Update_MergesCustomFields(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockTodoRepository(ctrl)
+	mockCache := mock_cache.NewMockCacheable(ctrl)
+	mockEvents := mock_service.NewMockEventRecorder(ctrl)
+	mockCustomFields := mock_service.NewMockCustomFieldValidator(ctrl)
+	service := NewTodoService(mockRepo, mockCache, passThroughTransactor(ctrl), mockEvents, mockCustomFields, noopRevisions(ctrl))
+
+	ctx := context.Background()
+	existingTodo := entity.Todo{ID: 1, UserID: 5, Title: "Laporan", CustomFields: entity.CustomFieldValues{"status": "draft", "estimasi": 3.0}}
+	updateData := entity.Todo{CustomFields: entity.CustomFieldValues{"status": "review", "estimasi": nil}}
+
+	// Nilai null diteruskan ke validator agar dihapus, nilai lain digabung dengan yang lama
+	mockRepo.EXPECT().FindByID(ctx, int64(1)).Return(&existingTodo, nil)
+	mockCustomFields.EXPECT().ValidateValues(ctx, int64(5), entity.CustomFieldValues{"status": "review", "estimasi": nil}).
+		Return(entity.CustomFieldValues{"status": "review"}, nil)
+	mockRepo.EXPECT().Update(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, todo entity.Todo) (entity.Todo, error) {
+		assert.Equal(t, entity.CustomFieldValues{"status": "review"}, todo.CustomFields)
+		return todo, nil
+	})
+	mockEvents.EXPECT().Record(ctx, gomock.Any()).Return(nil)
+	mockCache.EXPECT().Delete("go-todo-api:todos:find-all").Return(nil)
+
+	_, err := service.Update(ctx, 1, updateData)
+	assert.NoError(t, err)
+
+	// Nilai yang tidak valid ditolak sebelum menyimpan
+	mockRepo.EXPECT().FindByID(ctx, int64(1)).Return(&entity.Todo{ID: 1, UserID: 5}, nil)
+	mockCustomFields.EXPECT().ValidateValues(ctx, int64(5), gomock.Any()).Return(nil, ErrNilaiFieldKustomTidakValid)
+
+	_, err = service.Update(ctx, 1, entity.Todo{CustomFields: entity.CustomFieldValues{"status": "selesai"}})
+	assert.ErrorIs(t, err, ErrNilaiFieldKustomTidakValid)
+}
+
+func TestTodoService_Replace_ClearsFieldsAndStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockTodoRepository(ctrl)
+	mockCache := mock_cache.NewMockCacheable(ctrl)
+	mockEvents := mock_service.NewMockEventRecorder(ctrl)
+	service := NewTodoService(mockRepo, mockCache, passThroughTransactor(ctrl), mockEvents, passThroughCustomFields(ctrl), noopRevisions(ctrl))
+
+	ctx := context.Background()
+	completedAt := time.Now()
+	existing := entity.Todo{ID: 1, UserID: 5, Title: "Laporan", Content: "Isi baru", Completed: true, CompletedAt: &completedAt}
+	expected := entity.Todo{ID: 1, UserID: 5, Title: "Laporan", Content: "", Completed: false}
+
+	// Berbeda dengan Update, konten kosong dan status belum selesai ikut disimpan
+	mockRepo.EXPECT().FindByID(ctx, int64(1)).Return(&existing, nil)
+	mockRepo.EXPECT().Update(ctx, expected).Return(expected, nil)
+	mockEvents.EXPECT().Record(ctx, todoEvent(entity.EventTodoUpdated, expected)).Return(nil)
+	mockCache.EXPECT().Delete("go-todo-api:todos:find-all").Return(nil)
+
+	replaced, err := service.Replace(ctx, 1, entity.Todo{ID: 1, UserID: 5, Title: "Laporan"})
+	assert.NoError(t, err)
+	assert.Equal(t, expected, replaced)
+}
@@ -0,0 +1,272 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"go-todo/configs"
+	"go-todo/internal/entity"
+	"go-todo/internal/repository"
+	"go-todo/pkg/token"
+	"net/url"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	ErrRefreshTokenTidakValid   = errors.New("refresh token tidak valid atau sudah kedaluwarsa")
+	ErrRefreshTokenDipakaiUlang = errors.New("refresh token sudah pernah digunakan, seluruh sesi terkait telah dicabut")
+)
+
+const (
+	// defaultAccessTokenTTL dan defaultRefreshTokenTTL dipakai jika konfigurasi JWT tidak diatur
+	defaultAccessTokenTTL  = 15 * time.Minute
+	defaultRefreshTokenTTL = 30 * 24 * time.Hour
+	tokenIssuer            = "aplikasi-todo"
+)
+
+// TokenService menerbitkan pasangan access token dan refresh token. Refresh token dirotasi
+// setiap kali dipakai; token lama yang dipakai lagi dianggap bocor sehingga seluruh
+// family-nya dicabut dan pengguna harus login ulang.
+type TokenService interface {
+	Issue(ctx context.Context, user *entity.User) (*entity.TokenPair, error)
+	Refresh(ctx context.Context, refreshToken string) (*entity.TokenPair, error)
+	// Logout mencabut access token saat ini dan, jika diberikan, family refresh token-nya
+	Logout(ctx context.Context, claims *token.JwtCustomClaims, refreshToken string) error
+	// RevokeAll mencabut seluruh access token dan refresh token milik pengguna
+	RevokeAll(ctx context.Context, userID int64) error
+}
+
+type tokenService struct {
+	tokenUseCase           token.TokenUseCase
+	refreshTokenRepository repository.RefreshTokenRepository
+	userRepository         repository.UserRepository
+	transactor             repository.Transactor
+	revocations            token.RevocationStore
+	authorization          AuthorizationService
+	accessTokenTTL         time.Duration
+	refreshTokenTTL        time.Duration
+}
+
+// NewTokenService membuat instance baru dari TokenService.
+// Masa berlaku yang tidak diatur diganti dengan nilai default.
+func NewTokenService(
+	tokenUseCase token.TokenUseCase,
+	refreshTokenRepository repository.RefreshTokenRepository,
+	userRepository repository.UserRepository,
+	transactor repository.Transactor,
+	revocations token.RevocationStore,
+	authorization AuthorizationService,
+	config configs.JWTConfig,
+) TokenService {
+	accessTokenTTL := time.Duration(config.AccessTokenTTLMinutes) * time.Minute
+	if accessTokenTTL <= 0 {
+		accessTokenTTL = defaultAccessTokenTTL
+	}
+	refreshTokenTTL := time.Duration(config.RefreshTokenTTLHours) * time.Hour
+	if refreshTokenTTL <= 0 {
+		refreshTokenTTL = defaultRefreshTokenTTL
+	}
+	return &tokenService{
+		tokenUseCase:           tokenUseCase,
+		refreshTokenRepository: refreshTokenRepository,
+		userRepository:         userRepository,
+		transactor:             transactor,
+		revocations:            revocations,
+		authorization:          authorization,
+		accessTokenTTL:         accessTokenTTL,
+		refreshTokenTTL:        refreshTokenTTL,
+	}
+}
+
+// Issue menerbitkan pasangan token untuk login baru dengan family refresh token baru
+func (s *tokenService) Issue(ctx context.Context, user *entity.User) (*entity.TokenPair, error) {
+	return s.issue(ctx, user, newOpaqueToken())
+}
+
+// Refresh menukar refresh token dengan pasangan token baru. Data pengguna dibaca ulang
+// sehingga perubahan role atau nama langsung tercermin pada access token baru.
+func (s *tokenService) Refresh(ctx context.Context, refreshToken string) (*entity.TokenPair, error) {
+	var pair *entity.TokenPair
+	reused := false
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		stored, err := s.refreshTokenRepository.FindByHashForUpdate(ctx, hashToken(refreshToken))
+		if errors.Is(err, repository.ErrRefreshTokenTidakDitemukan) {
+			return ErrRefreshTokenTidakValid
+		}
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		switch {
+		case stored.RevokedAt != nil:
+			return ErrRefreshTokenTidakValid
+		case stored.UsedAt != nil:
+			// Pencabutan harus tetap di-commit, sehingga transaksi tidak dikembalikan dengan error
+			if _, err := s.refreshTokenRepository.RevokeFamily(ctx, stored.FamilyID, now); err != nil {
+				return err
+			}
+			reused = true
+			return nil
+		case !now.Before(stored.ExpiresAt):
+			return ErrRefreshTokenTidakValid
+		}
+
+		user, err := s.userRepository.FindByID(ctx, stored.UserID)
+		if errors.Is(err, repository.ErrPenggunaTidakDitemukan) {
+			return ErrRefreshTokenTidakValid
+		}
+		if err != nil {
+			return err
+		}
+		if err := s.refreshTokenRepository.MarkUsed(ctx, stored.ID, now); err != nil {
+			return err
+		}
+		pair, err = s.issue(ctx, user, stored.FamilyID)
+		return err
+	})
+	if reused {
+		fmt.Printf("peringatan: refresh token family dipakai ulang dan dicabut\n")
+		return nil, ErrRefreshTokenDipakaiUlang
+	}
+	if err != nil {
+		if errors.Is(err, ErrRefreshTokenTidakValid) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("gagal memperbarui token: %w", err)
+	}
+	return pair, nil
+}
+
+// Logout mencabut access token yang sedang dipakai. Refresh token milik pengguna lain
+// atau yang sudah tidak dikenal diabaikan sehingga logout selalu dapat diulang.
+func (s *tokenService) Logout(ctx context.Context, claims *token.JwtCustomClaims, refreshToken string) error {
+	if claims.ID != "" && claims.ExpiresAt != nil {
+		if err := s.revocations.Revoke(ctx, claims.ID, claims.ExpiresAt.Time); err != nil {
+			return fmt.Errorf("gagal logout: %w", err)
+		}
+	}
+	if refreshToken == "" {
+		return nil
+	}
+
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		stored, err := s.refreshTokenRepository.FindByHashForUpdate(ctx, hashToken(refreshToken))
+		if errors.Is(err, repository.ErrRefreshTokenTidakDitemukan) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if stored.UserID != claims.UserID {
+			return nil
+		}
+		_, err = s.refreshTokenRepository.RevokeFamily(ctx, stored.FamilyID, time.Now())
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("gagal logout: %w", err)
+	}
+	return nil
+}
+
+// RevokeAll mencabut seluruh refresh token pengguna lalu menaikkan generation token-nya
+// sehingga access token yang sudah terbit ikut ditolak oleh JWTMiddleware. Jika dipanggil
+// di dalam transaksi, kegagalan Redis ikut membatalkan perubahan yang memicunya.
+func (s *tokenService) RevokeAll(ctx context.Context, userID int64) error {
+	if _, err := s.refreshTokenRepository.RevokeByUserID(ctx, userID, time.Now()); err != nil {
+		return fmt.Errorf("gagal mencabut sesi pengguna: %w", err)
+	}
+	if _, err := s.revocations.BumpGeneration(ctx, userID); err != nil {
+		return fmt.Errorf("gagal mencabut sesi pengguna: %w", err)
+	}
+	return nil
+}
+
+// issue membuat access token dan menyimpan refresh token baru dalam family yang diberikan
+func (s *tokenService) issue(ctx context.Context, user *entity.User, familyID string) (*entity.TokenPair, error) {
+	generation, err := s.revocations.Generation(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("gagal membuat token: %w", err)
+	}
+	authorization, err := s.authorization.Lookup(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("gagal membuat token: %w", err)
+	}
+
+	now := time.Now()
+	accessExpiresAt := now.Add(s.accessTokenTTL)
+	claims := token.JwtCustomClaims{
+		UserID:      user.ID,
+		Username:    user.Username,
+		Roles:       authorization.Roles,
+		Permissions: authorization.Permissions,
+		FullName:    user.FullName,
+		Generation:  generation,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        newOpaqueToken(),
+			Issuer:    tokenIssuer,
+			ExpiresAt: jwt.NewNumericDate(accessExpiresAt),
+			IssuedAt:  jwt.NewNumericDate(now),
+		},
+	}
+	accessToken, err := s.tokenUseCase.GenerateAccessToken(claims)
+	if err != nil {
+		return nil, fmt.Errorf("gagal membuat token: %w", err)
+	}
+
+	refreshToken := newOpaqueToken()
+	stored := &entity.RefreshToken{
+		UserID:    user.ID,
+		FamilyID:  familyID,
+		TokenHash: hashToken(refreshToken),
+		ExpiresAt: now.Add(s.refreshTokenTTL),
+		CreatedAt: now,
+	}
+	if err := s.refreshTokenRepository.Create(ctx, stored); err != nil {
+		return nil, fmt.Errorf("gagal menyimpan refresh token: %w", err)
+	}
+
+	return &entity.TokenPair{
+		AccessToken:           accessToken,
+		TokenType:             "Bearer",
+		ExpiresAt:             accessExpiresAt,
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: stored.ExpiresAt,
+	}, nil
+}
+
+// newOpaqueToken membuat token acak 256-bit yang aman dimuat di URL
+func newOpaqueToken() string {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		panic(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf)
+}
+
+// hashToken menghasilkan hash SHA-256 dari token opaque untuk disimpan di database.
+// Token sudah acak 256-bit sehingga tidak perlu hash lambat seperti bcrypt.
+func hashToken(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
+
+// tokenURL menambahkan token opaque ke query string URL halaman frontend, misalnya untuk
+// link reset password atau verifikasi email
+func tokenURL(base, value string) string {
+	link, err := url.Parse(base)
+	if err != nil {
+		return base + "?token=" + url.QueryEscape(value)
+	}
+	query := link.Query()
+	query.Set("token", value)
+	link.RawQuery = query.Encode()
+	return link.String()
+}
@@ -0,0 +1,194 @@
+package service
+
+import (
+	"context"
+	"go-todo/configs"
+	"go-todo/internal/entity"
+	"go-todo/internal/repository"
+	"go-todo/pkg/token"
+	mock_token "go-todo/test/mock/pkg/token"
+	mock_repository "go-todo/test/mock/repository"
+	mock_service "go-todo/test/mock/service"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+type tokenServiceMocks struct {
+	tokenUseCase *mock_token.MockTokenUseCase
+	refreshRepo  *mock_repository.MockRefreshTokenRepository
+	userRepo     *mock_repository.MockUserRepository
+	revocations  *mock_token.MockRevocationStore
+	authz        *mock_service.MockAuthorizationService
+}
+
+func setupTokenService(ctrl *gomock.Controller) (TokenService, *tokenServiceMocks) {
+	m := &tokenServiceMocks{
+		tokenUseCase: mock_token.NewMockTokenUseCase(ctrl),
+		refreshRepo:  mock_repository.NewMockRefreshTokenRepository(ctrl),
+		userRepo:     mock_repository.NewMockUserRepository(ctrl),
+		revocations:  mock_token.NewMockRevocationStore(ctrl),
+		authz:        mock_service.NewMockAuthorizationService(ctrl),
+	}
+	service := NewTokenService(m.tokenUseCase, m.refreshRepo, m.userRepo, passThroughTransactor(ctrl), m.revocations, m.authz,
+		configs.JWTConfig{AccessTokenTTLMinutes: 10, RefreshTokenTTLHours: 24})
+	return service, m
+}
+
+func TestTokenService_Issue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, m := setupTokenService(ctrl)
+	ctx := context.Background()
+	user := &entity.User{ID: 1, Username: "budi"}
+
+	var stored *entity.RefreshToken
+	m.revocations.EXPECT().Generation(ctx, int64(1)).Return(int64(3), nil)
+	m.authz.EXPECT().Lookup(ctx, int64(1)).Return(&entity.Authorization{Roles: []string{"user"}, Permissions: []string{"todo:read", "todo:write"}}, nil)
+	m.tokenUseCase.EXPECT().GenerateAccessToken(gomock.Any()).DoAndReturn(func(claims token.JwtCustomClaims) (string, error) {
+		// Setiap token membawa jti unik, generation pengguna saat ini, serta role dan izinnya
+		assert.NotEmpty(t, claims.ID)
+		assert.Equal(t, int64(3), claims.Generation)
+		assert.Equal(t, []string{"user"}, claims.Roles)
+		assert.Equal(t, []string{"todo:read", "todo:write"}, claims.Permissions)
+		return "access", nil
+	})
+	m.refreshRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, refreshToken *entity.RefreshToken) error {
+		stored = refreshToken
+		return nil
+	})
+
+	pair, err := service.Issue(ctx, user)
+	assert.NoError(t, err)
+	assert.Equal(t, "access", pair.AccessToken)
+	assert.WithinDuration(t, time.Now().Add(10*time.Minute), pair.ExpiresAt, time.Minute)
+	assert.WithinDuration(t, time.Now().Add(24*time.Hour), pair.RefreshTokenExpiresAt, time.Minute)
+
+	// Hanya hash yang disimpan, bukan token aslinya
+	assert.NotEqual(t, pair.RefreshToken, stored.TokenHash)
+	assert.Equal(t, hashToken(pair.RefreshToken), stored.TokenHash)
+	assert.NotEmpty(t, stored.FamilyID)
+}
+
+func TestTokenService_Refresh_RotatesWithinFamily(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, m := setupTokenService(ctrl)
+	ctx := context.Background()
+	stored := &entity.RefreshToken{ID: 7, UserID: 1, FamilyID: "fam", ExpiresAt: time.Now().Add(time.Hour)}
+
+	m.refreshRepo.EXPECT().FindByHashForUpdate(ctx, hashToken("lama")).Return(stored, nil)
+	m.userRepo.EXPECT().FindByID(ctx, int64(1)).Return(&entity.User{ID: 1}, nil)
+	m.refreshRepo.EXPECT().MarkUsed(ctx, int64(7), gomock.Any()).Return(nil)
+	m.revocations.EXPECT().Generation(ctx, int64(1)).Return(int64(0), nil)
+	m.authz.EXPECT().Lookup(ctx, int64(1)).Return(&entity.Authorization{Roles: []string{"admin"}, Permissions: []string{"*"}}, nil)
+	m.tokenUseCase.EXPECT().GenerateAccessToken(gomock.Any()).Return("access-baru", nil)
+	m.refreshRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, refreshToken *entity.RefreshToken) error {
+		assert.Equal(t, "fam", refreshToken.FamilyID)
+		return nil
+	})
+
+	pair, err := service.Refresh(ctx, "lama")
+	assert.NoError(t, err)
+	assert.Equal(t, "access-baru", pair.AccessToken)
+	assert.NotEqual(t, "lama", pair.RefreshToken)
+}
+
+func TestTokenService_Refresh_ReuseRevokesFamily(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, m := setupTokenService(ctrl)
+	ctx := context.Background()
+	usedAt := time.Now().Add(-time.Minute)
+	stored := &entity.RefreshToken{ID: 7, UserID: 1, FamilyID: "fam", ExpiresAt: time.Now().Add(time.Hour), UsedAt: &usedAt}
+
+	m.refreshRepo.EXPECT().FindByHashForUpdate(ctx, hashToken("lama")).Return(stored, nil)
+	m.refreshRepo.EXPECT().RevokeFamily(ctx, "fam", gomock.Any()).Return(int64(2), nil)
+
+	_, err := service.Refresh(ctx, "lama")
+	assert.ErrorIs(t, err, ErrRefreshTokenDipakaiUlang)
+}
+
+func TestTokenService_Refresh_Invalid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, m := setupTokenService(ctrl)
+	ctx := context.Background()
+	revokedAt := time.Now()
+
+	// Token tidak dikenal
+	m.refreshRepo.EXPECT().FindByHashForUpdate(ctx, hashToken("acak")).Return(nil, repository.ErrRefreshTokenTidakDitemukan)
+	_, err := service.Refresh(ctx, "acak")
+	assert.ErrorIs(t, err, ErrRefreshTokenTidakValid)
+
+	// Token kedaluwarsa
+	m.refreshRepo.EXPECT().FindByHashForUpdate(ctx, hashToken("lama")).
+		Return(&entity.RefreshToken{ID: 7, ExpiresAt: time.Now().Add(-time.Second)}, nil)
+	_, err = service.Refresh(ctx, "lama")
+	assert.ErrorIs(t, err, ErrRefreshTokenTidakValid)
+
+	// Family sudah dicabut
+	m.refreshRepo.EXPECT().FindByHashForUpdate(ctx, hashToken("dicabut")).
+		Return(&entity.RefreshToken{ID: 8, ExpiresAt: time.Now().Add(time.Hour), RevokedAt: &revokedAt}, nil)
+	_, err = service.Refresh(ctx, "dicabut")
+	assert.ErrorIs(t, err, ErrRefreshTokenTidakValid)
+}
+
+func TestTokenService_Logout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, m := setupTokenService(ctrl)
+	ctx := context.Background()
+	expiresAt := time.Now().Add(5 * time.Minute)
+	claims := &token.JwtCustomClaims{UserID: 1}
+	claims.ID = "jti-1"
+	claims.ExpiresAt = jwt.NewNumericDate(expiresAt)
+
+	m.revocations.EXPECT().Revoke(ctx, "jti-1", claims.ExpiresAt.Time).Return(nil)
+	m.refreshRepo.EXPECT().FindByHashForUpdate(ctx, hashToken("refresh")).
+		Return(&entity.RefreshToken{ID: 7, UserID: 1, FamilyID: "fam"}, nil)
+	m.refreshRepo.EXPECT().RevokeFamily(ctx, "fam", gomock.Any()).Return(int64(1), nil)
+
+	assert.NoError(t, service.Logout(ctx, claims, "refresh"))
+}
+
+func TestTokenService_Logout_IgnoresForeignRefreshToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, m := setupTokenService(ctrl)
+	ctx := context.Background()
+	claims := &token.JwtCustomClaims{UserID: 1}
+	claims.ID = "jti-1"
+	claims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(time.Minute))
+
+	m.revocations.EXPECT().Revoke(ctx, "jti-1", gomock.Any()).Return(nil)
+	// Refresh token milik pengguna lain tidak boleh dicabut oleh permintaan ini
+	m.refreshRepo.EXPECT().FindByHashForUpdate(ctx, hashToken("milik-orang-lain")).
+		Return(&entity.RefreshToken{ID: 9, UserID: 2, FamilyID: "fam-lain"}, nil)
+
+	assert.NoError(t, service.Logout(ctx, claims, "milik-orang-lain"))
+}
+
+func TestTokenService_RevokeAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, m := setupTokenService(ctrl)
+	ctx := context.Background()
+
+	gomock.InOrder(
+		m.refreshRepo.EXPECT().RevokeByUserID(ctx, int64(1), gomock.Any()).Return(int64(2), nil),
+		m.revocations.EXPECT().BumpGeneration(ctx, int64(1)).Return(int64(4), nil),
+	)
+
+	assert.NoError(t, service.RevokeAll(ctx, 1))
+}
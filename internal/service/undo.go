@@ -0,0 +1,234 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-todo/configs"
+	"go-todo/internal/entity"
+	"go-todo/internal/repository"
+	"go-todo/pkg/cache"
+	"time"
+)
+
+var (
+	ErrTokenUndoTidakValid = errors.New("token undo tidak valid atau sudah kedaluwarsa")
+	ErrUndoKonflik         = errors.New("todo sudah berubah sejak aksi tersebut sehingga tidak dapat dibatalkan")
+)
+
+// defaultUndoWindow dipakai jika UNDO_WINDOW_SECONDS tidak diatur
+const defaultUndoWindow = 30 * time.Second
+
+// UndoService menjalankan perubahan dan penghapusan todo sambil menyimpan keadaan
+// sebelumnya di cache, sehingga aksi tersebut dapat dibatalkan selama jendela undo.
+type UndoService interface {
+	UpdateTodo(ctx context.Context, actorID, id int64, todo entity.Todo) (entity.Todo, *entity.UndoToken, error)
+	DeleteTodo(ctx context.Context, actorID, id int64) (*entity.UndoToken, error)
+	Undo(ctx context.Context, actorID int64, token string) (entity.Todo, error)
+}
+
+type undoService struct {
+	todoRepository repository.TodoRepository
+	syncRepository repository.SyncRepository
+	todoService    TodoService
+	cacheable      cache.Cacheable
+	transactor     repository.Transactor
+	window         time.Duration
+}
+
+// NewUndoService membuat instance baru dari UndoService
+func NewUndoService(
+	todoRepository repository.TodoRepository,
+	syncRepository repository.SyncRepository,
+	todoService TodoService,
+	cacheable cache.Cacheable,
+	transactor repository.Transactor,
+	config configs.UndoConfig,
+) UndoService {
+	window := time.Duration(config.WindowSeconds) * time.Second
+	if window <= 0 {
+		window = defaultUndoWindow
+	}
+	return &undoService{
+		todoRepository: todoRepository,
+		syncRepository: syncRepository,
+		todoService:    todoService,
+		cacheable:      cacheable,
+		transactor:     transactor,
+		window:         window,
+	}
+}
+
+// UpdateTodo memperbarui todo melalui TodoService dan mengembalikan token untuk membatalkannya.
+// Token bernilai nil jika keadaan undo gagal disimpan; perubahan todo tetap berhasil.
+func (s *undoService) UpdateTodo(ctx context.Context, actorID, id int64, todo entity.Todo) (entity.Todo, *entity.UndoToken, error) {
+	before, err := s.todoRepository.FindByID(ctx, id)
+	if err != nil {
+		return entity.Todo{}, nil, errors.New("todo tidak ditemukan")
+	}
+	updated, err := s.todoService.Update(ctx, id, todo)
+	if err != nil {
+		return entity.Todo{}, nil, err
+	}
+
+	// Trigger sinkronisasi menaikkan versi tepat satu kali untuk setiap UPDATE
+	token := s.remember(entity.UndoAction{
+		Type:    entity.UndoActionUpdate,
+		ActorID: actorID,
+		Version: before.Version + 1,
+		Todos:   []entity.Todo{*before},
+	})
+	return updated, token, nil
+}
+
+// DeleteTodo menghapus todo beserta subtask-nya dan mengembalikan token untuk memulihkannya.
+// Catatan waktu, revisi, dan notifikasi yang ikut terhapus tidak dipulihkan.
+func (s *undoService) DeleteTodo(ctx context.Context, actorID, id int64) (*entity.UndoToken, error) {
+	existing, err := s.todoRepository.FindByID(ctx, id)
+	if err != nil {
+		return nil, errors.New("todo tidak ditemukan")
+	}
+	todos, err := s.withSubtasks(ctx, *existing)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.todoService.Delete(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return s.remember(entity.UndoAction{
+		Type:    entity.UndoActionDelete,
+		ActorID: actorID,
+		Todos:   todos,
+	}), nil
+}
+
+// withSubtasks mengumpulkan todo beserta seluruh turunannya per tingkat, induk lebih dulu
+func (s *undoService) withSubtasks(ctx context.Context, todo entity.Todo) ([]entity.Todo, error) {
+	todos := []entity.Todo{todo}
+	parentIDs := []int64{todo.ID}
+	for len(parentIDs) > 0 {
+		children, err := s.todoRepository.FindByCondition(ctx, todo.UserID, "parent_id IN ?", parentIDs)
+		if err != nil {
+			return nil, fmt.Errorf("gagal mengambil subtask: %w", err)
+		}
+		parentIDs = parentIDs[:0]
+		for _, child := range children {
+			todos = append(todos, child)
+			parentIDs = append(parentIDs, child.ID)
+		}
+	}
+	return todos, nil
+}
+
+// remember menyimpan keadaan undo di cache. Kegagalan hanya dicatat karena aksi utama sudah berhasil.
+func (s *undoService) remember(action entity.UndoAction) *entity.UndoToken {
+	token := newUndoToken()
+	if err := s.cacheable.Set(undoKey(token), action, s.window); err != nil {
+		fmt.Printf("kesalahan menyimpan token undo: %v\n", err)
+		return nil
+	}
+	return &entity.UndoToken{Token: token, ExpiresAt: time.Now().Add(s.window)}
+}
+
+// Undo membatalkan aksi yang diwakili token. Token hanya berlaku untuk pengguna yang
+// melakukan aksi dan hangus setelah berhasil dipakai. Jika todo sudah berubah atau dibuat
+// ulang sejak aksi tersebut, undo ditolak agar perubahan yang lebih baru tidak tertimpa.
+func (s *undoService) Undo(ctx context.Context, actorID int64, token string) (entity.Todo, error) {
+	data, err := s.cacheable.Get(undoKey(token))
+	if err != nil {
+		return entity.Todo{}, fmt.Errorf("gagal membaca token undo: %w", err)
+	}
+	var action entity.UndoAction
+	if data == "" || json.Unmarshal([]byte(data), &action) != nil || action.ActorID != actorID || len(action.Todos) == 0 {
+		return entity.Todo{}, ErrTokenUndoTidakValid
+	}
+
+	var restored entity.Todo
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		switch action.Type {
+		case entity.UndoActionUpdate:
+			restored, err = s.undoUpdate(ctx, action)
+		case entity.UndoActionDelete:
+			restored, err = s.undoDelete(ctx, action)
+		default:
+			err = ErrTokenUndoTidakValid
+		}
+		return err
+	})
+	if err != nil {
+		return entity.Todo{}, err
+	}
+
+	// Token hangus setelah dipakai. Pemakaian ganda yang berjalan bersamaan tidak memulihkan
+	// dua kali: versi diperiksa pada baris yang terkunci dan ID todo yang dipulihkan unik.
+	s.cacheable.Delete(undoKey(token))
+	return restored, nil
+}
+
+func (s *undoService) undoUpdate(ctx context.Context, action entity.UndoAction) (entity.Todo, error) {
+	before := action.Todos[0]
+	current, err := s.syncRepository.LockTodo(ctx, before.UserID, before.ID, "")
+	if errors.Is(err, repository.ErrTodoTidakDitemukan) {
+		return entity.Todo{}, ErrUndoKonflik
+	}
+	if err != nil {
+		return entity.Todo{}, fmt.Errorf("gagal mengambil todo: %w", err)
+	}
+	if current.Version != action.Version {
+		return entity.Todo{}, ErrUndoKonflik
+	}
+	return s.todoService.Replace(ctx, before.ID, before)
+}
+
+func (s *undoService) undoDelete(ctx context.Context, action entity.UndoAction) (entity.Todo, error) {
+	root := action.Todos[0]
+	if _, err := s.todoRepository.FindByID(ctx, root.ID); err == nil {
+		return entity.Todo{}, ErrUndoKonflik
+	}
+
+	// Tombstone dari penghapusan sebelumnya dibuang agar todo yang dipulihkan dapat dihapus lagi
+	ids := make([]int64, len(action.Todos))
+	for i, todo := range action.Todos {
+		ids[i] = todo.ID
+	}
+	if err := s.syncRepository.DeleteTombstones(ctx, ids); err != nil {
+		return entity.Todo{}, fmt.Errorf("gagal menghapus tombstone: %w", err)
+	}
+
+	var restored entity.Todo
+	for i, todo := range action.Todos {
+		created, err := s.todoService.Create(ctx, todo)
+		if err != nil {
+			return entity.Todo{}, err
+		}
+		// Penanggung jawab tidak ikut disimpan saat INSERT sehingga dipulihkan terpisah
+		if todo.AssigneeID != nil {
+			if err := s.todoRepository.UpdateAssignee(ctx, created.ID, todo.AssigneeID); err != nil {
+				return entity.Todo{}, fmt.Errorf("gagal memulihkan penanggung jawab: %w", err)
+			}
+			created.AssigneeID = todo.AssigneeID
+		}
+		if i == 0 {
+			restored = created
+		}
+	}
+	return restored, nil
+}
+
+func undoKey(token string) string {
+	return "go-todo-api:undo:" + token
+}
+
+// newUndoToken membuat token acak yang tidak dapat ditebak
+func newUndoToken() string {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(buf)
+}
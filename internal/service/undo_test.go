@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"go-todo/configs"
+	"go-todo/internal/entity"
+	"go-todo/internal/repository"
+	mock_cache "go-todo/test/mock/pkg/cache"
+	mock_repository "go-todo/test/mock/repository"
+	mock_service "go-todo/test/mock/service"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+type undoMocks struct {
+	todoRepo    *mock_repository.MockTodoRepository
+	syncRepo    *mock_repository.MockSyncRepository
+	todoService *mock_service.MockTodoService
+	cache       *mock_cache.MockCacheable
+}
+
+func newUndoServiceForTest(ctrl *gomock.Controller) (UndoService, undoMocks) {
+	mocks := undoMocks{
+		todoRepo:    mock_repository.NewMockTodoRepository(ctrl),
+		syncRepo:    mock_repository.NewMockSyncRepository(ctrl),
+		todoService: mock_service.NewMockTodoService(ctrl),
+		cache:       mock_cache.NewMockCacheable(ctrl),
+	}
+	service := NewUndoService(mocks.todoRepo, mocks.syncRepo, mocks.todoService, mocks.cache,
+		passThroughTransactor(ctrl), configs.UndoConfig{WindowSeconds: 60})
+	return service, mocks
+}
+
+// cachedUndo mengembalikan isi cache seperti yang disimpan oleh Cacheable.Set
+func cachedUndo(action entity.UndoAction) string {
+	data, _ := json.Marshal(action)
+	return string(data)
+}
+
+func TestUndoService_UpdateTodo_RemembersPreviousState(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, mocks := newUndoServiceForTest(ctrl)
+	ctx := context.Background()
+	before := entity.Todo{ID: 1, UserID: 5, Title: "Laporan", Version: 4}
+
+	mocks.todoRepo.EXPECT().FindByID(ctx, int64(1)).Return(&before, nil)
+	mocks.todoService.EXPECT().Update(ctx, int64(1), entity.Todo{Completed: true}).Return(entity.Todo{ID: 1, Completed: true}, nil)
+	mocks.cache.EXPECT().Set(gomock.Any(), gomock.Any(), time.Minute).DoAndReturn(
+		func(key string, value interface{}, duration time.Duration) error {
+			action := value.(entity.UndoAction)
+			assert.True(t, strings.HasPrefix(key, "go-todo-api:undo:"))
+			assert.Equal(t, entity.UndoActionUpdate, action.Type)
+			assert.Equal(t, int64(5), action.ActorID)
+			assert.Equal(t, int64(5), action.Version)
+			assert.Equal(t, []entity.Todo{before}, action.Todos)
+			return nil
+		})
+
+	updated, token, err := service.UpdateTodo(ctx, 5, 1, entity.Todo{Completed: true})
+	assert.NoError(t, err)
+	assert.True(t, updated.Completed)
+	assert.NotNil(t, token)
+	assert.Len(t, token.Token, 32)
+}
+
+func TestUndoService_Undo_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, mocks := newUndoServiceForTest(ctrl)
+	ctx := context.Background()
+	before := entity.Todo{ID: 1, UserID: 5, Title: "Laporan", Completed: false}
+	action := entity.UndoAction{Type: entity.UndoActionUpdate, ActorID: 5, Version: 5, Todos: []entity.Todo{before}}
+
+	mocks.cache.EXPECT().Get("go-todo-api:undo:abc").Return(cachedUndo(action), nil)
+	mocks.syncRepo.EXPECT().LockTodo(ctx, int64(5), int64(1), "").Return(&entity.Todo{ID: 1, Version: 5, Completed: true}, nil)
+	mocks.todoService.EXPECT().Replace(ctx, int64(1), gomock.Any()).Return(before, nil)
+	mocks.cache.EXPECT().Delete("go-todo-api:undo:abc").Return(nil)
+
+	restored, err := service.Undo(ctx, 5, "abc")
+	assert.NoError(t, err)
+	assert.False(t, restored.Completed)
+}
+
+func TestUndoService_Undo_UpdateChangedAgain(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, mocks := newUndoServiceForTest(ctrl)
+	ctx := context.Background()
+	action := entity.UndoAction{Type: entity.UndoActionUpdate, ActorID: 5, Version: 5, Todos: []entity.Todo{{ID: 1, UserID: 5}}}
+
+	// Versi 6 berarti todo sudah diubah lagi setelah aksi yang ingin dibatalkan
+	mocks.cache.EXPECT().Get("go-todo-api:undo:abc").Return(cachedUndo(action), nil)
+	mocks.syncRepo.EXPECT().LockTodo(ctx, int64(5), int64(1), "").Return(&entity.Todo{ID: 1, Version: 6}, nil)
+
+	_, err := service.Undo(ctx, 5, "abc")
+	assert.ErrorIs(t, err, ErrUndoKonflik)
+
+	// Todo yang sudah dihapus juga tidak dapat dibatalkan perubahannya
+	mocks.cache.EXPECT().Get("go-todo-api:undo:abc").Return(cachedUndo(action), nil)
+	mocks.syncRepo.EXPECT().LockTodo(ctx, int64(5), int64(1), "").Return(nil, repository.ErrTodoTidakDitemukan)
+
+	_, err = service.Undo(ctx, 5, "abc")
+	assert.ErrorIs(t, err, ErrUndoKonflik)
+}
+
+func TestUndoService_Undo_InvalidToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, mocks := newUndoServiceForTest(ctrl)
+	ctx := context.Background()
+	action := entity.UndoAction{Type: entity.UndoActionDelete, ActorID: 5, Todos: []entity.Todo{{ID: 1, UserID: 5}}}
+
+	// Token kedaluwarsa
+	mocks.cache.EXPECT().Get("go-todo-api:undo:abc").Return("", nil)
+	_, err := service.Undo(ctx, 5, "abc")
+	assert.ErrorIs(t, err, ErrTokenUndoTidakValid)
+
+	// Token milik pengguna lain
+	mocks.cache.EXPECT().Get("go-todo-api:undo:abc").Return(cachedUndo(action), nil)
+	_, err = service.Undo(ctx, 6, "abc")
+	assert.ErrorIs(t, err, ErrTokenUndoTidakValid)
+}
+
+func TestUndoService_DeleteTodo_AndUndo(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, mocks := newUndoServiceForTest(ctrl)
+	ctx := context.Background()
+	assignee := int64(8)
+	parent := entity.Todo{ID: 1, UserID: 5, Title: "Rilis", AssigneeID: &assignee}
+	parentID := int64(1)
+	child := entity.Todo{ID: 2, UserID: 5, Title: "Uji", ParentID: &parentID}
+
+	var stored entity.UndoAction
+	mocks.todoRepo.EXPECT().FindByID(ctx, int64(1)).Return(&parent, nil)
+	mocks.todoRepo.EXPECT().FindByCondition(ctx, int64(5), "parent_id IN ?", []int64{1}).Return([]entity.Todo{child}, nil)
+	mocks.todoRepo.EXPECT().FindByCondition(ctx, int64(5), "parent_id IN ?", []int64{2}).Return([]entity.Todo{}, nil)
+	mocks.todoService.EXPECT().Delete(ctx, int64(1)).Return(nil)
+	mocks.cache.EXPECT().Set(gomock.Any(), gomock.Any(), time.Minute).DoAndReturn(
+		func(key string, value interface{}, duration time.Duration) error {
+			stored = value.(entity.UndoAction)
+			return nil
+		})
+
+	token, err := service.DeleteTodo(ctx, 5, 1)
+	assert.NoError(t, err)
+	assert.NotNil(t, token)
+	assert.Equal(t, []entity.Todo{parent, child}, stored.Todos)
+
+	// Induk dipulihkan lebih dulu dengan ID yang sama, kemudian subtask dan penanggung jawabnya
+	mocks.cache.EXPECT().Get("go-todo-api:undo:"+token.Token).Return(cachedUndo(stored), nil)
+	mocks.todoRepo.EXPECT().FindByID(ctx, int64(1)).Return(nil, repository.ErrTodoTidakDitemukan)
+	gomock.InOrder(
+		mocks.syncRepo.EXPECT().DeleteTombstones(ctx, []int64{1, 2}).Return(nil),
+		mocks.todoService.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, todo entity.Todo) (entity.Todo, error) {
+			assert.Equal(t, int64(1), todo.ID)
+			return todo, nil
+		}),
+		mocks.todoRepo.EXPECT().UpdateAssignee(ctx, int64(1), &assignee).Return(nil),
+		mocks.todoService.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, todo entity.Todo) (entity.Todo, error) {
+			assert.Equal(t, int64(2), todo.ID)
+			return todo, nil
+		}),
+	)
+	mocks.cache.EXPECT().Delete("go-todo-api:undo:" + token.Token).Return(nil)
+
+	restored, err := service.Undo(ctx, 5, token.Token)
+	assert.NoError(t, err)
+	assert.Equal(t, "Rilis", restored.Title)
+}
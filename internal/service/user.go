@@ -8,10 +8,10 @@ import (
 	"go-todo/internal/entity"
 	"go-todo/internal/repository"
 	"go-todo/pkg/cache"
-	"go-todo/pkg/token"
+	"go-todo/pkg/password"
+	"sync"
 	"time"
 
-	"github.com/golang-jwt/jwt/v5"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -22,30 +22,74 @@ var (
 	ErrUsernameSudahAda = errors.New("username sudah digunakan")
 )
 
+var (
+	ErrRoleTidakValid          = errors.New("role tidak dikenal")
+	ErrRoleDefaultTidakDicabut = errors.New("role default tidak dapat dicabut")
+	ErrAdminTerakhir           = errors.New("role admin tidak dapat dicabut dari admin terakhir")
+	ErrAdminSudahAda           = errors.New("admin sudah ada, gunakan endpoint role untuk menambah admin")
+
+	// errRoleTidakBerubah membatalkan transaksi ketika pengguna memang tidak memiliki role yang dicabut
+	errRoleTidakBerubah = errors.New("role tidak berubah")
+)
+
 type UserService interface {
 	FindAll(ctx context.Context) ([]entity.User, error)
-	Login(ctx context.Context, username, password string) (string, error)
+	// Login memeriksa password lalu mengembalikan token, atau challenge jika pengguna memakai TOTP
+	Login(ctx context.Context, username, password, ip string) (*entity.LoginResult, error)
+	// LoginMFA menukar challenge dari Login dan kode TOTP atau recovery code dengan token
+	LoginMFA(ctx context.Context, challengeToken, code, ip string) (*entity.TokenPair, error)
 	CreateUser(ctx context.Context, user *entity.User) (*entity.User, error)
 	UpdateUser(ctx context.Context, user *entity.User) (*entity.User, error)
 	DeleteUser(ctx context.Context, id int64) error
+	GrantRole(ctx context.Context, actorID, id int64, role string) (*entity.User, error)
+	RevokeRole(ctx context.Context, actorID, id int64, role string) (*entity.User, error)
+	BootstrapAdmin(ctx context.Context, user *entity.User) (*entity.User, error)
+	UnlockLogin(ctx context.Context, actorID, id int64) error
 }
 
 type userService struct {
-	userRepository repository.UserRepository
-	tokenUseCase   token.TokenUseCase
-	cacheable      cache.Cacheable
+	userRepository       repository.UserRepository
+	roleRepository       repository.RoleRepository
+	tokenService         TokenService
+	authorization        AuthorizationService
+	cacheable            cache.Cacheable
+	loginEventRepository repository.LoginEventRepository
+	loginGuard           LoginGuard
+	passwordPolicy       *password.Policy
+	emailVerification    EmailVerificationService
+	mfa                  MFAService
+	transactor           repository.Transactor
+	events               EventRecorder
 }
 
 // NewUserService membuat instance baru dari UserService
 func NewUserService(
 	userRepository repository.UserRepository,
-	tokenUseCase token.TokenUseCase,
+	roleRepository repository.RoleRepository,
+	tokenService TokenService,
+	authorization AuthorizationService,
 	cacheable cache.Cacheable,
+	loginEventRepository repository.LoginEventRepository,
+	loginGuard LoginGuard,
+	passwordPolicy *password.Policy,
+	emailVerification EmailVerificationService,
+	mfa MFAService,
+	transactor repository.Transactor,
+	events EventRecorder,
 ) UserService {
 	return &userService{
-		userRepository: userRepository,
-		tokenUseCase:  tokenUseCase,
-		cacheable:     cacheable,
+		userRepository:       userRepository,
+		roleRepository:       roleRepository,
+		tokenService:         tokenService,
+		authorization:        authorization,
+		cacheable:            cacheable,
+		loginEventRepository: loginEventRepository,
+		loginGuard:           loginGuard,
+		passwordPolicy:       passwordPolicy,
+		emailVerification:    emailVerification,
+		mfa:                  mfa,
+		transactor:           transactor,
+		events:               events,
 	}
 }
 
@@ -68,6 +112,19 @@ func (s *userService) FindAll(ctx context.Context) ([]entity.User, error) {
 		return nil, fmt.Errorf("gagal mengambil data pengguna: %w", err)
 	}
 
+	// Lengkapi setiap pengguna dengan daftar role-nya
+	userIDs := make([]int64, len(users))
+	for i, user := range users {
+		userIDs[i] = user.ID
+	}
+	roles, err := s.roleRepository.FindRoleNamesByUserIDs(ctx, userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil role pengguna: %w", err)
+	}
+	for i := range users {
+		users[i].Roles = roles[users[i].ID]
+	}
+
 	// Perbarui cache
 	if marshalledData, err := json.Marshal(users); err == nil {
 		if err := s.cacheable.Set(cacheKey, marshalledData, 5*time.Minute); err != nil {
@@ -79,43 +136,137 @@ func (s *userService) FindAll(ctx context.Context) ([]entity.User, error) {
 	return users, nil
 }
 
-// Login memproses autentikasi pengguna
-func (s *userService) Login(ctx context.Context, username, password string) (string, error) {
+// Login memproses autentikasi pengguna dan menerbitkan access token beserta refresh token.
+// Username yang tidak terdaftar melewati pemeriksaan dan perhitungan percobaan yang sama
+// dengan password salah sehingga respons maupun waktunya tidak membocorkan keberadaan akun.
+func (s *userService) Login(ctx context.Context, username, password, ip string) (*entity.LoginResult, error) {
+	if err := s.loginGuard.Check(ctx, username, ip); err != nil {
+		return nil, err
+	}
+
 	user, err := s.userRepository.FindByUsername(ctx, username)
 	if err != nil {
-		return "", ErrKredensialTidakValid
+		bcrypt.CompareHashAndPassword(dummyPasswordHash(), []byte(password))
+		s.recordLogin(ctx, nil, username, false)
+		s.loginGuard.RecordFailure(ctx, username, ip)
+		return nil, ErrKredensialTidakValid
 	}
 
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
-		return "", ErrKredensialTidakValid
+		s.recordLogin(ctx, &user.ID, username, false)
+		s.loginGuard.RecordFailure(ctx, username, ip)
+		return nil, ErrKredensialTidakValid
 	}
 
-	claims := token.JwtCustomClaims{
-		Username: user.Username,
-		Role:     user.Role,
-		FullName: user.FullName,
-		RegisteredClaims: jwt.RegisteredClaims{
-			Issuer:    "aplikasi-todo",
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-		},
+	// Password benar tetapi login belum selesai hingga kode kedua diverifikasi
+	enabled, err := s.mfa.Enabled(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if enabled {
+		challenge, err := s.mfa.CreateChallenge(ctx, user.ID)
+		if err != nil {
+			return nil, err
+		}
+		return &entity.LoginResult{Challenge: challenge}, nil
 	}
 
-	token, err := s.tokenUseCase.GenerateAccessToken(claims)
+	pair, err := s.completeLogin(ctx, user)
 	if err != nil {
-		return "", fmt.Errorf("gagal membuat token: %w", err)
+		return nil, err
 	}
+	return &entity.LoginResult{Tokens: pair}, nil
+}
 
-	return token, nil
+// LoginMFA menyelesaikan login dua langkah. Kode yang salah dihitung sebagai login gagal
+// sehingga lockout username juga berlaku untuk langkah kedua.
+func (s *userService) LoginMFA(ctx context.Context, challengeToken, code, ip string) (*entity.TokenPair, error) {
+	userID, err := s.mfa.ChallengeUser(ctx, challengeToken)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.userRepository.FindByID(ctx, userID)
+	if err != nil {
+		return nil, ErrChallengeMFATidakValid
+	}
+	if err := s.loginGuard.Check(ctx, user.Username, ip); err != nil {
+		return nil, err
+	}
+
+	if err := s.mfa.VerifyChallenge(ctx, challengeToken, code); err != nil {
+		if errors.Is(err, ErrKodeMFATidakValid) {
+			s.recordLogin(ctx, &user.ID, user.Username, false)
+			s.loginGuard.RecordFailure(ctx, user.Username, ip)
+		}
+		return nil, err
+	}
+
+	return s.completeLogin(ctx, user)
 }
 
-// CreateUser menambahkan pengguna baru
+// completeLogin menerbitkan token lalu mencatat login berhasil. Login yang ditolak kebijakan
+// verifikasi email tidak dicatat berhasil dan tidak mereset counter percobaan login.
+func (s *userService) completeLogin(ctx context.Context, user *entity.User) (*entity.TokenPair, error) {
+	if err := s.emailVerification.CheckLogin(user); err != nil {
+		return nil, err
+	}
+
+	pair, err := s.tokenService.Issue(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	s.recordLogin(ctx, &user.ID, user.Username, true)
+	s.loginGuard.RecordSuccess(ctx, user.Username)
+	return pair, nil
+}
+
+// dummyPasswordHash adalah hash pembanding untuk username yang tidak terdaftar agar waktu
+// respons login setara dengan pemeriksaan password sungguhan
+var dummyPasswordHash = sync.OnceValue(func() []byte {
+	hash, _ := bcrypt.GenerateFromPassword([]byte("go-todo-api:dummy-password"), bcrypt.DefaultCost)
+	return hash
+})
+
+// recordLogin mencatat percobaan login untuk analitik; kegagalan pencatatan tidak menggagalkan login
+func (s *userService) recordLogin(ctx context.Context, userID *int64, username string, success bool) {
+	event := &entity.LoginEvent{UserID: userID, Username: username, Success: success}
+	if err := s.loginEventRepository.Create(ctx, event); err != nil {
+		fmt.Printf("kesalahan mencatat login: %v\n", err)
+	}
+}
+
+// CreateUser menambahkan pengguna baru. Pengguna tanpa role mendapat role default.
 func (s *userService) CreateUser(ctx context.Context, user *entity.User) (*entity.User, error) {
+	if err := s.passwordPolicy.Validate(user.Password, user.Username); err != nil {
+		return nil, err
+	}
+
 	// Cek apakah username sudah ada
 	if _, err := s.userRepository.FindByUsername(ctx, user.Username); err == nil {
 		return nil, ErrUsernameSudahAda
 	}
 
+	if user.Email != "" {
+		email, err := s.uniqueEmail(ctx, user.Email, 0)
+		if err != nil {
+			return nil, err
+		}
+		user.Email = email
+	}
+
+	if len(user.Roles) == 0 {
+		user.Roles = []string{entity.DefaultRole}
+	}
+	roles := make([]*entity.Role, 0, len(user.Roles))
+	for _, name := range user.Roles {
+		role, err := s.findRole(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		roles = append(roles, role)
+	}
+
 	// Hash password
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(user.Password), bcrypt.DefaultCost)
 	if err != nil {
@@ -123,8 +274,22 @@ func (s *userService) CreateUser(ctx context.Context, user *entity.User) (*entit
 	}
 	user.Password = string(hashedPassword)
 
-	// Simpan pengguna ke database
-	createdUser, err := s.userRepository.Create(ctx, user)
+	// Simpan pengguna ke database beserta event-nya dalam satu transaksi
+	var createdUser *entity.User
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		createdUser, err = s.userRepository.Create(ctx, user)
+		if err != nil {
+			return err
+		}
+		for _, role := range roles {
+			if err := s.roleRepository.AssignToUser(ctx, createdUser.ID, role.ID); err != nil {
+				return err
+			}
+		}
+		createdUser.Roles = user.Roles
+		return s.events.Record(ctx, userEvent(entity.EventUserCreated, createdUser))
+	})
 	if err != nil {
 		return nil, fmt.Errorf("gagal membuat pengguna: %w", err)
 	}
@@ -132,6 +297,11 @@ func (s *userService) CreateUser(ctx context.Context, user *entity.User) (*entit
 	// Hapus cache agar data konsisten
 	s.cacheable.Delete("pengguna:semua")
 
+	// Pendaftaran tetap berhasil meskipun token gagal dibuat; pengguna dapat meminta kirim ulang
+	if err := s.emailVerification.Send(ctx, createdUser); err != nil {
+		fmt.Printf("kesalahan mengirim verifikasi email: %v\n", err)
+	}
+
 	return createdUser, nil
 }
 
@@ -146,19 +316,35 @@ func (s *userService) UpdateUser(ctx context.Context, user *entity.User) (*entit
 		return nil, ErrPenggunaTidakDitemukan
 	}
 
+	// Perubahan password membuat seluruh token lama tidak berlaku
+	revokeSessions := user.Password != ""
+
 	// Update fields yang tidak kosong
 	if user.FullName != "" {
 		existingUser.FullName = user.FullName
 	}
-	if user.Role != "" {
-		existingUser.Role = user.Role
-	}
 	if user.Username != "" {
 		existingUser.Username = user.Username
 	}
+	// Email baru harus diverifikasi ulang
+	emailChanged := false
+	if user.Email != "" {
+		email, err := s.uniqueEmail(ctx, user.Email, existingUser.ID)
+		if err != nil {
+			return nil, err
+		}
+		if email != existingUser.Email {
+			existingUser.Email = email
+			existingUser.EmailVerifiedAt = nil
+			emailChanged = true
+		}
+	}
 
-	// Khusus untuk password, hanya update jika ada nilai baru
+	// Khusus untuk password, hanya update jika ada nilai baru yang memenuhi kebijakan
 	if user.Password != "" {
+		if err := s.passwordPolicy.Validate(user.Password, existingUser.Username); err != nil {
+			return nil, err
+		}
 		hashedPassword, err := bcrypt.GenerateFromPassword([]byte(user.Password), bcrypt.DefaultCost)
 		if err != nil {
 			return nil, fmt.Errorf("gagal mengenkripsi password: %w", err)
@@ -166,8 +352,27 @@ func (s *userService) UpdateUser(ctx context.Context, user *entity.User) (*entit
 		existingUser.Password = string(hashedPassword)
 	}
 
-	// Update pengguna
-	updatedUser, err := s.userRepository.Update(ctx, existingUser)
+	// Update pengguna beserta event-nya dalam satu transaksi
+	var updatedUser *entity.User
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		updatedUser, err = s.userRepository.Update(ctx, existingUser)
+		if err != nil {
+			return err
+		}
+		if emailChanged {
+			if err := s.userRepository.SetEmailVerifiedAt(ctx, existingUser.ID, nil); err != nil {
+				return err
+			}
+			updatedUser.EmailVerifiedAt = nil
+		}
+		if revokeSessions {
+			if err := s.tokenService.RevokeAll(ctx, existingUser.ID); err != nil {
+				return err
+			}
+		}
+		return s.events.Record(ctx, userEvent(entity.EventUserUpdated, updatedUser))
+	})
 	if err != nil {
 		return nil, fmt.Errorf("gagal memperbarui pengguna: %w", err)
 	}
@@ -175,6 +380,14 @@ func (s *userService) UpdateUser(ctx context.Context, user *entity.User) (*entit
 	// Hapus cache
 	s.cacheable.Delete("pengguna:semua")
 
+	if emailChanged {
+		// Izin pengguna dibatasi kembali hingga email baru diverifikasi
+		s.authorization.InvalidateUser(existingUser.ID)
+		if err := s.emailVerification.Send(ctx, updatedUser); err != nil {
+			fmt.Printf("kesalahan mengirim verifikasi email: %v\n", err)
+		}
+	}
+
 	return updatedUser, nil
 }
 
@@ -185,11 +398,22 @@ func (s *userService) DeleteUser(ctx context.Context, id int64) error {
 	}
 
 	// Cek apakah pengguna ada
-	if _, err := s.userRepository.FindByID(ctx, id); err != nil {
+	existingUser, err := s.userRepository.FindByID(ctx, id)
+	if err != nil {
 		return ErrPenggunaTidakDitemukan
 	}
 
-	if err := s.userRepository.Delete(ctx, id); err != nil {
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.userRepository.Delete(ctx, id); err != nil {
+			return err
+		}
+		// Access token pengguna yang dihapus tidak boleh dipakai hingga kedaluwarsa
+		if err := s.tokenService.RevokeAll(ctx, id); err != nil {
+			return err
+		}
+		return s.events.Record(ctx, userEvent(entity.EventUserDeleted, existingUser))
+	})
+	if err != nil {
 		return fmt.Errorf("gagal menghapus pengguna: %w", err)
 	}
 
@@ -198,3 +422,211 @@ func (s *userService) DeleteUser(ctx context.Context, id int64) error {
 
 	return nil
 }
+
+// GrantRole menambahkan role kepada pengguna. Seluruh sesi pengguna dicabut agar klaim
+// role pada token diperbarui, dan cache izinnya dihapus agar perubahan langsung berlaku.
+func (s *userService) GrantRole(ctx context.Context, actorID, id int64, name string) (*entity.User, error) {
+	if err := s.authorization.Require(ctx, actorID, entity.PermissionRoleManage); err != nil {
+		return nil, err
+	}
+	role, err := s.findRole(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.userRepository.FindByID(ctx, id)
+	if err != nil {
+		return nil, ErrPenggunaTidakDitemukan
+	}
+	if user, err = s.withRoles(ctx, user); err != nil {
+		return nil, err
+	}
+	for _, existing := range user.Roles {
+		if existing == role.Name {
+			return user, nil
+		}
+	}
+
+	err = s.changeRoles(ctx, user, func(ctx context.Context) error {
+		return s.roleRepository.AssignToUser(ctx, user.ID, role.ID)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("gagal memberikan role: %w", err)
+	}
+	fmt.Printf("role %s diberikan kepada pengguna %d oleh pengguna %d\n", role.Name, user.ID, actorID)
+	return s.withRoles(ctx, user)
+}
+
+// RevokeRole mencabut role dari pengguna. Role default tidak dapat dicabut, dan role
+// admin tidak dapat dicabut dari admin terakhir agar aplikasi tidak kehilangan pengelola.
+func (s *userService) RevokeRole(ctx context.Context, actorID, id int64, name string) (*entity.User, error) {
+	if err := s.authorization.Require(ctx, actorID, entity.PermissionRoleManage); err != nil {
+		return nil, err
+	}
+	if name == entity.DefaultRole {
+		return nil, ErrRoleDefaultTidakDicabut
+	}
+	role, err := s.findRole(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.userRepository.FindByID(ctx, id)
+	if err != nil {
+		return nil, ErrPenggunaTidakDitemukan
+	}
+
+	err = s.changeRoles(ctx, user, func(ctx context.Context) error {
+		removed, err := s.roleRepository.RemoveFromUser(ctx, user.ID, role.ID)
+		if err != nil {
+			return err
+		}
+		if removed == 0 {
+			return errRoleTidakBerubah
+		}
+		if role.Name != entity.RoleAdmin {
+			return nil
+		}
+		// Dihitung setelah penghapusan di dalam transaksi yang sama
+		admins, err := s.roleRepository.CountUsers(ctx, role.ID)
+		if err != nil {
+			return err
+		}
+		if admins == 0 {
+			return ErrAdminTerakhir
+		}
+		return nil
+	})
+	if errors.Is(err, errRoleTidakBerubah) {
+		return s.withRoles(ctx, user)
+	}
+	if errors.Is(err, ErrAdminTerakhir) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("gagal mencabut role: %w", err)
+	}
+	fmt.Printf("role %s dicabut dari pengguna %d oleh pengguna %d\n", role.Name, user.ID, actorID)
+	return s.withRoles(ctx, user)
+}
+
+// changeRoles menjalankan perubahan keanggotaan role, mencabut sesi lama, dan mencatat
+// event dalam satu transaksi, lalu menghapus cache yang terkait
+func (s *userService) changeRoles(ctx context.Context, user *entity.User, change func(ctx context.Context) error) error {
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := change(ctx); err != nil {
+			return err
+		}
+		if err := s.tokenService.RevokeAll(ctx, user.ID); err != nil {
+			return err
+		}
+		return s.events.Record(ctx, userEvent(entity.EventUserUpdated, user))
+	})
+	if err != nil {
+		return err
+	}
+
+	s.authorization.InvalidateUser(user.ID)
+	s.cacheable.Delete("pengguna:semua")
+	return nil
+}
+
+// BootstrapAdmin membuat admin pertama dari konfigurasi atau perintah CLI. Jika username
+// sudah terdaftar, pengguna tersebut diberi role admin tanpa mengubah password-nya.
+// Setelah ada admin, penambahan admin berikutnya harus melalui GrantRole.
+func (s *userService) BootstrapAdmin(ctx context.Context, user *entity.User) (*entity.User, error) {
+	adminRole, err := s.findRole(ctx, entity.RoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+	admins, err := s.roleRepository.CountUsers(ctx, adminRole.ID)
+	if err != nil {
+		return nil, fmt.Errorf("gagal memeriksa admin: %w", err)
+	}
+	if admins > 0 {
+		return nil, ErrAdminSudahAda
+	}
+
+	existingUser, err := s.userRepository.FindByUsername(ctx, user.Username)
+	if err == nil {
+		err = s.changeRoles(ctx, existingUser, func(ctx context.Context) error {
+			return s.roleRepository.AssignToUser(ctx, existingUser.ID, adminRole.ID)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("gagal memberikan role admin: %w", err)
+		}
+		return s.withRoles(ctx, existingUser)
+	}
+	if !errors.Is(err, repository.ErrPenggunaTidakDitemukan) {
+		return nil, fmt.Errorf("gagal memeriksa pengguna: %w", err)
+	}
+	if user.Password == "" {
+		return nil, errors.New("password admin harus diisi")
+	}
+
+	user.Roles = []string{entity.DefaultRole, entity.RoleAdmin}
+	return s.CreateUser(ctx, user)
+}
+
+// UnlockLogin membuka kunci login pengguna yang terkena lockout sebelum waktunya berakhir
+func (s *userService) UnlockLogin(ctx context.Context, actorID, id int64) error {
+	if err := s.authorization.Require(ctx, actorID, entity.PermissionUserWrite); err != nil {
+		return err
+	}
+	user, err := s.userRepository.FindByID(ctx, id)
+	if err != nil {
+		return ErrPenggunaTidakDitemukan
+	}
+	if err := s.loginGuard.Unlock(ctx, user.Username); err != nil {
+		return fmt.Errorf("gagal membuka kunci login: %w", err)
+	}
+	fmt.Printf("kunci login pengguna %d dibuka oleh pengguna %d\n", user.ID, actorID)
+	return nil
+}
+
+// uniqueEmail menormalkan email lalu memastikan email belum dipakai pengguna lain.
+// ownerID adalah pengguna yang boleh sudah memiliki email tersebut, nol untuk pengguna baru.
+func (s *userService) uniqueEmail(ctx context.Context, email string, ownerID int64) (string, error) {
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return "", err
+	}
+	existingUser, err := s.userRepository.FindByEmail(ctx, email)
+	if err == nil && existingUser.ID != ownerID {
+		return "", ErrEmailSudahAda
+	}
+	if err != nil && !errors.Is(err, repository.ErrPenggunaTidakDitemukan) {
+		return "", fmt.Errorf("gagal memeriksa email: %w", err)
+	}
+	return email, nil
+}
+
+// findRole mencari role berdasarkan nama; role yang tidak ada dianggap tidak valid
+func (s *userService) findRole(ctx context.Context, name string) (*entity.Role, error) {
+	role, err := s.roleRepository.FindByName(ctx, name)
+	if errors.Is(err, repository.ErrRoleTidakDitemukan) {
+		return nil, ErrRoleTidakValid
+	}
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil role: %w", err)
+	}
+	return role, nil
+}
+
+// withRoles melengkapi pengguna dengan daftar nama role terbarunya
+func (s *userService) withRoles(ctx context.Context, user *entity.User) (*entity.User, error) {
+	roles, err := s.roleRepository.FindRoleNamesByUserIDs(ctx, []int64{user.ID})
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil role pengguna: %w", err)
+	}
+	user.Roles = roles[user.ID]
+	return user, nil
+}
+
+// userEvent menyusun domain event untuk aggregate user
+func userEvent(eventType string, user *entity.User) entity.DomainEvent {
+	return entity.DomainEvent{
+		Type:          eventType,
+		AggregateType: entity.AggregateUser,
+		AggregateID:   user.ID,
+		Data:          user,
+	}
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-todo/internal/entity"
+	"go-todo/internal/repository"
+	"go-todo/pkg/cache"
+	"regexp"
+	"time"
+)
+
+var (
+	ErrZonaWaktuTidakValid     = errors.New("zona waktu harus berupa nama IANA, misalnya Asia/Jakarta")
+	ErrLocaleTidakValid        = errors.New("locale harus berformat bahasa-WILAYAH, misalnya id-ID")
+	ErrAwalMingguTidakValid    = errors.New("awal minggu harus antara 0 (Minggu) dan 6 (Sabtu)")
+	ErrFormatTanggalTidakValid = errors.New("format tanggal tidak didukung")
+)
+
+var localePattern = regexp.MustCompile(`^[a-z]{2,3}(-[A-Z]{2})?$`)
+
+type UserPreferenceService interface {
+	Get(ctx context.Context, userID int64) (*entity.UserPreference, error)
+	Update(ctx context.Context, preference *entity.UserPreference) (*entity.UserPreference, error)
+	Location(ctx context.Context, userID int64) *time.Location
+}
+
+type userPreferenceService struct {
+	userPreferenceRepository repository.UserPreferenceRepository
+	cacheable                cache.Cacheable
+}
+
+// NewUserPreferenceService membuat instance baru dari UserPreferenceService
+func NewUserPreferenceService(
+	userPreferenceRepository repository.UserPreferenceRepository,
+	cacheable cache.Cacheable,
+) UserPreferenceService {
+	return &userPreferenceService{
+		userPreferenceRepository: userPreferenceRepository,
+		cacheable:                cacheable,
+	}
+}
+
+// Get mengambil preferensi pengguna, atau nilai default jika pengguna belum menyimpannya
+func (s *userPreferenceService) Get(ctx context.Context, userID int64) (*entity.UserPreference, error) {
+	cacheKey := preferenceCacheKey(userID)
+
+	if cachedData, err := s.cacheable.Get(cacheKey); err == nil && cachedData != "" {
+		var preference entity.UserPreference
+		if err := json.Unmarshal([]byte(cachedData), &preference); err == nil {
+			return &preference, nil
+		}
+	}
+
+	preference, err := s.userPreferenceRepository.FindByUserID(ctx, userID)
+	if err != nil {
+		if !errors.Is(err, repository.ErrPreferensiTidakDitemukan) {
+			return nil, fmt.Errorf("gagal mengambil preferensi: %w", err)
+		}
+		preference = entity.DefaultUserPreference(userID)
+	}
+
+	if err := s.cacheable.Set(cacheKey, preference, 10*time.Minute); err != nil {
+		fmt.Printf("kesalahan menyimpan cache: %v\n", err)
+	}
+	return preference, nil
+}
+
+// Update memvalidasi dan menyimpan seluruh preferensi pengguna
+func (s *userPreferenceService) Update(ctx context.Context, preference *entity.UserPreference) (*entity.UserPreference, error) {
+	if _, err := time.LoadLocation(preference.Timezone); err != nil || preference.Timezone == "" || preference.Timezone == "Local" {
+		return nil, ErrZonaWaktuTidakValid
+	}
+	if !localePattern.MatchString(preference.Locale) {
+		return nil, ErrLocaleTidakValid
+	}
+	if preference.WeekStart < 0 || preference.WeekStart > 6 {
+		return nil, ErrAwalMingguTidakValid
+	}
+	if !isSupportedDateFormat(preference.DateFormat) {
+		return nil, ErrFormatTanggalTidakValid
+	}
+
+	updated, err := s.userPreferenceRepository.Upsert(ctx, preference)
+	if err != nil {
+		return nil, fmt.Errorf("gagal menyimpan preferensi: %w", err)
+	}
+
+	s.cacheable.Delete(preferenceCacheKey(preference.UserID))
+	return updated, nil
+}
+
+// Location mengembalikan zona waktu pengguna. Jika gagal, zona waktu default yang digunakan.
+func (s *userPreferenceService) Location(ctx context.Context, userID int64) *time.Location {
+	preference, err := s.Get(ctx, userID)
+	if err != nil {
+		return defaultLocation()
+	}
+	loc, err := time.LoadLocation(preference.Timezone)
+	if err != nil {
+		return defaultLocation()
+	}
+	return loc
+}
+
+// defaultLocation memuat zona waktu default aplikasi
+func defaultLocation() *time.Location {
+	loc, err := time.LoadLocation(entity.DefaultTimezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+func isSupportedDateFormat(format string) bool {
+	for _, supported := range entity.DateFormats {
+		if format == supported {
+			return true
+		}
+	}
+	return false
+}
+
+func preferenceCacheKey(userID int64) string {
+	return fmt.Sprintf("go-todo-api:preferences:%d", userID)
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"go-todo/internal/entity"
+	"go-todo/internal/repository"
+	mock_cache "go-todo/test/mock/pkg/cache"
+	mock_repository "go-todo/test/mock/repository"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupUserPreferenceService(t *testing.T) (*gomock.Controller, UserPreferenceService, *mock_repository.MockUserPreferenceRepository, *mock_cache.MockCacheable) {
+	ctrl := gomock.NewController(t)
+	mockRepo := mock_repository.NewMockUserPreferenceRepository(ctrl)
+	mockCache := mock_cache.NewMockCacheable(ctrl)
+	service := NewUserPreferenceService(mockRepo, mockCache)
+	return ctrl, service, mockRepo, mockCache
+}
+
+func TestUserPreferenceService_Get_Default(t *testing.T) {
+	ctrl, service, mockRepo, mockCache := setupUserPreferenceService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockCache.EXPECT().Get("go-todo-api:preferences:5").Return("", nil)
+	mockRepo.EXPECT().FindByUserID(ctx, int64(5)).Return(nil, repository.ErrPreferensiTidakDitemukan)
+	mockCache.EXPECT().Set("go-todo-api:preferences:5", gomock.Any(), 10*time.Minute).Return(nil)
+
+	preference, err := service.Get(ctx, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, entity.DefaultUserPreference(5), preference)
+}
+
+func TestUserPreferenceService_Get_CacheHit(t *testing.T) {
+	ctrl, service, _, mockCache := setupUserPreferenceService(t)
+	defer ctrl.Finish()
+
+	cachedData, _ := json.Marshal(entity.UserPreference{UserID: 5, Timezone: "Asia/Tokyo"})
+	mockCache.EXPECT().Get("go-todo-api:preferences:5").Return(string(cachedData), nil)
+
+	preference, err := service.Get(context.Background(), 5)
+	assert.NoError(t, err)
+	assert.Equal(t, "Asia/Tokyo", preference.Timezone)
+}
+
+func TestUserPreferenceService_Update(t *testing.T) {
+	ctrl, service, mockRepo, mockCache := setupUserPreferenceService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	preference := &entity.UserPreference{UserID: 5, Timezone: "America/New_York", Locale: "en-US", WeekStart: 0, DateFormat: "MM/DD/YYYY"}
+
+	mockRepo.EXPECT().Upsert(ctx, preference).Return(preference, nil)
+	mockCache.EXPECT().Delete("go-todo-api:preferences:5").Return(nil)
+
+	updated, err := service.Update(ctx, preference)
+	assert.NoError(t, err)
+	assert.Equal(t, "America/New_York", updated.Timezone)
+}
+
+func TestUserPreferenceService_Update_Invalid(t *testing.T) {
+	ctrl, service, _, _ := setupUserPreferenceService(t)
+	defer ctrl.Finish()
+
+	valid := entity.DefaultUserPreference(5)
+	tests := []struct {
+		name   string
+		modify func(p *entity.UserPreference)
+		err    error
+	}{
+		{"unknown timezone", func(p *entity.UserPreference) { p.Timezone = "Mars/Olympus" }, ErrZonaWaktuTidakValid},
+		{"local timezone", func(p *entity.UserPreference) { p.Timezone = "Local" }, ErrZonaWaktuTidakValid},
+		{"empty timezone", func(p *entity.UserPreference) { p.Timezone = "" }, ErrZonaWaktuTidakValid},
+		{"invalid locale", func(p *entity.UserPreference) { p.Locale = "indonesia" }, ErrLocaleTidakValid},
+		{"invalid week start", func(p *entity.UserPreference) { p.WeekStart = 7 }, ErrAwalMingguTidakValid},
+		{"invalid date format", func(p *entity.UserPreference) { p.DateFormat = "YYYY/DD/MM" }, ErrFormatTanggalTidakValid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			preference := *valid
+			tt.modify(&preference)
+			_, err := service.Update(context.Background(), &preference)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestUserPreferenceService_Location_Fallback(t *testing.T) {
+	ctrl, service, mockRepo, mockCache := setupUserPreferenceService(t)
+	defer ctrl.Finish()
+
+	mockCache.EXPECT().Get(gomock.Any()).Return("", nil)
+	mockRepo.EXPECT().FindByUserID(gomock.Any(), int64(5)).Return(nil, errors.New("database error"))
+
+	loc := service.Location(context.Background(), 5)
+	assert.Equal(t, entity.DefaultTimezone, loc.String())
+}
@@ -4,10 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"go-todo/configs"
 	"go-todo/internal/entity"
+	"go-todo/internal/repository"
+	"go-todo/pkg/password"
 	mock_cache "go-todo/test/mock/pkg/cache"
-	mock_token "go-todo/test/mock/pkg/token"
 	mock_repository "go-todo/test/mock/repository"
+	mock_service "go-todo/test/mock/service"
 	"testing"
 	"time"
 
@@ -16,26 +19,60 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
-func setupUserService(t *testing.T) (*gomock.Controller, UserService, *mock_repository.MockUserRepository, *mock_cache.MockCacheable, *mock_token.MockTokenUseCase) {
+// userServiceMocks mengelompokkan semua dependensi mock dari UserService
+type userServiceMocks struct {
+	repo       *mock_repository.MockUserRepository
+	roleRepo   *mock_repository.MockRoleRepository
+	authz      *mock_service.MockAuthorizationService
+	cache      *mock_cache.MockCacheable
+	token      *mock_service.MockTokenService
+	loginEvent *mock_repository.MockLoginEventRepository
+	guard      *mock_service.MockLoginGuard
+	verify     *mock_service.MockEmailVerificationService
+	mfa        *mock_service.MockMFAService
+	tx         *mock_repository.MockTransactor
+	events     *mock_service.MockEventRecorder
+}
+
+var testPasswordPolicy = password.NewPolicy(configs.PasswordConfig{
+	MinLength:        10,
+	RequireUppercase: true,
+	RequireLowercase: true,
+	RequireDigit:     true,
+	DisallowUsername: true,
+	RejectCommon:     true,
+})
+
+func setupUserService(t *testing.T) (*gomock.Controller, UserService, *userServiceMocks) {
 	ctrl := gomock.NewController(t)
-	mockRepo := mock_repository.NewMockUserRepository(ctrl)
-	mockCache := mock_cache.NewMockCacheable(ctrl)
-	mockToken := mock_token.NewMockTokenUseCase(ctrl)
-	service := NewUserService(mockRepo, mockToken, mockCache)
-	return ctrl, service, mockRepo, mockCache, mockToken
+	m := &userServiceMocks{
+		repo:       mock_repository.NewMockUserRepository(ctrl),
+		roleRepo:   mock_repository.NewMockRoleRepository(ctrl),
+		authz:      mock_service.NewMockAuthorizationService(ctrl),
+		cache:      mock_cache.NewMockCacheable(ctrl),
+		token:      mock_service.NewMockTokenService(ctrl),
+		loginEvent: mock_repository.NewMockLoginEventRepository(ctrl),
+		guard:      mock_service.NewMockLoginGuard(ctrl),
+		verify:     mock_service.NewMockEmailVerificationService(ctrl),
+		mfa:        mock_service.NewMockMFAService(ctrl),
+		tx:         passThroughTransactor(ctrl),
+		events:     mock_service.NewMockEventRecorder(ctrl),
+	}
+	service := NewUserService(m.repo, m.roleRepo, m.token, m.authz, m.cache, m.loginEvent, m.guard, testPasswordPolicy, m.verify, m.mfa, m.tx, m.events)
+	return ctrl, service, m
 }
 
 // Kasus uji untuk FindAll
 
 func TestUserService_FindAll_CacheHit(t *testing.T) {
-	ctrl, service, _, mockCache, _ := setupUserService(t)
+	ctrl, service, m := setupUserService(t)
 	defer ctrl.Finish()
 
 	ctx := context.Background()
 	expectedUsers := []entity.User{{ID: 1, Username: "user1"}, {ID: 2, Username: "user2"}}
 	cachedData, _ := json.Marshal(expectedUsers)
 
-	mockCache.EXPECT().Get("pengguna:semua").Return(string(cachedData), nil)
+	m.cache.EXPECT().Get("pengguna:semua").Return(string(cachedData), nil)
 
 	users, err := service.FindAll(ctx)
 	assert.NoError(t, err)
@@ -43,17 +80,25 @@ func TestUserService_FindAll_CacheHit(t *testing.T) {
 }
 
 func TestUserService_FindAll_CacheMiss(t *testing.T) {
-	ctrl, service, mockRepo, mockCache, _ := setupUserService(t)
+	ctrl, service, m := setupUserService(t)
 	defer ctrl.Finish()
 
 	ctx := context.Background()
-	expectedUsers := []entity.User{{ID: 1, Username: "user1"}, {ID: 2, Username: "user2"}}
-
-	mockCache.EXPECT().Get("pengguna:semua").Return("", nil)
-	mockRepo.EXPECT().FindAll(ctx).Return(expectedUsers, nil)
+	expectedUsers := []entity.User{
+		{ID: 1, Username: "user1", Roles: []string{"admin", "user"}},
+		{ID: 2, Username: "user2", Roles: []string{"user"}},
+	}
+
+	m.cache.EXPECT().Get("pengguna:semua").Return("", nil)
+	m.repo.EXPECT().FindAll(ctx).Return([]entity.User{{ID: 1, Username: "user1"}, {ID: 2, Username: "user2"}}, nil)
+	// Role seluruh pengguna diambil dengan satu query
+	m.roleRepo.EXPECT().FindRoleNamesByUserIDs(ctx, []int64{1, 2}).Return(map[int64][]string{
+		1: {"admin", "user"},
+		2: {"user"},
+	}, nil)
 
 	marshalledData, _ := json.Marshal(expectedUsers)
-	mockCache.EXPECT().Set("pengguna:semua", marshalledData, 5*time.Minute).Return(nil)
+	m.cache.EXPECT().Set("pengguna:semua", marshalledData, 5*time.Minute).Return(nil)
 
 	users, err := service.FindAll(ctx)
 	assert.NoError(t, err)
@@ -61,17 +106,18 @@ func TestUserService_FindAll_CacheMiss(t *testing.T) {
 }
 
 func TestUserService_FindAll_CacheError(t *testing.T) {
-	ctrl, service, mockRepo, mockCache, _ := setupUserService(t)
+	ctrl, service, m := setupUserService(t)
 	defer ctrl.Finish()
 
 	ctx := context.Background()
 	expectedUsers := []entity.User{{ID: 1, Username: "user1"}, {ID: 2, Username: "user2"}}
 
-	mockCache.EXPECT().Get("pengguna:semua").Return("", errors.New("cache error"))
-	mockRepo.EXPECT().FindAll(ctx).Return(expectedUsers, nil)
+	m.cache.EXPECT().Get("pengguna:semua").Return("", errors.New("cache error"))
+	m.repo.EXPECT().FindAll(ctx).Return(expectedUsers, nil)
+	m.roleRepo.EXPECT().FindRoleNamesByUserIDs(ctx, []int64{1, 2}).Return(map[int64][]string{}, nil)
 
 	marshalledData, _ := json.Marshal(expectedUsers)
-	mockCache.EXPECT().Set("pengguna:semua", marshalledData, 5*time.Minute).Return(nil)
+	m.cache.EXPECT().Set("pengguna:semua", marshalledData, 5*time.Minute).Return(nil)
 
 	users, err := service.FindAll(ctx)
 	assert.NoError(t, err)
@@ -81,24 +127,118 @@ func TestUserService_FindAll_CacheError(t *testing.T) {
 // Kasus uji untuk Login
 
 func TestUserService_Login_ValidCredentials(t *testing.T) {
-	ctrl, service, mockRepo, _, mockToken := setupUserService(t)
+	ctrl, service, m := setupUserService(t)
 	defer ctrl.Finish()
 
 	ctx := context.Background()
 	username, password := "user1", "password"
 	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
-	user := entity.User{ID: 1, Username: username, Password: string(hashedPassword), Role: "user"}
+	user := entity.User{ID: 1, Username: username, Password: string(hashedPassword)}
 
-	mockRepo.EXPECT().FindByUsername(ctx, username).Return(&user, nil)
-	mockToken.EXPECT().GenerateAccessToken(gomock.Any()).Return("mockToken", nil)
+	m.guard.EXPECT().Check(ctx, username, "10.0.0.1").Return(nil)
+	m.repo.EXPECT().FindByUsername(ctx, username).Return(&user, nil)
+	m.mfa.EXPECT().Enabled(ctx, int64(1)).Return(false, nil)
+	m.loginEvent.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, event *entity.LoginEvent) error {
+			assert.True(t, event.Success)
+			assert.Equal(t, int64(1), *event.UserID)
+			return nil
+		})
+	m.guard.EXPECT().RecordSuccess(ctx, username)
+	m.verify.EXPECT().CheckLogin(&user).Return(nil)
+	m.token.EXPECT().Issue(ctx, &user).Return(&entity.TokenPair{AccessToken: "mockToken", RefreshToken: "mockRefresh"}, nil)
+
+	result, err := service.Login(ctx, username, password, "10.0.0.1")
+	assert.NoError(t, err)
+	assert.Nil(t, result.Challenge)
+	assert.Equal(t, "mockToken", result.Tokens.AccessToken)
+	assert.Equal(t, "mockRefresh", result.Tokens.RefreshToken)
+}
 
-	token, err := service.Login(ctx, username, password)
+func TestUserService_Login_MFAChallenge(t *testing.T) {
+	ctrl, service, m := setupUserService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.DefaultCost)
+	user := entity.User{ID: 1, Username: "admin", Password: string(hashedPassword)}
+	challenge := &entity.MFAChallenge{Token: "challenge", ExpiresAt: time.Now().Add(5 * time.Minute)}
+
+	m.guard.EXPECT().Check(ctx, "admin", "10.0.0.1").Return(nil)
+	m.repo.EXPECT().FindByUsername(ctx, "admin").Return(&user, nil)
+	m.mfa.EXPECT().Enabled(ctx, int64(1)).Return(true, nil)
+	m.mfa.EXPECT().CreateChallenge(ctx, int64(1)).Return(challenge, nil)
+
+	// Password benar saja belum dicatat sebagai login berhasil dan tidak menerbitkan token
+	result, err := service.Login(ctx, "admin", "password", "10.0.0.1")
+	assert.NoError(t, err)
+	assert.Nil(t, result.Tokens)
+	assert.Equal(t, challenge, result.Challenge)
+}
+
+// Kasus uji untuk LoginMFA
+
+func TestUserService_LoginMFA_Success(t *testing.T) {
+	ctrl, service, m := setupUserService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	user := &entity.User{ID: 1, Username: "admin"}
+
+	m.mfa.EXPECT().ChallengeUser(ctx, "challenge").Return(int64(1), nil)
+	m.repo.EXPECT().FindByID(ctx, int64(1)).Return(user, nil)
+	m.guard.EXPECT().Check(ctx, "admin", "10.0.0.1").Return(nil)
+	m.mfa.EXPECT().VerifyChallenge(ctx, "challenge", "123456").Return(nil)
+	m.loginEvent.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, event *entity.LoginEvent) error {
+			assert.True(t, event.Success)
+			return nil
+		})
+	m.guard.EXPECT().RecordSuccess(ctx, "admin")
+	m.verify.EXPECT().CheckLogin(user).Return(nil)
+	m.token.EXPECT().Issue(ctx, user).Return(&entity.TokenPair{AccessToken: "mockToken"}, nil)
+
+	pair, err := service.LoginMFA(ctx, "challenge", "123456", "10.0.0.1")
 	assert.NoError(t, err)
-	assert.Equal(t, "mockToken", token)
+	assert.Equal(t, "mockToken", pair.AccessToken)
+}
+
+func TestUserService_LoginMFA_InvalidCode(t *testing.T) {
+	ctrl, service, m := setupUserService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	user := &entity.User{ID: 1, Username: "admin"}
+
+	m.mfa.EXPECT().ChallengeUser(ctx, "challenge").Return(int64(1), nil)
+	m.repo.EXPECT().FindByID(ctx, int64(1)).Return(user, nil)
+	m.guard.EXPECT().Check(ctx, "admin", "10.0.0.1").Return(nil)
+	m.mfa.EXPECT().VerifyChallenge(ctx, "challenge", "000000").Return(ErrKodeMFATidakValid)
+	m.loginEvent.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, event *entity.LoginEvent) error {
+			assert.False(t, event.Success)
+			return nil
+		})
+	// Kode salah ikut dihitung pada lockout username
+	m.guard.EXPECT().RecordFailure(ctx, "admin", "10.0.0.1")
+
+	_, err := service.LoginMFA(ctx, "challenge", "000000", "10.0.0.1")
+	assert.ErrorIs(t, err, ErrKodeMFATidakValid)
+}
+
+func TestUserService_LoginMFA_InvalidChallenge(t *testing.T) {
+	ctrl, service, m := setupUserService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m.mfa.EXPECT().ChallengeUser(ctx, "kedaluwarsa").Return(int64(0), ErrChallengeMFATidakValid)
+
+	_, err := service.LoginMFA(ctx, "kedaluwarsa", "123456", "10.0.0.1")
+	assert.ErrorIs(t, err, ErrChallengeMFATidakValid)
 }
 
 func TestUserService_Login_InvalidCredentials(t *testing.T) {
-	ctrl, service, mockRepo, _, _ := setupUserService(t)
+	ctrl, service, m := setupUserService(t)
 	defer ctrl.Finish()
 
 	ctx := context.Background()
@@ -106,39 +246,189 @@ func TestUserService_Login_InvalidCredentials(t *testing.T) {
 	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.DefaultCost)
 	user := entity.User{ID: 1, Username: username, Password: string(hashedPassword)}
 
-	mockRepo.EXPECT().FindByUsername(ctx, username).Return(&user, nil)
+	m.guard.EXPECT().Check(ctx, username, "10.0.0.1").Return(nil)
+	m.repo.EXPECT().FindByUsername(ctx, username).Return(&user, nil)
+	m.loginEvent.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, event *entity.LoginEvent) error {
+			assert.False(t, event.Success)
+			return nil
+		})
+	m.guard.EXPECT().RecordFailure(ctx, username, "10.0.0.1")
 
-	_, err := service.Login(ctx, username, password)
+	_, err := service.Login(ctx, username, password, "10.0.0.1")
 	assert.ErrorIs(t, err, ErrKredensialTidakValid)
 }
 
+func TestUserService_Login_UnknownUsername(t *testing.T) {
+	ctrl, service, m := setupUserService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	m.guard.EXPECT().Check(ctx, "ghost", "10.0.0.1").Return(nil)
+	m.repo.EXPECT().FindByUsername(ctx, "ghost").Return(nil, ErrPenggunaTidakDitemukan)
+	m.loginEvent.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, event *entity.LoginEvent) error {
+			assert.Nil(t, event.UserID)
+			assert.Equal(t, "ghost", event.Username)
+			return errors.New("database error") // Kegagalan pencatatan tidak mengubah hasil login
+		})
+	// Username yang tidak terdaftar tetap dihitung agar tidak dapat dibedakan dari password salah
+	m.guard.EXPECT().RecordFailure(ctx, "ghost", "10.0.0.1")
+
+	_, err := service.Login(ctx, "ghost", "password", "10.0.0.1")
+	assert.ErrorIs(t, err, ErrKredensialTidakValid)
+}
+
+func TestUserService_Login_Locked(t *testing.T) {
+	ctrl, service, m := setupUserService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	// Saat dikunci, password tidak diperiksa sama sekali, termasuk password yang benar
+	m.guard.EXPECT().Check(ctx, "budi", "10.0.0.1").Return(&LoginLockedError{RetryAfter: time.Minute})
+
+	_, err := service.Login(ctx, "budi", "password", "10.0.0.1")
+	assert.ErrorIs(t, err, ErrTerlaluBanyakPercobaan)
+	var locked *LoginLockedError
+	assert.ErrorAs(t, err, &locked)
+	assert.Equal(t, time.Minute, locked.RetryAfter)
+}
+
+func TestUserService_Login_EmailNotVerified(t *testing.T) {
+	ctrl, service, m := setupUserService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.DefaultCost)
+	user := entity.User{ID: 1, Username: "budi", Password: string(hashedPassword), Email: "budi@example.com"}
+
+	m.guard.EXPECT().Check(ctx, "budi", "10.0.0.1").Return(nil)
+	m.repo.EXPECT().FindByUsername(ctx, "budi").Return(&user, nil)
+	m.mfa.EXPECT().Enabled(ctx, int64(1)).Return(false, nil)
+	// Kebijakan block menolak login sebelum token diterbitkan. Login yang ditolak tidak dicatat
+	// berhasil dan tidak mereset counter percobaan login, sehingga loginEvent.Create dan
+	// guard.RecordSuccess tidak boleh dipanggil.
+	m.verify.EXPECT().CheckLogin(&user).Return(ErrEmailBelumDiverifikasi)
+	m.guard.EXPECT().RecordSuccess(gomock.Any(), gomock.Any()).Times(0)
+	m.loginEvent.EXPECT().Create(gomock.Any(), gomock.Any()).Times(0)
+
+	_, err := service.Login(ctx, "budi", "password", "10.0.0.1")
+	assert.ErrorIs(t, err, ErrEmailBelumDiverifikasi)
+}
+
 // Kasus uji untuk CreateUser
 
 func TestUserService_CreateUser_NewUsername(t *testing.T) {
-	ctrl, service, mockRepo, mockCache, _ := setupUserService(t)
+	ctrl, service, m := setupUserService(t)
 	defer ctrl.Finish()
 
 	ctx := context.Background()
-	user := &entity.User{Username: "newUser", Password: "password"}
+	user := &entity.User{Username: "newUser", Password: "Kopi-Pagi-2026"}
 	expectedUser := &entity.User{ID: 1, Username: "newUser"}
 
-	mockRepo.EXPECT().FindByUsername(ctx, user.Username).Return(nil, errors.New("not found"))
-	mockRepo.EXPECT().Create(ctx, user).Return(expectedUser, nil)
-	mockCache.EXPECT().Delete("pengguna:semua").Return(nil)
+	m.roleRepo.EXPECT().FindByName(ctx, entity.DefaultRole).Return(&entity.Role{ID: 2, Name: entity.DefaultRole}, nil)
+	m.repo.EXPECT().FindByUsername(ctx, user.Username).Return(nil, errors.New("not found"))
+	m.repo.EXPECT().Create(ctx, user).Return(expectedUser, nil)
+	m.roleRepo.EXPECT().AssignToUser(ctx, int64(1), int64(2)).Return(nil)
+	m.cache.EXPECT().Delete("pengguna:semua").Return(nil)
+	m.events.EXPECT().Record(ctx, userEvent(entity.EventUserCreated, expectedUser)).Return(nil)
+	m.verify.EXPECT().Send(ctx, expectedUser).Return(nil)
 
 	createdUser, err := service.CreateUser(ctx, user)
 	assert.NoError(t, err)
 	assert.Equal(t, expectedUser, createdUser)
+	// Pengguna baru tanpa role mendapat role default
+	assert.Equal(t, []string{entity.DefaultRole}, createdUser.Roles)
+}
+
+func TestUserService_CreateUser_WithEmail(t *testing.T) {
+	ctrl, service, m := setupUserService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	user := &entity.User{Username: "budi", Password: "Kopi-Pagi-2026", Email: "  Budi@Example.com "}
+	createdUser := &entity.User{ID: 1, Username: "budi", Email: "budi@example.com"}
+
+	m.repo.EXPECT().FindByUsername(ctx, "budi").Return(nil, repository.ErrPenggunaTidakDitemukan)
+	m.repo.EXPECT().FindByEmail(ctx, "budi@example.com").Return(nil, repository.ErrPenggunaTidakDitemukan)
+	m.roleRepo.EXPECT().FindByName(ctx, entity.DefaultRole).Return(&entity.Role{ID: 2, Name: entity.DefaultRole}, nil)
+	m.repo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, user *entity.User) (*entity.User, error) {
+			// Email disimpan dalam bentuk yang sudah dinormalisasi
+			assert.Equal(t, "budi@example.com", user.Email)
+			return createdUser, nil
+		})
+	m.roleRepo.EXPECT().AssignToUser(ctx, int64(1), int64(2)).Return(nil)
+	m.events.EXPECT().Record(ctx, gomock.Any()).Return(nil)
+	m.cache.EXPECT().Delete("pengguna:semua").Return(nil)
+	// Kegagalan mengirim verifikasi tidak menggagalkan pendaftaran
+	m.verify.EXPECT().Send(ctx, createdUser).Return(errors.New("smtp mati"))
+
+	result, err := service.CreateUser(ctx, user)
+	assert.NoError(t, err)
+	assert.Equal(t, "budi@example.com", result.Email)
+}
+
+func TestUserService_CreateUser_ExistingEmail(t *testing.T) {
+	ctrl, service, m := setupUserService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m.repo.EXPECT().FindByUsername(ctx, "budi").Return(nil, repository.ErrPenggunaTidakDitemukan)
+	m.repo.EXPECT().FindByEmail(ctx, "budi@example.com").Return(&entity.User{ID: 9}, nil)
+
+	_, err := service.CreateUser(ctx, &entity.User{Username: "budi", Password: "Kopi-Pagi-2026", Email: "BUDI@example.com"})
+	assert.ErrorIs(t, err, ErrEmailSudahAda)
+}
+
+func TestUserService_CreateUser_InvalidEmail(t *testing.T) {
+	ctrl, service, m := setupUserService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m.repo.EXPECT().FindByUsername(ctx, "budi").Return(nil, repository.ErrPenggunaTidakDitemukan)
+
+	_, err := service.CreateUser(ctx, &entity.User{Username: "budi", Password: "Kopi-Pagi-2026", Email: "Budi <budi@example.com>"})
+	assert.ErrorIs(t, err, ErrEmailTidakValid)
+}
+
+func TestUserService_CreateUser_InvalidRole(t *testing.T) {
+	ctrl, service, m := setupUserService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m.repo.EXPECT().FindByUsername(ctx, "baru").Return(nil, repository.ErrPenggunaTidakDitemukan)
+	m.roleRepo.EXPECT().FindByName(ctx, "superuser").Return(nil, repository.ErrRoleTidakDitemukan)
+
+	_, err := service.CreateUser(ctx, &entity.User{Username: "baru", Password: "Kopi-Pagi-2026", Roles: []string{"superuser"}})
+	assert.ErrorIs(t, err, ErrRoleTidakValid)
+}
+
+func TestUserService_CreateUser_WeakPassword(t *testing.T) {
+	ctrl, service, _ := setupUserService(t)
+	defer ctrl.Finish()
+
+	// Password ditolak sebelum menyentuh database, dan seluruh pelanggaran dilaporkan sekaligus
+	_, err := service.CreateUser(context.Background(), &entity.User{Username: "budi", Password: "budi123"})
+	assert.ErrorIs(t, err, password.ErrTidakMemenuhiKebijakan)
+	var policyErr *password.ValidationError
+	assert.ErrorAs(t, err, &policyErr)
+	codes := make([]string, len(policyErr.Violations))
+	for i, violation := range policyErr.Violations {
+		codes[i] = violation.Code
+	}
+	assert.Equal(t, []string{password.CodeTooShort, password.CodeMissingUppercase, password.CodeContainsUsername}, codes)
 }
 
 func TestUserService_CreateUser_ExistingUsername(t *testing.T) {
-	ctrl, service, mockRepo, _, _ := setupUserService(t)
+	ctrl, service, m := setupUserService(t)
 	defer ctrl.Finish()
 
 	ctx := context.Background()
-	user := &entity.User{Username: "existingUser"}
+	user := &entity.User{Username: "existingUser", Password: "Kopi-Pagi-2026"}
 
-	mockRepo.EXPECT().FindByUsername(ctx, user.Username).Return(user, nil)
+	m.repo.EXPECT().FindByUsername(ctx, user.Username).Return(user, nil)
 
 	_, err := service.CreateUser(ctx, user)
 	assert.ErrorIs(t, err, ErrUsernameSudahAda)
@@ -147,59 +437,164 @@ func TestUserService_CreateUser_ExistingUsername(t *testing.T) {
 // Kasus uji untuk UpdateUser
 
 func TestUserService_UpdateUser_ValidID(t *testing.T) {
-	ctrl, service, mockRepo, mockCache, _ := setupUserService(t)
+	ctrl, service, m := setupUserService(t)
 	defer ctrl.Finish()
 
 	ctx := context.Background()
-	existingUser := &entity.User{ID: 1, Username: "user1", FullName: "Old Name", Role: "user"}
+	existingUser := &entity.User{ID: 1, Username: "user1", FullName: "Old Name"}
 	updateData := &entity.User{ID: 1, FullName: "New Name"} // Hanya memperbarui FullName
 
 	// Mengharapkan repository untuk mengambil pengguna yang ada
-	mockRepo.EXPECT().FindByID(ctx, existingUser.ID).Return(existingUser, nil)
+	m.repo.EXPECT().FindByID(ctx, existingUser.ID).Return(existingUser, nil)
 
 	// Mengharapkan repository untuk memperbarui pengguna dengan hanya field yang tidak kosong
-	expectedUpdatedUser := &entity.User{ID: 1, Username: "user1", FullName: "New Name", Role: "user"}
-	mockRepo.EXPECT().Update(ctx, expectedUpdatedUser).Return(expectedUpdatedUser, nil)
+	expectedUpdatedUser := &entity.User{ID: 1, Username: "user1", FullName: "New Name"}
+	m.repo.EXPECT().Update(ctx, expectedUpdatedUser).Return(expectedUpdatedUser, nil)
 
-	// Mengharapkan cache dihapus setelah pembaruan
-	mockCache.EXPECT().Delete("pengguna:semua").Return(nil)
+	// Mengharapkan cache dihapus dan event dikirim setelah pembaruan
+	m.cache.EXPECT().Delete("pengguna:semua").Return(nil)
+	m.events.EXPECT().Record(ctx, userEvent(entity.EventUserUpdated, expectedUpdatedUser)).Return(nil)
 
 	// Menjalankan fungsi pembaruan service
 	result, err := service.UpdateUser(ctx, updateData)
 	assert.NoError(t, err)
 	assert.Equal(t, "New Name", result.FullName)
 	assert.Equal(t, "user1", result.Username) // Memastikan field yang tidak berubah tidak terpengaruh
-	assert.Equal(t, "user", result.Role)
+}
+
+func TestUserService_UpdateUser_EmailChangeRequiresVerification(t *testing.T) {
+	ctrl, service, m := setupUserService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	verifiedAt := time.Now()
+	existingUser := &entity.User{ID: 1, Username: "budi", Email: "lama@example.com", EmailVerifiedAt: &verifiedAt}
+	updatedUser := &entity.User{ID: 1, Username: "budi", Email: "baru@example.com", EmailVerifiedAt: &verifiedAt}
+
+	m.repo.EXPECT().FindByID(ctx, int64(1)).Return(existingUser, nil)
+	m.repo.EXPECT().FindByEmail(ctx, "baru@example.com").Return(nil, repository.ErrPenggunaTidakDitemukan)
+	m.repo.EXPECT().Update(ctx, gomock.Any()).Return(updatedUser, nil)
+	m.repo.EXPECT().SetEmailVerifiedAt(ctx, int64(1), nil).Return(nil)
+	m.events.EXPECT().Record(ctx, gomock.Any()).Return(nil)
+	m.cache.EXPECT().Delete("pengguna:semua").Return(nil)
+	// Izin kembali dibatasi dan token verifikasi dikirim ke email baru
+	m.authz.EXPECT().InvalidateUser(int64(1))
+	m.verify.EXPECT().Send(ctx, updatedUser).Return(nil)
+
+	result, err := service.UpdateUser(ctx, &entity.User{ID: 1, Email: "Baru@Example.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, "baru@example.com", result.Email)
+	assert.Nil(t, result.EmailVerifiedAt)
+}
+
+func TestUserService_UpdateUser_SameEmailKeepsVerification(t *testing.T) {
+	ctrl, service, m := setupUserService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	verifiedAt := time.Now()
+	existingUser := &entity.User{ID: 1, Username: "budi", Email: "budi@example.com", EmailVerifiedAt: &verifiedAt}
+
+	m.repo.EXPECT().FindByID(ctx, int64(1)).Return(existingUser, nil)
+	m.repo.EXPECT().FindByEmail(ctx, "budi@example.com").Return(existingUser, nil)
+	m.repo.EXPECT().Update(ctx, existingUser).Return(existingUser, nil)
+	m.events.EXPECT().Record(ctx, gomock.Any()).Return(nil)
+	m.cache.EXPECT().Delete("pengguna:semua").Return(nil)
+
+	result, err := service.UpdateUser(ctx, &entity.User{ID: 1, Email: "BUDI@example.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, &verifiedAt, result.EmailVerifiedAt)
+}
+
+func TestUserService_UpdateUser_EmailTakenByOtherUser(t *testing.T) {
+	ctrl, service, m := setupUserService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m.repo.EXPECT().FindByID(ctx, int64(1)).Return(&entity.User{ID: 1, Username: "budi"}, nil)
+	m.repo.EXPECT().FindByEmail(ctx, "ani@example.com").Return(&entity.User{ID: 2}, nil)
+
+	_, err := service.UpdateUser(ctx, &entity.User{ID: 1, Email: "ani@example.com"})
+	assert.ErrorIs(t, err, ErrEmailSudahAda)
 }
 
 func TestUserService_UpdateUser_PartialUpdate(t *testing.T) {
-	ctrl, service, mockRepo, mockCache, _ := setupUserService(t)
+	ctrl, service, m := setupUserService(t)
 	defer ctrl.Finish()
 
 	ctx := context.Background()
-	existingUser := &entity.User{ID: 2, Username: "user2", FullName: "Old Name", Role: "user"}
+	existingUser := &entity.User{ID: 2, Username: "user2", FullName: "Old Name"}
 	updateData := &entity.User{ID: 2, Username: "newUser2"} // Hanya memperbarui Username
 
 	// Mock pengambilan pengguna yang ada
-	mockRepo.EXPECT().FindByID(ctx, existingUser.ID).Return(existingUser, nil)
+	m.repo.EXPECT().FindByID(ctx, existingUser.ID).Return(existingUser, nil)
 
 	// Mengharapkan hanya Username yang diperbarui di repository
-	expectedUpdatedUser := &entity.User{ID: 2, Username: "newUser2", FullName: "Old Name", Role: "user"}
-	mockRepo.EXPECT().Update(ctx, expectedUpdatedUser).Return(expectedUpdatedUser, nil)
+	expectedUpdatedUser := &entity.User{ID: 2, Username: "newUser2", FullName: "Old Name"}
+	m.repo.EXPECT().Update(ctx, expectedUpdatedUser).Return(expectedUpdatedUser, nil)
 
 	// Mengharapkan cache dihapus
-	mockCache.EXPECT().Delete("pengguna:semua").Return(nil)
+	m.cache.EXPECT().Delete("pengguna:semua").Return(nil)
+	m.events.EXPECT().Record(ctx, userEvent(entity.EventUserUpdated, expectedUpdatedUser)).Return(nil)
 
 	// Menjalankan fungsi pembaruan service
 	result, err := service.UpdateUser(ctx, updateData)
 	assert.NoError(t, err)
 	assert.Equal(t, "newUser2", result.Username)
 	assert.Equal(t, "Old Name", result.FullName) // Memastikan field lain tetap sama
-	assert.Equal(t, "user", result.Role)
+}
+
+func TestUserService_UpdateUser_PasswordChangeRevokesSessions(t *testing.T) {
+	ctrl, service, m := setupUserService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	existingUser := &entity.User{ID: 4, Username: "user4", FullName: "Nama", Password: "hash-lama"}
+
+	m.repo.EXPECT().FindByID(ctx, existingUser.ID).Return(existingUser, nil)
+	m.repo.EXPECT().Update(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, user *entity.User) (*entity.User, error) {
+		assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(user.Password), []byte("Rahasia-Baru-42")))
+		return user, nil
+	})
+	// Token lama tetap berlaku setelah password diganti kecuali dicabut
+	m.token.EXPECT().RevokeAll(ctx, int64(4)).Return(nil)
+	m.cache.EXPECT().Delete("pengguna:semua").Return(nil)
+	m.events.EXPECT().Record(ctx, gomock.Any()).Return(nil)
+
+	_, err := service.UpdateUser(ctx, &entity.User{ID: 4, Password: "Rahasia-Baru-42"})
+	assert.NoError(t, err)
+}
+
+func TestUserService_UpdateUser_RevokeFailureRollsBack(t *testing.T) {
+	ctrl, service, m := setupUserService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	existingUser := &entity.User{ID: 5, Username: "user5"}
+
+	m.repo.EXPECT().FindByID(ctx, existingUser.ID).Return(existingUser, nil)
+	m.repo.EXPECT().Update(ctx, gomock.Any()).Return(existingUser, nil)
+	m.token.EXPECT().RevokeAll(ctx, int64(5)).Return(errors.New("redis mati"))
+
+	// Password baru tidak boleh tersimpan jika sesi lama gagal dicabut
+	_, err := service.UpdateUser(ctx, &entity.User{ID: 5, Password: "Rahasia-Baru-42"})
+	assert.Error(t, err)
+}
+
+func TestUserService_UpdateUser_PasswordContainsUsername(t *testing.T) {
+	ctrl, service, m := setupUserService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m.repo.EXPECT().FindByID(ctx, int64(6)).Return(&entity.User{ID: 6, Username: "sinta"}, nil)
+
+	// Username baru yang dikirim bersamaan juga ikut diperiksa
+	_, err := service.UpdateUser(ctx, &entity.User{ID: 6, Username: "rina", Password: "Rina-Rahasia-99"})
+	assert.ErrorIs(t, err, password.ErrTidakMemenuhiKebijakan)
 }
 
 func TestUserService_UpdateUser_InvalidID(t *testing.T) {
-	ctrl, service, _, _, _ := setupUserService(t)
+	ctrl, service, _ := setupUserService(t)
 	defer ctrl.Finish()
 
 	ctx := context.Background()
@@ -212,14 +607,14 @@ func TestUserService_UpdateUser_InvalidID(t *testing.T) {
 }
 
 func TestUserService_UpdateUser_NotFound(t *testing.T) {
-	ctrl, service, mockRepo, _, _ := setupUserService(t)
+	ctrl, service, m := setupUserService(t)
 	defer ctrl.Finish()
 
 	ctx := context.Background()
 	updateData := &entity.User{ID: 3, FullName: "Non-Existent User"}
 
 	// Mengharapkan repository mengembalikan error yang menunjukkan pengguna tidak ditemukan
-	mockRepo.EXPECT().FindByID(ctx, updateData.ID).Return(nil, ErrPenggunaTidakDitemukan)
+	m.repo.EXPECT().FindByID(ctx, updateData.ID).Return(nil, ErrPenggunaTidakDitemukan)
 
 	// Menjalankan fungsi pembaruan service
 	_, err := service.UpdateUser(ctx, updateData)
@@ -229,22 +624,24 @@ func TestUserService_UpdateUser_NotFound(t *testing.T) {
 // Kasus uji untuk DeleteUser
 
 func TestUserService_DeleteUser_ValidID(t *testing.T) {
-	ctrl, service, mockRepo, mockCache, _ := setupUserService(t)
+	ctrl, service, m := setupUserService(t)
 	defer ctrl.Finish()
 
 	ctx := context.Background()
 	userID := int64(1)
 
-	mockRepo.EXPECT().FindByID(ctx, userID).Return(&entity.User{ID: userID}, nil)
-	mockRepo.EXPECT().Delete(ctx, userID).Return(nil)
-	mockCache.EXPECT().Delete("pengguna:semua").Return(nil)
+	m.repo.EXPECT().FindByID(ctx, userID).Return(&entity.User{ID: userID}, nil)
+	m.repo.EXPECT().Delete(ctx, userID).Return(nil)
+	m.token.EXPECT().RevokeAll(ctx, userID).Return(nil)
+	m.cache.EXPECT().Delete("pengguna:semua").Return(nil)
+	m.events.EXPECT().Record(ctx, userEvent(entity.EventUserDeleted, &entity.User{ID: userID})).Return(nil)
 
 	err := service.DeleteUser(ctx, userID)
 	assert.NoError(t, err)
 }
 
 func TestUserService_DeleteUser_InvalidID(t *testing.T) {
-	ctrl, service, _, _, _ := setupUserService(t)
+	ctrl, service, _ := setupUserService(t)
 	defer ctrl.Finish()
 
 	ctx := context.Background()
@@ -254,3 +651,142 @@ func TestUserService_DeleteUser_InvalidID(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, "ID pengguna tidak valid", err.Error())
 }
+
+// Kasus uji untuk pengelolaan role
+
+var (
+	adminRole = &entity.Role{ID: 1, Name: entity.RoleAdmin, Permissions: entity.StringList{entity.PermissionAll}, BuiltIn: true}
+	userRole  = &entity.Role{ID: 2, Name: entity.RoleUser, BuiltIn: true}
+)
+
+func TestUserService_GrantRole(t *testing.T) {
+	ctrl, service, m := setupUserService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m.authz.EXPECT().Require(ctx, int64(1), entity.PermissionRoleManage).Return(nil).Times(2)
+	m.roleRepo.EXPECT().FindByName(ctx, entity.RoleAdmin).Return(adminRole, nil)
+	m.repo.EXPECT().FindByID(ctx, int64(2)).Return(&entity.User{ID: 2, Username: "budi"}, nil)
+	gomock.InOrder(
+		m.roleRepo.EXPECT().FindRoleNamesByUserIDs(ctx, []int64{2}).Return(map[int64][]string{2: {"user"}}, nil),
+		m.roleRepo.EXPECT().FindRoleNamesByUserIDs(ctx, []int64{2}).Return(map[int64][]string{2: {"admin", "user"}}, nil),
+	)
+	m.roleRepo.EXPECT().AssignToUser(ctx, int64(2), int64(1)).Return(nil)
+	// Token lama membawa role lama sehingga harus dicabut, dan cache izin dihapus
+	m.token.EXPECT().RevokeAll(ctx, int64(2)).Return(nil)
+	m.events.EXPECT().Record(ctx, gomock.Any()).Return(nil)
+	m.authz.EXPECT().InvalidateUser(int64(2))
+	m.cache.EXPECT().Delete("pengguna:semua").Return(nil)
+
+	user, err := service.GrantRole(ctx, 1, 2, entity.RoleAdmin)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"admin", "user"}, user.Roles)
+
+	m.roleRepo.EXPECT().FindByName(ctx, "superuser").Return(nil, repository.ErrRoleTidakDitemukan)
+	_, err = service.GrantRole(ctx, 1, 2, "superuser")
+	assert.ErrorIs(t, err, ErrRoleTidakValid)
+}
+
+func TestUserService_GrantRole_Forbidden(t *testing.T) {
+	ctrl, service, m := setupUserService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	// Service tetap memeriksa izin meskipun dipanggil di luar route HTTP
+	m.authz.EXPECT().Require(ctx, int64(5), entity.PermissionRoleManage).Return(ErrAksesDitolak)
+
+	_, err := service.GrantRole(ctx, 5, 5, entity.RoleAdmin)
+	assert.ErrorIs(t, err, ErrAksesDitolak)
+}
+
+func TestUserService_RevokeRole_LastAdmin(t *testing.T) {
+	ctrl, service, m := setupUserService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m.authz.EXPECT().Require(ctx, int64(1), entity.PermissionRoleManage).Return(nil).Times(2)
+	m.roleRepo.EXPECT().FindByName(ctx, entity.RoleAdmin).Return(adminRole, nil)
+	m.repo.EXPECT().FindByID(ctx, int64(1)).Return(&entity.User{ID: 1}, nil)
+	m.roleRepo.EXPECT().RemoveFromUser(ctx, int64(1), int64(1)).Return(int64(1), nil)
+	m.roleRepo.EXPECT().CountUsers(ctx, int64(1)).Return(int64(0), nil)
+
+	_, err := service.RevokeRole(ctx, 1, 1, entity.RoleAdmin)
+	assert.ErrorIs(t, err, ErrAdminTerakhir)
+
+	_, err = service.RevokeRole(ctx, 1, 1, entity.DefaultRole)
+	assert.ErrorIs(t, err, ErrRoleDefaultTidakDicabut)
+}
+
+func TestUserService_RevokeRole(t *testing.T) {
+	ctrl, service, m := setupUserService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m.authz.EXPECT().Require(ctx, int64(1), entity.PermissionRoleManage).Return(nil)
+	m.roleRepo.EXPECT().FindByName(ctx, entity.RoleAdmin).Return(adminRole, nil)
+	m.repo.EXPECT().FindByID(ctx, int64(3)).Return(&entity.User{ID: 3}, nil)
+	m.roleRepo.EXPECT().RemoveFromUser(ctx, int64(3), int64(1)).Return(int64(1), nil)
+	m.roleRepo.EXPECT().CountUsers(ctx, int64(1)).Return(int64(1), nil)
+	m.token.EXPECT().RevokeAll(ctx, int64(3)).Return(nil)
+	m.events.EXPECT().Record(ctx, gomock.Any()).Return(nil)
+	m.authz.EXPECT().InvalidateUser(int64(3))
+	m.cache.EXPECT().Delete("pengguna:semua").Return(nil)
+	m.roleRepo.EXPECT().FindRoleNamesByUserIDs(ctx, []int64{3}).Return(map[int64][]string{3: {"user"}}, nil)
+
+	user, err := service.RevokeRole(ctx, 1, 3, entity.RoleAdmin)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{entity.DefaultRole}, user.Roles)
+}
+
+func TestUserService_BootstrapAdmin(t *testing.T) {
+	ctrl, service, m := setupUserService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	admin := &entity.User{Username: "root", Password: "Admin-Pertama-01", FullName: "Administrator"}
+	created := &entity.User{ID: 1, Username: "root"}
+
+	m.roleRepo.EXPECT().FindByName(ctx, entity.RoleAdmin).Return(adminRole, nil).Times(2)
+	m.roleRepo.EXPECT().FindByName(ctx, entity.RoleUser).Return(userRole, nil)
+	m.roleRepo.EXPECT().CountUsers(ctx, int64(1)).Return(int64(0), nil)
+	m.repo.EXPECT().FindByUsername(ctx, "root").Return(nil, repository.ErrPenggunaTidakDitemukan).Times(2)
+	m.repo.EXPECT().Create(ctx, admin).Return(created, nil)
+	m.roleRepo.EXPECT().AssignToUser(ctx, int64(1), int64(2)).Return(nil)
+	m.roleRepo.EXPECT().AssignToUser(ctx, int64(1), int64(1)).Return(nil)
+	m.events.EXPECT().Record(ctx, gomock.Any()).Return(nil)
+	m.cache.EXPECT().Delete("pengguna:semua").Return(nil)
+	m.verify.EXPECT().Send(ctx, created).Return(nil)
+
+	user, err := service.BootstrapAdmin(ctx, admin)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{entity.RoleUser, entity.RoleAdmin}, user.Roles)
+}
+
+func TestUserService_BootstrapAdmin_AlreadyExists(t *testing.T) {
+	ctrl, service, m := setupUserService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	// Bootstrap tidak boleh dipakai untuk menambah admin jika sudah ada admin
+	m.roleRepo.EXPECT().FindByName(ctx, entity.RoleAdmin).Return(adminRole, nil)
+	m.roleRepo.EXPECT().CountUsers(ctx, int64(1)).Return(int64(1), nil)
+
+	_, err := service.BootstrapAdmin(ctx, &entity.User{Username: "penyusup", Password: "x"})
+	assert.ErrorIs(t, err, ErrAdminSudahAda)
+}
+
+// Kasus uji untuk UnlockLogin
+
+func TestUserService_UnlockLogin(t *testing.T) {
+	ctrl, service, m := setupUserService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	m.authz.EXPECT().Require(ctx, int64(1), entity.PermissionUserWrite).Return(nil)
+	m.repo.EXPECT().FindByID(ctx, int64(4)).Return(&entity.User{ID: 4, Username: "budi"}, nil)
+	m.guard.EXPECT().Unlock(ctx, "budi").Return(nil)
+	assert.NoError(t, service.UnlockLogin(ctx, 1, 4))
+
+	m.authz.EXPECT().Require(ctx, int64(4), entity.PermissionUserWrite).Return(ErrAksesDitolak)
+	assert.ErrorIs(t, service.UnlockLogin(ctx, 4, 4), ErrAksesDitolak)
+}
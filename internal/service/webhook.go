@@ -0,0 +1,332 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-todo/configs"
+	"go-todo/internal/entity"
+	"go-todo/internal/repository"
+	"go-todo/pkg/webhook"
+	"net/url"
+	"time"
+)
+
+var (
+	ErrURLWebhookTidakValid     = errors.New("url webhook harus berupa URL http atau https yang lengkap")
+	ErrJenisEventTidakValid     = errors.New("jenis event tidak dikenal")
+	ErrJenisEventKosong         = errors.New("minimal satu jenis event harus dipilih")
+	ErrWebhookTidakDitemukan    = errors.New("webhook tidak ditemukan")
+	ErrPengirimanTidakDitemukan = errors.New("pengiriman webhook tidak ditemukan")
+)
+
+// Jumlah log pengiriman yang ditampilkan per webhook
+const deliveryLogLimit = 100
+
+// WebhookService mengelola langganan webhook dan juga berperan sebagai EventSubscriber
+type WebhookService interface {
+	HandleEvent(ctx context.Context, event entity.Event) error
+	FindAll(ctx context.Context) ([]entity.Webhook, error)
+	FindByID(ctx context.Context, id int64) (*entity.Webhook, error)
+	Create(ctx context.Context, webhook *entity.Webhook) (*entity.Webhook, error)
+	Update(ctx context.Context, webhook *entity.Webhook) (*entity.Webhook, error)
+	Delete(ctx context.Context, id int64) error
+	Deliveries(ctx context.Context, webhookID int64) ([]entity.WebhookDelivery, error)
+	Replay(ctx context.Context, deliveryID int64) (*entity.WebhookDelivery, error)
+	DispatchDue(ctx context.Context) (int, error)
+}
+
+type webhookService struct {
+	webhookRepository repository.WebhookRepository
+	sender            webhook.Sender
+	config            configs.WebhookConfig
+}
+
+// NewWebhookService membuat instance baru dari WebhookService.
+// Nilai konfigurasi yang kosong diganti dengan nilai default.
+func NewWebhookService(
+	webhookRepository repository.WebhookRepository,
+	sender webhook.Sender,
+	config configs.WebhookConfig,
+) WebhookService {
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = 8
+	}
+	if config.BackoffBaseSeconds <= 0 {
+		config.BackoffBaseSeconds = 30
+	}
+	if config.BackoffMaxSeconds <= 0 {
+		config.BackoffMaxSeconds = 3600
+	}
+	if config.TimeoutSeconds <= 0 {
+		config.TimeoutSeconds = 10
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = 50
+	}
+	return &webhookService{
+		webhookRepository: webhookRepository,
+		sender:            sender,
+		config:            config,
+	}
+}
+
+// FindAll mengambil semua langganan webhook
+func (s *webhookService) FindAll(ctx context.Context) ([]entity.Webhook, error) {
+	webhooks, err := s.webhookRepository.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil webhook: %w", err)
+	}
+	return webhooks, nil
+}
+
+// FindByID mengambil satu webhook
+func (s *webhookService) FindByID(ctx context.Context, id int64) (*entity.Webhook, error) {
+	found, err := s.webhookRepository.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrWebhookTidakDitemukan) {
+			return nil, ErrWebhookTidakDitemukan
+		}
+		return nil, fmt.Errorf("gagal mengambil webhook: %w", err)
+	}
+	return found, nil
+}
+
+// Create mendaftarkan webhook baru. Secret dibuat otomatis jika tidak diisi.
+func (s *webhookService) Create(ctx context.Context, hook *entity.Webhook) (*entity.Webhook, error) {
+	if err := validateWebhook(hook); err != nil {
+		return nil, err
+	}
+	if hook.Secret == "" {
+		hook.Secret = newWebhookSecret()
+	}
+
+	created, err := s.webhookRepository.Create(ctx, hook)
+	if err != nil {
+		return nil, fmt.Errorf("gagal membuat webhook: %w", err)
+	}
+	return created, nil
+}
+
+// Update memperbarui webhook. Secret lama dipertahankan jika tidak diisi.
+func (s *webhookService) Update(ctx context.Context, hook *entity.Webhook) (*entity.Webhook, error) {
+	existing, err := s.FindByID(ctx, hook.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if hook.URL != "" {
+		existing.URL = hook.URL
+	}
+	if hook.EventTypes != nil {
+		existing.EventTypes = hook.EventTypes
+	}
+	if hook.Secret != "" {
+		existing.Secret = hook.Secret
+	}
+	existing.Active = hook.Active
+
+	if err := validateWebhook(existing); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.webhookRepository.Update(ctx, existing)
+	if err != nil {
+		return nil, fmt.Errorf("gagal memperbarui webhook: %w", err)
+	}
+	return updated, nil
+}
+
+// Delete menghapus webhook
+func (s *webhookService) Delete(ctx context.Context, id int64) error {
+	if err := s.webhookRepository.Delete(ctx, id); err != nil {
+		if errors.Is(err, repository.ErrWebhookTidakDitemukan) {
+			return ErrWebhookTidakDitemukan
+		}
+		return fmt.Errorf("gagal menghapus webhook: %w", err)
+	}
+	return nil
+}
+
+// Deliveries mengambil log pengiriman terbaru dari sebuah webhook
+func (s *webhookService) Deliveries(ctx context.Context, webhookID int64) ([]entity.WebhookDelivery, error) {
+	if _, err := s.FindByID(ctx, webhookID); err != nil {
+		return nil, err
+	}
+
+	deliveries, err := s.webhookRepository.FindDeliveriesByWebhookID(ctx, webhookID, deliveryLogLimit)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil log pengiriman: %w", err)
+	}
+	return deliveries, nil
+}
+
+// Replay mengantrekan ulang payload dari pengiriman sebelumnya sebagai pengiriman baru,
+// sehingga log pengiriman asli tetap utuh.
+func (s *webhookService) Replay(ctx context.Context, deliveryID int64) (*entity.WebhookDelivery, error) {
+	original, err := s.webhookRepository.FindDeliveryByID(ctx, deliveryID)
+	if err != nil {
+		if errors.Is(err, repository.ErrPengirimanTidakDitemukan) {
+			return nil, ErrPengirimanTidakDitemukan
+		}
+		return nil, fmt.Errorf("gagal mengambil pengiriman: %w", err)
+	}
+
+	now := time.Now()
+	replay := &entity.WebhookDelivery{
+		WebhookID:     original.WebhookID,
+		EventID:       original.EventID,
+		EventType:     original.EventType,
+		Payload:       original.Payload,
+		Status:        entity.DeliveryStatusPending,
+		NextAttemptAt: &now,
+		ReplayOf:      &original.ID,
+	}
+
+	created, err := s.webhookRepository.CreateDelivery(ctx, replay)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengantrekan ulang pengiriman: %w", err)
+	}
+	return created, nil
+}
+
+// HandleEvent mengantrekan event dari outbox untuk setiap webhook aktif yang melanggannya.
+// Pengiriman dilakukan oleh DispatchDue agar relay tidak menunggu penerima webhook.
+// ID event dipertahankan sehingga penerima dapat mengabaikan event yang sudah diterima.
+func (s *webhookService) HandleEvent(ctx context.Context, event entity.Event) error {
+	webhooks, err := s.webhookRepository.FindActiveByEventType(ctx, event.Type)
+	if err != nil {
+		return fmt.Errorf("gagal mengambil webhook untuk event %s: %w", event.Type, err)
+	}
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("gagal menyusun payload event %s: %w", event.Type, err)
+	}
+
+	now := time.Now()
+	for _, hook := range webhooks {
+		delivery := &entity.WebhookDelivery{
+			WebhookID:     hook.ID,
+			EventID:       event.ID,
+			EventType:     event.Type,
+			Payload:       payload,
+			Status:        entity.DeliveryStatusPending,
+			NextAttemptAt: &now,
+		}
+		if _, err := s.webhookRepository.CreateDelivery(ctx, delivery); err != nil {
+			return fmt.Errorf("gagal mengantrekan webhook %d: %w", hook.ID, err)
+		}
+	}
+	return nil
+}
+
+// DispatchDue mengirim pengiriman yang jatuh waktu dan mengembalikan jumlah yang diproses.
+// Pengiriman gagal dijadwalkan ulang dengan exponential backoff hingga MaxAttempts,
+// setelah itu berpindah ke status dead dan hanya dapat dikirim ulang melalui Replay.
+func (s *webhookService) DispatchDue(ctx context.Context) (int, error) {
+	timeout := time.Duration(s.config.TimeoutSeconds) * time.Second
+	now := time.Now()
+	// Lease lebih panjang dari batas waktu pengiriman agar tidak diambil instance lain
+	deliveries, err := s.webhookRepository.ClaimDueDeliveries(ctx, now, now.Add(2*timeout), s.config.BatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("gagal mengambil antrean webhook: %w", err)
+	}
+
+	for i := range deliveries {
+		s.deliver(ctx, &deliveries[i])
+	}
+	return len(deliveries), nil
+}
+
+// deliver melakukan satu percobaan pengiriman dan menyimpan hasilnya
+func (s *webhookService) deliver(ctx context.Context, delivery *entity.WebhookDelivery) {
+	hook, err := s.webhookRepository.FindByID(ctx, delivery.WebhookID)
+	if err != nil && !errors.Is(err, repository.ErrWebhookTidakDitemukan) {
+		// Kesalahan sementara: pengiriman akan diambil lagi setelah lease berakhir
+		fmt.Printf("kesalahan mengambil webhook %d: %v\n", delivery.WebhookID, err)
+		return
+	}
+	if err == nil && !hook.Active {
+		err = errors.New("webhook tidak aktif")
+	}
+	if err != nil {
+		// Webhook dihapus atau dinonaktifkan, pengiriman tidak dicoba ulang
+		delivery.Status = entity.DeliveryStatusDead
+		delivery.NextAttemptAt = nil
+		delivery.LastError = err.Error()
+		delivery.UpdatedAt = time.Now()
+		s.saveDelivery(ctx, delivery)
+		return
+	}
+
+	status, err := s.sender.Send(ctx, webhook.Request{
+		URL:       hook.URL,
+		Secret:    hook.Secret,
+		EventID:   delivery.EventID,
+		EventType: delivery.EventType,
+		Body:      delivery.Payload,
+	})
+
+	now := time.Now()
+	delivery.Attempts++
+	delivery.ResponseStatus = status
+	delivery.UpdatedAt = now
+
+	switch {
+	case err == nil:
+		delivery.Status = entity.DeliveryStatusSucceeded
+		delivery.NextAttemptAt = nil
+		delivery.LastError = ""
+		delivery.DeliveredAt = &now
+	case delivery.Attempts >= s.config.MaxAttempts:
+		delivery.Status = entity.DeliveryStatusDead
+		delivery.NextAttemptAt = nil
+		delivery.LastError = err.Error()
+	default:
+		next := now.Add(webhook.Backoff(delivery.Attempts,
+			time.Duration(s.config.BackoffBaseSeconds)*time.Second,
+			time.Duration(s.config.BackoffMaxSeconds)*time.Second))
+		delivery.NextAttemptAt = &next
+		delivery.LastError = err.Error()
+	}
+	s.saveDelivery(ctx, delivery)
+}
+
+func (s *webhookService) saveDelivery(ctx context.Context, delivery *entity.WebhookDelivery) {
+	if err := s.webhookRepository.UpdateDelivery(ctx, delivery); err != nil {
+		fmt.Printf("kesalahan menyimpan hasil pengiriman webhook %d: %v\n", delivery.ID, err)
+	}
+}
+
+// validateWebhook memeriksa URL dan jenis event dari webhook
+func validateWebhook(hook *entity.Webhook) error {
+	parsed, err := url.Parse(hook.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return ErrURLWebhookTidakValid
+	}
+	if len(hook.EventTypes) == 0 {
+		return ErrJenisEventKosong
+	}
+	for _, eventType := range hook.EventTypes {
+		if !entity.IsValidEventType(eventType) {
+			return fmt.Errorf("%w: %s", ErrJenisEventTidakValid, eventType)
+		}
+	}
+	return nil
+}
+
+// newWebhookSecret membuat secret acak 32 byte untuk penandatanganan HMAC
+func newWebhookSecret() string {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		panic(err)
+	}
+	return "whsec_" + hex.EncodeToString(buf)
+}
@@ -0,0 +1,257 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"go-todo/configs"
+	"go-todo/internal/entity"
+	"go-todo/internal/repository"
+	"go-todo/pkg/webhook"
+	mock_repository "go-todo/test/mock/repository"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// receiver adalah penerima webhook lokal yang memverifikasi tanda tangan setiap permintaan
+type receiver struct {
+	server   *httptest.Server
+	status   int
+	received []entity.Event
+}
+
+func newReceiver(t *testing.T, secret string, status int) *receiver {
+	r := &receiver{status: status}
+	r.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		timestamp, _ := strconv.ParseInt(req.Header.Get(webhook.HeaderTimestamp), 10, 64)
+		assert.True(t, webhook.Verify(secret, timestamp, body, req.Header.Get(webhook.HeaderSignature)))
+
+		var event entity.Event
+		assert.NoError(t, json.Unmarshal(body, &event))
+		r.received = append(r.received, event)
+		w.WriteHeader(r.status)
+	}))
+	t.Cleanup(r.server.Close)
+	return r
+}
+
+func setupWebhookService(t *testing.T) (*gomock.Controller, WebhookService, *mock_repository.MockWebhookRepository) {
+	ctrl := gomock.NewController(t)
+	mockRepo := mock_repository.NewMockWebhookRepository(ctrl)
+	service := NewWebhookService(mockRepo, webhook.NewSender(time.Second), configs.WebhookConfig{
+		MaxAttempts:        3,
+		BackoffBaseSeconds: 10,
+		BackoffMaxSeconds:  60,
+		TimeoutSeconds:     1,
+	})
+	return ctrl, service, mockRepo
+}
+
+func TestWebhookService_Create(t *testing.T) {
+	ctrl, service, mockRepo := setupWebhookService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	hook := &entity.Webhook{URL: "https://example.com/hook", EventTypes: entity.StringList{entity.EventTodoCreated}, Active: true}
+
+	mockRepo.EXPECT().Create(ctx, hook).Return(hook, nil)
+
+	created, err := service.Create(ctx, hook)
+	assert.NoError(t, err)
+	// Secret dibuat otomatis jika tidak diisi
+	assert.Regexp(t, "^whsec_[0-9a-f]{64}$", created.Secret)
+}
+
+func TestWebhookService_Create_Invalid(t *testing.T) {
+	ctrl, service, _ := setupWebhookService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	_, err := service.Create(ctx, &entity.Webhook{URL: "ftp://example.com", EventTypes: entity.StringList{entity.EventTodoCreated}})
+	assert.ErrorIs(t, err, ErrURLWebhookTidakValid)
+
+	_, err = service.Create(ctx, &entity.Webhook{URL: "https://example.com"})
+	assert.ErrorIs(t, err, ErrJenisEventKosong)
+
+	_, err = service.Create(ctx, &entity.Webhook{URL: "https://example.com", EventTypes: entity.StringList{"todo.archived"}})
+	assert.ErrorIs(t, err, ErrJenisEventTidakValid)
+}
+
+func TestWebhookService_HandleEvent(t *testing.T) {
+	ctrl, service, mockRepo := setupWebhookService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo.EXPECT().FindActiveByEventType(ctx, entity.EventTodoCreated).
+		Return([]entity.Webhook{{ID: 1}, {ID: 2}}, nil)
+
+	var eventIDs []string
+	mockRepo.EXPECT().CreateDelivery(ctx, gomock.Any()).Times(2).DoAndReturn(
+		func(_ context.Context, delivery *entity.WebhookDelivery) (*entity.WebhookDelivery, error) {
+			assert.Equal(t, entity.DeliveryStatusPending, delivery.Status)
+			assert.NotNil(t, delivery.NextAttemptAt)
+
+			var event entity.Event
+			assert.NoError(t, json.Unmarshal(delivery.Payload, &event))
+			assert.Equal(t, entity.EventTodoCreated, event.Type)
+			assert.Equal(t, int64(7), event.AggregateID)
+			eventIDs = append(eventIDs, delivery.EventID)
+			return delivery, nil
+		})
+
+	err := service.HandleEvent(ctx, entity.Event{
+		ID:            "evt_1",
+		Type:          entity.EventTodoCreated,
+		AggregateType: entity.AggregateTodo,
+		AggregateID:   7,
+		Data:          json.RawMessage(`{"id":7,"title":"Todo"}`),
+	})
+	assert.NoError(t, err)
+	// Semua webhook menerima ID event dari outbox
+	assert.Equal(t, []string{"evt_1", "evt_1"}, eventIDs)
+}
+
+func TestWebhookService_HandleEvent_Error(t *testing.T) {
+	ctrl, service, mockRepo := setupWebhookService(t)
+	defer ctrl.Finish()
+
+	// Kegagalan dikembalikan agar relay outbox mencoba ulang event
+	ctx := context.Background()
+	mockRepo.EXPECT().FindActiveByEventType(ctx, entity.EventTodoCreated).Return(nil, repository.ErrDatabaseError)
+
+	err := service.HandleEvent(ctx, entity.Event{ID: "evt_1", Type: entity.EventTodoCreated})
+	assert.ErrorIs(t, err, repository.ErrDatabaseError)
+}
+
+func TestWebhookService_DispatchDue_Success(t *testing.T) {
+	ctrl, service, mockRepo := setupWebhookService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	recv := newReceiver(t, "rahasia", http.StatusOK)
+	payload, _ := json.Marshal(entity.Event{ID: "evt_1", Type: entity.EventTodoCreated})
+
+	mockRepo.EXPECT().ClaimDueDeliveries(ctx, gomock.Any(), gomock.Any(), 50).
+		Return([]entity.WebhookDelivery{{ID: 10, WebhookID: 1, EventID: "evt_1", EventType: entity.EventTodoCreated, Payload: payload}}, nil)
+	mockRepo.EXPECT().FindByID(ctx, int64(1)).Return(&entity.Webhook{ID: 1, URL: recv.server.URL, Secret: "rahasia", Active: true}, nil)
+	mockRepo.EXPECT().UpdateDelivery(ctx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, delivery *entity.WebhookDelivery) error {
+			assert.Equal(t, entity.DeliveryStatusSucceeded, delivery.Status)
+			assert.Equal(t, 1, delivery.Attempts)
+			assert.Equal(t, http.StatusOK, delivery.ResponseStatus)
+			assert.NotNil(t, delivery.DeliveredAt)
+			assert.Nil(t, delivery.NextAttemptAt)
+			return nil
+		})
+
+	processed, err := service.DispatchDue(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, processed)
+	assert.Len(t, recv.received, 1)
+	assert.Equal(t, "evt_1", recv.received[0].ID)
+}
+
+func TestWebhookService_DispatchDue_RetryWithBackoff(t *testing.T) {
+	ctrl, service, mockRepo := setupWebhookService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	recv := newReceiver(t, "rahasia", http.StatusInternalServerError)
+
+	mockRepo.EXPECT().ClaimDueDeliveries(ctx, gomock.Any(), gomock.Any(), gomock.Any()).
+		Return([]entity.WebhookDelivery{{ID: 10, WebhookID: 1, Status: entity.DeliveryStatusPending, Attempts: 1, Payload: []byte("{}")}}, nil)
+	mockRepo.EXPECT().FindByID(ctx, int64(1)).Return(&entity.Webhook{ID: 1, URL: recv.server.URL, Secret: "rahasia", Active: true}, nil)
+	mockRepo.EXPECT().UpdateDelivery(ctx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, delivery *entity.WebhookDelivery) error {
+			// Percobaan kedua gagal, dijadwalkan ulang 20 detik kemudian (10s * 2)
+			assert.Equal(t, entity.DeliveryStatusPending, delivery.Status)
+			assert.Equal(t, 2, delivery.Attempts)
+			assert.Equal(t, http.StatusInternalServerError, delivery.ResponseStatus)
+			assert.Contains(t, delivery.LastError, "500")
+			assert.WithinDuration(t, time.Now().Add(20*time.Second), *delivery.NextAttemptAt, 2*time.Second)
+			return nil
+		})
+
+	_, err := service.DispatchDue(ctx)
+	assert.NoError(t, err)
+}
+
+func TestWebhookService_DispatchDue_DeadLetter(t *testing.T) {
+	ctrl, service, mockRepo := setupWebhookService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	recv := newReceiver(t, "rahasia", http.StatusBadGateway)
+
+	mockRepo.EXPECT().ClaimDueDeliveries(ctx, gomock.Any(), gomock.Any(), gomock.Any()).
+		Return([]entity.WebhookDelivery{
+			{ID: 10, WebhookID: 1, Attempts: 2, Payload: []byte("{}")},
+			{ID: 11, WebhookID: 2, Payload: []byte("{}")},
+		}, nil)
+	mockRepo.EXPECT().FindByID(ctx, int64(1)).Return(&entity.Webhook{ID: 1, URL: recv.server.URL, Secret: "rahasia", Active: true}, nil)
+	mockRepo.EXPECT().FindByID(ctx, int64(2)).Return(nil, repository.ErrWebhookTidakDitemukan)
+
+	var dead []int64
+	mockRepo.EXPECT().UpdateDelivery(ctx, gomock.Any()).Times(2).DoAndReturn(
+		func(_ context.Context, delivery *entity.WebhookDelivery) error {
+			// Percobaan terakhir yang gagal dan webhook yang sudah dihapus masuk ke dead-letter
+			assert.Equal(t, entity.DeliveryStatusDead, delivery.Status)
+			assert.Nil(t, delivery.NextAttemptAt)
+			dead = append(dead, delivery.ID)
+			return nil
+		})
+
+	processed, err := service.DispatchDue(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, processed)
+	assert.Equal(t, []int64{10, 11}, dead)
+}
+
+func TestWebhookService_Replay(t *testing.T) {
+	ctrl, service, mockRepo := setupWebhookService(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	original := &entity.WebhookDelivery{ID: 10, WebhookID: 1, EventID: "evt_1", Status: entity.DeliveryStatusDead, Attempts: 3, Payload: []byte("{}")}
+
+	mockRepo.EXPECT().FindDeliveryByID(ctx, int64(10)).Return(original, nil)
+	mockRepo.EXPECT().CreateDelivery(ctx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, delivery *entity.WebhookDelivery) (*entity.WebhookDelivery, error) {
+			delivery.ID = 11
+			return delivery, nil
+		})
+
+	replay, err := service.Replay(ctx, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, entity.DeliveryStatusPending, replay.Status)
+	assert.Equal(t, 0, replay.Attempts)
+	assert.Equal(t, "evt_1", replay.EventID)
+	assert.Equal(t, int64(10), *replay.ReplayOf)
+
+	mockRepo.EXPECT().FindDeliveryByID(ctx, int64(99)).Return(nil, repository.ErrPengirimanTidakDitemukan)
+	_, err = service.Replay(ctx, 99)
+	assert.ErrorIs(t, err, ErrPengirimanTidakDitemukan)
+}
+
+func TestWebhookService_Deliveries_NotFound(t *testing.T) {
+	ctrl, service, mockRepo := setupWebhookService(t)
+	defer ctrl.Finish()
+
+	mockRepo.EXPECT().FindByID(gomock.Any(), int64(5)).Return(nil, repository.ErrWebhookTidakDitemukan)
+
+	_, err := service.Deliveries(context.Background(), 5)
+	assert.ErrorIs(t, err, ErrWebhookTidakDitemukan)
+
+	mockRepo.EXPECT().FindByID(gomock.Any(), int64(6)).Return(nil, errors.New("database error"))
+	_, err = service.Deliveries(context.Background(), 6)
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrWebhookTidakDitemukan)
+}
@@ -10,7 +10,8 @@ import (
 )
 
 func InitDatabase(cfg configs.PostgresConfig) (*gorm.DB, error) {
-	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=disable TimeZone=Asia/Jakarta", cfg.Host, cfg.User, cfg.Password, cfg.Database, cfg.Port)
+	// Timestamp disimpan sebagai TIMESTAMPTZ; zona waktu pengguna diterapkan pada query
+	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=disable TimeZone=UTC", cfg.Host, cfg.User, cfg.Password, cfg.Database, cfg.Port)
 	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
 		Logger: logger.Default.LogMode(logger.Info),
 	})
@@ -0,0 +1,63 @@
+package eventstream
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// DefaultStream adalah nama Redis Stream tempat seluruh domain event aplikasi ditulis
+const DefaultStream = "go-todo-api:events"
+
+// Entry adalah satu domain event yang ditulis ke stream. Konsumen sebaiknya
+// mengabaikan ID yang sudah pernah diproses karena event dapat terkirim lebih dari sekali.
+type Entry struct {
+	ID            string
+	Type          string
+	AggregateType string
+	AggregateID   int64
+	Payload       []byte
+}
+
+// Writer menulis domain event ke Redis Stream agar dapat dikonsumsi layanan lain
+// melalui consumer group
+type Writer interface {
+	Write(ctx context.Context, entry Entry) error
+}
+
+type writer struct {
+	rdb    *redis.Client
+	stream string
+	maxLen int64
+}
+
+// NewWriter membuat Writer untuk stream tertentu. Stream dipangkas kira-kira
+// hingga maxLen entri; nilai 0 berarti tanpa batas.
+func NewWriter(rdb *redis.Client, stream string, maxLen int64) Writer {
+	if stream == "" {
+		stream = DefaultStream
+	}
+	return &writer{rdb: rdb, stream: stream, maxLen: maxLen}
+}
+
+// Write menambahkan event ke akhir stream
+func (w *writer) Write(ctx context.Context, entry Entry) error {
+	err := w.rdb.XAdd(ctx, &redis.XAddArgs{
+		Stream: w.stream,
+		MaxLen: w.maxLen,
+		Approx: w.maxLen > 0,
+		Values: []interface{}{
+			"id", entry.ID,
+			"type", entry.Type,
+			"aggregate_type", entry.AggregateType,
+			"aggregate_id", strconv.FormatInt(entry.AggregateID, 10),
+			"payload", string(entry.Payload),
+		},
+	}).Err()
+	if err != nil {
+		return fmt.Errorf("gagal menulis event ke stream: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package eventstream
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-redis/redismock/v9"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriter_Write(t *testing.T) {
+	db, mock := redismock.NewClientMock()
+	w := NewWriter(db, "", 10000)
+
+	mock.ExpectXAdd(&redis.XAddArgs{
+		Stream: DefaultStream,
+		MaxLen: 10000,
+		Approx: true,
+		Values: []interface{}{
+			"id", "evt_1",
+			"type", "todo.created",
+			"aggregate_type", "todo",
+			"aggregate_id", "7",
+			"payload", `{"id":7}`,
+		},
+	}).SetVal("1700000000000-0")
+
+	err := w.Write(context.Background(), Entry{
+		ID:            "evt_1",
+		Type:          "todo.created",
+		AggregateType: "todo",
+		AggregateID:   7,
+		Payload:       []byte(`{"id":7}`),
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWriter_Write_Error(t *testing.T) {
+	db, mock := redismock.NewClientMock()
+	w := NewWriter(db, "events", 0)
+
+	mock.ExpectXAdd(&redis.XAddArgs{
+		Stream: "events",
+		Values: []interface{}{
+			"id", "evt_1",
+			"type", "user.deleted",
+			"aggregate_type", "user",
+			"aggregate_id", "3",
+			"payload", `{}`,
+		},
+	}).SetErr(errors.New("redis error"))
+
+	err := w.Write(context.Background(), Entry{ID: "evt_1", Type: "user.deleted", AggregateType: "user", AggregateID: 3, Payload: []byte(`{}`)})
+	assert.Error(t, err)
+}
@@ -0,0 +1,332 @@
+package filter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrTidakValid menandakan definisi filter yang tidak dapat dikompilasi
+var ErrTidakValid = errors.New("definisi filter tidak valid")
+
+// Batas ukuran definisi agar query yang dihasilkan tetap wajar
+const (
+	maxDepth      = 5
+	maxConditions = 50
+	maxInValues   = 50
+)
+
+// Operator yang didukung
+const (
+	OpEq          = "eq"
+	OpNeq         = "neq"
+	OpContains    = "contains"
+	OpNotContains = "not_contains"
+	OpIn          = "in"
+	OpLt          = "lt"
+	OpLte         = "lte"
+	OpGt          = "gt"
+	OpGte         = "gte"
+	OpIsSet       = "is_set"
+	OpIsNotSet    = "is_not_set"
+)
+
+// Expr adalah satu node DSL filter: gabungan And atau Or dari node lain, atau satu
+// kondisi Field-Op-Value. Contoh "jatuh tempo minggu ini dengan tag home":
+//
+//	{"and": [
+//	  {"field": "due_date", "op": "lt", "value": "+7d"},
+//	  {"field": "tags", "op": "contains", "value": "home"}
+//	]}
+//
+// Nilai tanggal berupa YYYY-MM-DD atau tanggal relatif: today, tomorrow, yesterday, +Nd, -Nd.
+type Expr struct {
+	And   []Expr      `json:"and,omitempty"`
+	Or    []Expr      `json:"or,omitempty"`
+	Field string      `json:"field,omitempty"`
+	Op    string      `json:"op,omitempty"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+type fieldKind int
+
+const (
+	kindText fieldKind = iota
+	kindBool
+	kindTags
+	kindDate
+)
+
+type fieldSpec struct {
+	column string
+	kind   fieldKind
+	// zeroIsUnset berarti kolom tanggal tanpa nilai disimpan sebagai zero time, bukan NULL
+	zeroIsUnset bool
+}
+
+// fields adalah daftar putih field todo yang dapat difilter beserta kolomnya
+var fields = map[string]fieldSpec{
+	"title":        {column: "title", kind: kindText},
+	"content":      {column: "content", kind: kindText},
+	"project":      {column: "project", kind: kindText},
+	"priority":     {column: "priority", kind: kindText},
+	"completed":    {column: "completed", kind: kindBool},
+	"tags":         {column: "tags", kind: kindTags},
+	"due_date":     {column: "due_date", kind: kindDate, zeroIsUnset: true},
+	"created_at":   {column: "created_at", kind: kindDate},
+	"completed_at": {column: "completed_at", kind: kindDate},
+}
+
+var operators = map[fieldKind][]string{
+	kindText: {OpEq, OpNeq, OpContains, OpNotContains, OpIn},
+	kindBool: {OpEq},
+	kindTags: {OpContains, OpNotContains},
+	kindDate: {OpEq, OpLt, OpLte, OpGt, OpGte, OpIsSet, OpIsNotSet},
+}
+
+var relativeDayPattern = regexp.MustCompile(`^([+-])(\d{1,4})d$`)
+
+// Validate memeriksa struktur, field, operator, dan tipe nilai dari seluruh definisi
+func (e Expr) Validate() error {
+	conditions := 0
+	return e.validate(1, &conditions)
+}
+
+func (e Expr) validate(depth int, conditions *int) error {
+	if depth > maxDepth {
+		return fmt.Errorf("%w: kedalaman maksimal %d tingkat", ErrTidakValid, maxDepth)
+	}
+
+	isGroup := e.And != nil || e.Or != nil
+	switch {
+	case isGroup && e.Field != "":
+		return fmt.Errorf("%w: node tidak boleh berisi and/or sekaligus kondisi", ErrTidakValid)
+	case e.And != nil && e.Or != nil:
+		return fmt.Errorf("%w: gunakan and atau or secara terpisah", ErrTidakValid)
+	case isGroup:
+		children := e.And
+		if e.Or != nil {
+			children = e.Or
+		}
+		if len(children) == 0 {
+			return fmt.Errorf("%w: and/or tidak boleh kosong", ErrTidakValid)
+		}
+		for _, child := range children {
+			if err := child.validate(depth+1, conditions); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	*conditions++
+	if *conditions > maxConditions {
+		return fmt.Errorf("%w: maksimal %d kondisi", ErrTidakValid, maxConditions)
+	}
+	spec, ok := fields[e.Field]
+	if !ok {
+		return fmt.Errorf("%w: field %q tidak dikenal", ErrTidakValid, e.Field)
+	}
+	if !supports(spec.kind, e.Op) {
+		return fmt.Errorf("%w: operator %q tidak didukung untuk field %s", ErrTidakValid, e.Op, e.Field)
+	}
+	return validateValue(spec, e)
+}
+
+func supports(kind fieldKind, op string) bool {
+	for _, supported := range operators[kind] {
+		if op == supported {
+			return true
+		}
+	}
+	return false
+}
+
+func validateValue(spec fieldSpec, e Expr) error {
+	invalid := fmt.Errorf("%w: nilai untuk field %s dengan operator %s tidak valid", ErrTidakValid, e.Field, e.Op)
+	switch {
+	case e.Op == OpIsSet || e.Op == OpIsNotSet:
+		if e.Value != nil {
+			return invalid
+		}
+	case e.Op == OpIn:
+		values, ok := stringList(e.Value)
+		if !ok || len(values) == 0 || len(values) > maxInValues {
+			return invalid
+		}
+	case spec.kind == kindBool:
+		if _, ok := e.Value.(bool); !ok {
+			return invalid
+		}
+	case spec.kind == kindDate:
+		value, ok := e.Value.(string)
+		if !ok {
+			return invalid
+		}
+		if _, err := resolveDate(value, time.Now()); err != nil {
+			return invalid
+		}
+	default:
+		if value, ok := e.Value.(string); !ok || value == "" {
+			return invalid
+		}
+	}
+	return nil
+}
+
+// Compile mengubah definisi menjadi kondisi WHERE berparameter. Nama kolom hanya berasal
+// dari daftar putih sehingga nilai dari pengguna tidak pernah masuk ke teks SQL.
+// Tanggal relatif dihitung terhadap now, termasuk zona waktunya.
+func (e Expr) Compile(now time.Time) (string, []interface{}, error) {
+	if err := e.Validate(); err != nil {
+		return "", nil, err
+	}
+	return e.compile(now)
+}
+
+func (e Expr) compile(now time.Time) (string, []interface{}, error) {
+	if e.And != nil || e.Or != nil {
+		joiner, children := " AND ", e.And
+		if e.Or != nil {
+			joiner, children = " OR ", e.Or
+		}
+
+		parts := make([]string, 0, len(children))
+		var args []interface{}
+		for _, child := range children {
+			sql, childArgs, err := child.compile(now)
+			if err != nil {
+				return "", nil, err
+			}
+			parts = append(parts, "("+sql+")")
+			args = append(args, childArgs...)
+		}
+		return strings.Join(parts, joiner), args, nil
+	}
+
+	spec := fields[e.Field]
+	column := spec.column
+	switch spec.kind {
+	case kindBool:
+		return column + " = ?", []interface{}{e.Value}, nil
+	case kindTags:
+		tag, _ := json.Marshal([]string{e.Value.(string)})
+		if e.Op == OpNotContains {
+			return "NOT (" + column + " @> ?)", []interface{}{string(tag)}, nil
+		}
+		return column + " @> ?", []interface{}{string(tag)}, nil
+	case kindDate:
+		return compileDate(spec, e, now)
+	}
+
+	switch e.Op {
+	case OpEq:
+		return column + " = ?", []interface{}{e.Value}, nil
+	case OpNeq:
+		return column + " <> ?", []interface{}{e.Value}, nil
+	case OpContains:
+		return column + " ILIKE ?", []interface{}{"%" + EscapeLike(e.Value.(string)) + "%"}, nil
+	case OpNotContains:
+		return column + " NOT ILIKE ?", []interface{}{"%" + EscapeLike(e.Value.(string)) + "%"}, nil
+	default:
+		values, _ := stringList(e.Value)
+		return column + " IN ?", []interface{}{values}, nil
+	}
+}
+
+// compileDate membandingkan kolom dengan awal hari dari tanggal yang diminta
+func compileDate(spec fieldSpec, e Expr, now time.Time) (string, []interface{}, error) {
+	column := spec.column
+	isSet := column + " IS NOT NULL"
+	if spec.zeroIsUnset {
+		isSet = "EXTRACT(YEAR FROM " + column + ") > 1"
+	}
+
+	switch e.Op {
+	case OpIsSet:
+		return isSet, nil, nil
+	case OpIsNotSet:
+		return "NOT (" + isSet + ")", nil, nil
+	}
+
+	day, err := resolveDate(e.Value.(string), now)
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: %v", ErrTidakValid, err)
+	}
+	next := day.AddDate(0, 0, 1)
+
+	// Tanggal kosong yang disimpan sebagai zero time tidak boleh dianggap "sebelum" tanggal apa pun
+	guard := ""
+	if spec.zeroIsUnset {
+		guard = " AND " + isSet
+	}
+
+	switch e.Op {
+	case OpEq:
+		return column + " >= ? AND " + column + " < ?", []interface{}{day, next}, nil
+	case OpLt:
+		return column + " < ?" + guard, []interface{}{day}, nil
+	case OpLte:
+		return column + " < ?" + guard, []interface{}{next}, nil
+	case OpGt:
+		return column + " >= ?", []interface{}{next}, nil
+	default:
+		return column + " >= ?", []interface{}{day}, nil
+	}
+}
+
+// resolveDate mengubah nilai tanggal absolut atau relatif menjadi awal hari pada zona waktu now
+func resolveDate(value string, now time.Time) (time.Time, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	switch strings.ToLower(value) {
+	case "today":
+		return today, nil
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), nil
+	}
+
+	if match := relativeDayPattern.FindStringSubmatch(value); match != nil {
+		days, _ := strconv.Atoi(match[2])
+		if match[1] == "-" {
+			days = -days
+		}
+		return today.AddDate(0, 0, days), nil
+	}
+
+	day, err := time.ParseInLocation(time.DateOnly, value, now.Location())
+	if err != nil {
+		return time.Time{}, fmt.Errorf("tanggal %q tidak dikenali", value)
+	}
+	return day, nil
+}
+
+// stringList membaca nilai operator in yang berasal dari JSON ([]interface{}) atau kode ([]string)
+func stringList(value interface{}) ([]string, bool) {
+	switch v := value.(type) {
+	case []string:
+		return v, true
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil, false
+			}
+			values = append(values, s)
+		}
+		return values, true
+	}
+	return nil, false
+}
+
+// EscapeLike meloloskan karakter wildcard LIKE agar dicari secara harfiah, dengan backslash
+// sebagai karakter escape
+func EscapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
@@ -0,0 +1,92 @@
+package filter
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompile_AndOr(t *testing.T) {
+	jakarta, _ := time.LoadLocation("Asia/Jakarta")
+	now := time.Date(2026, 10, 18, 15, 30, 0, 0, jakarta)
+
+	var expr Expr
+	err := json.Unmarshal([]byte(`{"and": [
+		{"field": "due_date", "op": "lt", "value": "+7d"},
+		{"or": [
+			{"field": "tags", "op": "contains", "value": "home"},
+			{"field": "priority", "op": "in", "value": ["high", "urgent"]}
+		]},
+		{"field": "completed", "op": "eq", "value": false}
+	]}`), &expr)
+	assert.NoError(t, err)
+
+	sql, args, err := expr.Compile(now)
+	assert.NoError(t, err)
+	assert.Equal(t, "(due_date < ? AND EXTRACT(YEAR FROM due_date) > 1) AND ((tags @> ?) OR (priority IN ?)) AND (completed = ?)", sql)
+	assert.Equal(t, []interface{}{
+		time.Date(2026, 10, 25, 0, 0, 0, 0, jakarta),
+		`["home"]`,
+		[]string{"high", "urgent"},
+		false,
+	}, args)
+}
+
+func TestCompile_Dates(t *testing.T) {
+	now := time.Date(2026, 10, 18, 15, 30, 0, 0, time.UTC)
+	today := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	tomorrow := today.AddDate(0, 0, 1)
+
+	tests := []struct {
+		expr Expr
+		sql  string
+		args []interface{}
+	}{
+		{Expr{Field: "due_date", Op: OpEq, Value: "today"}, "due_date >= ? AND due_date < ?", []interface{}{today, tomorrow}},
+		{Expr{Field: "due_date", Op: OpLte, Value: "today"}, "due_date < ? AND EXTRACT(YEAR FROM due_date) > 1", []interface{}{tomorrow}},
+		{Expr{Field: "created_at", Op: OpGt, Value: "2026-10-17"}, "created_at >= ?", []interface{}{today}},
+		{Expr{Field: "completed_at", Op: OpGte, Value: "-1d"}, "completed_at >= ?", []interface{}{today.AddDate(0, 0, -1)}},
+		{Expr{Field: "due_date", Op: OpIsNotSet}, "NOT (EXTRACT(YEAR FROM due_date) > 1)", nil},
+		{Expr{Field: "completed_at", Op: OpIsSet}, "completed_at IS NOT NULL", nil},
+	}
+	for _, tt := range tests {
+		sql, args, err := tt.expr.Compile(now)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.sql, sql)
+		assert.Equal(t, tt.args, args)
+	}
+}
+
+func TestCompile_EscapesLikeWildcards(t *testing.T) {
+	sql, args, err := Expr{Field: "title", Op: OpContains, Value: "50%_off"}.Compile(time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, "title ILIKE ?", sql)
+	assert.Equal(t, []interface{}{`%50\%\_off%`}, args)
+}
+
+func TestValidate_Invalid(t *testing.T) {
+	deep := Expr{Field: "completed", Op: OpEq, Value: true}
+	for i := 0; i < maxDepth; i++ {
+		deep = Expr{And: []Expr{deep}}
+	}
+
+	tests := []Expr{
+		{},
+		{And: []Expr{}},
+		{And: []Expr{{Field: "title", Op: OpEq, Value: "a"}}, Or: []Expr{{Field: "title", Op: OpEq, Value: "b"}}},
+		{Field: "user_id", Op: OpEq, Value: "1"},
+		{Field: "title; DROP TABLE todos", Op: OpEq, Value: "a"},
+		{Field: "title", Op: "like", Value: "a"},
+		{Field: "completed", Op: OpEq, Value: "true"},
+		{Field: "due_date", Op: OpLt, Value: "next week"},
+		{Field: "due_date", Op: OpIsSet, Value: "today"},
+		{Field: "priority", Op: OpIn, Value: []interface{}{"high", 1}},
+		{Field: "tags", Op: OpEq, Value: "home"},
+		deep,
+	}
+	for _, expr := range tests {
+		assert.ErrorIs(t, expr.Validate(), ErrTidakValid, "%+v", expr)
+	}
+}
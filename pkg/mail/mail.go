@@ -0,0 +1,132 @@
+package mail
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// Message berisi satu email teks biasa
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Sender mengirim email ke penerima
+type Sender interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// SMTPConfig berisi alamat server SMTP dan identitas pengirim. Username kosong berarti
+// server tidak memerlukan autentikasi, misalnya relay lokal.
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+	Timeout  time.Duration
+}
+
+type smtpSender struct {
+	config SMTPConfig
+}
+
+// NewSMTPSender membuat Sender yang mengirim email melalui SMTP
+func NewSMTPSender(config SMTPConfig) Sender {
+	if config.Timeout <= 0 {
+		config.Timeout = 10 * time.Second
+	}
+	return &smtpSender{config: config}
+}
+
+// Send mengirim email. STARTTLS dipakai jika ditawarkan server, dan kredensial hanya
+// dikirim melalui koneksi terenkripsi atau ke server lokal.
+func (s *smtpSender) Send(ctx context.Context, msg Message) error {
+	if strings.ContainsAny(msg.To, "\r\n") || strings.ContainsAny(msg.Subject, "\r\n") {
+		return fmt.Errorf("header email tidak valid")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.config.Timeout)
+	defer cancel()
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(s.config.Host, s.config.Port))
+	if err != nil {
+		return fmt.Errorf("gagal terhubung ke server SMTP: %w", err)
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, s.config.Host)
+	if err != nil {
+		return fmt.Errorf("gagal memulai sesi SMTP: %w", err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: s.config.Host}); err != nil {
+			return fmt.Errorf("gagal memulai STARTTLS: %w", err)
+		}
+	}
+	if s.config.Username != "" {
+		auth := smtp.PlainAuth("", s.config.Username, s.config.Password, s.config.Host)
+		if err := client.Auth(auth); err != nil {
+			return fmt.Errorf("gagal autentikasi SMTP: %w", err)
+		}
+	}
+
+	if err := client.Mail(s.config.From); err != nil {
+		return fmt.Errorf("gagal mengirim email: %w", err)
+	}
+	if err := client.Rcpt(msg.To); err != nil {
+		return fmt.Errorf("gagal mengirim email: %w", err)
+	}
+	writer, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("gagal mengirim email: %w", err)
+	}
+	if _, err := writer.Write(s.compose(msg)); err != nil {
+		return fmt.Errorf("gagal mengirim email: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("gagal mengirim email: %w", err)
+	}
+	return client.Quit()
+}
+
+// compose menyusun header dan isi email dengan akhir baris CRLF
+func (s *smtpSender) compose(msg Message) []byte {
+	var b strings.Builder
+	b.WriteString("From: " + s.config.From + "\r\n")
+	b.WriteString("To: " + msg.To + "\r\n")
+	b.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", msg.Subject) + "\r\n")
+	b.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(strings.ReplaceAll(msg.Body, "\r\n", "\n"), "\n", "\r\n"))
+	return []byte(b.String())
+}
+
+type logSender struct{}
+
+// NewLogSender membuat Sender yang hanya mencetak email ke log. Hanya untuk pengembangan
+// tanpa server SMTP karena isi email, termasuk token, ikut tercetak. Builder hanya memakainya
+// jika MAIL DRIVER diatur ke log secara eksplisit.
+func NewLogSender() Sender {
+	return logSender{}
+}
+
+func (logSender) Send(ctx context.Context, msg Message) error {
+	fmt.Printf("email untuk %s: %s\n%s\n", msg.To, msg.Subject, msg.Body)
+	return nil
+}
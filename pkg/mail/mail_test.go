@@ -0,0 +1,108 @@
+package mail
+
+import (
+	"context"
+	"net"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeSMTPServer adalah server SMTP minimal yang menerima satu email dan mengirimkan
+// perintah serta isi DATA yang diterimanya melalui channel
+type fakeSMTPServer struct {
+	listener net.Listener
+	commands chan string
+	data     chan string
+}
+
+func startFakeSMTPServer(t *testing.T) *fakeSMTPServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	server := &fakeSMTPServer{listener: listener, commands: make(chan string, 16), data: make(chan string, 1)}
+	go server.serve()
+	t.Cleanup(func() { listener.Close() })
+	return server
+}
+
+func (s *fakeSMTPServer) serve() {
+	conn, err := s.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	text := textproto.NewConn(conn)
+	text.PrintfLine("220 localhost ESMTP")
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+		s.commands <- line
+		switch verb := strings.ToUpper(strings.Fields(line)[0]); verb {
+		case "EHLO", "HELO":
+			text.PrintfLine("250 localhost")
+		case "DATA":
+			text.PrintfLine("354 kirim isi email")
+			lines, _ := text.ReadDotLines()
+			s.data <- strings.Join(lines, "\n")
+			text.PrintfLine("250 diterima")
+		case "QUIT":
+			text.PrintfLine("221 sampai jumpa")
+			return
+		default:
+			text.PrintfLine("250 OK")
+		}
+	}
+}
+
+func (s *fakeSMTPServer) port() string {
+	_, port, _ := net.SplitHostPort(s.listener.Addr().String())
+	return port
+}
+
+func TestSMTPSender_Send(t *testing.T) {
+	server := startFakeSMTPServer(t)
+	sender := NewSMTPSender(SMTPConfig{Host: "127.0.0.1", Port: server.port(), From: "noreply@go-todo.local", Timeout: 5 * time.Second})
+
+	err := sender.Send(context.Background(), Message{
+		To:      "budi@example.com",
+		Subject: "Reset password",
+		Body:    "Baris pertama\nBaris kedua",
+	})
+	assert.NoError(t, err)
+
+	var commands []string
+	for len(server.commands) > 0 {
+		commands = append(commands, <-server.commands)
+	}
+	assert.Contains(t, commands, "MAIL FROM:<noreply@go-todo.local>")
+	assert.Contains(t, commands, "RCPT TO:<budi@example.com>")
+
+	data := <-server.data
+	headers, body, _ := strings.Cut(data, "\n\n")
+	assert.Contains(t, headers, "To: budi@example.com")
+	assert.Contains(t, headers, "Subject: Reset password")
+	assert.Equal(t, "Baris pertama\nBaris kedua", body)
+}
+
+func TestSMTPSender_RejectsHeaderInjection(t *testing.T) {
+	sender := NewSMTPSender(SMTPConfig{Host: "127.0.0.1", Port: "1", From: "noreply@go-todo.local"})
+
+	err := sender.Send(context.Background(), Message{To: "budi@example.com\r\nBcc: semua@example.com", Subject: "Reset"})
+	assert.Error(t, err)
+}
+
+func TestSMTPSender_ServerUnavailable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	_, port, _ := net.SplitHostPort(listener.Addr().String())
+	listener.Close()
+
+	sender := NewSMTPSender(SMTPConfig{Host: "127.0.0.1", Port: port, From: "noreply@go-todo.local", Timeout: time.Second})
+	assert.Error(t, sender.Send(context.Background(), Message{To: "budi@example.com", Subject: "Reset"}))
+}
@@ -0,0 +1,158 @@
+package password
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"go-todo/configs"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MaxBcryptBytes adalah batas panjang input bcrypt; byte setelahnya diabaikan diam-diam
+// sehingga password yang lebih panjang ditolak alih-alih dipotong.
+const MaxBcryptBytes = 72
+
+// Kode pelanggaran kebijakan password yang dikembalikan ke klien
+const (
+	CodeTooShort         = "too_short"
+	CodeTooLong          = "too_long"
+	CodeMissingUppercase = "missing_uppercase"
+	CodeMissingLowercase = "missing_lowercase"
+	CodeMissingDigit     = "missing_digit"
+	CodeMissingSymbol    = "missing_symbol"
+	CodeContainsUsername = "contains_username"
+	CodeCommon           = "common_password"
+)
+
+var ErrTidakMemenuhiKebijakan = errors.New("password tidak memenuhi kebijakan")
+
+//go:embed common_passwords.txt
+var commonPasswordList string
+
+// Violation adalah satu aturan kebijakan password yang dilanggar
+type Violation struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// ValidationError berisi seluruh pelanggaran sekaligus agar klien dapat menampilkan
+// semuanya tanpa harus mencoba berulang kali.
+type ValidationError struct {
+	Violations []Violation `json:"errors"`
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Violations))
+	for i, violation := range e.Violations {
+		messages[i] = violation.Message
+	}
+	return ErrTidakMemenuhiKebijakan.Error() + ": " + strings.Join(messages, "; ")
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrTidakMemenuhiKebijakan
+}
+
+// Policy memeriksa password baru sebelum di-hash
+type Policy struct {
+	MinLength        int
+	MaxBytes         int
+	RequireUppercase bool
+	RequireLowercase bool
+	RequireDigit     bool
+	RequireSymbol    bool
+	DisallowUsername bool
+	RejectCommon     bool
+
+	common map[string]bool
+}
+
+// NewPolicy membuat Policy dari konfigurasi. MaxBytes tidak pernah melebihi batas bcrypt.
+func NewPolicy(cfg configs.PasswordConfig) *Policy {
+	maxBytes := cfg.MaxBytes
+	if maxBytes <= 0 || maxBytes > MaxBcryptBytes {
+		maxBytes = MaxBcryptBytes
+	}
+	return &Policy{
+		MinLength:        cfg.MinLength,
+		MaxBytes:         maxBytes,
+		RequireUppercase: cfg.RequireUppercase,
+		RequireLowercase: cfg.RequireLowercase,
+		RequireDigit:     cfg.RequireDigit,
+		RequireSymbol:    cfg.RequireSymbol,
+		DisallowUsername: cfg.DisallowUsername,
+		RejectCommon:     cfg.RejectCommon,
+		common:           parseCommonPasswords(commonPasswordList),
+	}
+}
+
+// Validate mengembalikan *ValidationError jika password melanggar satu atau lebih aturan
+func (p *Policy) Validate(password, username string) error {
+	var violations []Violation
+	add := func(code, message string) {
+		violations = append(violations, Violation{Field: "password", Code: code, Message: message})
+	}
+
+	if utf8.RuneCountInString(password) < p.MinLength {
+		add(CodeTooShort, fmt.Sprintf("password minimal %d karakter", p.MinLength))
+	}
+	if len(password) > p.MaxBytes {
+		add(CodeTooLong, fmt.Sprintf("password maksimal %d byte", p.MaxBytes))
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			hasSymbol = true
+		}
+	}
+	if p.RequireUppercase && !hasUpper {
+		add(CodeMissingUppercase, "password harus mengandung huruf besar")
+	}
+	if p.RequireLowercase && !hasLower {
+		add(CodeMissingLowercase, "password harus mengandung huruf kecil")
+	}
+	if p.RequireDigit && !hasDigit {
+		add(CodeMissingDigit, "password harus mengandung angka")
+	}
+	if p.RequireSymbol && !hasSymbol {
+		add(CodeMissingSymbol, "password harus mengandung simbol")
+	}
+
+	lowered := strings.ToLower(password)
+	username = strings.ToLower(strings.TrimSpace(username))
+	if p.DisallowUsername && username != "" && strings.Contains(lowered, username) {
+		add(CodeContainsUsername, "password tidak boleh mengandung username")
+	}
+	if p.RejectCommon && p.common[lowered] {
+		add(CodeCommon, "password terlalu umum dan mudah ditebak")
+	}
+
+	if len(violations) > 0 {
+		return &ValidationError{Violations: violations}
+	}
+	return nil
+}
+
+// parseCommonPasswords membaca daftar password umum, satu password per baris
+func parseCommonPasswords(list string) map[string]bool {
+	common := make(map[string]bool)
+	for _, line := range strings.Split(list, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		common[strings.ToLower(line)] = true
+	}
+	return common
+}
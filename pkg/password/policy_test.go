@@ -0,0 +1,68 @@
+package password
+
+import (
+	"go-todo/configs"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func violationCodes(err error) []string {
+	validationErr, ok := err.(*ValidationError)
+	if !ok {
+		return nil
+	}
+	codes := make([]string, len(validationErr.Violations))
+	for i, violation := range validationErr.Violations {
+		codes[i] = violation.Code
+	}
+	return codes
+}
+
+func TestPolicy_Validate(t *testing.T) {
+	policy := NewPolicy(configs.PasswordConfig{
+		MinLength:        10,
+		RequireUppercase: true,
+		RequireLowercase: true,
+		RequireDigit:     true,
+		RequireSymbol:    true,
+		DisallowUsername: true,
+		RejectCommon:     true,
+	})
+
+	assert.NoError(t, policy.Validate("Kopi-Pagi-2026", "budi"))
+
+	tests := []struct {
+		password string
+		username string
+		codes    []string
+	}{
+		{"", "budi", []string{CodeTooShort, CodeMissingUppercase, CodeMissingLowercase, CodeMissingDigit, CodeMissingSymbol}},
+		{"kopi-pagi-2026", "budi", []string{CodeMissingUppercase}},
+		{"KopiPagi2026", "budi", []string{CodeMissingSymbol}},
+		{"Budi-Kopi-2026", "BUDI", []string{CodeContainsUsername}},
+		// Daftar password umum dibandingkan tanpa membedakan huruf besar dan kecil
+		{"P@ssw0rd", "budi", []string{CodeTooShort, CodeCommon}},
+		{strings.Repeat("Aa1-", 19), "budi", []string{CodeTooLong}},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.codes, violationCodes(policy.Validate(tt.password, tt.username)), tt.password)
+	}
+}
+
+func TestPolicy_MaxBytesIsBcryptLimit(t *testing.T) {
+	// Konfigurasi lebih dari 72 byte tetap dibatasi karena bcrypt memotong sisanya
+	policy := NewPolicy(configs.PasswordConfig{MaxBytes: 200})
+	assert.Equal(t, MaxBcryptBytes, policy.MaxBytes)
+
+	// Panjang minimal dihitung dalam karakter, panjang maksimal dalam byte
+	assert.NoError(t, policy.Validate(strings.Repeat("é", 36), ""))
+	assert.Equal(t, []string{CodeTooLong}, violationCodes(policy.Validate(strings.Repeat("é", 37), "")))
+}
+
+func TestValidationError(t *testing.T) {
+	err := NewPolicy(configs.PasswordConfig{MinLength: 10, RequireDigit: true}).Validate("pendek", "")
+	assert.ErrorIs(t, err, ErrTidakMemenuhiKebijakan)
+	assert.Equal(t, "password tidak memenuhi kebijakan: password minimal 10 karakter; password harus mengandung angka", err.Error())
+}
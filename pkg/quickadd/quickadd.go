@@ -0,0 +1,368 @@
+package quickadd
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Result adalah hasil interpretasi teks quick-add
+type Result struct {
+	Title    string     `json:"title"`
+	DueDate  *time.Time `json:"due_date"`
+	Tags     []string   `json:"tags"`
+	Priority string     `json:"priority"`
+}
+
+// Jam default ketika hanya tanggal yang disebutkan: todo jatuh tempo di akhir hari
+const (
+	defaultHour   = 23
+	defaultMinute = 59
+)
+
+var (
+	isoDatePattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+	timePattern    = regexp.MustCompile(`^(\d{1,2})(?:[:.](\d{2}))?(am|pm)?$`)
+)
+
+var priorities = map[string]string{
+	"low":      "low",
+	"rendah":   "low",
+	"medium":   "medium",
+	"med":      "medium",
+	"normal":   "medium",
+	"sedang":   "medium",
+	"high":     "high",
+	"tinggi":   "high",
+	"penting":  "high",
+	"urgent":   "urgent",
+	"mendesak": "urgent",
+	"darurat":  "urgent",
+}
+
+var weekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+	"minggu":    time.Sunday,
+	"senin":     time.Monday,
+	"selasa":    time.Tuesday,
+	"rabu":      time.Wednesday,
+	"kamis":     time.Thursday,
+	"jumat":     time.Friday,
+	"sabtu":     time.Saturday,
+}
+
+// parser menyimpan state selama teks diproses token demi token
+type parser struct {
+	now     time.Time
+	words   []string
+	date    time.Time
+	dateSet bool
+	hour    int
+	minute  int
+	timeSet bool
+	exact   *time.Time
+}
+
+// Parse menginterpretasikan teks bebas seperti "Bayar sewa besok jam 9 pagi #rumah !tinggi".
+// Tanggal relatif dihitung dari now, sehingga now harus berada di zona waktu pengguna.
+// Mendukung frasa bahasa Inggris dan Indonesia.
+func Parse(input string, now time.Time) Result {
+	tokens := strings.Fields(input)
+	p := &parser{now: now, words: make([]string, len(tokens))}
+	for i, token := range tokens {
+		p.words[i] = normalize(token)
+	}
+
+	result := Result{Tags: []string{}}
+	title := make([]string, 0, len(tokens))
+
+	for i := 0; i < len(tokens); {
+		token := tokens[i]
+
+		if strings.HasPrefix(token, "#") && len(token) > 1 {
+			result.Tags = appendUnique(result.Tags, strings.ToLower(strings.TrimPrefix(p.words[i], "#")))
+			i++
+			continue
+		}
+		if strings.HasPrefix(token, "!") {
+			if priority, ok := priorities[strings.TrimPrefix(p.words[i], "!")]; ok {
+				result.Priority = priority
+				i++
+				continue
+			}
+		}
+		if n := p.matchDatePhrase(i); n > 0 {
+			i += n
+			continue
+		}
+		if n := p.matchTimePhrase(i); n > 0 {
+			i += n
+			continue
+		}
+
+		title = append(title, token)
+		i++
+	}
+
+	result.Title = strings.Join(title, " ")
+	result.DueDate = p.dueDate()
+	return result
+}
+
+// matchDatePhrase mencoba mencocokkan frasa tanggal (beserta kata depan opsional) pada posisi i
+func (p *parser) matchDatePhrase(i int) int {
+	switch p.word(i) {
+	case "on", "pada", "by", "due":
+		if n := p.matchDate(i + 1); n > 0 {
+			return n + 1
+		}
+		return 0
+	}
+	return p.matchDate(i)
+}
+
+// matchDate mencocokkan frasa tanggal pada posisi i dan mengembalikan jumlah token yang dipakai
+func (p *parser) matchDate(i int) int {
+	today := time.Date(p.now.Year(), p.now.Month(), p.now.Day(), 0, 0, 0, 0, p.now.Location())
+	w := p.word(i)
+
+	switch {
+	case w == "today", w == "hari" && p.word(i+1) == "ini":
+		p.setDate(today)
+		if w == "hari" {
+			return 2
+		}
+		return 1
+	case w == "tonight", w == "malam" && p.word(i+1) == "ini", w == "nanti" && p.word(i+1) == "malam":
+		p.setDate(today)
+		if !p.timeSet {
+			p.setTime(20, 0)
+		}
+		if w == "tonight" {
+			return 1
+		}
+		return 2
+	case w == "tomorrow", w == "besok":
+		p.setDate(today.AddDate(0, 0, 1))
+		return 1
+	case w == "lusa":
+		p.setDate(today.AddDate(0, 0, 2))
+		return 1
+	case w == "day" && p.word(i+1) == "after" && p.word(i+2) == "tomorrow":
+		p.setDate(today.AddDate(0, 0, 2))
+		return 3
+	case w == "next" && p.word(i+1) == "week", w == "minggu" && p.word(i+1) == "depan":
+		p.setDate(today.AddDate(0, 0, 7))
+		return 2
+	case w == "next" && p.word(i+1) == "month", w == "bulan" && p.word(i+1) == "depan":
+		p.setDate(today.AddDate(0, 1, 0))
+		return 2
+	case w == "in" || w == "dalam":
+		return p.matchRelative(i)
+	case isoDatePattern.MatchString(w):
+		date, err := time.ParseInLocation("2006-01-02", w, p.now.Location())
+		if err != nil {
+			return 0
+		}
+		p.setDate(date)
+		return 1
+	}
+
+	return p.matchWeekday(i, today)
+}
+
+// matchRelative mencocokkan frasa "in 3 days" atau "dalam 2 jam"
+func (p *parser) matchRelative(i int) int {
+	amount, err := strconv.Atoi(p.word(i + 1))
+	if err != nil || amount <= 0 {
+		return 0
+	}
+
+	today := time.Date(p.now.Year(), p.now.Month(), p.now.Day(), 0, 0, 0, 0, p.now.Location())
+	switch p.word(i + 2) {
+	case "day", "days", "hari":
+		p.setDate(today.AddDate(0, 0, amount))
+	case "week", "weeks", "minggu":
+		p.setDate(today.AddDate(0, 0, 7*amount))
+	case "month", "months", "bulan":
+		p.setDate(today.AddDate(0, amount, 0))
+	case "hour", "hours", "jam":
+		exact := p.now.Add(time.Duration(amount) * time.Hour).Truncate(time.Minute)
+		p.exact = &exact
+	case "minute", "minutes", "menit":
+		exact := p.now.Add(time.Duration(amount) * time.Minute).Truncate(time.Minute)
+		p.exact = &exact
+	default:
+		return 0
+	}
+	return 3
+}
+
+// matchWeekday mencocokkan nama hari seperti "friday", "next monday", "hari senin", atau "senin depan".
+// Nama hari selalu diartikan sebagai hari tersebut berikutnya setelah hari ini.
+func (p *parser) matchWeekday(i int, today time.Time) int {
+	consumed := 0
+	if w := p.word(i); w == "next" || w == "hari" {
+		consumed++
+	}
+
+	weekday, ok := weekdays[p.word(i+consumed)]
+	if !ok {
+		return 0
+	}
+	consumed++
+	if p.word(i+consumed) == "depan" {
+		consumed++
+	}
+
+	days := (int(weekday) - int(today.Weekday()) + 7) % 7
+	if days == 0 {
+		days = 7
+	}
+	p.setDate(today.AddDate(0, 0, days))
+	return consumed
+}
+
+// matchTimePhrase mencocokkan waktu seperti "9am", "at 14:30", "jam 9 pagi", atau "pukul 19.00"
+func (p *parser) matchTimePhrase(i int) int {
+	switch p.word(i) {
+	case "at", "jam", "pukul":
+		if n := p.matchTime(i+1, true); n > 0 {
+			return n + 1
+		}
+		return 0
+	}
+	return p.matchTime(i, false)
+}
+
+// matchTime mencocokkan token waktu. Angka tanpa menit atau am/pm hanya diterima setelah penanda waktu.
+func (p *parser) matchTime(i int, marked bool) int {
+	match := timePattern.FindStringSubmatch(p.word(i))
+	if match == nil {
+		return 0
+	}
+
+	consumed := 1
+	meridiem := match[3]
+	if meridiem == "" {
+		if next := p.word(i + 1); next == "am" || next == "pm" {
+			meridiem = next
+			consumed++
+		}
+	}
+	if match[2] == "" && meridiem == "" && !marked {
+		return 0
+	}
+
+	hour, _ := strconv.Atoi(match[1])
+	minute := 0
+	if match[2] != "" {
+		minute, _ = strconv.Atoi(match[2])
+	}
+
+	switch meridiem {
+	case "am":
+		if hour == 12 {
+			hour = 0
+		}
+	case "pm":
+		if hour < 12 {
+			hour += 12
+		}
+	default:
+		// Keterangan waktu dalam bahasa Indonesia
+		switch p.word(i + consumed) {
+		case "pagi":
+			if hour == 12 {
+				hour = 0
+			}
+			consumed++
+		case "siang":
+			if hour <= 5 {
+				hour += 12
+			}
+			consumed++
+		case "sore":
+			if hour < 12 {
+				hour += 12
+			}
+			consumed++
+		case "malam":
+			if hour >= 6 && hour < 12 {
+				hour += 12
+			}
+			consumed++
+		}
+	}
+
+	if hour > 23 || minute > 59 {
+		return 0
+	}
+	p.setTime(hour, minute)
+	return consumed
+}
+
+// dueDate menggabungkan tanggal dan jam yang ditemukan menjadi due date
+func (p *parser) dueDate() *time.Time {
+	if p.exact != nil {
+		return p.exact
+	}
+	if !p.dateSet && !p.timeSet {
+		return nil
+	}
+
+	date := p.now
+	if p.dateSet {
+		date = p.date
+	}
+	hour, minute := defaultHour, defaultMinute
+	if p.timeSet {
+		hour, minute = p.hour, p.minute
+	}
+
+	due := time.Date(date.Year(), date.Month(), date.Day(), hour, minute, 0, 0, p.now.Location())
+	// Jika hanya jam yang disebutkan dan sudah lewat, gunakan hari berikutnya
+	if !p.dateSet && due.Before(p.now) {
+		due = due.AddDate(0, 0, 1)
+	}
+	return &due
+}
+
+func (p *parser) setDate(date time.Time) {
+	p.date = date
+	p.dateSet = true
+}
+
+func (p *parser) setTime(hour, minute int) {
+	p.hour, p.minute = hour, minute
+	p.timeSet = true
+}
+
+// word mengembalikan token yang sudah dinormalisasi, atau string kosong jika di luar batas
+func (p *parser) word(i int) string {
+	if i < 0 || i >= len(p.words) {
+		return ""
+	}
+	return p.words[i]
+}
+
+// normalize mengubah token menjadi huruf kecil tanpa tanda baca di akhir
+func normalize(token string) string {
+	return strings.TrimRight(strings.ToLower(token), ",;.?")
+}
+
+func appendUnique(values []string, value string) []string {
+	for _, existing := range values {
+		if existing == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
@@ -0,0 +1,58 @@
+package quickadd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	jakarta := time.FixedZone("WIB", 7*60*60)
+	// Sabtu, 17 Oktober 2026 pukul 10:00 WIB
+	now := time.Date(2026, 10, 17, 10, 0, 0, 0, jakarta)
+	at := func(day, hour, minute int) *time.Time {
+		due := time.Date(2026, 10, day, hour, minute, 0, 0, jakarta)
+		return &due
+	}
+
+	tests := []struct {
+		name     string
+		input    string
+		title    string
+		due      *time.Time
+		tags     []string
+		priority string
+	}{
+		{"english example", "Pay rent tomorrow 9am #home !high", "Pay rent", at(18, 9, 0), []string{"home"}, "high"},
+		{"indonesian besok jam pagi", "Bayar listrik besok jam 9 pagi #rumah !tinggi", "Bayar listrik", at(18, 9, 0), []string{"rumah"}, "high"},
+		{"indonesian malam", "Telepon ibu hari ini pukul 7 malam", "Telepon ibu", at(17, 19, 0), []string{}, ""},
+		{"lusa without time", "Kirim laporan lusa !mendesak", "Kirim laporan", at(19, 23, 59), []string{}, "urgent"},
+		{"weekday english", "Team sync next monday at 14:30", "Team sync", at(19, 14, 30), []string{}, ""},
+		{"weekday indonesian", "Rapat hari jumat jam 13.00 #kantor #Kantor", "Rapat", at(23, 13, 0), []string{"kantor"}, ""},
+		{"same weekday means next week", "Grocery saturday", "Grocery", at(24, 23, 59), []string{}, ""},
+		{"relative days", "Renew passport in 3 days", "Renew passport", at(20, 23, 59), []string{}, ""},
+		{"relative hours indonesian", "Angkat jemuran dalam 2 jam", "Angkat jemuran", at(17, 12, 0), []string{}, ""},
+		{"time only already passed", "Stand-up 9:15am", "Stand-up", at(18, 9, 15), []string{}, ""},
+		{"time only upcoming", "Lunch 12pm", "Lunch", at(17, 12, 0), []string{}, ""},
+		{"iso date", "File taxes on 2026-10-31 !low", "File taxes", at(31, 23, 59), []string{}, "low"},
+		{"tonight", "Watch movie tonight", "Watch movie", at(17, 20, 0), []string{}, ""},
+		{"plain numbers stay in title", "Buy 3 apples", "Buy 3 apples", nil, []string{}, ""},
+		{"unknown priority stays in title", "Wow !amazing", "Wow !amazing", nil, []string{}, ""},
+		{"trailing punctuation", "Call Budi tomorrow, 5pm.", "Call Budi", at(18, 17, 0), []string{}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Parse(tt.input, now)
+			assert.Equal(t, tt.title, result.Title)
+			assert.Equal(t, tt.tags, result.Tags)
+			assert.Equal(t, tt.priority, result.Priority)
+			if tt.due == nil {
+				assert.Nil(t, result.DueDate)
+			} else if assert.NotNil(t, result.DueDate) {
+				assert.True(t, tt.due.Equal(*result.DueDate), "due date %v, expected %v", result.DueDate, tt.due)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// historySize adalah jumlah event terakhir per pengguna yang disimpan untuk resume
+	historySize = 1000
+	// historyTTL menghapus riwayat pengguna yang tidak menerima event dalam waktu lama
+	historyTTL = 24 * time.Hour
+)
+
+// Message adalah event yang dikirim ke klien melalui SSE atau WebSocket.
+// ID berasal dari Redis Stream sehingga terurut dan dapat digunakan sebagai Last-Event-ID.
+type Message struct {
+	ID   string          `json:"id"`
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// Broker menyebarkan event ke semua instance aplikasi melalui Redis pub/sub
+// dan menyimpan riwayat singkat per pengguna di Redis Stream untuk resume.
+type Broker interface {
+	Publish(ctx context.Context, userID int64, eventType string, data interface{}) error
+	Subscribe(ctx context.Context, userID int64, lastEventID string) (<-chan Message, error)
+}
+
+type broker struct {
+	rdb *redis.Client
+}
+
+// NewBroker membuat Broker di atas klien Redis yang sudah ada
+func NewBroker(rdb *redis.Client) Broker {
+	return &broker{rdb: rdb}
+}
+
+// Publish menyimpan event ke riwayat pengguna lalu menyiarkannya ke semua subscriber
+func (b *broker) Publish(ctx context.Context, userID int64, eventType string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("gagal melakukan marshal event: %w", err)
+	}
+
+	stream := streamKey(userID)
+	id, err := b.rdb.XAdd(ctx, &redis.XAddArgs{
+		Stream: stream,
+		MaxLen: historySize,
+		Approx: true,
+		Values: map[string]interface{}{"type": eventType, "data": string(payload)},
+	}).Result()
+	if err != nil {
+		return fmt.Errorf("gagal menyimpan riwayat event: %w", err)
+	}
+	b.rdb.Expire(ctx, stream, historyTTL)
+
+	message, err := json.Marshal(Message{ID: id, Type: eventType, Data: payload})
+	if err != nil {
+		return fmt.Errorf("gagal melakukan marshal pesan: %w", err)
+	}
+	if err := b.rdb.Publish(ctx, channelKey(userID), message).Err(); err != nil {
+		return fmt.Errorf("gagal menyiarkan event: %w", err)
+	}
+	return nil
+}
+
+// Subscribe mengirim event milik pengguna hingga ctx dibatalkan. Jika lastEventID diisi,
+// event setelah ID tersebut dikirim terlebih dahulu dari riwayat.
+func (b *broker) Subscribe(ctx context.Context, userID int64, lastEventID string) (<-chan Message, error) {
+	// Berlangganan lebih dulu agar tidak ada event yang terlewat saat membaca riwayat
+	pubsub := b.rdb.Subscribe(ctx, channelKey(userID))
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		return nil, fmt.Errorf("gagal berlangganan event: %w", err)
+	}
+
+	var history []Message
+	if lastEventID != "" {
+		var err error
+		history, err = b.history(ctx, userID, lastEventID)
+		if err != nil {
+			pubsub.Close()
+			return nil, err
+		}
+	}
+
+	out := make(chan Message, 16)
+	go func() {
+		defer close(out)
+		defer pubsub.Close()
+
+		last := lastEventID
+		for _, message := range history {
+			if !send(ctx, out, message) {
+				return
+			}
+			last = message.ID
+		}
+
+		live := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case raw, ok := <-live:
+				if !ok {
+					return
+				}
+				var message Message
+				if err := json.Unmarshal([]byte(raw.Payload), &message); err != nil {
+					continue
+				}
+				// Event yang sudah dikirim dari riwayat diabaikan
+				if last != "" && !IsAfter(message.ID, last) {
+					continue
+				}
+				if !send(ctx, out, message) {
+					return
+				}
+				last = message.ID
+			}
+		}
+	}()
+	return out, nil
+}
+
+// history membaca event setelah lastEventID dari riwayat pengguna
+func (b *broker) history(ctx context.Context, userID int64, lastEventID string) ([]Message, error) {
+	entries, err := b.rdb.XRange(ctx, streamKey(userID), "("+lastEventID, "+").Result()
+	if err != nil {
+		return nil, fmt.Errorf("gagal membaca riwayat event: %w", err)
+	}
+
+	messages := make([]Message, 0, len(entries))
+	for _, entry := range entries {
+		eventType, _ := entry.Values["type"].(string)
+		data, _ := entry.Values["data"].(string)
+		messages = append(messages, Message{ID: entry.ID, Type: eventType, Data: json.RawMessage(data)})
+	}
+	return messages, nil
+}
+
+// IsAfter membandingkan dua ID Redis Stream ("<milidetik>-<urutan>")
+func IsAfter(id, other string) bool {
+	ms1, seq1 := parseStreamID(id)
+	ms2, seq2 := parseStreamID(other)
+	if ms1 != ms2 {
+		return ms1 > ms2
+	}
+	return seq1 > seq2
+}
+
+func parseStreamID(id string) (uint64, uint64) {
+	msPart, seqPart, _ := strings.Cut(id, "-")
+	ms, _ := strconv.ParseUint(msPart, 10, 64)
+	seq, _ := strconv.ParseUint(seqPart, 10, 64)
+	return ms, seq
+}
+
+func send(ctx context.Context, out chan<- Message, message Message) bool {
+	select {
+	case out <- message:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func streamKey(userID int64) string {
+	return fmt.Sprintf("go-todo-api:realtime:history:%d", userID)
+}
+
+func channelKey(userID int64) string {
+	return fmt.Sprintf("go-todo-api:realtime:user:%d", userID)
+}
@@ -0,0 +1,72 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redismock/v9"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBroker_Publish(t *testing.T) {
+	db, mock := redismock.NewClientMock()
+	b := NewBroker(db)
+
+	mock.ExpectXAdd(&redis.XAddArgs{
+		Stream: "go-todo-api:realtime:history:5",
+		MaxLen: historySize,
+		Approx: true,
+		Values: map[string]interface{}{"type": "todo.created", "data": `{"id":1}`},
+	}).SetVal("1700000000000-0")
+	mock.ExpectExpire("go-todo-api:realtime:history:5", historyTTL).SetVal(true)
+
+	message, _ := json.Marshal(Message{ID: "1700000000000-0", Type: "todo.created", Data: json.RawMessage(`{"id":1}`)})
+	mock.ExpectPublish("go-todo-api:realtime:user:5", message).SetVal(1)
+
+	err := b.Publish(context.Background(), 5, "todo.created", map[string]int{"id": 1})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestBroker_History(t *testing.T) {
+	db, mock := redismock.NewClientMock()
+	b := &broker{rdb: db}
+
+	mock.ExpectXRange("go-todo-api:realtime:history:5", "(1700000000000-0", "+").SetVal([]redis.XMessage{
+		{ID: "1700000000000-1", Values: map[string]interface{}{"type": "todo.updated", "data": `{"id":1}`}},
+		{ID: "1700000000500-0", Values: map[string]interface{}{"type": "todo.deleted", "data": `{"id":2}`}},
+	})
+
+	messages, err := b.history(context.Background(), 5, "1700000000000-0")
+	assert.NoError(t, err)
+	assert.Len(t, messages, 2)
+	assert.Equal(t, "todo.updated", messages[0].Type)
+	assert.JSONEq(t, `{"id":2}`, string(messages[1].Data))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIsAfter(t *testing.T) {
+	assert.True(t, IsAfter("1700000000001-0", "1700000000000-5"))
+	assert.True(t, IsAfter("1700000000000-10", "1700000000000-9"))
+	assert.False(t, IsAfter("1700000000000-1", "1700000000000-1"))
+	assert.False(t, IsAfter("999-0", "1000-0"))
+}
+
+func TestSend_StopsWhenContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	out := make(chan Message)
+
+	done := make(chan bool)
+	go func() { done <- send(ctx, out, Message{ID: "1-0"}) }()
+	cancel()
+
+	select {
+	case ok := <-done:
+		assert.False(t, ok)
+	case <-time.After(time.Second):
+		t.Fatal("send tidak berhenti setelah context dibatalkan")
+	}
+}
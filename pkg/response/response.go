@@ -24,4 +24,12 @@ func ErrorResponse(code int, message string) Response {
 		Meta: Meta{Code: code, Message: message},
 		Data: nil,
 	}
-}
\ No newline at end of file
+}
+
+// ValidationErrorResponse menyusun respons 400 beserta rincian setiap aturan yang dilanggar
+func ValidationErrorResponse(message string, errors interface{}) Response {
+	return Response{
+		Meta: Meta{Code: http.StatusBadRequest, Message: message},
+		Data: errors,
+	}
+}
@@ -2,9 +2,14 @@ package route
 
 import "github.com/labstack/echo/v4"
 
+// Route adalah definisi satu endpoint. Permission adalah izin yang wajib dimiliki untuk
+// route privat; kosong berarti cukup login, misalnya untuk data akun milik sendiri.
+// QueryToken mengizinkan access token dikirim melalui query access_token, khusus untuk
+// EventSource dan WebSocket di browser yang tidak dapat mengirim header Authorization.
 type Route struct {
-	Method  string
-	Path    string
-	Handler echo.HandlerFunc
-	Roles   []string
+	Method     string
+	Path       string
+	Handler    echo.HandlerFunc
+	Permission string
+	QueryToken bool
 }
\ No newline at end of file
@@ -0,0 +1,67 @@
+package secretbox
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+)
+
+var ErrDataTidakValid = errors.New("data terenkripsi tidak valid")
+
+// Box mengenkripsi data kecil seperti secret TOTP sebelum disimpan di database sehingga
+// salinan database saja tidak cukup untuk membuat kode login.
+type Box interface {
+	// Seal mengenkripsi plaintext dan mengembalikan hasilnya dalam base64
+	Seal(plaintext string) (string, error)
+	// Open mendekripsi hasil Seal; mengembalikan ErrDataTidakValid jika data diubah atau kunci salah
+	Open(sealed string) (string, error)
+}
+
+type box struct {
+	aead cipher.AEAD
+}
+
+// New membuat Box AES-256-GCM. Kunci AES diturunkan dari SHA-256 passphrase sehingga
+// panjang passphrase bebas.
+func New(passphrase string) (Box, error) {
+	if passphrase == "" {
+		return nil, errors.New("kunci enkripsi tidak boleh kosong")
+	}
+	key := sha256.Sum256([]byte(passphrase))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, fmt.Errorf("gagal membuat cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("gagal membuat cipher: %w", err)
+	}
+	return &box{aead: aead}, nil
+}
+
+// Seal menyimpan nonce acak di depan ciphertext
+func (b *box) Seal(plaintext string) (string, error) {
+	nonce := make([]byte, b.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("gagal membuat nonce: %w", err)
+	}
+	sealed := b.aead.Seal(nonce, nonce, []byte(plaintext), nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func (b *box) Open(sealed string) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(sealed)
+	if err != nil || len(data) < b.aead.NonceSize() {
+		return "", ErrDataTidakValid
+	}
+	nonce, ciphertext := data[:b.aead.NonceSize()], data[b.aead.NonceSize():]
+	plaintext, err := b.aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", ErrDataTidakValid
+	}
+	return string(plaintext), nil
+}
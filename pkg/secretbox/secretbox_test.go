@@ -0,0 +1,46 @@
+package secretbox
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBox_SealOpen(t *testing.T) {
+	b, err := New("rahasia")
+	assert.NoError(t, err)
+
+	sealed, err := b.Seal("JBSWY3DPEHPK3PXP")
+	assert.NoError(t, err)
+	assert.NotContains(t, sealed, "JBSWY3DPEHPK3PXP")
+
+	again, _ := b.Seal("JBSWY3DPEHPK3PXP")
+	assert.NotEqual(t, sealed, again)
+
+	plaintext, err := b.Open(sealed)
+	assert.NoError(t, err)
+	assert.Equal(t, "JBSWY3DPEHPK3PXP", plaintext)
+}
+
+func TestBox_OpenRejectsWrongKeyOrTampering(t *testing.T) {
+	b, _ := New("rahasia")
+	other, _ := New("kunci-lain")
+	sealed, _ := b.Seal("JBSWY3DPEHPK3PXP")
+
+	_, err := other.Open(sealed)
+	assert.ErrorIs(t, err, ErrDataTidakValid)
+
+	data, _ := base64.StdEncoding.DecodeString(sealed)
+	data[len(data)-1] ^= 1
+	_, err = b.Open(base64.StdEncoding.EncodeToString(data))
+	assert.ErrorIs(t, err, ErrDataTidakValid)
+
+	_, err = b.Open("bukan base64!")
+	assert.ErrorIs(t, err, ErrDataTidakValid)
+}
+
+func TestNew_EmptyKey(t *testing.T) {
+	_, err := New("")
+	assert.Error(t, err)
+}
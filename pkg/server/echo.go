@@ -1,10 +1,13 @@
 package server
 
 import (
+	"context"
+	"fmt"
 	"go-todo/configs"
 	"go-todo/pkg/response"
 	"go-todo/pkg/route"
 	"go-todo/pkg/token"
+	"net"
 	"net/http"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -16,10 +19,25 @@ type Server struct {
 	*echo.Echo
 }
 
-func NewServer(cfg *configs.Config,
-	publicRoutes, privateRoutes []route.Route) *Server {
+// PermissionLookup menyediakan izin terbaru milik pengguna, biasanya dari cache
+type PermissionLookup interface {
+	Permissions(ctx context.Context, userID int64) ([]string, error)
+}
+
+func NewServer(cfg *configs.Config, keys *token.KeySet, revocations token.RevocationStore, permissions PermissionLookup,
+	publicRoutes, privateRoutes []route.Route) (*Server, error) {
 	e := echo.New()
 	e.HideBanner = true
+	// IP klien dipakai untuk pembatasan login, sehingga X-Forwarded-For hanya dipercaya dari
+	// proxy yang terdaftar secara eksplisit di konfigurasi
+	ipExtractor, err := NewIPExtractor(cfg.Server.TrustedProxies)
+	if err != nil {
+		return nil, err
+	}
+	e.IPExtractor = ipExtractor
+
+	// Public key dipublikasikan di luar prefix API agar dapat ditemukan verifier standar
+	e.GET("/.well-known/jwks.json", JWKSHandler(keys))
 
 	v1 := e.Group("/api/v1")
 
@@ -30,40 +48,112 @@ func NewServer(cfg *configs.Config,
 	}
 
 	if len(privateRoutes) > 0 {
+		headerAuth := JWTMiddleware(keys, revocations, HeaderTokenLookup)
+		queryAuth := JWTMiddleware(keys, revocations, QueryTokenLookup)
 		for _, route := range privateRoutes {
-			v1.Add(route.Method, route.Path, route.Handler, JWTMiddleware(cfg.JWT.SecretKey), RBACMiddleware(route.Roles))
+			auth := headerAuth
+			if route.QueryToken {
+				auth = queryAuth
+			}
+			v1.Add(route.Method, route.Path, route.Handler, auth, PermissionMiddleware(permissions, route.Permission))
 		}
 	}
-	return &Server{e}
+	return &Server{e}, nil
 }
 
-func JWTMiddleware(secretKey string) echo.MiddlewareFunc {
-	return echojwt.WithConfig(echojwt.Config{
+// NewIPExtractor mengambil IP klien langsung dari koneksi. Jika trustedProxies diisi,
+// X-Forwarded-For hanya dibaca dari proxy dalam rentang CIDR tersebut; loopback dan
+// jaringan privat tidak lagi dipercaya secara otomatis.
+func NewIPExtractor(trustedProxies []string) (echo.IPExtractor, error) {
+	if len(trustedProxies) == 0 {
+		return echo.ExtractIPDirect(), nil
+	}
+
+	options := []echo.TrustOption{
+		echo.TrustLoopback(false),
+		echo.TrustLinkLocal(false),
+		echo.TrustPrivateNet(false),
+	}
+	for _, cidr := range trustedProxies {
+		_, ipRange, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("rentang trusted proxy %q tidak valid: %w", cidr, err)
+		}
+		options = append(options, echo.TrustIPRange(ipRange))
+	}
+	return echo.ExtractIPFromXFFHeader(options...), nil
+}
+
+const (
+	// HeaderTokenLookup hanya menerima access token dari header Authorization
+	HeaderTokenLookup = "header:Authorization:Bearer "
+	// QueryTokenLookup juga menerima token melalui query access_token untuk EventSource dan
+	// WebSocket di browser yang tidak dapat mengirim header Authorization. Token di URL dapat
+	// tercatat di log, sehingga hanya dipakai pada route dengan QueryToken.
+	QueryTokenLookup = HeaderTokenLookup + ",query:access_token"
+)
+
+// JWTMiddleware memverifikasi access token dengan kunci yang dipilih berdasarkan kid, lalu
+// memastikan token tersebut belum dicabut melalui logout maupun perubahan password atau role.
+// tokenLookup menentukan lokasi token, yaitu HeaderTokenLookup atau QueryTokenLookup.
+func JWTMiddleware(keys *token.KeySet, revocations token.RevocationStore, tokenLookup string) echo.MiddlewareFunc {
+	verify := echojwt.WithConfig(echojwt.Config{
 		NewClaimsFunc: func(c echo.Context) jwt.Claims {
 			return new(token.JwtCustomClaims)
 		},
-		SigningKey: []byte(secretKey),
+		KeyFunc:     keys.Keyfunc,
+		TokenLookup: tokenLookup,
 		ErrorHandler: func(ctx echo.Context, err error) error {
 			return ctx.JSON(http.StatusUnauthorized, response.ErrorResponse(http.StatusUnauthorized, "Anda harus login untuk mengakses resource ini."))
 		},
 	})
+	if revocations == nil {
+		return verify
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return verify(func(ctx echo.Context) error {
+			claims := ctx.Get("user").(*jwt.Token).Claims.(*token.JwtCustomClaims)
+			revoked, err := token.CheckRevoked(ctx.Request().Context(), revocations, claims)
+			if err != nil {
+				// Gagal tertutup: token tidak diterima jika status pencabutannya tidak dapat dipastikan
+				ctx.Logger().Errorf("gagal memeriksa pencabutan token: %v", err)
+				return ctx.JSON(http.StatusServiceUnavailable, response.ErrorResponse(http.StatusServiceUnavailable, "Layanan autentikasi sedang tidak tersedia."))
+			}
+			if revoked {
+				return ctx.JSON(http.StatusUnauthorized, response.ErrorResponse(http.StatusUnauthorized, "Sesi Anda telah berakhir, silakan login kembali."))
+			}
+			return next(ctx)
+		})
+	}
+}
+
+// JWKSHandler mengembalikan public key penandatangan token dalam format JWKS
+func JWKSHandler(keys *token.KeySet) echo.HandlerFunc {
+	return func(ctx echo.Context) error {
+		// Verifier boleh menyimpan JWKS sebentar; kunci baru sudah dipublikasikan sebelum aktif
+		ctx.Response().Header().Set("Cache-Control", "public, max-age=300")
+		return ctx.JSON(http.StatusOK, keys.JWKS())
+	}
 }
 
-func RBACMiddleware(roles []string) echo.MiddlewareFunc {
+// PermissionMiddleware memeriksa izin route terhadap izin terbaru pengguna, bukan salinan
+// pada klaim token, sehingga perubahan role langsung berlaku. Izin yang sudah dimuat
+// disimpan di context agar handler dapat memakai ulang tanpa lookup kedua.
+func PermissionMiddleware(permissions PermissionLookup, permission string) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(ctx echo.Context) error {
 			user := ctx.Get("user").(*jwt.Token)
 			claims := user.Claims.(*token.JwtCustomClaims)
 
-			allowed := false
-			for _, role := range roles {
-				if role == claims.Role {
-					allowed = true
-					break
-				}
+			granted, err := permissions.Permissions(ctx.Request().Context(), claims.UserID)
+			if err != nil {
+				ctx.Logger().Errorf("gagal memuat izin pengguna: %v", err)
+				return ctx.JSON(http.StatusServiceUnavailable, response.ErrorResponse(http.StatusServiceUnavailable, "Layanan autentikasi sedang tidak tersedia."))
 			}
+			ctx.Set(PermissionsContextKey, granted)
 
-			if !allowed {
+			if permission != "" && !HasPermission(granted, permission) {
 				return ctx.JSON(http.StatusForbidden, response.ErrorResponse(http.StatusForbidden, "Anda tidak diizinkan untuk mengakses resource ini."))
 			}
 
@@ -71,3 +161,16 @@ func RBACMiddleware(roles []string) echo.MiddlewareFunc {
 		}
 	}
 }
+
+// PermissionsContextKey adalah key echo.Context tempat PermissionMiddleware menyimpan izin pengguna
+const PermissionsContextKey = "permissions"
+
+// HasPermission memeriksa apakah izin ada di daftar, termasuk melalui izin wildcard "*"
+func HasPermission(granted []string, permission string) bool {
+	for _, candidate := range granted {
+		if candidate == permission || candidate == "*" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+package server
+
+import (
+	"context"
+	"go-todo/configs"
+	"go-todo/pkg/route"
+	"go-todo/pkg/token"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubPermissions memberikan seluruh izin kepada setiap pengguna
+type stubPermissions struct{}
+
+func (stubPermissions) Permissions(ctx context.Context, userID int64) ([]string, error) {
+	return []string{"*"}, nil
+}
+
+func TestNewServer_QueryTokenOnlyOnStreamingRoutes(t *testing.T) {
+	keys := token.NewHMACKeySet("rahasia")
+	accessToken, err := token.NewKeySetTokenUseCase(keys).GenerateAccessToken(token.JwtCustomClaims{
+		UserID:           1,
+		RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour))},
+	})
+	assert.NoError(t, err)
+
+	ok := func(c echo.Context) error { return c.NoContent(http.StatusOK) }
+	srv, err := NewServer(&configs.Config{}, keys, nil, stubPermissions{}, nil, []route.Route{
+		{Method: http.MethodGet, Path: "/todos", Handler: ok},
+		{Method: http.MethodGet, Path: "/events", Handler: ok, QueryToken: true},
+	})
+	assert.NoError(t, err)
+
+	serve := func(target string, header bool) int {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		if header {
+			req.Header.Set("Authorization", "Bearer "+accessToken)
+		}
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	// Route biasa hanya menerima token dari header Authorization
+	assert.Equal(t, http.StatusOK, serve("/api/v1/todos", true))
+	assert.Equal(t, http.StatusUnauthorized, serve("/api/v1/todos?access_token="+accessToken, false))
+
+	// Route streaming menerima token dari header maupun query access_token
+	assert.Equal(t, http.StatusOK, serve("/api/v1/events", true))
+	assert.Equal(t, http.StatusOK, serve("/api/v1/events?access_token="+accessToken, false))
+}
+
+func TestNewIPExtractor_DefaultIgnoresForwardedHeader(t *testing.T) {
+	extractor, err := NewIPExtractor(nil)
+	assert.NoError(t, err)
+
+	// Peer loopback maupun jaringan privat tidak boleh menentukan IP klien tanpa konfigurasi
+	for _, peer := range []string{"127.0.0.1", "10.0.0.5"} {
+		req := httptest.NewRequest("POST", "/api/v1/login", nil)
+		req.RemoteAddr = peer + ":5000"
+		req.Header.Set("X-Forwarded-For", "203.0.113.9")
+		assert.Equal(t, peer, extractor(req))
+	}
+}
+
+func TestNewIPExtractor_TrustedProxy(t *testing.T) {
+	extractor, err := NewIPExtractor([]string{"10.0.0.0/24"})
+	assert.NoError(t, err)
+
+	req := httptest.NewRequest("POST", "/api/v1/login", nil)
+	req.RemoteAddr = "10.0.0.5:5000"
+	req.Header.Set("X-Forwarded-For", "203.0.113.9")
+	assert.Equal(t, "203.0.113.9", extractor(req))
+
+	// Jaringan privat di luar rentang yang dikonfigurasi tidak lagi dipercaya otomatis
+	req = httptest.NewRequest("POST", "/api/v1/login", nil)
+	req.RemoteAddr = "192.168.1.5:5000"
+	req.Header.Set("X-Forwarded-For", "203.0.113.9")
+	assert.Equal(t, "192.168.1.5", extractor(req))
+
+	req = httptest.NewRequest("POST", "/api/v1/login", nil)
+	req.RemoteAddr = "127.0.0.1:5000"
+	req.Header.Set("X-Forwarded-For", "203.0.113.9")
+	assert.Equal(t, "127.0.0.1", extractor(req))
+}
+
+func TestNewIPExtractor_InvalidCIDR(t *testing.T) {
+	_, err := NewIPExtractor([]string{"10.0.0.0"})
+	assert.Error(t, err)
+}
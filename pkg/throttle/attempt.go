@@ -0,0 +1,103 @@
+package throttle
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	failuresKeyPrefix = "go-todo-api:throttle:failures:"
+	lockKeyPrefix     = "go-todo-api:throttle:lock:"
+)
+
+// AttemptStore mencatat percobaan gagal dalam sliding window dan menyimpan kunci sementara.
+// Key bebas ditentukan pemanggil, misalnya "login:username:budi" atau "login:ip:10.0.0.1".
+type AttemptStore interface {
+	// RecordFailure mencatat satu kegagalan dan mengembalikan jumlah kegagalan dalam window
+	RecordFailure(ctx context.Context, key string, window time.Duration) (int64, error)
+	// Lock mengunci key selama durasi tertentu tanpa memperpendek kunci yang sudah ada
+	Lock(ctx context.Context, key string, duration time.Duration) error
+	// LockedFor mengembalikan sisa durasi kunci; nol jika key tidak sedang dikunci
+	LockedFor(ctx context.Context, key string) (time.Duration, error)
+	// Reset menghapus riwayat kegagalan beserta kuncinya
+	Reset(ctx context.Context, key string) error
+}
+
+type redisAttemptStore struct {
+	rdb *redis.Client
+}
+
+// NewAttemptStore membuat AttemptStore berbasis Redis.
+func NewAttemptStore(rdb *redis.Client) AttemptStore {
+	return &redisAttemptStore{rdb: rdb}
+}
+
+// RecordFailure menyimpan kegagalan pada sorted set dengan skor waktu kejadian. Entri di luar
+// window dibuang sebelum dihitung sehingga jumlahnya selalu mencerminkan window terakhir.
+func (s *redisAttemptStore) RecordFailure(ctx context.Context, key string, window time.Duration) (int64, error) {
+	now := time.Now()
+	failuresKey := failuresKeyPrefix + key
+
+	var count *redis.IntCmd
+	_, err := s.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZRemRangeByScore(ctx, failuresKey, "-inf", strconv.FormatInt(now.Add(-window).UnixNano(), 10))
+		pipe.ZAdd(ctx, failuresKey, redis.Z{Score: float64(now.UnixNano()), Member: newMember(now)})
+		count = pipe.ZCard(ctx, failuresKey)
+		pipe.Expire(ctx, failuresKey, window)
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("gagal mencatat percobaan gagal: %w", err)
+	}
+	return count.Val(), nil
+}
+
+// Lock mengunci key. Kunci yang sisa durasinya lebih lama dibiarkan agar lockout tidak
+// tertimpa oleh jeda singkat.
+func (s *redisAttemptStore) Lock(ctx context.Context, key string, duration time.Duration) error {
+	remaining, err := s.LockedFor(ctx, key)
+	if err != nil {
+		return err
+	}
+	if remaining >= duration {
+		return nil
+	}
+	if err := s.rdb.Set(ctx, lockKeyPrefix+key, 1, duration).Err(); err != nil {
+		return fmt.Errorf("gagal mengunci percobaan: %w", err)
+	}
+	return nil
+}
+
+// LockedFor mengembalikan sisa durasi kunci key.
+func (s *redisAttemptStore) LockedFor(ctx context.Context, key string) (time.Duration, error) {
+	remaining, err := s.rdb.PTTL(ctx, lockKeyPrefix+key).Result()
+	if err != nil {
+		return 0, fmt.Errorf("gagal memeriksa kunci percobaan: %w", err)
+	}
+	// Redis mengembalikan nilai negatif jika key tidak ada atau tidak memiliki TTL
+	if remaining < 0 {
+		return 0, nil
+	}
+	return remaining, nil
+}
+
+// Reset menghapus riwayat kegagalan dan kunci key.
+func (s *redisAttemptStore) Reset(ctx context.Context, key string) error {
+	if err := s.rdb.Del(ctx, failuresKeyPrefix+key, lockKeyPrefix+key).Err(); err != nil {
+		return fmt.Errorf("gagal menghapus percobaan: %w", err)
+	}
+	return nil
+}
+
+// newMember membuat anggota sorted set yang unik meskipun dua kegagalan terjadi pada waktu sama
+func newMember(now time.Time) string {
+	suffix := make([]byte, 4)
+	_, _ = rand.Read(suffix)
+	return strconv.FormatInt(now.UnixNano(), 10) + "-" + hex.EncodeToString(suffix)
+}
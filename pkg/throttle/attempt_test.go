@@ -0,0 +1,75 @@
+package throttle
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redismock/v9"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+// matchKey hanya mencocokkan nama perintah dan key karena skor dan anggota bergantung pada waktu
+func matchKey(expected, actual []interface{}) error {
+	if fmt.Sprint(expected[:2]) != fmt.Sprint(actual[:2]) {
+		return fmt.Errorf("perintah %v tidak sesuai dengan %v", actual[:2], expected[:2])
+	}
+	return nil
+}
+
+func TestAttemptStore_RecordFailure(t *testing.T) {
+	db, mock := redismock.NewClientMock()
+	store := NewAttemptStore(db)
+
+	mock.ExpectTxPipeline()
+	mock.CustomMatch(matchKey).ExpectZRemRangeByScore("go-todo-api:throttle:failures:login:ip:10.0.0.1", "-inf", "0").SetVal(1)
+	mock.CustomMatch(matchKey).ExpectZAdd("go-todo-api:throttle:failures:login:ip:10.0.0.1", redis.Z{}).SetVal(1)
+	mock.ExpectZCard("go-todo-api:throttle:failures:login:ip:10.0.0.1").SetVal(4)
+	mock.ExpectExpire("go-todo-api:throttle:failures:login:ip:10.0.0.1", 15*time.Minute).SetVal(true)
+	mock.ExpectTxPipelineExec()
+
+	count, err := store.RecordFailure(context.Background(), "login:ip:10.0.0.1", 15*time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAttemptStore_Lock(t *testing.T) {
+	db, mock := redismock.NewClientMock()
+	store := NewAttemptStore(db)
+	ctx := context.Background()
+
+	// Kunci baru dibuat jika belum ada kunci
+	mock.ExpectPTTL("go-todo-api:throttle:lock:login:username:budi").SetVal(-2)
+	mock.ExpectSet("go-todo-api:throttle:lock:login:username:budi", 1, 2*time.Second).SetVal("OK")
+	assert.NoError(t, store.Lock(ctx, "login:username:budi", 2*time.Second))
+
+	// Lockout yang lebih lama tidak diperpendek oleh jeda singkat
+	mock.ExpectPTTL("go-todo-api:throttle:lock:login:username:budi").SetVal(10 * time.Minute)
+	assert.NoError(t, store.Lock(ctx, "login:username:budi", 4*time.Second))
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAttemptStore_LockedForAndReset(t *testing.T) {
+	db, mock := redismock.NewClientMock()
+	store := NewAttemptStore(db)
+	ctx := context.Background()
+
+	mock.ExpectPTTL("go-todo-api:throttle:lock:login:username:budi").SetVal(90 * time.Second)
+	remaining, err := store.LockedFor(ctx, "login:username:budi")
+	assert.NoError(t, err)
+	assert.Equal(t, 90*time.Second, remaining)
+
+	mock.ExpectPTTL("go-todo-api:throttle:lock:login:username:ani").SetVal(-2)
+	remaining, err = store.LockedFor(ctx, "login:username:ani")
+	assert.NoError(t, err)
+	assert.Zero(t, remaining)
+
+	mock.ExpectDel("go-todo-api:throttle:failures:login:username:budi", "go-todo-api:throttle:lock:login:username:budi").SetVal(2)
+	assert.NoError(t, store.Reset(ctx, "login:username:budi"))
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
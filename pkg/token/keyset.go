@@ -0,0 +1,328 @@
+package token
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"go-todo/configs"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Algoritma penandatanganan yang didukung
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmRS256 = "RS256"
+	AlgorithmES256 = "ES256"
+	AlgorithmEdDSA = "EdDSA"
+)
+
+var (
+	ErrKunciPenandatanganTidakAda = errors.New("tidak ada kunci aktif untuk menandatangani token")
+	ErrKunciTidakDikenal          = errors.New("kunci token tidak dikenal atau sudah dipensiunkan")
+)
+
+// Key adalah satu kunci penandatanganan access token. Kunci tanpa PrivateKey hanya dipakai
+// untuk verifikasi, misalnya kunci lama yang private key-nya sudah dimusnahkan.
+// Untuk HS256, PrivateKey dan PublicKey berisi secret yang sama dalam bentuk []byte.
+type Key struct {
+	ID         string
+	Algorithm  string
+	PrivateKey crypto.PrivateKey
+	PublicKey  crypto.PublicKey
+	// ActiveFrom adalah saat kunci mulai dipakai untuk menandatangani token baru
+	ActiveFrom time.Time
+	// RetireAt adalah saat kunci berhenti diterima untuk verifikasi; nol berarti tanpa batas
+	RetireAt time.Time
+}
+
+func (k *Key) retired(now time.Time) bool {
+	return !k.RetireAt.IsZero() && !now.Before(k.RetireAt)
+}
+
+func (k *Key) method() jwt.SigningMethod {
+	return jwt.GetSigningMethod(k.Algorithm)
+}
+
+// KeySet menyimpan seluruh kunci beserta jadwal rotasinya. Kunci penandatangan dipilih
+// ulang setiap kali token dibuat, sehingga rotasi terjadi tepat pada ActiveFrom kunci baru
+// tanpa perlu restart. Kunci lama tetap diterima untuk verifikasi hingga RetireAt-nya.
+type KeySet struct {
+	keys []Key
+	now  func() time.Time
+}
+
+// NewKeySet memvalidasi dan membuat KeySet dari daftar kunci.
+func NewKeySet(keys []Key) (*KeySet, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("minimal satu kunci JWT harus dikonfigurasi")
+	}
+	seen := make(map[string]bool, len(keys))
+	for i := range keys {
+		key := &keys[i]
+		if seen[key.ID] {
+			return nil, fmt.Errorf("kid %q terdaftar lebih dari sekali", key.ID)
+		}
+		seen[key.ID] = true
+		if err := validateKey(key); err != nil {
+			return nil, fmt.Errorf("kunci %q tidak valid: %w", key.ID, err)
+		}
+	}
+	if len(keys) > 1 {
+		for _, key := range keys {
+			if key.ID == "" {
+				return nil, errors.New("kid wajib diisi jika terdapat lebih dari satu kunci")
+			}
+		}
+	}
+	return &KeySet{keys: keys, now: time.Now}, nil
+}
+
+// NewHMACKeySet membuat KeySet berisi satu secret HS256 tanpa kid, sesuai perilaku lama.
+func NewHMACKeySet(secretKey string) *KeySet {
+	secret := []byte(secretKey)
+	return &KeySet{
+		keys: []Key{{Algorithm: AlgorithmHS256, PrivateKey: secret, PublicKey: secret}},
+		now:  time.Now,
+	}
+}
+
+// validateKey memastikan tipe kunci sesuai algoritma dan melengkapi PublicKey dari PrivateKey
+func validateKey(key *Key) error {
+	if key.method() == nil {
+		return fmt.Errorf("algoritma %q tidak didukung", key.Algorithm)
+	}
+	if key.PrivateKey == nil && key.PublicKey == nil {
+		return errors.New("private key atau public key harus diisi")
+	}
+	if key.PrivateKey != nil {
+		signer, ok := key.PrivateKey.(crypto.Signer)
+		if key.Algorithm == AlgorithmHS256 {
+			key.PublicKey = key.PrivateKey
+		} else if !ok {
+			return errors.New("private key tidak dapat dipakai untuk menandatangani")
+		} else {
+			key.PublicKey = signer.Public()
+		}
+	}
+
+	switch public := key.PublicKey.(type) {
+	case []byte:
+		if key.Algorithm != AlgorithmHS256 || len(public) == 0 {
+			return errors.New("secret hanya dapat dipakai dengan HS256")
+		}
+	case *rsa.PublicKey:
+		if key.Algorithm != AlgorithmRS256 {
+			return fmt.Errorf("kunci RSA tidak dapat dipakai dengan %s", key.Algorithm)
+		}
+		if public.N.BitLen() < 2048 {
+			return errors.New("kunci RSA minimal 2048 bit")
+		}
+	case *ecdsa.PublicKey:
+		if key.Algorithm != AlgorithmES256 || public.Curve != elliptic.P256() {
+			return errors.New("ES256 membutuhkan kunci EC dengan kurva P-256")
+		}
+	case ed25519.PublicKey:
+		if key.Algorithm != AlgorithmEdDSA {
+			return fmt.Errorf("kunci Ed25519 tidak dapat dipakai dengan %s", key.Algorithm)
+		}
+	default:
+		return fmt.Errorf("tipe kunci %T tidak didukung", key.PublicKey)
+	}
+	return nil
+}
+
+// SigningKey mengembalikan kunci yang sedang aktif, yaitu kunci dengan private key dan
+// ActiveFrom paling akhir yang sudah lewat dan belum dipensiunkan.
+func (s *KeySet) SigningKey() (*Key, error) {
+	now := s.now()
+	var active *Key
+	for i := range s.keys {
+		key := &s.keys[i]
+		if key.PrivateKey == nil || key.ActiveFrom.After(now) || key.retired(now) {
+			continue
+		}
+		if active == nil || key.ActiveFrom.After(active.ActiveFrom) {
+			active = key
+		}
+	}
+	if active == nil {
+		return nil, ErrKunciPenandatanganTidakAda
+	}
+	return active, nil
+}
+
+// Keyfunc memilih kunci verifikasi berdasarkan header kid. Algoritma token harus sama
+// dengan algoritma kunci agar token HS256 tidak dapat ditandatangani memakai public key.
+func (s *KeySet) Keyfunc(t *jwt.Token) (interface{}, error) {
+	kid, _ := t.Header["kid"].(string)
+	now := s.now()
+	for i := range s.keys {
+		key := &s.keys[i]
+		if key.ID != kid || key.retired(now) {
+			continue
+		}
+		if t.Method.Alg() != key.Algorithm {
+			return nil, fmt.Errorf("algoritma token %s tidak sesuai dengan kunci %q", t.Method.Alg(), kid)
+		}
+		return key.PublicKey, nil
+	}
+	return nil, ErrKunciTidakDikenal
+}
+
+// JWK adalah representasi public key sesuai RFC 7517
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid,omitempty"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	Curve     string `json:"crv,omitempty"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+	X         string `json:"x,omitempty"`
+	Y         string `json:"y,omitempty"`
+}
+
+// JWKS adalah kumpulan public key yang dipublikasikan untuk layanan lain
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS mengembalikan public key dari seluruh kunci asimetris yang belum dipensiunkan.
+// Kunci yang baru akan aktif ikut dipublikasikan agar cache verifier sudah memilikinya
+// saat rotasi terjadi. Secret HS256 tidak pernah dipublikasikan.
+func (s *KeySet) JWKS() JWKS {
+	now := s.now()
+	jwks := JWKS{Keys: []JWK{}}
+	for i := range s.keys {
+		key := &s.keys[i]
+		if key.retired(now) {
+			continue
+		}
+		jwk := JWK{KeyID: key.ID, Use: "sig", Algorithm: key.Algorithm}
+		switch public := key.PublicKey.(type) {
+		case *rsa.PublicKey:
+			jwk.KeyType = "RSA"
+			jwk.N = base64URL(public.N.Bytes())
+			jwk.E = base64URL(big.NewInt(int64(public.E)).Bytes())
+		case *ecdsa.PublicKey:
+			size := (public.Curve.Params().BitSize + 7) / 8
+			jwk.KeyType = "EC"
+			jwk.Curve = public.Curve.Params().Name
+			jwk.X = base64URL(public.X.FillBytes(make([]byte, size)))
+			jwk.Y = base64URL(public.Y.FillBytes(make([]byte, size)))
+		case ed25519.PublicKey:
+			jwk.KeyType = "OKP"
+			jwk.Curve = "Ed25519"
+			jwk.X = base64URL(public)
+		default:
+			continue
+		}
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+	return jwks
+}
+
+func base64URL(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// LoadKeySet membaca kunci dari konfigurasi. Jika KEYS kosong, SECRET_KEY dipakai
+// sebagai kunci HS256 tunggal agar konfigurasi lama tetap berjalan.
+func LoadKeySet(cfg configs.JWTConfig) (*KeySet, error) {
+	if len(cfg.Keys) == 0 {
+		return NewHMACKeySet(cfg.SecretKey), nil
+	}
+
+	keys := make([]Key, 0, len(cfg.Keys))
+	for _, keyConfig := range cfg.Keys {
+		key, err := loadKey(keyConfig)
+		if err != nil {
+			return nil, fmt.Errorf("gagal memuat kunci JWT %q: %w", keyConfig.ID, err)
+		}
+		keys = append(keys, key)
+	}
+	return NewKeySet(keys)
+}
+
+// loadKey membaca satu kunci PEM beserta jadwal aktif dan pensiunnya
+func loadKey(cfg configs.JWTKeyConfig) (Key, error) {
+	key := Key{ID: cfg.ID, Algorithm: cfg.Algorithm}
+	if cfg.ID == "" {
+		return key, errors.New("ID wajib diisi")
+	}
+	if cfg.Algorithm == AlgorithmHS256 {
+		return key, errors.New("gunakan SECRET_KEY untuk HS256, KEYS hanya untuk kunci asimetris")
+	}
+
+	var err error
+	if key.ActiveFrom, err = parseKeyTime(cfg.ActiveFrom); err != nil {
+		return key, fmt.Errorf("ACTIVE_FROM tidak valid: %w", err)
+	}
+	if key.RetireAt, err = parseKeyTime(cfg.RetireAt); err != nil {
+		return key, fmt.Errorf("RETIRE_AT tidak valid: %w", err)
+	}
+
+	switch {
+	case cfg.PrivateKeyFile != "":
+		pem, err := os.ReadFile(cfg.PrivateKeyFile)
+		if err != nil {
+			return key, err
+		}
+		key.PrivateKey, err = parsePrivateKey(cfg.Algorithm, pem)
+		if err != nil {
+			return key, err
+		}
+	case cfg.PublicKeyFile != "":
+		pem, err := os.ReadFile(cfg.PublicKeyFile)
+		if err != nil {
+			return key, err
+		}
+		key.PublicKey, err = parsePublicKey(cfg.Algorithm, pem)
+		if err != nil {
+			return key, err
+		}
+	default:
+		return key, errors.New("PRIVATE_KEY_FILE atau PUBLIC_KEY_FILE harus diisi")
+	}
+	return key, nil
+}
+
+func parseKeyTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+func parsePrivateKey(algorithm string, pem []byte) (crypto.PrivateKey, error) {
+	switch algorithm {
+	case AlgorithmRS256:
+		return jwt.ParseRSAPrivateKeyFromPEM(pem)
+	case AlgorithmES256:
+		return jwt.ParseECPrivateKeyFromPEM(pem)
+	case AlgorithmEdDSA:
+		return jwt.ParseEdPrivateKeyFromPEM(pem)
+	}
+	return nil, fmt.Errorf("algoritma %q tidak didukung", algorithm)
+}
+
+func parsePublicKey(algorithm string, pem []byte) (crypto.PublicKey, error) {
+	switch algorithm {
+	case AlgorithmRS256:
+		return jwt.ParseRSAPublicKeyFromPEM(pem)
+	case AlgorithmES256:
+		return jwt.ParseECPublicKeyFromPEM(pem)
+	case AlgorithmEdDSA:
+		return jwt.ParseEdPublicKeyFromPEM(pem)
+	}
+	return nil, fmt.Errorf("algoritma %q tidak didukung", algorithm)
+}
@@ -0,0 +1,181 @@
+package token
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"go-todo/configs"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func newECKey(t *testing.T) *ecdsa.PrivateKey {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	return key
+}
+
+func parseWithKeySet(keys *KeySet, tokenString string) (*JwtCustomClaims, error) {
+	claims := new(JwtCustomClaims)
+	_, err := jwt.ParseWithClaims(tokenString, claims, keys.Keyfunc)
+	return claims, err
+}
+
+func TestKeySet_Rotation(t *testing.T) {
+	now := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	keys, err := NewKeySet([]Key{
+		{ID: "lama", Algorithm: AlgorithmES256, PrivateKey: newECKey(t), ActiveFrom: now.Add(-30 * 24 * time.Hour), RetireAt: now.Add(24 * time.Hour)},
+		{ID: "baru", Algorithm: AlgorithmES256, PrivateKey: newECKey(t), ActiveFrom: now.Add(time.Hour)},
+	})
+	assert.NoError(t, err)
+	keys.now = func() time.Time { return now }
+	useCase := NewKeySetTokenUseCase(keys)
+
+	// Sebelum jadwal rotasi, kunci lama masih dipakai untuk menandatangani
+	oldToken, err := useCase.GenerateAccessToken(JwtCustomClaims{UserID: 1})
+	assert.NoError(t, err)
+	parsed, _, err := jwt.NewParser().ParseUnverified(oldToken, &JwtCustomClaims{})
+	assert.NoError(t, err)
+	assert.Equal(t, "lama", parsed.Header["kid"])
+
+	// Setelah ACTIVE_FROM kunci baru lewat, token baru memakai kunci baru
+	keys.now = func() time.Time { return now.Add(2 * time.Hour) }
+	newToken, err := useCase.GenerateAccessToken(JwtCustomClaims{UserID: 1})
+	assert.NoError(t, err)
+	parsed, _, err = jwt.NewParser().ParseUnverified(newToken, &JwtCustomClaims{})
+	assert.NoError(t, err)
+	assert.Equal(t, "baru", parsed.Header["kid"])
+
+	// Token lama tetap valid hingga kunci lama dipensiunkan
+	claims, err := parseWithKeySet(keys, oldToken)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), claims.UserID)
+
+	keys.now = func() time.Time { return now.Add(25 * time.Hour) }
+	_, err = parseWithKeySet(keys, oldToken)
+	assert.ErrorIs(t, err, ErrKunciTidakDikenal)
+	_, err = parseWithKeySet(keys, newToken)
+	assert.NoError(t, err)
+}
+
+func TestKeySet_NoActiveSigningKey(t *testing.T) {
+	keys, err := NewKeySet([]Key{
+		{ID: "nanti", Algorithm: AlgorithmEdDSA, PrivateKey: ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize)), ActiveFrom: time.Now().Add(time.Hour)},
+	})
+	assert.NoError(t, err)
+
+	_, err = NewKeySetTokenUseCase(keys).GenerateAccessToken(JwtCustomClaims{})
+	assert.ErrorIs(t, err, ErrKunciPenandatanganTidakAda)
+}
+
+func TestKeySet_RejectsAlgorithmConfusion(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	keys, err := NewKeySet([]Key{{ID: "rsa", Algorithm: AlgorithmRS256, PrivateKey: rsaKey}})
+	assert.NoError(t, err)
+
+	// Token HS256 yang ditandatangani memakai public key RSA sebagai secret harus ditolak
+	publicDER, err := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+	assert.NoError(t, err)
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, JwtCustomClaims{UserID: 1, Roles: []string{"admin"}})
+	forged.Header["kid"] = "rsa"
+	forgedString, err := forged.SignedString(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}))
+	assert.NoError(t, err)
+
+	_, err = parseWithKeySet(keys, forgedString)
+	assert.Error(t, err)
+
+	// kid yang tidak dikenal juga ditolak
+	unknown := jwt.NewWithClaims(jwt.SigningMethodRS256, JwtCustomClaims{UserID: 1})
+	unknown.Header["kid"] = "lain"
+	unknownString, err := unknown.SignedString(rsaKey)
+	assert.NoError(t, err)
+	_, err = parseWithKeySet(keys, unknownString)
+	assert.ErrorIs(t, err, ErrKunciTidakDikenal)
+}
+
+func TestNewKeySet_Validation(t *testing.T) {
+	weakRSA, err := rsa.GenerateKey(rand.Reader, 1024)
+	assert.NoError(t, err)
+
+	_, err = NewKeySet([]Key{{ID: "lemah", Algorithm: AlgorithmRS256, PrivateKey: weakRSA}})
+	assert.Error(t, err)
+
+	_, err = NewKeySet([]Key{{ID: "salah", Algorithm: AlgorithmRS256, PrivateKey: newECKey(t)}})
+	assert.Error(t, err)
+
+	_, err = NewKeySet([]Key{
+		{ID: "sama", Algorithm: AlgorithmES256, PrivateKey: newECKey(t)},
+		{ID: "sama", Algorithm: AlgorithmES256, PrivateKey: newECKey(t)},
+	})
+	assert.Error(t, err)
+}
+
+func TestKeySet_JWKS(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	edPublic, _, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	keys, err := NewKeySet([]Key{
+		{ID: "rsa", Algorithm: AlgorithmRS256, PrivateKey: rsaKey},
+		{ID: "ec", Algorithm: AlgorithmES256, PrivateKey: newECKey(t)},
+		// Kunci yang hanya dipakai verifikasi tetap dipublikasikan
+		{ID: "ed", Algorithm: AlgorithmEdDSA, PublicKey: edPublic},
+		{ID: "pensiun", Algorithm: AlgorithmES256, PrivateKey: newECKey(t), RetireAt: time.Now().Add(-time.Hour)},
+	})
+	assert.NoError(t, err)
+
+	jwks := keys.JWKS()
+	assert.Len(t, jwks.Keys, 3)
+
+	assert.Equal(t, JWK{KeyType: "RSA", KeyID: "rsa", Use: "sig", Algorithm: "RS256", N: base64URL(rsaKey.N.Bytes()), E: "AQAB"}, jwks.Keys[0])
+	assert.Equal(t, "EC", jwks.Keys[1].KeyType)
+	assert.Equal(t, "P-256", jwks.Keys[1].Curve)
+	assert.Len(t, jwks.Keys[1].X, 43) // 32 byte dalam base64url tanpa padding
+	assert.Equal(t, JWK{KeyType: "OKP", KeyID: "ed", Use: "sig", Algorithm: "EdDSA", Curve: "Ed25519", X: base64URL(edPublic)}, jwks.Keys[2])
+
+	// Secret HS256 tidak boleh dipublikasikan
+	assert.Empty(t, NewHMACKeySet("rahasia").JWKS().Keys)
+}
+
+func TestLoadKeySet(t *testing.T) {
+	_, edPrivate, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(edPrivate)
+	assert.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "jwt.pem")
+	assert.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600))
+
+	keys, err := LoadKeySet(configs.JWTConfig{Keys: []configs.JWTKeyConfig{
+		{ID: "ed-1", Algorithm: AlgorithmEdDSA, PrivateKeyFile: path, ActiveFrom: "2026-01-01T00:00:00Z"},
+	}})
+	assert.NoError(t, err)
+
+	tokenString, err := NewKeySetTokenUseCase(keys).GenerateAccessToken(JwtCustomClaims{Username: "budi"})
+	assert.NoError(t, err)
+	claims, err := parseWithKeySet(keys, tokenString)
+	assert.NoError(t, err)
+	assert.Equal(t, "budi", claims.Username)
+
+	_, err = LoadKeySet(configs.JWTConfig{Keys: []configs.JWTKeyConfig{
+		{ID: "ed-2", Algorithm: AlgorithmEdDSA, PrivateKeyFile: path, ActiveFrom: "besok"},
+	}})
+	assert.Error(t, err)
+
+	// Tanpa KEYS, SECRET_KEY tetap dipakai sebagai HS256
+	keys, err = LoadKeySet(configs.JWTConfig{SecretKey: "rahasia"})
+	assert.NoError(t, err)
+	signing, err := keys.SigningKey()
+	assert.NoError(t, err)
+	assert.Equal(t, AlgorithmHS256, signing.Algorithm)
+}
@@ -0,0 +1,102 @@
+package token
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	denylistKeyPrefix   = "go-todo-api:token:denylist:"
+	generationKeyPrefix = "go-todo-api:token:generation:"
+)
+
+// RevocationStore menyimpan status pencabutan access token. Token dicabut satu per satu
+// melalui jti-nya, atau seluruh token milik pengguna sekaligus dengan menaikkan generation.
+type RevocationStore interface {
+	// Revoke memasukkan jti ke denylist hingga token tersebut kedaluwarsa
+	Revoke(ctx context.Context, jti string, expiresAt time.Time) error
+	IsRevoked(ctx context.Context, jti string) (bool, error)
+	// Generation mengembalikan generation token pengguna saat ini; nol jika belum pernah dinaikkan
+	Generation(ctx context.Context, userID int64) (int64, error)
+	// BumpGeneration membuat seluruh token yang sudah terbit untuk pengguna menjadi tidak berlaku
+	BumpGeneration(ctx context.Context, userID int64) (int64, error)
+}
+
+type redisRevocationStore struct {
+	rdb *redis.Client
+}
+
+// NewRevocationStore membuat RevocationStore berbasis Redis.
+func NewRevocationStore(rdb *redis.Client) RevocationStore {
+	return &redisRevocationStore{rdb: rdb}
+}
+
+// Revoke memasukkan jti ke denylist. Entri dihapus otomatis oleh Redis saat token
+// kedaluwarsa karena setelah itu token sudah ditolak oleh validasi exp.
+func (s *redisRevocationStore) Revoke(ctx context.Context, jti string, expiresAt time.Time) error {
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+	if err := s.rdb.Set(ctx, denylistKeyPrefix+jti, 1, ttl).Err(); err != nil {
+		return fmt.Errorf("gagal mencabut token: %w", err)
+	}
+	return nil
+}
+
+// IsRevoked memeriksa apakah jti ada di denylist.
+func (s *redisRevocationStore) IsRevoked(ctx context.Context, jti string) (bool, error) {
+	n, err := s.rdb.Exists(ctx, denylistKeyPrefix+jti).Result()
+	if err != nil {
+		return false, fmt.Errorf("gagal memeriksa denylist token: %w", err)
+	}
+	return n > 0, nil
+}
+
+// Generation mengembalikan generation token pengguna saat ini.
+func (s *redisRevocationStore) Generation(ctx context.Context, userID int64) (int64, error) {
+	value, err := s.rdb.Get(ctx, generationKey(userID)).Result()
+	if err == redis.Nil {
+		return 0, nil
+	} else if err != nil {
+		return 0, fmt.Errorf("gagal mengambil generation token: %w", err)
+	}
+	generation, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("generation token tidak valid: %w", err)
+	}
+	return generation, nil
+}
+
+// BumpGeneration menaikkan generation token pengguna secara atomik.
+func (s *redisRevocationStore) BumpGeneration(ctx context.Context, userID int64) (int64, error) {
+	generation, err := s.rdb.Incr(ctx, generationKey(userID)).Result()
+	if err != nil {
+		return 0, fmt.Errorf("gagal menaikkan generation token: %w", err)
+	}
+	return generation, nil
+}
+
+func generationKey(userID int64) string {
+	return generationKeyPrefix + strconv.FormatInt(userID, 10)
+}
+
+// CheckRevoked mengembalikan true jika token dengan klaim tersebut sudah dicabut, baik
+// melalui denylist jti maupun karena generation-nya lebih lama dari generation pengguna.
+func CheckRevoked(ctx context.Context, store RevocationStore, claims *JwtCustomClaims) (bool, error) {
+	if claims.ID != "" {
+		revoked, err := store.IsRevoked(ctx, claims.ID)
+		if err != nil || revoked {
+			return revoked, err
+		}
+	}
+	generation, err := store.Generation(ctx, claims.UserID)
+	if err != nil {
+		return false, err
+	}
+	return claims.Generation < generation, nil
+}
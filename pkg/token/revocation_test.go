@@ -0,0 +1,82 @@
+package token
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redismock/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckRevoked_Denylist(t *testing.T) {
+	db, mock := redismock.NewClientMock()
+	store := NewRevocationStore(db)
+	claims := &JwtCustomClaims{UserID: 1}
+	claims.ID = "jti-1"
+
+	mock.ExpectExists("go-todo-api:token:denylist:jti-1").SetVal(1)
+
+	revoked, err := CheckRevoked(context.Background(), store, claims)
+	assert.NoError(t, err)
+	assert.True(t, revoked)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCheckRevoked_Generation(t *testing.T) {
+	db, mock := redismock.NewClientMock()
+	store := NewRevocationStore(db)
+	claims := &JwtCustomClaims{UserID: 1, Generation: 1}
+	claims.ID = "jti-1"
+
+	// Generation pengguna sudah dinaikkan setelah token ini terbit
+	mock.ExpectExists("go-todo-api:token:denylist:jti-1").SetVal(0)
+	mock.ExpectGet("go-todo-api:token:generation:1").SetVal("2")
+
+	revoked, err := CheckRevoked(context.Background(), store, claims)
+	assert.NoError(t, err)
+	assert.True(t, revoked)
+
+	// Token dengan generation terbaru tetap berlaku
+	claims.Generation = 2
+	mock.ExpectExists("go-todo-api:token:denylist:jti-1").SetVal(0)
+	mock.ExpectGet("go-todo-api:token:generation:1").SetVal("2")
+
+	revoked, err = CheckRevoked(context.Background(), store, claims)
+	assert.NoError(t, err)
+	assert.False(t, revoked)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRevocationStore_Revoke(t *testing.T) {
+	db, mock := redismock.NewClientMock()
+	store := NewRevocationStore(db)
+
+	// TTL mengikuti sisa masa berlaku token sehingga hanya key dan nilainya yang dicocokkan
+	mock.CustomMatch(func(expected, actual []interface{}) error {
+		assert.Equal(t, expected[:3], actual[:3])
+		assert.Equal(t, "px", actual[3])
+		return nil
+	}).ExpectSet("go-todo-api:token:denylist:jti-1", 1, time.Minute).SetVal("OK")
+	assert.NoError(t, store.Revoke(context.Background(), "jti-1", time.Now().Add(time.Minute)))
+
+	// Token yang sudah kedaluwarsa tidak perlu dimasukkan ke denylist
+	assert.NoError(t, store.Revoke(context.Background(), "jti-2", time.Now().Add(-time.Minute)))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRevocationStore_Generation_Default(t *testing.T) {
+	db, mock := redismock.NewClientMock()
+	store := NewRevocationStore(db)
+
+	mock.ExpectGet("go-todo-api:token:generation:7").RedisNil()
+	generation, err := store.Generation(context.Background(), 7)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), generation)
+
+	mock.ExpectIncr("go-todo-api:token:generation:7").SetVal(1)
+	generation, err = store.BumpGeneration(context.Background(), 7)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), generation)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
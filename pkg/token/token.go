@@ -9,24 +9,47 @@ type TokenUseCase interface {
 }
 
 type tokenUseCase struct {
-	secretKey string
+	keys *KeySet
 }
 
+// NewTokenUseCase membuat TokenUseCase yang menandatangani token dengan secret HS256.
 func NewTokenUseCase(secretKey string) TokenUseCase {
-	return &tokenUseCase{secretKey}
+	return &tokenUseCase{NewHMACKeySet(secretKey)}
 }
 
+// NewKeySetTokenUseCase membuat TokenUseCase yang menandatangani token dengan kunci aktif
+// dari KeySet dan mencantumkan kid-nya pada header token.
+func NewKeySetTokenUseCase(keys *KeySet) TokenUseCase {
+	return &tokenUseCase{keys}
+}
+
+// JwtCustomClaims adalah klaim access token. RegisteredClaims.ID berisi jti unik per token
+// untuk keperluan denylist, sedangkan Generation dibandingkan dengan generation pengguna
+// di RevocationStore sehingga seluruh token lama dapat dicabut sekaligus.
+// Roles dan Permissions adalah salinan saat token diterbitkan untuk klien dan layanan lain;
+// server sendiri selalu memeriksa izin terbaru dari database melalui cache.
 type JwtCustomClaims struct {
-	Username string `json:"username"`
-	Role     string `json:"role"`
-	FullName string `json:"full_name"`
+	UserID      int64    `json:"user_id"`
+	Username    string   `json:"username"`
+	Roles       []string `json:"roles"`
+	Permissions []string `json:"permissions,omitempty"`
+	FullName    string   `json:"full_name"`
+	Generation  int64    `json:"gen,omitempty"`
 	jwt.RegisteredClaims
 }
 
 func (t *tokenUseCase) GenerateAccessToken(claims JwtCustomClaims) (string, error) {
-	plainToken := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	key, err := t.keys.SigningKey()
+	if err != nil {
+		return "", err
+	}
+
+	plainToken := jwt.NewWithClaims(key.method(), claims)
+	if key.ID != "" {
+		plainToken.Header["kid"] = key.ID
+	}
 
-	encodedToken, err := plainToken.SignedString([]byte(t.secretKey))
+	encodedToken, err := plainToken.SignedString(key.PrivateKey)
 	if err != nil {
 		return "", err
 	}
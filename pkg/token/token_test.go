@@ -14,7 +14,7 @@ func TestGenerateAccessToken(t *testing.T) {
 
 	claims := JwtCustomClaims{
 		Username: "testuser",
-		Role:     "user",
+		Roles:    []string{"user"},
 		FullName: "Test User",
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour * 24)), // Token expired after 1 day
@@ -38,7 +38,7 @@ func TestGenerateAccessToken(t *testing.T) {
 	parsedClaims, ok := parsedToken.Claims.(*JwtCustomClaims)
 	assert.True(t, ok)
 	assert.Equal(t, claims.Username, parsedClaims.Username)
-	assert.Equal(t, claims.Role, parsedClaims.Role)
+	assert.Equal(t, claims.Roles, parsedClaims.Roles)
 	assert.Equal(t, claims.FullName, parsedClaims.FullName)
 	assert.Equal(t, claims.Issuer, parsedClaims.Issuer)
 }
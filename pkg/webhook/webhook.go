@@ -0,0 +1,109 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Header yang dikirim bersama setiap webhook
+const (
+	HeaderSignature = "X-Webhook-Signature"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderEventID   = "X-Webhook-Event-Id"
+	HeaderEventType = "X-Webhook-Event"
+)
+
+// Request berisi data satu pengiriman webhook
+type Request struct {
+	URL       string
+	Secret    string
+	EventID   string
+	EventType string
+	Body      []byte
+}
+
+// Sender mengirim webhook ke penerima dan mengembalikan status HTTP respons
+type Sender interface {
+	Send(ctx context.Context, req Request) (int, error)
+}
+
+type sender struct {
+	client *http.Client
+}
+
+// NewSender membuat Sender dengan batas waktu per permintaan
+func NewSender(timeout time.Duration) Sender {
+	return &sender{client: &http.Client{Timeout: timeout}}
+}
+
+// Send mengirim payload dengan tanda tangan HMAC-SHA256.
+// Respons di luar 2xx dianggap gagal sehingga pengiriman akan dicoba ulang.
+func (s *sender) Send(ctx context.Context, req Request) (int, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, req.URL, bytes.NewReader(req.Body))
+	if err != nil {
+		return 0, fmt.Errorf("gagal membuat permintaan webhook: %w", err)
+	}
+
+	timestamp := time.Now().Unix()
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("User-Agent", "go-todo-webhook/1.0")
+	httpReq.Header.Set(HeaderEventID, req.EventID)
+	httpReq.Header.Set(HeaderEventType, req.EventType)
+	httpReq.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	httpReq.Header.Set(HeaderSignature, Sign(req.Secret, timestamp, req.Body))
+
+	res, err := s.client.Do(httpReq)
+	if err != nil {
+		return 0, fmt.Errorf("gagal mengirim webhook: %w", err)
+	}
+	defer res.Body.Close()
+	// Body dibaca sebagian agar koneksi dapat digunakan kembali
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, 64<<10))
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return res.StatusCode, fmt.Errorf("penerima webhook merespons status %d", res.StatusCode)
+	}
+	return res.StatusCode, nil
+}
+
+// Sign menghitung tanda tangan "sha256=<hex>" dari "<timestamp>.<body>".
+// Timestamp ikut ditandatangani agar penerima dapat menolak permintaan lama (replay attack).
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify memeriksa tanda tangan webhook dengan perbandingan waktu konstan
+func Verify(secret string, timestamp int64, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
+
+// Backoff menghitung jeda sebelum percobaan berikutnya secara eksponensial:
+// base, 2*base, 4*base, ... dan tidak pernah melebihi max.
+func Backoff(attempt int, base, max time.Duration) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+	delay := base
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= max {
+			return max
+		}
+	}
+	if delay > max {
+		return max
+	}
+	return delay
+}
@@ -0,0 +1,76 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSender_Send_SignedPayload(t *testing.T) {
+	body := []byte(`{"id":"evt_1","type":"todo.created"}`)
+
+	// Penerima lokal memverifikasi tanda tangan seperti yang dilakukan sistem lain
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received, _ := io.ReadAll(r.Body)
+		timestamp, err := strconv.ParseInt(r.Header.Get(HeaderTimestamp), 10, 64)
+		assert.NoError(t, err)
+		assert.Equal(t, "evt_1", r.Header.Get(HeaderEventID))
+		assert.Equal(t, "todo.created", r.Header.Get(HeaderEventType))
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Equal(t, body, received)
+
+		if !Verify("rahasia", timestamp, received, r.Header.Get(HeaderSignature)) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	status, err := NewSender(time.Second).Send(context.Background(), Request{
+		URL: receiver.URL, Secret: "rahasia", EventID: "evt_1", EventType: "todo.created", Body: body,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, status)
+
+	// Secret yang berbeda menghasilkan tanda tangan yang ditolak penerima
+	status, err = NewSender(time.Second).Send(context.Background(), Request{
+		URL: receiver.URL, Secret: "salah", EventID: "evt_1", EventType: "todo.created", Body: body,
+	})
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusUnauthorized, status)
+}
+
+func TestSender_Send_Timeout(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer receiver.Close()
+
+	status, err := NewSender(50*time.Millisecond).Send(context.Background(), Request{URL: receiver.URL, Body: []byte("{}")})
+	assert.Error(t, err)
+	assert.Equal(t, 0, status)
+}
+
+func TestSign(t *testing.T) {
+	signature := Sign("rahasia", 1700000000, []byte("{}"))
+	assert.Equal(t, "sha256=", signature[:7])
+	assert.Len(t, signature, 7+64)
+	assert.True(t, Verify("rahasia", 1700000000, []byte("{}"), signature))
+	assert.False(t, Verify("rahasia", 1700000001, []byte("{}"), signature))
+}
+
+func TestBackoff(t *testing.T) {
+	base, max := 30*time.Second, 10*time.Minute
+	assert.Equal(t, 30*time.Second, Backoff(1, base, max))
+	assert.Equal(t, time.Minute, Backoff(2, base, max))
+	assert.Equal(t, 4*time.Minute, Backoff(4, base, max))
+	assert.Equal(t, max, Backoff(6, base, max))
+	assert.Equal(t, max, Backoff(60, base, max))
+}
@@ -0,0 +1,64 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./pkg/secretbox/secretbox.go
+
+// Package mock_secretbox is a generated GoMock package.
+package mock_secretbox
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockBox is a mock of Box interface.
+type MockBox struct {
+	ctrl     *gomock.Controller
+	recorder *MockBoxMockRecorder
+}
+
+// MockBoxMockRecorder is the mock recorder for MockBox.
+type MockBoxMockRecorder struct {
+	mock *MockBox
+}
+
+// NewMockBox creates a new mock instance.
+func NewMockBox(ctrl *gomock.Controller) *MockBox {
+	mock := &MockBox{ctrl: ctrl}
+	mock.recorder = &MockBoxMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBox) EXPECT() *MockBoxMockRecorder {
+	return m.recorder
+}
+
+// Open mocks base method.
+func (m *MockBox) Open(sealed string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Open", sealed)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Open indicates an expected call of Open.
+func (mr *MockBoxMockRecorder) Open(sealed interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Open", reflect.TypeOf((*MockBox)(nil).Open), sealed)
+}
+
+// Seal mocks base method.
+func (m *MockBox) Seal(plaintext string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Seal", plaintext)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Seal indicates an expected call of Seal.
+func (mr *MockBoxMockRecorder) Seal(plaintext interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Seal", reflect.TypeOf((*MockBox)(nil).Seal), plaintext)
+}
@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./pkg/server/echo.go
+
+// Package mock_server is a generated GoMock package.
+package mock_server
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPermissionLookup is a mock of PermissionLookup interface.
+type MockPermissionLookup struct {
+	ctrl     *gomock.Controller
+	recorder *MockPermissionLookupMockRecorder
+}
+
+// MockPermissionLookupMockRecorder is the mock recorder for MockPermissionLookup.
+type MockPermissionLookupMockRecorder struct {
+	mock *MockPermissionLookup
+}
+
+// NewMockPermissionLookup creates a new mock instance.
+func NewMockPermissionLookup(ctrl *gomock.Controller) *MockPermissionLookup {
+	mock := &MockPermissionLookup{ctrl: ctrl}
+	mock.recorder = &MockPermissionLookupMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPermissionLookup) EXPECT() *MockPermissionLookupMockRecorder {
+	return m.recorder
+}
+
+// Permissions mocks base method.
+func (m *MockPermissionLookup) Permissions(ctx context.Context, userID int64) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Permissions", ctx, userID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Permissions indicates an expected call of Permissions.
+func (mr *MockPermissionLookupMockRecorder) Permissions(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Permissions", reflect.TypeOf((*MockPermissionLookup)(nil).Permissions), ctx, userID)
+}
@@ -0,0 +1,97 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/analytics.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	entity "go-todo/internal/entity"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAnalyticsRepository is a mock of AnalyticsRepository interface.
+type MockAnalyticsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAnalyticsRepositoryMockRecorder
+}
+
+// MockAnalyticsRepositoryMockRecorder is the mock recorder for MockAnalyticsRepository.
+type MockAnalyticsRepositoryMockRecorder struct {
+	mock *MockAnalyticsRepository
+}
+
+// NewMockAnalyticsRepository creates a new mock instance.
+func NewMockAnalyticsRepository(ctrl *gomock.Controller) *MockAnalyticsRepository {
+	mock := &MockAnalyticsRepository{ctrl: ctrl}
+	mock.recorder = &MockAnalyticsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAnalyticsRepository) EXPECT() *MockAnalyticsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ActiveUsers mocks base method.
+func (m *MockAnalyticsRepository) ActiveUsers(ctx context.Context, from, to time.Time, timezone string) ([]entity.DailyCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActiveUsers", ctx, from, to, timezone)
+	ret0, _ := ret[0].([]entity.DailyCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActiveUsers indicates an expected call of ActiveUsers.
+func (mr *MockAnalyticsRepositoryMockRecorder) ActiveUsers(ctx, from, to, timezone interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActiveUsers", reflect.TypeOf((*MockAnalyticsRepository)(nil).ActiveUsers), ctx, from, to, timezone)
+}
+
+// LoginActivity mocks base method.
+func (m *MockAnalyticsRepository) LoginActivity(ctx context.Context, from, to time.Time, timezone string) ([]entity.DailyLoginActivity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoginActivity", ctx, from, to, timezone)
+	ret0, _ := ret[0].([]entity.DailyLoginActivity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoginActivity indicates an expected call of LoginActivity.
+func (mr *MockAnalyticsRepositoryMockRecorder) LoginActivity(ctx, from, to, timezone interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginActivity", reflect.TypeOf((*MockAnalyticsRepository)(nil).LoginActivity), ctx, from, to, timezone)
+}
+
+// TodoActivity mocks base method.
+func (m *MockAnalyticsRepository) TodoActivity(ctx context.Context, from, to time.Time, timezone string) ([]entity.DailyTodoActivity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TodoActivity", ctx, from, to, timezone)
+	ret0, _ := ret[0].([]entity.DailyTodoActivity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TodoActivity indicates an expected call of TodoActivity.
+func (mr *MockAnalyticsRepositoryMockRecorder) TodoActivity(ctx, from, to, timezone interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TodoActivity", reflect.TypeOf((*MockAnalyticsRepository)(nil).TodoActivity), ctx, from, to, timezone)
+}
+
+// TopUsers mocks base method.
+func (m *MockAnalyticsRepository) TopUsers(ctx context.Context, from, to time.Time, limit int) ([]entity.TopUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TopUsers", ctx, from, to, limit)
+	ret0, _ := ret[0].([]entity.TopUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TopUsers indicates an expected call of TopUsers.
+func (mr *MockAnalyticsRepositoryMockRecorder) TopUsers(ctx, from, to, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TopUsers", reflect.TypeOf((*MockAnalyticsRepository)(nil).TopUsers), ctx, from, to, limit)
+}
@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/custom_field.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	entity "go-todo/internal/entity"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCustomFieldRepository is a mock of CustomFieldRepository interface.
+type MockCustomFieldRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCustomFieldRepositoryMockRecorder
+}
+
+// MockCustomFieldRepositoryMockRecorder is the mock recorder for MockCustomFieldRepository.
+type MockCustomFieldRepositoryMockRecorder struct {
+	mock *MockCustomFieldRepository
+}
+
+// NewMockCustomFieldRepository creates a new mock instance.
+func NewMockCustomFieldRepository(ctrl *gomock.Controller) *MockCustomFieldRepository {
+	mock := &MockCustomFieldRepository{ctrl: ctrl}
+	mock.recorder = &MockCustomFieldRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCustomFieldRepository) EXPECT() *MockCustomFieldRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCustomFieldRepository) Create(ctx context.Context, definition *entity.CustomFieldDefinition) (*entity.CustomFieldDefinition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, definition)
+	ret0, _ := ret[0].(*entity.CustomFieldDefinition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCustomFieldRepositoryMockRecorder) Create(ctx, definition interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCustomFieldRepository)(nil).Create), ctx, definition)
+}
+
+// Delete mocks base method.
+func (m *MockCustomFieldRepository) Delete(ctx context.Context, userID, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCustomFieldRepositoryMockRecorder) Delete(ctx, userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCustomFieldRepository)(nil).Delete), ctx, userID, id)
+}
+
+// FindByID mocks base method.
+func (m *MockCustomFieldRepository) FindByID(ctx context.Context, userID, id int64) (*entity.CustomFieldDefinition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, userID, id)
+	ret0, _ := ret[0].(*entity.CustomFieldDefinition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockCustomFieldRepositoryMockRecorder) FindByID(ctx, userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockCustomFieldRepository)(nil).FindByID), ctx, userID, id)
+}
+
+// FindByUserID mocks base method.
+func (m *MockCustomFieldRepository) FindByUserID(ctx context.Context, userID int64) ([]entity.CustomFieldDefinition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUserID", ctx, userID)
+	ret0, _ := ret[0].([]entity.CustomFieldDefinition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByUserID indicates an expected call of FindByUserID.
+func (mr *MockCustomFieldRepositoryMockRecorder) FindByUserID(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserID", reflect.TypeOf((*MockCustomFieldRepository)(nil).FindByUserID), ctx, userID)
+}
+
+// Update mocks base method.
+func (m *MockCustomFieldRepository) Update(ctx context.Context, definition *entity.CustomFieldDefinition) (*entity.CustomFieldDefinition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, definition)
+	ret0, _ := ret[0].(*entity.CustomFieldDefinition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCustomFieldRepositoryMockRecorder) Update(ctx, definition interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCustomFieldRepository)(nil).Update), ctx, definition)
+}
@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/login_event.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	entity "go-todo/internal/entity"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockLoginEventRepository is a mock of LoginEventRepository interface.
+type MockLoginEventRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLoginEventRepositoryMockRecorder
+}
+
+// MockLoginEventRepositoryMockRecorder is the mock recorder for MockLoginEventRepository.
+type MockLoginEventRepositoryMockRecorder struct {
+	mock *MockLoginEventRepository
+}
+
+// NewMockLoginEventRepository creates a new mock instance.
+func NewMockLoginEventRepository(ctrl *gomock.Controller) *MockLoginEventRepository {
+	mock := &MockLoginEventRepository{ctrl: ctrl}
+	mock.recorder = &MockLoginEventRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLoginEventRepository) EXPECT() *MockLoginEventRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockLoginEventRepository) Create(ctx context.Context, event *entity.LoginEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockLoginEventRepositoryMockRecorder) Create(ctx, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockLoginEventRepository)(nil).Create), ctx, event)
+}
@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/revision.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	entity "go-todo/internal/entity"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRevisionRepository is a mock of RevisionRepository interface.
+type MockRevisionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRevisionRepositoryMockRecorder
+}
+
+// MockRevisionRepositoryMockRecorder is the mock recorder for MockRevisionRepository.
+type MockRevisionRepositoryMockRecorder struct {
+	mock *MockRevisionRepository
+}
+
+// NewMockRevisionRepository creates a new mock instance.
+func NewMockRevisionRepository(ctrl *gomock.Controller) *MockRevisionRepository {
+	mock := &MockRevisionRepository{ctrl: ctrl}
+	mock.recorder = &MockRevisionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRevisionRepository) EXPECT() *MockRevisionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockRevisionRepository) Create(ctx context.Context, revision *entity.TodoRevision) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, revision)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRevisionRepositoryMockRecorder) Create(ctx, revision interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRevisionRepository)(nil).Create), ctx, revision)
+}
+
+// FindByRevision mocks base method.
+func (m *MockRevisionRepository) FindByRevision(ctx context.Context, todoID int64, revision int) (*entity.TodoRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByRevision", ctx, todoID, revision)
+	ret0, _ := ret[0].(*entity.TodoRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByRevision indicates an expected call of FindByRevision.
+func (mr *MockRevisionRepositoryMockRecorder) FindByRevision(ctx, todoID, revision interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByRevision", reflect.TypeOf((*MockRevisionRepository)(nil).FindByRevision), ctx, todoID, revision)
+}
+
+// FindByTodoID mocks base method.
+func (m *MockRevisionRepository) FindByTodoID(ctx context.Context, todoID int64) ([]entity.TodoRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByTodoID", ctx, todoID)
+	ret0, _ := ret[0].([]entity.TodoRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByTodoID indicates an expected call of FindByTodoID.
+func (mr *MockRevisionRepositoryMockRecorder) FindByTodoID(ctx, todoID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByTodoID", reflect.TypeOf((*MockRevisionRepository)(nil).FindByTodoID), ctx, todoID)
+}
+
+// FindLatest mocks base method.
+func (m *MockRevisionRepository) FindLatest(ctx context.Context, todoID int64) (*entity.TodoRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindLatest", ctx, todoID)
+	ret0, _ := ret[0].(*entity.TodoRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindLatest indicates an expected call of FindLatest.
+func (mr *MockRevisionRepositoryMockRecorder) FindLatest(ctx, todoID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLatest", reflect.TypeOf((*MockRevisionRepository)(nil).FindLatest), ctx, todoID)
+}
@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/saved_filter.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	entity "go-todo/internal/entity"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSavedFilterRepository is a mock of SavedFilterRepository interface.
+type MockSavedFilterRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSavedFilterRepositoryMockRecorder
+}
+
+// MockSavedFilterRepositoryMockRecorder is the mock recorder for MockSavedFilterRepository.
+type MockSavedFilterRepositoryMockRecorder struct {
+	mock *MockSavedFilterRepository
+}
+
+// NewMockSavedFilterRepository creates a new mock instance.
+func NewMockSavedFilterRepository(ctrl *gomock.Controller) *MockSavedFilterRepository {
+	mock := &MockSavedFilterRepository{ctrl: ctrl}
+	mock.recorder = &MockSavedFilterRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSavedFilterRepository) EXPECT() *MockSavedFilterRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockSavedFilterRepository) Create(ctx context.Context, savedFilter *entity.SavedFilter) (*entity.SavedFilter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, savedFilter)
+	ret0, _ := ret[0].(*entity.SavedFilter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockSavedFilterRepositoryMockRecorder) Create(ctx, savedFilter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSavedFilterRepository)(nil).Create), ctx, savedFilter)
+}
+
+// Delete mocks base method.
+func (m *MockSavedFilterRepository) Delete(ctx context.Context, userID, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockSavedFilterRepositoryMockRecorder) Delete(ctx, userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSavedFilterRepository)(nil).Delete), ctx, userID, id)
+}
+
+// FindByID mocks base method.
+func (m *MockSavedFilterRepository) FindByID(ctx context.Context, userID, id int64) (*entity.SavedFilter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, userID, id)
+	ret0, _ := ret[0].(*entity.SavedFilter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockSavedFilterRepositoryMockRecorder) FindByID(ctx, userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockSavedFilterRepository)(nil).FindByID), ctx, userID, id)
+}
+
+// FindByUserID mocks base method.
+func (m *MockSavedFilterRepository) FindByUserID(ctx context.Context, userID int64) ([]entity.SavedFilter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUserID", ctx, userID)
+	ret0, _ := ret[0].([]entity.SavedFilter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByUserID indicates an expected call of FindByUserID.
+func (mr *MockSavedFilterRepositoryMockRecorder) FindByUserID(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserID", reflect.TypeOf((*MockSavedFilterRepository)(nil).FindByUserID), ctx, userID)
+}
+
+// Update mocks base method.
+func (m *MockSavedFilterRepository) Update(ctx context.Context, savedFilter *entity.SavedFilter) (*entity.SavedFilter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, savedFilter)
+	ret0, _ := ret[0].(*entity.SavedFilter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockSavedFilterRepositoryMockRecorder) Update(ctx, savedFilter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockSavedFilterRepository)(nil).Update), ctx, savedFilter)
+}
@@ -0,0 +1,97 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/stats.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	entity "go-todo/internal/entity"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockStatsRepository is a mock of StatsRepository interface.
+type MockStatsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatsRepositoryMockRecorder
+}
+
+// MockStatsRepositoryMockRecorder is the mock recorder for MockStatsRepository.
+type MockStatsRepositoryMockRecorder struct {
+	mock *MockStatsRepository
+}
+
+// NewMockStatsRepository creates a new mock instance.
+func NewMockStatsRepository(ctrl *gomock.Controller) *MockStatsRepository {
+	mock := &MockStatsRepository{ctrl: ctrl}
+	mock.recorder = &MockStatsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatsRepository) EXPECT() *MockStatsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AverageLeadTime mocks base method.
+func (m *MockStatsRepository) AverageLeadTime(ctx context.Context, userID int64) (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AverageLeadTime", ctx, userID)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AverageLeadTime indicates an expected call of AverageLeadTime.
+func (mr *MockStatsRepositoryMockRecorder) AverageLeadTime(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AverageLeadTime", reflect.TypeOf((*MockStatsRepository)(nil).AverageLeadTime), ctx, userID)
+}
+
+// CompletionBuckets mocks base method.
+func (m *MockStatsRepository) CompletionBuckets(ctx context.Context, userID int64, bucket string, from, to time.Time, timezone string, weekStart int) ([]entity.CompletionBucket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompletionBuckets", ctx, userID, bucket, from, to, timezone, weekStart)
+	ret0, _ := ret[0].([]entity.CompletionBucket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompletionBuckets indicates an expected call of CompletionBuckets.
+func (mr *MockStatsRepositoryMockRecorder) CompletionBuckets(ctx, userID, bucket, from, to, timezone, weekStart interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompletionBuckets", reflect.TypeOf((*MockStatsRepository)(nil).CompletionBuckets), ctx, userID, bucket, from, to, timezone, weekStart)
+}
+
+// CompletionDays mocks base method.
+func (m *MockStatsRepository) CompletionDays(ctx context.Context, userID int64, timezone string) ([]time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompletionDays", ctx, userID, timezone)
+	ret0, _ := ret[0].([]time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompletionDays indicates an expected call of CompletionDays.
+func (mr *MockStatsRepositoryMockRecorder) CompletionDays(ctx, userID, timezone interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompletionDays", reflect.TypeOf((*MockStatsRepository)(nil).CompletionDays), ctx, userID, timezone)
+}
+
+// StatusCounts mocks base method.
+func (m *MockStatsRepository) StatusCounts(ctx context.Context, userID int64, now time.Time) (entity.TodoStatusCounts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StatusCounts", ctx, userID, now)
+	ret0, _ := ret[0].(entity.TodoStatusCounts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StatusCounts indicates an expected call of StatusCounts.
+func (mr *MockStatsRepositoryMockRecorder) StatusCounts(ctx, userID, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StatusCounts", reflect.TypeOf((*MockStatsRepository)(nil).StatusCounts), ctx, userID, now)
+}
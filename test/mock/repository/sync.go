@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/sync.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	entity "go-todo/internal/entity"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSyncRepository is a mock of SyncRepository interface.
+type MockSyncRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSyncRepositoryMockRecorder
+}
+
+// MockSyncRepositoryMockRecorder is the mock recorder for MockSyncRepository.
+type MockSyncRepositoryMockRecorder struct {
+	mock *MockSyncRepository
+}
+
+// NewMockSyncRepository creates a new mock instance.
+func NewMockSyncRepository(ctrl *gomock.Controller) *MockSyncRepository {
+	mock := &MockSyncRepository{ctrl: ctrl}
+	mock.recorder = &MockSyncRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSyncRepository) EXPECT() *MockSyncRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ChangedTodos mocks base method.
+func (m *MockSyncRepository) ChangedTodos(ctx context.Context, userID, since int64, limit int) ([]entity.Todo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangedTodos", ctx, userID, since, limit)
+	ret0, _ := ret[0].([]entity.Todo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangedTodos indicates an expected call of ChangedTodos.
+func (mr *MockSyncRepositoryMockRecorder) ChangedTodos(ctx, userID, since, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangedTodos", reflect.TypeOf((*MockSyncRepository)(nil).ChangedTodos), ctx, userID, since, limit)
+}
+
+// DeleteTombstones mocks base method.
+func (m *MockSyncRepository) DeleteTombstones(ctx context.Context, todoIDs []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTombstones", ctx, todoIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTombstones indicates an expected call of DeleteTombstones.
+func (mr *MockSyncRepositoryMockRecorder) DeleteTombstones(ctx, todoIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTombstones", reflect.TypeOf((*MockSyncRepository)(nil).DeleteTombstones), ctx, todoIDs)
+}
+
+// FindTombstone mocks base method.
+func (m *MockSyncRepository) FindTombstone(ctx context.Context, userID, id int64, clientID string) (*entity.TodoTombstone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTombstone", ctx, userID, id, clientID)
+	ret0, _ := ret[0].(*entity.TodoTombstone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindTombstone indicates an expected call of FindTombstone.
+func (mr *MockSyncRepositoryMockRecorder) FindTombstone(ctx, userID, id, clientID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTombstone", reflect.TypeOf((*MockSyncRepository)(nil).FindTombstone), ctx, userID, id, clientID)
+}
+
+// LockTodo mocks base method.
+func (m *MockSyncRepository) LockTodo(ctx context.Context, userID, id int64, clientID string) (*entity.Todo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockTodo", ctx, userID, id, clientID)
+	ret0, _ := ret[0].(*entity.Todo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockTodo indicates an expected call of LockTodo.
+func (mr *MockSyncRepositoryMockRecorder) LockTodo(ctx, userID, id, clientID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockTodo", reflect.TypeOf((*MockSyncRepository)(nil).LockTodo), ctx, userID, id, clientID)
+}
+
+// Tombstones mocks base method.
+func (m *MockSyncRepository) Tombstones(ctx context.Context, userID, since int64, limit int) ([]entity.TodoTombstone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Tombstones", ctx, userID, since, limit)
+	ret0, _ := ret[0].([]entity.TodoTombstone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Tombstones indicates an expected call of Tombstones.
+func (mr *MockSyncRepositoryMockRecorder) Tombstones(ctx, userID, since, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Tombstones", reflect.TypeOf((*MockSyncRepository)(nil).Tombstones), ctx, userID, since, limit)
+}
@@ -0,0 +1,140 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/time_entry.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	entity "go-todo/internal/entity"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTimeEntryRepository is a mock of TimeEntryRepository interface.
+type MockTimeEntryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTimeEntryRepositoryMockRecorder
+}
+
+// MockTimeEntryRepositoryMockRecorder is the mock recorder for MockTimeEntryRepository.
+type MockTimeEntryRepositoryMockRecorder struct {
+	mock *MockTimeEntryRepository
+}
+
+// NewMockTimeEntryRepository creates a new mock instance.
+func NewMockTimeEntryRepository(ctrl *gomock.Controller) *MockTimeEntryRepository {
+	mock := &MockTimeEntryRepository{ctrl: ctrl}
+	mock.recorder = &MockTimeEntryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTimeEntryRepository) EXPECT() *MockTimeEntryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockTimeEntryRepository) Create(ctx context.Context, entry *entity.TimeEntry) (*entity.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, entry)
+	ret0, _ := ret[0].(*entity.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockTimeEntryRepositoryMockRecorder) Create(ctx, entry interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTimeEntryRepository)(nil).Create), ctx, entry)
+}
+
+// Delete mocks base method.
+func (m *MockTimeEntryRepository) Delete(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTimeEntryRepositoryMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTimeEntryRepository)(nil).Delete), ctx, id)
+}
+
+// FindByID mocks base method.
+func (m *MockTimeEntryRepository) FindByID(ctx context.Context, id int64) (*entity.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, id)
+	ret0, _ := ret[0].(*entity.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockTimeEntryRepositoryMockRecorder) FindByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockTimeEntryRepository)(nil).FindByID), ctx, id)
+}
+
+// FindByTodoID mocks base method.
+func (m *MockTimeEntryRepository) FindByTodoID(ctx context.Context, todoID int64) ([]entity.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByTodoID", ctx, todoID)
+	ret0, _ := ret[0].([]entity.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByTodoID indicates an expected call of FindByTodoID.
+func (mr *MockTimeEntryRepositoryMockRecorder) FindByTodoID(ctx, todoID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByTodoID", reflect.TypeOf((*MockTimeEntryRepository)(nil).FindByTodoID), ctx, todoID)
+}
+
+// FindRunningByUserID mocks base method.
+func (m *MockTimeEntryRepository) FindRunningByUserID(ctx context.Context, userID int64) (*entity.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRunningByUserID", ctx, userID)
+	ret0, _ := ret[0].(*entity.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRunningByUserID indicates an expected call of FindRunningByUserID.
+func (mr *MockTimeEntryRepositoryMockRecorder) FindRunningByUserID(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRunningByUserID", reflect.TypeOf((*MockTimeEntryRepository)(nil).FindRunningByUserID), ctx, userID)
+}
+
+// Report mocks base method.
+func (m *MockTimeEntryRepository) Report(ctx context.Context, filter entity.TimeReportFilter) ([]entity.TimeReportRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Report", ctx, filter)
+	ret0, _ := ret[0].([]entity.TimeReportRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Report indicates an expected call of Report.
+func (mr *MockTimeEntryRepositoryMockRecorder) Report(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Report", reflect.TypeOf((*MockTimeEntryRepository)(nil).Report), ctx, filter)
+}
+
+// Update mocks base method.
+func (m *MockTimeEntryRepository) Update(ctx context.Context, entry *entity.TimeEntry) (*entity.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, entry)
+	ret0, _ := ret[0].(*entity.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockTimeEntryRepositoryMockRecorder) Update(ctx, entry interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTimeEntryRepository)(nil).Update), ctx, entry)
+}
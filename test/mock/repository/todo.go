@@ -79,6 +79,56 @@ func (mr *MockTodoRepositoryMockRecorder) FindAll(ctx interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockTodoRepository)(nil).FindAll), ctx)
 }
 
+// FindByAssigneeID mocks base method.
+func (m *MockTodoRepository) FindByAssigneeID(ctx context.Context, assigneeID int64) ([]entity.Todo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByAssigneeID", ctx, assigneeID)
+	ret0, _ := ret[0].([]entity.Todo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByAssigneeID indicates an expected call of FindByAssigneeID.
+func (mr *MockTodoRepositoryMockRecorder) FindByAssigneeID(ctx, assigneeID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByAssigneeID", reflect.TypeOf((*MockTodoRepository)(nil).FindByAssigneeID), ctx, assigneeID)
+}
+
+// FindByCondition mocks base method.
+func (m *MockTodoRepository) FindByCondition(ctx context.Context, userID int64, condition string, args ...interface{}) ([]entity.Todo, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, userID, condition}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FindByCondition", varargs...)
+	ret0, _ := ret[0].([]entity.Todo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByCondition indicates an expected call of FindByCondition.
+func (mr *MockTodoRepositoryMockRecorder) FindByCondition(ctx, userID, condition interface{}, args ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, userID, condition}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByCondition", reflect.TypeOf((*MockTodoRepository)(nil).FindByCondition), varargs...)
+}
+
+// FindByCustomFields mocks base method.
+func (m *MockTodoRepository) FindByCustomFields(ctx context.Context, userID int64, conditions []entity.CustomFieldCondition, sort *entity.CustomFieldSort) ([]entity.Todo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByCustomFields", ctx, userID, conditions, sort)
+	ret0, _ := ret[0].([]entity.Todo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByCustomFields indicates an expected call of FindByCustomFields.
+func (mr *MockTodoRepositoryMockRecorder) FindByCustomFields(ctx, userID, conditions, sort interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByCustomFields", reflect.TypeOf((*MockTodoRepository)(nil).FindByCustomFields), ctx, userID, conditions, sort)
+}
+
 // FindByID mocks base method.
 func (m *MockTodoRepository) FindByID(ctx context.Context, id int64) (*entity.Todo, error) {
 	m.ctrl.T.Helper()
@@ -108,3 +158,17 @@ func (mr *MockTodoRepositoryMockRecorder) Update(ctx, todo interface{}) *gomock.
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTodoRepository)(nil).Update), ctx, todo)
 }
+
+// UpdateAssignee mocks base method.
+func (m *MockTodoRepository) UpdateAssignee(ctx context.Context, id int64, assigneeID *int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAssignee", ctx, id, assigneeID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAssignee indicates an expected call of UpdateAssignee.
+func (mr *MockTodoRepositoryMockRecorder) UpdateAssignee(ctx, id, assigneeID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAssignee", reflect.TypeOf((*MockTodoRepository)(nil).UpdateAssignee), ctx, id, assigneeID)
+}
@@ -8,6 +8,7 @@ import (
 	context "context"
 	entity "go-todo/internal/entity"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -79,6 +80,21 @@ func (mr *MockUserRepositoryMockRecorder) FindAll(ctx interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockUserRepository)(nil).FindAll), ctx)
 }
 
+// FindByEmail mocks base method.
+func (m *MockUserRepository) FindByEmail(ctx context.Context, email string) (*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByEmail", ctx, email)
+	ret0, _ := ret[0].(*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByEmail indicates an expected call of FindByEmail.
+func (mr *MockUserRepositoryMockRecorder) FindByEmail(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByEmail", reflect.TypeOf((*MockUserRepository)(nil).FindByEmail), ctx, email)
+}
+
 // FindByID mocks base method.
 func (m *MockUserRepository) FindByID(ctx context.Context, id int64) (*entity.User, error) {
 	m.ctrl.T.Helper()
@@ -109,6 +125,20 @@ func (mr *MockUserRepositoryMockRecorder) FindByUsername(ctx, username interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUsername", reflect.TypeOf((*MockUserRepository)(nil).FindByUsername), ctx, username)
 }
 
+// SetEmailVerifiedAt mocks base method.
+func (m *MockUserRepository) SetEmailVerifiedAt(ctx context.Context, id int64, verifiedAt *time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetEmailVerifiedAt", ctx, id, verifiedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetEmailVerifiedAt indicates an expected call of SetEmailVerifiedAt.
+func (mr *MockUserRepositoryMockRecorder) SetEmailVerifiedAt(ctx, id, verifiedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetEmailVerifiedAt", reflect.TypeOf((*MockUserRepository)(nil).SetEmailVerifiedAt), ctx, id, verifiedAt)
+}
+
 // Update mocks base method.
 func (m *MockUserRepository) Update(ctx context.Context, user *entity.User) (*entity.User, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/user_preference.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	entity "go-todo/internal/entity"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUserPreferenceRepository is a mock of UserPreferenceRepository interface.
+type MockUserPreferenceRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserPreferenceRepositoryMockRecorder
+}
+
+// MockUserPreferenceRepositoryMockRecorder is the mock recorder for MockUserPreferenceRepository.
+type MockUserPreferenceRepositoryMockRecorder struct {
+	mock *MockUserPreferenceRepository
+}
+
+// NewMockUserPreferenceRepository creates a new mock instance.
+func NewMockUserPreferenceRepository(ctrl *gomock.Controller) *MockUserPreferenceRepository {
+	mock := &MockUserPreferenceRepository{ctrl: ctrl}
+	mock.recorder = &MockUserPreferenceRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserPreferenceRepository) EXPECT() *MockUserPreferenceRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindByUserID mocks base method.
+func (m *MockUserPreferenceRepository) FindByUserID(ctx context.Context, userID int64) (*entity.UserPreference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUserID", ctx, userID)
+	ret0, _ := ret[0].(*entity.UserPreference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByUserID indicates an expected call of FindByUserID.
+func (mr *MockUserPreferenceRepositoryMockRecorder) FindByUserID(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserID", reflect.TypeOf((*MockUserPreferenceRepository)(nil).FindByUserID), ctx, userID)
+}
+
+// Upsert mocks base method.
+func (m *MockUserPreferenceRepository) Upsert(ctx context.Context, preference *entity.UserPreference) (*entity.UserPreference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", ctx, preference)
+	ret0, _ := ret[0].(*entity.UserPreference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockUserPreferenceRepositoryMockRecorder) Upsert(ctx, preference interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockUserPreferenceRepository)(nil).Upsert), ctx, preference)
+}
@@ -0,0 +1,97 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/service/analytics.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	context "context"
+	entity "go-todo/internal/entity"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAnalyticsService is a mock of AnalyticsService interface.
+type MockAnalyticsService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAnalyticsServiceMockRecorder
+}
+
+// MockAnalyticsServiceMockRecorder is the mock recorder for MockAnalyticsService.
+type MockAnalyticsServiceMockRecorder struct {
+	mock *MockAnalyticsService
+}
+
+// NewMockAnalyticsService creates a new mock instance.
+func NewMockAnalyticsService(ctrl *gomock.Controller) *MockAnalyticsService {
+	mock := &MockAnalyticsService{ctrl: ctrl}
+	mock.recorder = &MockAnalyticsServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAnalyticsService) EXPECT() *MockAnalyticsServiceMockRecorder {
+	return m.recorder
+}
+
+// ActiveUsers mocks base method.
+func (m *MockAnalyticsService) ActiveUsers(ctx context.Context, from, to time.Time, loc *time.Location) ([]entity.DailyCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActiveUsers", ctx, from, to, loc)
+	ret0, _ := ret[0].([]entity.DailyCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActiveUsers indicates an expected call of ActiveUsers.
+func (mr *MockAnalyticsServiceMockRecorder) ActiveUsers(ctx, from, to, loc interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActiveUsers", reflect.TypeOf((*MockAnalyticsService)(nil).ActiveUsers), ctx, from, to, loc)
+}
+
+// LoginActivity mocks base method.
+func (m *MockAnalyticsService) LoginActivity(ctx context.Context, from, to time.Time, loc *time.Location) ([]entity.DailyLoginActivity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoginActivity", ctx, from, to, loc)
+	ret0, _ := ret[0].([]entity.DailyLoginActivity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoginActivity indicates an expected call of LoginActivity.
+func (mr *MockAnalyticsServiceMockRecorder) LoginActivity(ctx, from, to, loc interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginActivity", reflect.TypeOf((*MockAnalyticsService)(nil).LoginActivity), ctx, from, to, loc)
+}
+
+// TodoActivity mocks base method.
+func (m *MockAnalyticsService) TodoActivity(ctx context.Context, from, to time.Time, loc *time.Location) ([]entity.DailyTodoActivity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TodoActivity", ctx, from, to, loc)
+	ret0, _ := ret[0].([]entity.DailyTodoActivity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TodoActivity indicates an expected call of TodoActivity.
+func (mr *MockAnalyticsServiceMockRecorder) TodoActivity(ctx, from, to, loc interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TodoActivity", reflect.TypeOf((*MockAnalyticsService)(nil).TodoActivity), ctx, from, to, loc)
+}
+
+// TopUsers mocks base method.
+func (m *MockAnalyticsService) TopUsers(ctx context.Context, from, to time.Time, loc *time.Location, limit int) ([]entity.TopUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TopUsers", ctx, from, to, loc, limit)
+	ret0, _ := ret[0].([]entity.TopUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TopUsers indicates an expected call of TopUsers.
+func (mr *MockAnalyticsServiceMockRecorder) TopUsers(ctx, from, to, loc, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TopUsers", reflect.TypeOf((*MockAnalyticsService)(nil).TopUsers), ctx, from, to, loc, limit)
+}
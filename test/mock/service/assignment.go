@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/service/assignment.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	context "context"
+	entity "go-todo/internal/entity"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAssignmentService is a mock of AssignmentService interface.
+type MockAssignmentService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAssignmentServiceMockRecorder
+}
+
+// MockAssignmentServiceMockRecorder is the mock recorder for MockAssignmentService.
+type MockAssignmentServiceMockRecorder struct {
+	mock *MockAssignmentService
+}
+
+// NewMockAssignmentService creates a new mock instance.
+func NewMockAssignmentService(ctrl *gomock.Controller) *MockAssignmentService {
+	mock := &MockAssignmentService{ctrl: ctrl}
+	mock.recorder = &MockAssignmentServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAssignmentService) EXPECT() *MockAssignmentServiceMockRecorder {
+	return m.recorder
+}
+
+// Assign mocks base method.
+func (m *MockAssignmentService) Assign(ctx context.Context, actorID, todoID, assigneeID int64) (*entity.Todo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Assign", ctx, actorID, todoID, assigneeID)
+	ret0, _ := ret[0].(*entity.Todo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Assign indicates an expected call of Assign.
+func (mr *MockAssignmentServiceMockRecorder) Assign(ctx, actorID, todoID, assigneeID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Assign", reflect.TypeOf((*MockAssignmentService)(nil).Assign), ctx, actorID, todoID, assigneeID)
+}
+
+// AuthorizeEdit mocks base method.
+func (m *MockAssignmentService) AuthorizeEdit(ctx context.Context, actorID, todoID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthorizeEdit", ctx, actorID, todoID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AuthorizeEdit indicates an expected call of AuthorizeEdit.
+func (mr *MockAssignmentServiceMockRecorder) AuthorizeEdit(ctx, actorID, todoID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthorizeEdit", reflect.TypeOf((*MockAssignmentService)(nil).AuthorizeEdit), ctx, actorID, todoID)
+}
+
+// FindAssigned mocks base method.
+func (m *MockAssignmentService) FindAssigned(ctx context.Context, userID int64) ([]entity.Todo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAssigned", ctx, userID)
+	ret0, _ := ret[0].([]entity.Todo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAssigned indicates an expected call of FindAssigned.
+func (mr *MockAssignmentServiceMockRecorder) FindAssigned(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAssigned", reflect.TypeOf((*MockAssignmentService)(nil).FindAssigned), ctx, userID)
+}
+
+// Unassign mocks base method.
+func (m *MockAssignmentService) Unassign(ctx context.Context, actorID, todoID int64) (*entity.Todo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unassign", ctx, actorID, todoID)
+	ret0, _ := ret[0].(*entity.Todo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Unassign indicates an expected call of Unassign.
+func (mr *MockAssignmentServiceMockRecorder) Unassign(ctx, actorID, todoID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unassign", reflect.TypeOf((*MockAssignmentService)(nil).Unassign), ctx, actorID, todoID)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockAssignmentService) UpdateStatus(ctx context.Context, actorID, todoID int64, completed bool) (entity.Todo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, actorID, todoID, completed)
+	ret0, _ := ret[0].(entity.Todo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockAssignmentServiceMockRecorder) UpdateStatus(ctx, actorID, todoID, completed interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockAssignmentService)(nil).UpdateStatus), ctx, actorID, todoID, completed)
+}
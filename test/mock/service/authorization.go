@@ -0,0 +1,119 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/service/authorization.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	context "context"
+	entity "go-todo/internal/entity"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAuthorizationService is a mock of AuthorizationService interface.
+type MockAuthorizationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthorizationServiceMockRecorder
+}
+
+// MockAuthorizationServiceMockRecorder is the mock recorder for MockAuthorizationService.
+type MockAuthorizationServiceMockRecorder struct {
+	mock *MockAuthorizationService
+}
+
+// NewMockAuthorizationService creates a new mock instance.
+func NewMockAuthorizationService(ctrl *gomock.Controller) *MockAuthorizationService {
+	mock := &MockAuthorizationService{ctrl: ctrl}
+	mock.recorder = &MockAuthorizationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthorizationService) EXPECT() *MockAuthorizationServiceMockRecorder {
+	return m.recorder
+}
+
+// HasPermission mocks base method.
+func (m *MockAuthorizationService) HasPermission(ctx context.Context, userID int64, permission string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasPermission", ctx, userID, permission)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasPermission indicates an expected call of HasPermission.
+func (mr *MockAuthorizationServiceMockRecorder) HasPermission(ctx, userID, permission interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasPermission", reflect.TypeOf((*MockAuthorizationService)(nil).HasPermission), ctx, userID, permission)
+}
+
+// InvalidateAll mocks base method.
+func (m *MockAuthorizationService) InvalidateAll() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "InvalidateAll")
+}
+
+// InvalidateAll indicates an expected call of InvalidateAll.
+func (mr *MockAuthorizationServiceMockRecorder) InvalidateAll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateAll", reflect.TypeOf((*MockAuthorizationService)(nil).InvalidateAll))
+}
+
+// InvalidateUser mocks base method.
+func (m *MockAuthorizationService) InvalidateUser(userID int64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "InvalidateUser", userID)
+}
+
+// InvalidateUser indicates an expected call of InvalidateUser.
+func (mr *MockAuthorizationServiceMockRecorder) InvalidateUser(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateUser", reflect.TypeOf((*MockAuthorizationService)(nil).InvalidateUser), userID)
+}
+
+// Lookup mocks base method.
+func (m *MockAuthorizationService) Lookup(ctx context.Context, userID int64) (*entity.Authorization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Lookup", ctx, userID)
+	ret0, _ := ret[0].(*entity.Authorization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Lookup indicates an expected call of Lookup.
+func (mr *MockAuthorizationServiceMockRecorder) Lookup(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lookup", reflect.TypeOf((*MockAuthorizationService)(nil).Lookup), ctx, userID)
+}
+
+// Permissions mocks base method.
+func (m *MockAuthorizationService) Permissions(ctx context.Context, userID int64) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Permissions", ctx, userID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Permissions indicates an expected call of Permissions.
+func (mr *MockAuthorizationServiceMockRecorder) Permissions(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Permissions", reflect.TypeOf((*MockAuthorizationService)(nil).Permissions), ctx, userID)
+}
+
+// Require mocks base method.
+func (m *MockAuthorizationService) Require(ctx context.Context, userID int64, permission string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Require", ctx, userID, permission)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Require indicates an expected call of Require.
+func (mr *MockAuthorizationServiceMockRecorder) Require(ctx, userID, permission interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Require", reflect.TypeOf((*MockAuthorizationService)(nil).Require), ctx, userID, permission)
+}
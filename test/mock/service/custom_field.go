@@ -0,0 +1,163 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/service/custom_field.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	context "context"
+	entity "go-todo/internal/entity"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCustomFieldValidator is a mock of CustomFieldValidator interface.
+type MockCustomFieldValidator struct {
+	ctrl     *gomock.Controller
+	recorder *MockCustomFieldValidatorMockRecorder
+}
+
+// MockCustomFieldValidatorMockRecorder is the mock recorder for MockCustomFieldValidator.
+type MockCustomFieldValidatorMockRecorder struct {
+	mock *MockCustomFieldValidator
+}
+
+// NewMockCustomFieldValidator creates a new mock instance.
+func NewMockCustomFieldValidator(ctrl *gomock.Controller) *MockCustomFieldValidator {
+	mock := &MockCustomFieldValidator{ctrl: ctrl}
+	mock.recorder = &MockCustomFieldValidatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCustomFieldValidator) EXPECT() *MockCustomFieldValidatorMockRecorder {
+	return m.recorder
+}
+
+// ValidateValues mocks base method.
+func (m *MockCustomFieldValidator) ValidateValues(ctx context.Context, userID int64, values entity.CustomFieldValues) (entity.CustomFieldValues, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateValues", ctx, userID, values)
+	ret0, _ := ret[0].(entity.CustomFieldValues)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidateValues indicates an expected call of ValidateValues.
+func (mr *MockCustomFieldValidatorMockRecorder) ValidateValues(ctx, userID, values interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateValues", reflect.TypeOf((*MockCustomFieldValidator)(nil).ValidateValues), ctx, userID, values)
+}
+
+// MockCustomFieldService is a mock of CustomFieldService interface.
+type MockCustomFieldService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCustomFieldServiceMockRecorder
+}
+
+// MockCustomFieldServiceMockRecorder is the mock recorder for MockCustomFieldService.
+type MockCustomFieldServiceMockRecorder struct {
+	mock *MockCustomFieldService
+}
+
+// NewMockCustomFieldService creates a new mock instance.
+func NewMockCustomFieldService(ctrl *gomock.Controller) *MockCustomFieldService {
+	mock := &MockCustomFieldService{ctrl: ctrl}
+	mock.recorder = &MockCustomFieldServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCustomFieldService) EXPECT() *MockCustomFieldServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCustomFieldService) Create(ctx context.Context, definition *entity.CustomFieldDefinition) (*entity.CustomFieldDefinition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, definition)
+	ret0, _ := ret[0].(*entity.CustomFieldDefinition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCustomFieldServiceMockRecorder) Create(ctx, definition interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCustomFieldService)(nil).Create), ctx, definition)
+}
+
+// Delete mocks base method.
+func (m *MockCustomFieldService) Delete(ctx context.Context, userID, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCustomFieldServiceMockRecorder) Delete(ctx, userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCustomFieldService)(nil).Delete), ctx, userID, id)
+}
+
+// FindAll mocks base method.
+func (m *MockCustomFieldService) FindAll(ctx context.Context, userID int64) ([]entity.CustomFieldDefinition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, userID)
+	ret0, _ := ret[0].([]entity.CustomFieldDefinition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockCustomFieldServiceMockRecorder) FindAll(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockCustomFieldService)(nil).FindAll), ctx, userID)
+}
+
+// SearchTodos mocks base method.
+func (m *MockCustomFieldService) SearchTodos(ctx context.Context, userID int64, filters []entity.CustomFieldFilter, sort string) ([]entity.Todo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchTodos", ctx, userID, filters, sort)
+	ret0, _ := ret[0].([]entity.Todo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchTodos indicates an expected call of SearchTodos.
+func (mr *MockCustomFieldServiceMockRecorder) SearchTodos(ctx, userID, filters, sort interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchTodos", reflect.TypeOf((*MockCustomFieldService)(nil).SearchTodos), ctx, userID, filters, sort)
+}
+
+// Update mocks base method.
+func (m *MockCustomFieldService) Update(ctx context.Context, definition *entity.CustomFieldDefinition) (*entity.CustomFieldDefinition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, definition)
+	ret0, _ := ret[0].(*entity.CustomFieldDefinition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCustomFieldServiceMockRecorder) Update(ctx, definition interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCustomFieldService)(nil).Update), ctx, definition)
+}
+
+// ValidateValues mocks base method.
+func (m *MockCustomFieldService) ValidateValues(ctx context.Context, userID int64, values entity.CustomFieldValues) (entity.CustomFieldValues, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateValues", ctx, userID, values)
+	ret0, _ := ret[0].(entity.CustomFieldValues)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidateValues indicates an expected call of ValidateValues.
+func (mr *MockCustomFieldServiceMockRecorder) ValidateValues(ctx, userID, values interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateValues", reflect.TypeOf((*MockCustomFieldService)(nil).ValidateValues), ctx, userID, values)
+}
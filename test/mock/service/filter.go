@@ -0,0 +1,125 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/service/filter.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	context "context"
+	entity "go-todo/internal/entity"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockFilterService is a mock of FilterService interface.
+type MockFilterService struct {
+	ctrl     *gomock.Controller
+	recorder *MockFilterServiceMockRecorder
+}
+
+// MockFilterServiceMockRecorder is the mock recorder for MockFilterService.
+type MockFilterServiceMockRecorder struct {
+	mock *MockFilterService
+}
+
+// NewMockFilterService creates a new mock instance.
+func NewMockFilterService(ctrl *gomock.Controller) *MockFilterService {
+	mock := &MockFilterService{ctrl: ctrl}
+	mock.recorder = &MockFilterServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFilterService) EXPECT() *MockFilterServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockFilterService) Create(ctx context.Context, savedFilter *entity.SavedFilter) (*entity.SavedFilter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, savedFilter)
+	ret0, _ := ret[0].(*entity.SavedFilter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockFilterServiceMockRecorder) Create(ctx, savedFilter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockFilterService)(nil).Create), ctx, savedFilter)
+}
+
+// Delete mocks base method.
+func (m *MockFilterService) Delete(ctx context.Context, userID, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockFilterServiceMockRecorder) Delete(ctx, userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockFilterService)(nil).Delete), ctx, userID, id)
+}
+
+// Find mocks base method.
+func (m *MockFilterService) Find(ctx context.Context, userID int64, key string) (*entity.SavedFilter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", ctx, userID, key)
+	ret0, _ := ret[0].(*entity.SavedFilter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockFilterServiceMockRecorder) Find(ctx, userID, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockFilterService)(nil).Find), ctx, userID, key)
+}
+
+// FindAll mocks base method.
+func (m *MockFilterService) FindAll(ctx context.Context, userID int64) ([]entity.SavedFilter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, userID)
+	ret0, _ := ret[0].([]entity.SavedFilter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockFilterServiceMockRecorder) FindAll(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockFilterService)(nil).FindAll), ctx, userID)
+}
+
+// Todos mocks base method.
+func (m *MockFilterService) Todos(ctx context.Context, userID int64, key string) ([]entity.Todo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Todos", ctx, userID, key)
+	ret0, _ := ret[0].([]entity.Todo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Todos indicates an expected call of Todos.
+func (mr *MockFilterServiceMockRecorder) Todos(ctx, userID, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Todos", reflect.TypeOf((*MockFilterService)(nil).Todos), ctx, userID, key)
+}
+
+// Update mocks base method.
+func (m *MockFilterService) Update(ctx context.Context, savedFilter *entity.SavedFilter) (*entity.SavedFilter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, savedFilter)
+	ret0, _ := ret[0].(*entity.SavedFilter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockFilterServiceMockRecorder) Update(ctx, savedFilter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockFilterService)(nil).Update), ctx, savedFilter)
+}
@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/service/realtime.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	context "context"
+	entity "go-todo/internal/entity"
+	realtime "go-todo/pkg/realtime"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRealtimeService is a mock of RealtimeService interface.
+type MockRealtimeService struct {
+	ctrl     *gomock.Controller
+	recorder *MockRealtimeServiceMockRecorder
+}
+
+// MockRealtimeServiceMockRecorder is the mock recorder for MockRealtimeService.
+type MockRealtimeServiceMockRecorder struct {
+	mock *MockRealtimeService
+}
+
+// NewMockRealtimeService creates a new mock instance.
+func NewMockRealtimeService(ctrl *gomock.Controller) *MockRealtimeService {
+	mock := &MockRealtimeService{ctrl: ctrl}
+	mock.recorder = &MockRealtimeServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRealtimeService) EXPECT() *MockRealtimeServiceMockRecorder {
+	return m.recorder
+}
+
+// HandleEvent mocks base method.
+func (m *MockRealtimeService) HandleEvent(ctx context.Context, event entity.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleEvent", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandleEvent indicates an expected call of HandleEvent.
+func (mr *MockRealtimeServiceMockRecorder) HandleEvent(ctx, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleEvent", reflect.TypeOf((*MockRealtimeService)(nil).HandleEvent), ctx, event)
+}
+
+// Subscribe mocks base method.
+func (m *MockRealtimeService) Subscribe(ctx context.Context, userID int64, lastEventID string) (<-chan realtime.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", ctx, userID, lastEventID)
+	ret0, _ := ret[0].(<-chan realtime.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockRealtimeServiceMockRecorder) Subscribe(ctx, userID, lastEventID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockRealtimeService)(nil).Subscribe), ctx, userID, lastEventID)
+}
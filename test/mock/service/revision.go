@@ -0,0 +1,118 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/service/revision.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	context "context"
+	entity "go-todo/internal/entity"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRevisionRecorder is a mock of RevisionRecorder interface.
+type MockRevisionRecorder struct {
+	ctrl     *gomock.Controller
+	recorder *MockRevisionRecorderMockRecorder
+}
+
+// MockRevisionRecorderMockRecorder is the mock recorder for MockRevisionRecorder.
+type MockRevisionRecorderMockRecorder struct {
+	mock *MockRevisionRecorder
+}
+
+// NewMockRevisionRecorder creates a new mock instance.
+func NewMockRevisionRecorder(ctrl *gomock.Controller) *MockRevisionRecorder {
+	mock := &MockRevisionRecorder{ctrl: ctrl}
+	mock.recorder = &MockRevisionRecorderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRevisionRecorder) EXPECT() *MockRevisionRecorderMockRecorder {
+	return m.recorder
+}
+
+// Record mocks base method.
+func (m *MockRevisionRecorder) Record(ctx context.Context, before *entity.Todo, after entity.Todo, revertedFrom *int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Record", ctx, before, after, revertedFrom)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockRevisionRecorderMockRecorder) Record(ctx, before, after, revertedFrom interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockRevisionRecorder)(nil).Record), ctx, before, after, revertedFrom)
+}
+
+// MockRevisionService is a mock of RevisionService interface.
+type MockRevisionService struct {
+	ctrl     *gomock.Controller
+	recorder *MockRevisionServiceMockRecorder
+}
+
+// MockRevisionServiceMockRecorder is the mock recorder for MockRevisionService.
+type MockRevisionServiceMockRecorder struct {
+	mock *MockRevisionService
+}
+
+// NewMockRevisionService creates a new mock instance.
+func NewMockRevisionService(ctrl *gomock.Controller) *MockRevisionService {
+	mock := &MockRevisionService{ctrl: ctrl}
+	mock.recorder = &MockRevisionServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRevisionService) EXPECT() *MockRevisionServiceMockRecorder {
+	return m.recorder
+}
+
+// Diff mocks base method.
+func (m *MockRevisionService) Diff(ctx context.Context, todoID int64, from, to int) ([]entity.RevisionChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Diff", ctx, todoID, from, to)
+	ret0, _ := ret[0].([]entity.RevisionChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Diff indicates an expected call of Diff.
+func (mr *MockRevisionServiceMockRecorder) Diff(ctx, todoID, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Diff", reflect.TypeOf((*MockRevisionService)(nil).Diff), ctx, todoID, from, to)
+}
+
+// FindAll mocks base method.
+func (m *MockRevisionService) FindAll(ctx context.Context, todoID int64) ([]entity.TodoRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, todoID)
+	ret0, _ := ret[0].([]entity.TodoRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockRevisionServiceMockRecorder) FindAll(ctx, todoID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockRevisionService)(nil).FindAll), ctx, todoID)
+}
+
+// Revert mocks base method.
+func (m *MockRevisionService) Revert(ctx context.Context, todoID int64, revision int) (entity.Todo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revert", ctx, todoID, revision)
+	ret0, _ := ret[0].(entity.Todo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Revert indicates an expected call of Revert.
+func (mr *MockRevisionServiceMockRecorder) Revert(ctx, todoID, revision interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revert", reflect.TypeOf((*MockRevisionService)(nil).Revert), ctx, todoID, revision)
+}
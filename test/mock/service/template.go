@@ -0,0 +1,155 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/service/template.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	context "context"
+	entity "go-todo/internal/entity"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTemplateService is a mock of TemplateService interface.
+type MockTemplateService struct {
+	ctrl     *gomock.Controller
+	recorder *MockTemplateServiceMockRecorder
+}
+
+// MockTemplateServiceMockRecorder is the mock recorder for MockTemplateService.
+type MockTemplateServiceMockRecorder struct {
+	mock *MockTemplateService
+}
+
+// NewMockTemplateService creates a new mock instance.
+func NewMockTemplateService(ctrl *gomock.Controller) *MockTemplateService {
+	mock := &MockTemplateService{ctrl: ctrl}
+	mock.recorder = &MockTemplateServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTemplateService) EXPECT() *MockTemplateServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockTemplateService) Create(ctx context.Context, template *entity.TodoTemplate) (*entity.TodoTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, template)
+	ret0, _ := ret[0].(*entity.TodoTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockTemplateServiceMockRecorder) Create(ctx, template interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTemplateService)(nil).Create), ctx, template)
+}
+
+// CreateFromProject mocks base method.
+func (m *MockTemplateService) CreateFromProject(ctx context.Context, userID int64, project, name string) (*entity.TodoTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFromProject", ctx, userID, project, name)
+	ret0, _ := ret[0].(*entity.TodoTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFromProject indicates an expected call of CreateFromProject.
+func (mr *MockTemplateServiceMockRecorder) CreateFromProject(ctx, userID, project, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFromProject", reflect.TypeOf((*MockTemplateService)(nil).CreateFromProject), ctx, userID, project, name)
+}
+
+// CreateFromTodo mocks base method.
+func (m *MockTemplateService) CreateFromTodo(ctx context.Context, userID, todoID int64, name string) (*entity.TodoTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFromTodo", ctx, userID, todoID, name)
+	ret0, _ := ret[0].(*entity.TodoTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFromTodo indicates an expected call of CreateFromTodo.
+func (mr *MockTemplateServiceMockRecorder) CreateFromTodo(ctx, userID, todoID, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFromTodo", reflect.TypeOf((*MockTemplateService)(nil).CreateFromTodo), ctx, userID, todoID, name)
+}
+
+// Delete mocks base method.
+func (m *MockTemplateService) Delete(ctx context.Context, userID, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTemplateServiceMockRecorder) Delete(ctx, userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTemplateService)(nil).Delete), ctx, userID, id)
+}
+
+// FindAll mocks base method.
+func (m *MockTemplateService) FindAll(ctx context.Context, userID int64) ([]entity.TodoTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, userID)
+	ret0, _ := ret[0].([]entity.TodoTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockTemplateServiceMockRecorder) FindAll(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockTemplateService)(nil).FindAll), ctx, userID)
+}
+
+// FindByID mocks base method.
+func (m *MockTemplateService) FindByID(ctx context.Context, userID, id int64) (*entity.TodoTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, userID, id)
+	ret0, _ := ret[0].(*entity.TodoTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockTemplateServiceMockRecorder) FindByID(ctx, userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockTemplateService)(nil).FindByID), ctx, userID, id)
+}
+
+// Instantiate mocks base method.
+func (m *MockTemplateService) Instantiate(ctx context.Context, userID, id int64, params entity.TemplateInstantiation) ([]entity.Todo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Instantiate", ctx, userID, id, params)
+	ret0, _ := ret[0].([]entity.Todo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Instantiate indicates an expected call of Instantiate.
+func (mr *MockTemplateServiceMockRecorder) Instantiate(ctx, userID, id, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Instantiate", reflect.TypeOf((*MockTemplateService)(nil).Instantiate), ctx, userID, id, params)
+}
+
+// Update mocks base method.
+func (m *MockTemplateService) Update(ctx context.Context, template *entity.TodoTemplate) (*entity.TodoTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, template)
+	ret0, _ := ret[0].(*entity.TodoTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockTemplateServiceMockRecorder) Update(ctx, template interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTemplateService)(nil).Update), ctx, template)
+}
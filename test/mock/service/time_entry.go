@@ -0,0 +1,140 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/service/time_entry.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	context "context"
+	entity "go-todo/internal/entity"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTimeEntryService is a mock of TimeEntryService interface.
+type MockTimeEntryService struct {
+	ctrl     *gomock.Controller
+	recorder *MockTimeEntryServiceMockRecorder
+}
+
+// MockTimeEntryServiceMockRecorder is the mock recorder for MockTimeEntryService.
+type MockTimeEntryServiceMockRecorder struct {
+	mock *MockTimeEntryService
+}
+
+// NewMockTimeEntryService creates a new mock instance.
+func NewMockTimeEntryService(ctrl *gomock.Controller) *MockTimeEntryService {
+	mock := &MockTimeEntryService{ctrl: ctrl}
+	mock.recorder = &MockTimeEntryServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTimeEntryService) EXPECT() *MockTimeEntryServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockTimeEntryService) Create(ctx context.Context, entry *entity.TimeEntry) (*entity.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, entry)
+	ret0, _ := ret[0].(*entity.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockTimeEntryServiceMockRecorder) Create(ctx, entry interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTimeEntryService)(nil).Create), ctx, entry)
+}
+
+// Delete mocks base method.
+func (m *MockTimeEntryService) Delete(ctx context.Context, userID, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTimeEntryServiceMockRecorder) Delete(ctx, userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTimeEntryService)(nil).Delete), ctx, userID, id)
+}
+
+// FindByTodoID mocks base method.
+func (m *MockTimeEntryService) FindByTodoID(ctx context.Context, userID, todoID int64) ([]entity.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByTodoID", ctx, userID, todoID)
+	ret0, _ := ret[0].([]entity.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByTodoID indicates an expected call of FindByTodoID.
+func (mr *MockTimeEntryServiceMockRecorder) FindByTodoID(ctx, userID, todoID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByTodoID", reflect.TypeOf((*MockTimeEntryService)(nil).FindByTodoID), ctx, userID, todoID)
+}
+
+// Report mocks base method.
+func (m *MockTimeEntryService) Report(ctx context.Context, filter entity.TimeReportFilter) ([]entity.TimeReportRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Report", ctx, filter)
+	ret0, _ := ret[0].([]entity.TimeReportRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Report indicates an expected call of Report.
+func (mr *MockTimeEntryServiceMockRecorder) Report(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Report", reflect.TypeOf((*MockTimeEntryService)(nil).Report), ctx, filter)
+}
+
+// StartTimer mocks base method.
+func (m *MockTimeEntryService) StartTimer(ctx context.Context, userID, todoID int64, note string) (*entity.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartTimer", ctx, userID, todoID, note)
+	ret0, _ := ret[0].(*entity.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartTimer indicates an expected call of StartTimer.
+func (mr *MockTimeEntryServiceMockRecorder) StartTimer(ctx, userID, todoID, note interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartTimer", reflect.TypeOf((*MockTimeEntryService)(nil).StartTimer), ctx, userID, todoID, note)
+}
+
+// StopTimer mocks base method.
+func (m *MockTimeEntryService) StopTimer(ctx context.Context, userID, todoID int64) (*entity.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopTimer", ctx, userID, todoID)
+	ret0, _ := ret[0].(*entity.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StopTimer indicates an expected call of StopTimer.
+func (mr *MockTimeEntryServiceMockRecorder) StopTimer(ctx, userID, todoID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopTimer", reflect.TypeOf((*MockTimeEntryService)(nil).StopTimer), ctx, userID, todoID)
+}
+
+// Update mocks base method.
+func (m *MockTimeEntryService) Update(ctx context.Context, userID int64, entry *entity.TimeEntry) (*entity.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, userID, entry)
+	ret0, _ := ret[0].(*entity.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockTimeEntryServiceMockRecorder) Update(ctx, userID, entry interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTimeEntryService)(nil).Update), ctx, userID, entry)
+}
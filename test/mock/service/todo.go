@@ -79,6 +79,36 @@ func (mr *MockTodoServiceMockRecorder) FindAll(ctx interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockTodoService)(nil).FindAll), ctx)
 }
 
+// Replace mocks base method.
+func (m *MockTodoService) Replace(ctx context.Context, id int64, todo entity.Todo) (entity.Todo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Replace", ctx, id, todo)
+	ret0, _ := ret[0].(entity.Todo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Replace indicates an expected call of Replace.
+func (mr *MockTodoServiceMockRecorder) Replace(ctx, id, todo interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Replace", reflect.TypeOf((*MockTodoService)(nil).Replace), ctx, id, todo)
+}
+
+// Restore mocks base method.
+func (m *MockTodoService) Restore(ctx context.Context, id int64, revision entity.TodoRevision) (entity.Todo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, id, revision)
+	ret0, _ := ret[0].(entity.Todo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockTodoServiceMockRecorder) Restore(ctx, id, revision interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockTodoService)(nil).Restore), ctx, id, revision)
+}
+
 // Update mocks base method.
 func (m *MockTodoService) Update(ctx context.Context, id int64, todo entity.Todo) (entity.Todo, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,82 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/service/undo.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	context "context"
+	entity "go-todo/internal/entity"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUndoService is a mock of UndoService interface.
+type MockUndoService struct {
+	ctrl     *gomock.Controller
+	recorder *MockUndoServiceMockRecorder
+}
+
+// MockUndoServiceMockRecorder is the mock recorder for MockUndoService.
+type MockUndoServiceMockRecorder struct {
+	mock *MockUndoService
+}
+
+// NewMockUndoService creates a new mock instance.
+func NewMockUndoService(ctrl *gomock.Controller) *MockUndoService {
+	mock := &MockUndoService{ctrl: ctrl}
+	mock.recorder = &MockUndoServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUndoService) EXPECT() *MockUndoServiceMockRecorder {
+	return m.recorder
+}
+
+// DeleteTodo mocks base method.
+func (m *MockUndoService) DeleteTodo(ctx context.Context, actorID, id int64) (*entity.UndoToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTodo", ctx, actorID, id)
+	ret0, _ := ret[0].(*entity.UndoToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTodo indicates an expected call of DeleteTodo.
+func (mr *MockUndoServiceMockRecorder) DeleteTodo(ctx, actorID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTodo", reflect.TypeOf((*MockUndoService)(nil).DeleteTodo), ctx, actorID, id)
+}
+
+// Undo mocks base method.
+func (m *MockUndoService) Undo(ctx context.Context, actorID int64, token string) (entity.Todo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Undo", ctx, actorID, token)
+	ret0, _ := ret[0].(entity.Todo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Undo indicates an expected call of Undo.
+func (mr *MockUndoServiceMockRecorder) Undo(ctx, actorID, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Undo", reflect.TypeOf((*MockUndoService)(nil).Undo), ctx, actorID, token)
+}
+
+// UpdateTodo mocks base method.
+func (m *MockUndoService) UpdateTodo(ctx context.Context, actorID, id int64, todo entity.Todo) (entity.Todo, *entity.UndoToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTodo", ctx, actorID, id, todo)
+	ret0, _ := ret[0].(entity.Todo)
+	ret1, _ := ret[1].(*entity.UndoToken)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UpdateTodo indicates an expected call of UpdateTodo.
+func (mr *MockUndoServiceMockRecorder) UpdateTodo(ctx, actorID, id, todo interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTodo", reflect.TypeOf((*MockUndoService)(nil).UpdateTodo), ctx, actorID, id, todo)
+}
@@ -35,6 +35,21 @@ func (m *MockUserService) EXPECT() *MockUserServiceMockRecorder {
 	return m.recorder
 }
 
+// BootstrapAdmin mocks base method.
+func (m *MockUserService) BootstrapAdmin(ctx context.Context, user *entity.User) (*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BootstrapAdmin", ctx, user)
+	ret0, _ := ret[0].(*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BootstrapAdmin indicates an expected call of BootstrapAdmin.
+func (mr *MockUserServiceMockRecorder) BootstrapAdmin(ctx, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BootstrapAdmin", reflect.TypeOf((*MockUserService)(nil).BootstrapAdmin), ctx, user)
+}
+
 // CreateUser mocks base method.
 func (m *MockUserService) CreateUser(ctx context.Context, user *entity.User) (*entity.User, error) {
 	m.ctrl.T.Helper()
@@ -79,19 +94,78 @@ func (mr *MockUserServiceMockRecorder) FindAll(ctx interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockUserService)(nil).FindAll), ctx)
 }
 
+// GrantRole mocks base method.
+func (m *MockUserService) GrantRole(ctx context.Context, actorID, id int64, role string) (*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GrantRole", ctx, actorID, id, role)
+	ret0, _ := ret[0].(*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GrantRole indicates an expected call of GrantRole.
+func (mr *MockUserServiceMockRecorder) GrantRole(ctx, actorID, id, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrantRole", reflect.TypeOf((*MockUserService)(nil).GrantRole), ctx, actorID, id, role)
+}
+
 // Login mocks base method.
-func (m *MockUserService) Login(ctx context.Context, username, password string) (string, error) {
+func (m *MockUserService) Login(ctx context.Context, username, password, ip string) (*entity.LoginResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Login", ctx, username, password)
-	ret0, _ := ret[0].(string)
+	ret := m.ctrl.Call(m, "Login", ctx, username, password, ip)
+	ret0, _ := ret[0].(*entity.LoginResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Login indicates an expected call of Login.
-func (mr *MockUserServiceMockRecorder) Login(ctx, username, password interface{}) *gomock.Call {
+func (mr *MockUserServiceMockRecorder) Login(ctx, username, password, ip interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockUserService)(nil).Login), ctx, username, password, ip)
+}
+
+// LoginMFA mocks base method.
+func (m *MockUserService) LoginMFA(ctx context.Context, challengeToken, code, ip string) (*entity.TokenPair, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoginMFA", ctx, challengeToken, code, ip)
+	ret0, _ := ret[0].(*entity.TokenPair)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoginMFA indicates an expected call of LoginMFA.
+func (mr *MockUserServiceMockRecorder) LoginMFA(ctx, challengeToken, code, ip interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginMFA", reflect.TypeOf((*MockUserService)(nil).LoginMFA), ctx, challengeToken, code, ip)
+}
+
+// RevokeRole mocks base method.
+func (m *MockUserService) RevokeRole(ctx context.Context, actorID, id int64, role string) (*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeRole", ctx, actorID, id, role)
+	ret0, _ := ret[0].(*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeRole indicates an expected call of RevokeRole.
+func (mr *MockUserServiceMockRecorder) RevokeRole(ctx, actorID, id, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeRole", reflect.TypeOf((*MockUserService)(nil).RevokeRole), ctx, actorID, id, role)
+}
+
+// UnlockLogin mocks base method.
+func (m *MockUserService) UnlockLogin(ctx context.Context, actorID, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlockLogin", ctx, actorID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlockLogin indicates an expected call of UnlockLogin.
+func (mr *MockUserServiceMockRecorder) UnlockLogin(ctx, actorID, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockUserService)(nil).Login), ctx, username, password)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockLogin", reflect.TypeOf((*MockUserService)(nil).UnlockLogin), ctx, actorID, id)
 }
 
 // UpdateUser mocks base method.
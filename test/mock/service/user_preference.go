@@ -0,0 +1,81 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/service/user_preference.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	context "context"
+	entity "go-todo/internal/entity"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUserPreferenceService is a mock of UserPreferenceService interface.
+type MockUserPreferenceService struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserPreferenceServiceMockRecorder
+}
+
+// MockUserPreferenceServiceMockRecorder is the mock recorder for MockUserPreferenceService.
+type MockUserPreferenceServiceMockRecorder struct {
+	mock *MockUserPreferenceService
+}
+
+// NewMockUserPreferenceService creates a new mock instance.
+func NewMockUserPreferenceService(ctrl *gomock.Controller) *MockUserPreferenceService {
+	mock := &MockUserPreferenceService{ctrl: ctrl}
+	mock.recorder = &MockUserPreferenceServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserPreferenceService) EXPECT() *MockUserPreferenceServiceMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockUserPreferenceService) Get(ctx context.Context, userID int64) (*entity.UserPreference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, userID)
+	ret0, _ := ret[0].(*entity.UserPreference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockUserPreferenceServiceMockRecorder) Get(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockUserPreferenceService)(nil).Get), ctx, userID)
+}
+
+// Location mocks base method.
+func (m *MockUserPreferenceService) Location(ctx context.Context, userID int64) *time.Location {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Location", ctx, userID)
+	ret0, _ := ret[0].(*time.Location)
+	return ret0
+}
+
+// Location indicates an expected call of Location.
+func (mr *MockUserPreferenceServiceMockRecorder) Location(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Location", reflect.TypeOf((*MockUserPreferenceService)(nil).Location), ctx, userID)
+}
+
+// Update mocks base method.
+func (m *MockUserPreferenceService) Update(ctx context.Context, preference *entity.UserPreference) (*entity.UserPreference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, preference)
+	ret0, _ := ret[0].(*entity.UserPreference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockUserPreferenceServiceMockRecorder) Update(ctx, preference interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockUserPreferenceService)(nil).Update), ctx, preference)
+}